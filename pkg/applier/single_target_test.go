@@ -3,6 +3,7 @@ package applier
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"sync"
 	"sync/atomic"
@@ -1356,3 +1357,64 @@ func TestSingleTargetApplierSetWriteWorkersBeforeStart(t *testing.T) {
 	require.NotPanics(t, func() { a.SetWriteWorkers(4) })
 	require.Equal(t, 0, a.ActiveWriteWorkers(), "no workers should be spawned before Start")
 }
+
+// TestSingleTargetApplierEscapingInterpolateParams checks that the statements
+// a flush runs are correct with the driver's InterpolateParams both off and
+// on. Values are written into the SQL as literals escaped by Datum.String(),
+// never as driver parameters, so the result must not depend on the setting:
+// the driver only interpolates statements that have arguments.
+func TestSingleTargetApplierEscapingInterpolateParams(t *testing.T) {
+	tricky := []string{
+		`it's`,
+		`say "hi"`,
+		`back\slash`,
+		`trailing\`,
+		`'; DROP TABLE test_table; --`,
+		`question?mark`,
+		"new\nline\x00nul",
+	}
+	for _, interpolate := range []bool{false, true} {
+		t.Run(fmt.Sprintf("interpolateParams=%v", interpolate), func(t *testing.T) {
+			testutils.RunSQL(t, "DROP DATABASE IF EXISTS single_escaping_test")
+			testutils.RunSQL(t, "CREATE DATABASE single_escaping_test")
+
+			target, err := mysql.ParseDSN(testutils.DSN())
+			require.NoError(t, err)
+			target.DBName = "single_escaping_test"
+			dbConfig := dbconn.NewDBConfig()
+			dbConfig.InterpolateParams = interpolate
+			targetDB, err := dbconn.New(target.FormatDSN(), dbConfig)
+			require.NoError(t, err)
+			defer utils.CloseAndLog(targetDB)
+
+			_, err = targetDB.ExecContext(t.Context(),
+				`CREATE TABLE test_table (id VARCHAR(64) NOT NULL PRIMARY KEY, name VARCHAR(100))`)
+			require.NoError(t, err)
+			targetTable := table.NewTableInfo(targetDB, target.DBName, "test_table")
+			require.NoError(t, targetTable.SetInfo(t.Context()))
+
+			applier, err := NewSingleTargetApplier(Target{DB: targetDB, Config: target, KeyRange: "0"}, NewApplierDefaultConfig())
+			require.NoError(t, err)
+
+			rows := make([]LogicalRow, 0, len(tricky))
+			for _, s := range tricky {
+				rows = append(rows, LogicalRow{RowImage: []any{s, "name " + s}})
+			}
+			_, err = applier.UpsertRows(t.Context(), table.NewColumnMapping(targetTable, targetTable, nil), rows, nil)
+			require.NoError(t, err)
+			for _, s := range tricky {
+				var name string
+				require.NoError(t, targetDB.QueryRowContext(t.Context(),
+					"SELECT name FROM test_table WHERE id = ?", s).Scan(&name))
+				require.Equal(t, "name "+s, name)
+			}
+
+			affected, err := applier.DeleteKeys(t.Context(), targetTable, targetTable, [][]any{{`it's`}, {`back\slash`}, {`question?mark`}}, nil)
+			require.NoError(t, err)
+			require.Equal(t, int64(3), affected)
+			var count int
+			require.NoError(t, targetDB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM test_table").Scan(&count))
+			require.Equal(t, len(tricky)-3, count)
+		})
+	}
+}
@@ -63,6 +63,7 @@ type binlogClient struct {
 	callerCancelFunc func(FatalReason) bool
 	ddlFilterSchema  string
 	ddlFilterTables  map[string]struct{}
+	ddlTolerated     map[string]struct{} // schema.table; see ClientConfig.DDLToleratedTables
 
 	serverID    uint32         // server ID for the binlog reader
 	bufferedPos mysql.Position // buffered position
@@ -89,6 +90,19 @@ type binlogClient struct {
 	// cap. See DefaultSubscriptionSoftLimitBytes.
 	subscriptionSoftLimitBytes int64
 
+	// ddlIgnore holds tables whose DDL processDDLNotification skips.
+	// See IgnoreDDL.
+	ddlIgnore ddlIgnoreSet
+
+	// heartbeatPeriod and readTimeout are passed to the syncer config.
+	// Zero disables them. See ClientConfig.
+	heartbeatPeriod time.Duration
+	readTimeout     time.Duration
+
+	lag lagTracker // see Lag
+
+	periodicFlushPaused func() bool // see ClientConfig.PeriodicFlushPaused
+
 	flushedBinlogs atomic.Int64 // for testing binlog flushing frequency
 }
 
@@ -106,6 +120,7 @@ func NewBinlogClient(db *sql.DB, host string, username, password string, appl ap
 	} else if softLimit < 0 {
 		softLimit = 0 // explicit opt-out
 	}
+	heartbeatPeriod, readTimeout := config.syncerTimeouts()
 	return &binlogClient{
 		db:                         db,
 		dbConfig:                   config.DBConfig,
@@ -117,9 +132,13 @@ func NewBinlogClient(db *sql.DB, host string, username, password string, appl ap
 		callerCancelFunc:           config.CancelFunc,
 		ddlFilterSchema:            config.DDLFilterSchema,
 		ddlFilterTables:            toSet(config.DDLFilterTables),
+		ddlTolerated:               toSet(config.DDLToleratedTables),
 		serverID:                   config.ServerID,
 		applier:                    appl,
 		subscriptionSoftLimitBytes: softLimit,
+		heartbeatPeriod:            heartbeatPeriod,
+		readTimeout:                readTimeout,
+		periodicFlushPaused:        config.PeriodicFlushPaused,
 	}
 }
 
@@ -260,6 +279,23 @@ func (c *binlogClient) GetDeltaLen() int {
 	return deltaLen
 }
 
+// DeltaMemoryBytes returns the estimated memory held by the changes
+// that are pending across all subscriptions.
+// Satisfies Source interface.
+func (c *binlogClient) DeltaMemoryBytes() int64 {
+	var n int64
+	for _, subscription := range c.subs.Snapshot() {
+		n += subscription.MemoryBytes()
+	}
+	return n
+}
+
+// Lag returns how far behind the source the stream was when it last read an
+// event. Satisfies Source interface.
+func (c *binlogClient) Lag() time.Duration {
+	return c.lag.get()
+}
+
 func (c *binlogClient) getCurrentBinlogPosition(ctx context.Context) (mysql.Position, error) {
 	// We rotate the binary log before we start, so we can always safely just resume
 	// by reopening the binary log file at Position 4. This is required to get the table map.
@@ -324,6 +360,10 @@ func (c *binlogClient) buildSyncerConfig(host string, port uint16) replication.B
 		User:     c.username,
 		Password: c.password,
 		Logger:   c.logger,
+		// Detect a hung connection instead of blocking forever; the
+		// heartbeat keeps an idle stream under the read timeout.
+		HeartbeatPeriod: c.heartbeatPeriod,
+		ReadTimeout:     c.readTimeout,
 		// Render JSON columns directly from the JSONB byte stream in the
 		// same textual form MySQL produces from SELECT json_col. The
 		// default decoder goes through Go intermediate values + json.Marshal
@@ -393,7 +433,10 @@ func (c *binlogClient) Start(ctx context.Context) error {
 		}
 	}
 	c.bufferedPos = c.flushedPos // set buffered to the initial flushed value
-	c.syncer = replication.NewBinlogSyncer(c.cfg)
+	c.syncer, err = newSyncer(&c.cfg, c.dbConfig)
+	if err != nil {
+		return err
+	}
 	c.streamer, err = c.syncer.StartSync(c.flushedPos)
 	if err != nil {
 		// Close the syncer we just created so its internal goroutines exit
@@ -450,8 +493,11 @@ func (c *binlogClient) recreateStreamer() error {
 		"new_start_position", newStartPos,
 	)
 
-	c.syncer = replication.NewBinlogSyncer(c.cfg)
 	var err error
+	c.syncer, err = newSyncer(&c.cfg, c.dbConfig)
+	if err != nil {
+		return err
+	}
 	c.streamer, err = c.syncer.StartSync(newStartPos)
 	if err != nil {
 		c.logger.Error("Failed to start binlog streamer in recreateStreamer",
@@ -599,6 +645,7 @@ func (c *binlogClient) readStream(ctx context.Context) {
 		if ev == nil {
 			continue
 		}
+		c.lag.observe(ev.Header, time.Now())
 		// Handle the event.
 		switch event := ev.Event.(type) {
 		case *replication.RotateEvent:
@@ -701,13 +748,26 @@ func (c *binlogClient) readStream(ctx context.Context) {
 	}
 }
 
+// IgnoreDDL makes the client ignore DDL on schema.table until the returned
+// func is called, so the caller can run its own DDL on a subscribed table
+// (e.g. adding deferred indexes to the new table) without the client
+// treating it as an external schema change. The caller should wait for the
+// client to read past its DDL (BlockWait) before calling the returned func.
+func (c *binlogClient) IgnoreDDL(schema, table string) (restore func()) {
+	return c.ddlIgnore.add(schema, table)
+}
+
 // processDDLNotification cancels the client if the DDL matches our filter criteria.
 // By default, only exact schema.table matches against subscriptions trigger cancellation.
 // If ddlFilterSchema is set, any DDL in that schema triggers cancellation instead.
 // If ddlFilterTables is also set (alongside ddlFilterSchema), only DDL on those
 // specific tables within the schema triggers cancellation — this is used for partial
 // moves where only a subset of tables from a schema are being moved.
+// A match on a table in ddlTolerated is logged but does not cancel.
 func (c *binlogClient) processDDLNotification(schema, table string) {
+	if c.ddlIgnore.contains(schema, table) {
+		return
+	}
 	if c.ddlFilterSchema != "" {
 		// Schema-level filtering: cancel on DDL in the specified schema.
 		if schema != c.ddlFilterSchema {
@@ -746,6 +806,10 @@ func (c *binlogClient) processDDLNotification(schema, table string) {
 			return
 		}
 	}
+	if _, ok := c.ddlTolerated[encodeSchemaTable(schema, table)]; ok {
+		c.logger.Warn("table definition changed on a tolerated table, continuing", "schema", schema, "table", table)
+		return
+	}
 	if c.fatalError(FatalReasonSchemaChange) {
 		c.logger.Error("table definition changed, cancelling operation", "schema", schema, "table", table)
 	}
@@ -1110,6 +1174,10 @@ func (c *binlogClient) runPeriodicFlush(ctx context.Context, interval time.Durat
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if c.periodicFlushPaused != nil && c.periodicFlushPaused() {
+				c.logger.Debug("periodic flush of binary log is paused")
+				continue
+			}
 			startLoop := time.Now()
 			c.logger.Debug("starting periodic flush of binary log")
 			// The periodic flush does not respect the throttler since we want to advance the binlog position
@@ -261,6 +261,9 @@ func TestReplClientTransactionCompression(t *testing.T) {
 	require.Equal(t, 20, b)
 }
 
+// TestReplClientResumeFromImpossible checks that a position in a binlog file
+// the server no longer has is rejected by the SHOW BINARY LOGS check, before
+// a syncer is opened.
 func TestReplClientResumeFromImpossible(t *testing.T) {
 	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
 	require.NoError(t, err)
@@ -283,7 +286,8 @@ func TestReplClientResumeFromImpossible(t *testing.T) {
 	require.NoError(t, err)
 	require.NoError(t, client.AddSubscription(t1, t2, chunker))
 	err = client.StartFromPosition(t.Context(), "impossible:12345")
-	require.Error(t, err)
+	require.ErrorIs(t, err, ErrPositionNotFound)
+	require.Nil(t, client.syncer, "no syncer is opened for a purged binlog")
 }
 
 func TestReplClientResumeFromPoint(t *testing.T) {
@@ -942,6 +946,7 @@ type gatedSubscription struct {
 
 func (s *gatedSubscription) HasChanged(key, row []any, deleted bool) {}
 func (s *gatedSubscription) Length() int                             { return 0 }
+func (s *gatedSubscription) MemoryBytes() int64                      { return 0 }
 func (s *gatedSubscription) Flush(_ context.Context, _ bool, _ []*dbconn.TableLock) (bool, error) {
 	<-<-s.gates
 	return true, nil
@@ -1446,6 +1451,46 @@ func TestProcessDDLNotification(t *testing.T) {
 		require.False(t, *cancelled, "should not cancel on DDL in a different schema even if table name matches")
 	})
 
+	t.Run("ignored table: does not cancel until restored", func(t *testing.T) {
+		c, cancelled := makeClient("mydb", nil)
+
+		restore := c.IgnoreDDL("mydb", "_orders_new")
+		nested := c.IgnoreDDL("mydb", "_orders_new")
+		c.processDDLNotification("mydb", "_orders_new")
+		require.False(t, *cancelled, "should not cancel on DDL for an ignored table")
+
+		// Other tables are still watched.
+		c.processDDLNotification("mydb", "orders")
+		require.True(t, *cancelled, "should cancel on DDL for a table that is not ignored")
+
+		// The ignore is refcounted: it holds until every restore is called.
+		*cancelled = false
+		nested()
+		c.processDDLNotification("mydb", "_orders_new")
+		require.False(t, *cancelled, "should not cancel while an IgnoreDDL is outstanding")
+		restore()
+		restore() // calling restore twice is harmless
+		c.processDDLNotification("mydb", "_orders_new")
+		require.True(t, *cancelled, "should cancel on DDL once restored")
+	})
+
+	t.Run("tolerated table: does not cancel, frozen table still does", func(t *testing.T) {
+		c, cancelled := makeClient("mydb", []string{"orders", "order_notes"})
+		c.ddlTolerated = toSet([]string{"mydb.order_notes"})
+
+		// DDL on a tolerated table is logged but does not cancel.
+		c.processDDLNotification("mydb", "order_notes")
+		require.False(t, *cancelled, "should not cancel on DDL for a tolerated table")
+
+		// The table name alone is not enough: the schema must match too.
+		c.processDDLNotification("otherdb", "order_notes")
+		require.False(t, *cancelled, "should not cancel on DDL outside the filtered schema")
+
+		// Tables not listed as tolerated remain frozen.
+		c.processDDLNotification("mydb", "orders")
+		require.True(t, *cancelled, "should cancel on DDL for a frozen table")
+	})
+
 	t.Run("no cancel func: does not panic", func(t *testing.T) {
 		c := &binlogClient{
 			logger:          slog.Default(),
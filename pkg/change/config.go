@@ -3,6 +3,7 @@ package change
 import (
 	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/block/spirit/pkg/dbconn"
 )
@@ -66,11 +67,50 @@ type ClientConfig struct {
 	// If empty (and DDLFilterSchema is set), all tables in the schema trigger cancellation.
 	DDLFilterTables []string
 
+	// DDLToleratedTables lists tables, as "schema.table", whose DDL is
+	// expected and must not cancel the operation. DDL on one of these tables
+	// is logged and otherwise ignored; CancelFunc is not called. This lets a
+	// multi-table caller keep only the tables whose definition it depends on
+	// frozen. If empty (the default), DDL on every watched table cancels.
+	DDLToleratedTables []string
+
 	// SubscriptionSoftLimitBytes overrides DefaultSubscriptionSoftLimitBytes
 	// for new subscriptions. Set to a negative value to disable the cap
 	// entirely (HasChanged will never block on memory). Zero (the
 	// zero-value default) means use DefaultSubscriptionSoftLimitBytes.
 	SubscriptionSoftLimitBytes int64
+
+	// HeartbeatPeriod overrides DefaultHeartbeatPeriod, the interval at
+	// which the source sends heartbeat events on an idle binlog stream.
+	// ReadTimeout overrides DefaultReadTimeout, how long the syncer waits
+	// for an event before treating the connection as dead. For both, zero
+	// means use the default and a negative value disables it. ReadTimeout
+	// should be larger than HeartbeatPeriod, otherwise an idle stream is
+	// repeatedly reconnected.
+	HeartbeatPeriod time.Duration
+	ReadTimeout     time.Duration
+
+	// PeriodicFlushPaused is an optional callback checked on each tick of the
+	// periodic flush: while it returns true the tick is skipped, so changes
+	// stay buffered (up to SubscriptionSoftLimitBytes, after which the stream
+	// stops reading) until it returns false. Explicit calls to Flush are not
+	// affected. The migration runner uses it to implement Runner.Pause.
+	PeriodicFlushPaused func() bool
+}
+
+// syncerTimeouts resolves HeartbeatPeriod and ReadTimeout to the values
+// passed to the binlog syncer, where zero means disabled.
+func (c *ClientConfig) syncerTimeouts() (heartbeatPeriod, readTimeout time.Duration) {
+	resolve := func(d, def time.Duration) time.Duration {
+		switch {
+		case d == 0:
+			return def
+		case d < 0:
+			return 0 // explicit opt-out
+		}
+		return d
+	}
+	return resolve(c.HeartbeatPeriod, DefaultHeartbeatPeriod), resolve(c.ReadTimeout, DefaultReadTimeout)
 }
 
 // NewClientDefaultConfig returns a default config for the copier.
@@ -52,6 +52,7 @@ type gtidClient struct {
 	callerCancelFunc func(FatalReason) bool
 	ddlFilterSchema  string
 	ddlFilterTables  map[string]struct{}
+	ddlTolerated     map[string]struct{} // schema.table; see ClientConfig.DDLToleratedTables
 
 	serverID uint32
 
@@ -83,6 +84,19 @@ type gtidClient struct {
 	streamWG   sync.WaitGroup
 
 	subscriptionSoftLimitBytes int64
+
+	// ddlIgnore holds tables whose DDL processDDLNotification skips.
+	// See IgnoreDDL.
+	ddlIgnore ddlIgnoreSet
+
+	// heartbeatPeriod and readTimeout are passed to the syncer config.
+	// Zero disables them. See ClientConfig.
+	heartbeatPeriod time.Duration
+	readTimeout     time.Duration
+
+	lag lagTracker // see Lag
+
+	periodicFlushPaused func() bool // see ClientConfig.PeriodicFlushPaused
 }
 
 // NewGTIDClient constructs the GTID-backed change.Source. It mirrors
@@ -100,6 +114,7 @@ func NewGTIDClient(db *sql.DB, host string, username, password string, appl appl
 	} else if softLimit < 0 {
 		softLimit = 0
 	}
+	heartbeatPeriod, readTimeout := config.syncerTimeouts()
 	return &gtidClient{
 		db:                         db,
 		dbConfig:                   config.DBConfig,
@@ -111,9 +126,13 @@ func NewGTIDClient(db *sql.DB, host string, username, password string, appl appl
 		callerCancelFunc:           config.CancelFunc,
 		ddlFilterSchema:            config.DDLFilterSchema,
 		ddlFilterTables:            toSet(config.DDLFilterTables),
+		ddlTolerated:               toSet(config.DDLToleratedTables),
 		serverID:                   config.ServerID,
 		applier:                    appl,
 		subscriptionSoftLimitBytes: softLimit,
+		heartbeatPeriod:            heartbeatPeriod,
+		readTimeout:                readTimeout,
+		periodicFlushPaused:        config.PeriodicFlushPaused,
 	}
 }
 
@@ -194,6 +213,18 @@ func validateResumeGTIDSet(flushed, executed, purged mysql.GTIDSet) error {
 	return nil
 }
 
+// IsGTIDPosition reports whether pos, a position returned by a Source's
+// Position, is a GTID set as written by the GTID client, rather than the
+// binlog client's <binlog-file>:<offset>. Callers resuming from a stored
+// position use it to pick the Source that can resume from it.
+func IsGTIDPosition(pos string) bool {
+	if strings.TrimSpace(pos) == "" {
+		return false
+	}
+	_, err := mysql.ParseMysqlGTIDSet(normalizeGTIDString(pos))
+	return err == nil
+}
+
 // normalizeGTIDString strips whitespace (including embedded newlines, which
 // MySQL injects when gtid_executed contains many UUID groups) before parse.
 func normalizeGTIDString(s string) string {
@@ -367,6 +398,10 @@ func (c *gtidClient) buildSyncerConfig(host string, port uint16) replication.Bin
 		User:     c.username,
 		Password: c.password,
 		Logger:   c.logger,
+		// Detect a hung connection instead of blocking forever; the
+		// heartbeat keeps an idle stream under the read timeout.
+		HeartbeatPeriod: c.heartbeatPeriod,
+		ReadTimeout:     c.readTimeout,
 		// Render JSON the same way the binlog client does — see the
 		// rationale on NewBinlogClient.
 		RenderJSONAsMySQLText: true,
@@ -427,7 +462,10 @@ func (c *gtidClient) Start(ctx context.Context) error {
 		}
 	}
 	c.bufferedGTID = c.flushedGTID.Clone()
-	c.syncer = replication.NewBinlogSyncer(c.cfg)
+	c.syncer, err = newSyncer(&c.cfg, c.dbConfig)
+	if err != nil {
+		return err
+	}
 	// Clone to avoid data race
 	c.streamer, err = c.syncer.StartSyncGTID(c.flushedGTID.Clone())
 	if err != nil {
@@ -460,8 +498,11 @@ func (c *gtidClient) recreateStreamer() error {
 		c.syncer.Close()
 	}
 	resumeFrom := c.bufferedGTID.Clone()
-	c.syncer = replication.NewBinlogSyncer(c.cfg)
 	var err error
+	c.syncer, err = newSyncer(&c.cfg, c.dbConfig)
+	if err != nil {
+		return err
+	}
 	c.streamer, err = c.syncer.StartSyncGTID(resumeFrom)
 	if err != nil {
 		c.logger.Error("Failed to start GTID binlog streamer in recreateStreamer",
@@ -586,6 +627,7 @@ func (c *gtidClient) readStream(ctx context.Context) {
 		if ev == nil {
 			continue
 		}
+		c.lag.observe(ev.Header, time.Now())
 		switch event := ev.Event.(type) {
 		case *replication.GTIDEvent:
 			// The server emits a GTIDEvent at the start of every
@@ -837,8 +879,20 @@ func (c *gtidClient) processTransactionPayload(e *replication.TransactionPayload
 	return nil
 }
 
+// IgnoreDDL makes the client ignore DDL on schema.table until the returned
+// func is called, so the caller can run its own DDL on a subscribed table
+// (e.g. adding deferred indexes to the new table) without the client
+// treating it as an external schema change. The caller should wait for the
+// client to read past its DDL (BlockWait) before calling the returned func.
+func (c *gtidClient) IgnoreDDL(schema, table string) (restore func()) {
+	return c.ddlIgnore.add(schema, table)
+}
+
 // processDDLNotification mirrors binlogClient.processDDLNotification.
 func (c *gtidClient) processDDLNotification(schema, table string) {
+	if c.ddlIgnore.contains(schema, table) {
+		return
+	}
 	if c.ddlFilterSchema != "" {
 		if schema != c.ddlFilterSchema {
 			return
@@ -873,6 +927,10 @@ func (c *gtidClient) processDDLNotification(schema, table string) {
 			return
 		}
 	}
+	if _, ok := c.ddlTolerated[encodeSchemaTable(schema, table)]; ok {
+		c.logger.Warn("table definition changed on a tolerated table, continuing", "schema", schema, "table", table)
+		return
+	}
 	if c.fatalError(FatalReasonSchemaChange) {
 		c.logger.Error("table definition changed, cancelling operation", "schema", schema, "table", table)
 	}
@@ -967,6 +1025,20 @@ func (c *gtidClient) GetDeltaLen() int {
 	return deltaLen
 }
 
+// DeltaMemoryBytes satisfies Source.
+func (c *gtidClient) DeltaMemoryBytes() int64 {
+	var n int64
+	for _, subscription := range c.subs.Snapshot() {
+		n += subscription.MemoryBytes()
+	}
+	return n
+}
+
+// Lag satisfies Source.
+func (c *gtidClient) Lag() time.Duration {
+	return c.lag.get()
+}
+
 func (c *gtidClient) Close() {
 	c.isClosed.Store(true)
 
@@ -1100,6 +1172,10 @@ func (c *gtidClient) runPeriodicFlush(ctx context.Context, interval time.Duratio
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
+			if c.periodicFlushPaused != nil && c.periodicFlushPaused() {
+				c.logger.Debug("periodic flush of GTID changeset is paused")
+				continue
+			}
 			startLoop := time.Now()
 			c.logger.Debug("starting periodic flush of GTID changeset")
 			if err := c.flush(ctx, false, nil); err != nil {
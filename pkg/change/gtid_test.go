@@ -41,6 +41,51 @@ func TestSyncerConfigDecodeOptions(t *testing.T) {
 	}
 }
 
+// TestSyncerConfigTimeouts verifies that both change sources pass the
+// configured heartbeat period and read timeout to the syncer, fall back to
+// the defaults when unset, and disable them when negative.
+func TestSyncerConfigTimeouts(t *testing.T) {
+	tests := []struct {
+		name              string
+		config            *ClientConfig
+		expectedHeartbeat time.Duration
+		expectedTimeout   time.Duration
+	}{
+		{
+			name:              "defaults",
+			config:            &ClientConfig{},
+			expectedHeartbeat: DefaultHeartbeatPeriod,
+			expectedTimeout:   DefaultReadTimeout,
+		},
+		{
+			name:              "configured",
+			config:            &ClientConfig{HeartbeatPeriod: 5 * time.Second, ReadTimeout: 20 * time.Second},
+			expectedHeartbeat: 5 * time.Second,
+			expectedTimeout:   20 * time.Second,
+		},
+		{
+			name:              "disabled",
+			config:            &ClientConfig{HeartbeatPeriod: -1, ReadTimeout: -1},
+			expectedHeartbeat: 0,
+			expectedTimeout:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			binlog := NewBinlogClient(nil, "127.0.0.1:3306", "", "", nil, tt.config).(*binlogClient)
+			gtid := NewGTIDClient(nil, "127.0.0.1:3306", "", "", nil, tt.config).(*gtidClient)
+			configs := map[string]replication.BinlogSyncerConfig{
+				"binlog": binlog.buildSyncerConfig("127.0.0.1", 3306),
+				"gtid":   gtid.buildSyncerConfig("127.0.0.1", 3306),
+			}
+			for name, cfg := range configs {
+				require.Equal(t, tt.expectedHeartbeat, cfg.HeartbeatPeriod, "%s client heartbeat period", name)
+				require.Equal(t, tt.expectedTimeout, cfg.ReadTimeout, "%s client read timeout", name)
+			}
+		})
+	}
+}
+
 // TestGTIDClient mirrors TestReplClient but uses the GTID-backed change
 // source. Verifies the basic INSERT → buffer → flush loop end-to-end.
 func TestGTIDClient(t *testing.T) {
@@ -1554,3 +1599,14 @@ func TestGTIDProcessDDLNotificationMoveStyle(t *testing.T) {
 	c.processDDLNotification(dbName, "orders")
 	require.True(t, cancelled, "should cancel on DDL matching the subscribed table")
 }
+
+func TestIsGTIDPosition(t *testing.T) {
+	require.True(t, IsGTIDPosition("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5"))
+	// A single transaction also parses as a binlog file:offset, but a binlog
+	// file is never named like a server UUID.
+	require.True(t, IsGTIDPosition("3e11fa47-71ca-11e1-9e33-c80aa9429562:5"))
+	require.True(t, IsGTIDPosition("3e11fa47-71ca-11e1-9e33-c80aa9429562:1-5,\n4a5cd22e-71ca-11e1-9e33-c80aa9429562:1-2"))
+
+	require.False(t, IsGTIDPosition("mysql-bin.000042:1234"))
+	require.False(t, IsGTIDPosition(""))
+}
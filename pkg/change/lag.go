@@ -0,0 +1,30 @@
+package change
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+)
+
+// lagTracker records how far behind the source a stream is: the time between
+// an event being written on the source (its header timestamp) and the stream
+// reading it, as of the last event read. Heartbeats and the artificial events
+// sent at the start of a stream have no timestamp and are ignored.
+//
+// Like Seconds_Behind_Source it assumes the clocks of spirit and the source
+// agree, and it does not grow while no events arrive.
+type lagTracker struct {
+	lag atomic.Int64 // nanoseconds
+}
+
+func (l *lagTracker) observe(header *replication.EventHeader, now time.Time) {
+	if header == nil || header.Timestamp == 0 {
+		return
+	}
+	l.lag.Store(int64(max(now.Sub(time.Unix(int64(header.Timestamp), 0)), 0)))
+}
+
+func (l *lagTracker) get() time.Duration {
+	return time.Duration(l.lag.Load())
+}
@@ -0,0 +1,26 @@
+package change
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/replication"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLagTracker(t *testing.T) {
+	var l lagTracker
+	require.Equal(t, time.Duration(0), l.get())
+
+	now := time.Unix(1_000_000, 0)
+	l.observe(&replication.EventHeader{Timestamp: 1_000_000 - 30}, now)
+	require.Equal(t, 30*time.Second, l.get())
+
+	// Events without a timestamp (heartbeats) do not change it.
+	l.observe(&replication.EventHeader{}, now)
+	require.Equal(t, 30*time.Second, l.get())
+
+	// Clock skew never makes it negative.
+	l.observe(&replication.EventHeader{Timestamp: 1_000_000 + 5}, now)
+	require.Equal(t, time.Duration(0), l.get())
+}
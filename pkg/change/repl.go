@@ -53,6 +53,15 @@ const (
 	// (binlog_expire_logs_seconds). Tune this value, or the source's
 	// retention, accordingly.
 	DefaultSubscriptionSoftLimitBytes = 256 << 20
+	// DefaultHeartbeatPeriod is how often the source is asked to send a
+	// heartbeat event when there are no binlog events to send. It keeps
+	// an idle but healthy stream from tripping DefaultReadTimeout.
+	DefaultHeartbeatPeriod = 15 * time.Second
+	// DefaultReadTimeout is how long the binlog syncer waits for any event
+	// (including heartbeats) before treating the connection as dead and
+	// reconnecting. Without it a hung connection stalls the migration
+	// indefinitely. It must be comfortably larger than DefaultHeartbeatPeriod.
+	DefaultReadTimeout = 60 * time.Second
 	// DefaultTimeout is how long BlockWait is supposed to wait before returning errors.
 	DefaultTimeout = 30 * time.Second
 	// Maximum number of consecutive errors before recreating the streamer
@@ -120,6 +120,21 @@ type Source interface {
 	// the backlog is small enough to consider cutover.
 	GetDeltaLen() int
 
+	// DeltaMemoryBytes returns an estimate of the memory held by the
+	// pending changes across all registered subscriptions. Each
+	// subscription blocks new changes once its own share reaches
+	// DefaultSubscriptionSoftLimitBytes, so operators can use it to size
+	// that limit.
+	DeltaMemoryBytes() int64
+
+	// Lag returns how far behind the source the stream was when it last
+	// read an event: the time between the event being written on the
+	// source and being read. It is zero before the first event, does not
+	// grow while the source is idle, and assumes the clocks of spirit and
+	// the source agree. Changes are applied to the targets later, when
+	// they are flushed; GetDeltaLen covers that backlog.
+	Lag() time.Duration
+
 	// SetWatermarkOptimization toggles the high/low watermark
 	// optimization across all subscriptions. Disabled before
 	// checksum/cutover to ensure all changes are flushed regardless of
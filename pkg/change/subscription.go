@@ -27,6 +27,10 @@ import (
 type Subscription interface {
 	HasChanged(key, row []any, deleted bool)
 	Length() int
+	// MemoryBytes returns an estimate of the memory held by the pending
+	// changes, including their hashed keys. It is the figure the soft
+	// memory limit is checked against.
+	MemoryBytes() int64
 	// Flush writes the pending changes to the target(s) via the applier.
 	// When underLock is true, locks carries the table locks the caller is
 	// holding — one per target server — and the applier executes each
@@ -339,6 +339,15 @@ func (s *bufferedMap) Length() int {
 	return len(s.changes) + len(s.queue)
 }
 
+// MemoryBytes returns sizeBytes; see estimateRowSize and the per-entry
+// overheads for what it counts.
+func (s *bufferedMap) MemoryBytes() int64 {
+	s.Lock()
+	defer s.Unlock()
+
+	return s.sizeBytes
+}
+
 func (s *bufferedMap) Tables() []*table.TableInfo {
 	if s.newTable == nil {
 		// Move-flow subscriptions have no destination-side TableInfo (see
@@ -391,9 +400,9 @@ func (s *bufferedMap) HasChanged(key, row []any, deleted bool) {
 	// We then disable the optimization after the copier phase has finished.
 	// Watermark drops happen before the soft-limit wait — those rows never
 	// enter the buffer, so there is no point parking on their behalf.
-	if s.watermarkOptimizationEnabled() && s.chunker.KeyAboveHighWatermark(key[0]) {
+	if s.watermarkOptimizationEnabled() && s.chunker.KeyAboveHighWatermark(key) {
 		s.keysDroppedAbove.Add(1)
-		s.logger.Debug("key above watermark", "key", key[0])
+		s.logger.Debug("key above watermark", "key", key)
 		return
 	}
 
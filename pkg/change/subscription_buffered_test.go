@@ -1317,6 +1317,34 @@ func TestBufferedMapSizeAccounting(t *testing.T) {
 		"sizeBytes must balance after a full drain")
 }
 
+// TestDeltaMemoryBytesGrowsWithKeys checks that the memory reported for the
+// pending changes grows with every newly tracked key, both per subscription
+// and summed across a client's subscriptions, and drops back to zero once
+// the changes are flushed.
+func TestDeltaMemoryBytesGrowsWithKeys(t *testing.T) {
+	sub1 := newBareBufferedMap(0)
+	sub2 := newBareBufferedMap(0)
+	client := &binlogClient{subs: newSubscriptionRegistry()}
+	require.True(t, client.subs.Add("test.t1", sub1))
+	require.True(t, client.subs.Add("test.t2", sub2))
+	require.Equal(t, int64(0), client.DeltaMemoryBytes())
+
+	var last int64
+	for i := range 100 {
+		sub1.HasChanged([]any{int32(i)}, []any{int32(i), "row"}, false)
+		require.Greater(t, sub1.MemoryBytes(), last, "key %d", i)
+		last = sub1.MemoryBytes()
+	}
+	require.Equal(t, last, client.DeltaMemoryBytes())
+
+	sub2.HasChanged([]any{int32(1)}, []any{int32(1), "row"}, false)
+	require.Equal(t, last+sub2.MemoryBytes(), client.DeltaMemoryBytes())
+
+	drainBareBufferedMap(sub1)
+	drainBareBufferedMap(sub2)
+	require.Equal(t, int64(0), client.DeltaMemoryBytes())
+}
+
 func TestBufferedMapSoftLimitBackpressure(t *testing.T) {
 	sub := newBareBufferedMap(1024)
 
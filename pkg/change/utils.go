@@ -4,7 +4,9 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 
+	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/utils"
 	"github.com/go-mysql-org/go-mysql/replication"
@@ -17,6 +19,21 @@ func encodeSchemaTable(schema, table string) string {
 	return schema + "." + table
 }
 
+// newSyncer returns a BinlogSyncer for cfg. When dbConfig has a
+// CredentialProvider, the user and password in cfg are refreshed from it
+// first, so that reconnecting after the original credentials expired (as
+// RDS IAM auth tokens do) still authenticates.
+func newSyncer(cfg *replication.BinlogSyncerConfig, dbConfig *dbconn.DBConfig) (*replication.BinlogSyncer, error) {
+	if dbConfig != nil && dbConfig.CredentialProvider != nil {
+		user, password, err := dbConfig.CredentialProvider()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get database credentials: %w", err)
+		}
+		cfg.User, cfg.Password = user, password
+	}
+	return replication.NewBinlogSyncer(*cfg), nil
+}
+
 // getTableIdentity extracts the schema and table name from an AST node that has table information
 func getTableIdentity(defaultSchema string, node ast.Node) (string, string) {
 	var schema, table string
@@ -36,6 +53,40 @@ func getTableIdentity(defaultSchema string, node ast.Node) (string, string) {
 	return schema, table
 }
 
+// ddlIgnoreSet holds the tables whose DDL a client has been asked to ignore,
+// because the caller is running that DDL itself. The zero value is ready to use.
+type ddlIgnoreSet struct {
+	mu     sync.Mutex
+	tables map[string]int // refcounted, so overlapping IgnoreDDL calls nest
+}
+
+// add ignores DDL on schema.table until the returned func is called.
+func (s *ddlIgnoreSet) add(schema, table string) func() {
+	key := encodeSchemaTable(schema, table)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.tables == nil {
+		s.tables = make(map[string]int)
+	}
+	s.tables[key]++
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+			if s.tables[key]--; s.tables[key] <= 0 {
+				delete(s.tables, key)
+			}
+		})
+	}
+}
+
+func (s *ddlIgnoreSet) contains(schema, table string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tables[encodeSchemaTable(schema, table)] > 0
+}
+
 // schemaTable is a parsed schema and table name pair extracted from a DDL statement.
 type schemaTable struct {
 	schema string
@@ -205,11 +256,6 @@ func isMinimalRowImage(e *replication.RowsEvent) bool {
 //     network blip or auth hiccup is recoverable,
 //     while reporting "purged" abandons the checkpoint
 //     and forces a full re-copy.
-//
-// pkg/migration.Runner has a near-identical helper (binlogFileExists)
-// over the same SHOW BINARY LOGS query. Consolidating into a single
-// shared helper would mean exposing it from pkg/change or pulling both
-// into a lower-level package; left as a follow-up.
 func binlogPositionIsImpossible(ctx context.Context, db *sql.DB, expectedLogName string) (bool, error) {
 	rows, err := db.QueryContext(ctx, "SHOW BINARY LOGS")
 	if err != nil {
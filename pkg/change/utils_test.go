@@ -1,8 +1,10 @@
 package change
 
 import (
+	"errors"
 	"testing"
 
+	"github.com/block/spirit/pkg/dbconn"
 	"github.com/go-mysql-org/go-mysql/replication"
 	"github.com/stretchr/testify/require"
 )
@@ -419,3 +421,27 @@ func TestIsMinimalRowImage(t *testing.T) {
 		require.True(t, isMinimalRowImage(e))
 	})
 }
+
+// TestNewSyncerRefreshesCredentials checks that newSyncer takes the user and
+// password from the DBConfig's CredentialProvider, and fails without a
+// syncer when the provider does.
+func TestNewSyncerRefreshesCredentials(t *testing.T) {
+	cfg := replication.BinlogSyncerConfig{ServerID: 1, Flavor: "mysql", User: "spirit", Password: "expired-token"}
+	dbConfig := dbconn.NewDBConfig()
+	dbConfig.CredentialProvider = func() (string, string, error) {
+		return "spirit_iam", "fresh-token", nil
+	}
+	syncer, err := newSyncer(&cfg, dbConfig)
+	require.NoError(t, err)
+	syncer.Close()
+	require.Equal(t, "spirit_iam", cfg.User)
+	require.Equal(t, "fresh-token", cfg.Password)
+
+	providerErr := errors.New("token service unavailable")
+	dbConfig.CredentialProvider = func() (string, string, error) {
+		return "", "", providerErr
+	}
+	syncer, err = newSyncer(&cfg, dbConfig)
+	require.ErrorIs(t, err, providerErr)
+	require.Nil(t, syncer)
+}
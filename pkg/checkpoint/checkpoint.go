@@ -18,9 +18,11 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/block/spirit/pkg/dbconn"
+	"github.com/block/spirit/pkg/dbconn/sqlescape"
 	"github.com/go-sql-driver/mysql"
 )
 
@@ -59,9 +61,9 @@ type Record struct {
 	// a single source (migration), or a JSON map of per-source positions for a
 	// multi-source move. Stored in the binlog_position column.
 	Position string
-	// Statement is the migration's ALTER statement, which the runner uses to
-	// match a resume to its checkpoint (ErrMismatchedAlter). Empty for move and
-	// datasync.
+	// Statement is the migration's ALTER statement, in the form returned by
+	// statement.Canonicalize, which the runner uses to match a resume to its
+	// checkpoint (ErrMismatchedAlter). Empty for move and datasync.
 	Statement string
 	// OriginalTableName is the untruncated source table name, stored so a
 	// single-table migration can detect the rare case where two long table
@@ -73,6 +75,26 @@ type Record struct {
 	// way). It gates resume so a restart neither re-copies nor re-cuts-over.
 	// Stored in move_phase.
 	Phase string
+	// CorrelationID is an external identifier (e.g. a ticket) the operator
+	// attached to the migration, recorded for auditing. Empty when unset, and
+	// always empty for move and datasync. Stored in correlation_id, which is
+	// only read and written in Transient mode: a Persistent table can predate
+	// the column, since it survives a spirit upgrade.
+	CorrelationID string
+	// NewTableName and OldTableName are the migration's --new-table-name and
+	// --old-table-name, so a later run only drops or resumes into tables with
+	// those names when they were given to the run that wrote the checkpoint.
+	// Empty when the generated names are used, and always empty for move and
+	// datasync. Stored in new_table_name and old_table_name, which like
+	// correlation_id are only read and written in Transient mode.
+	NewTableName string
+	OldTableName string
+	// DeferSecondaryIndexes records that the migration created its new tables
+	// without their non-unique secondary indexes, so a resume knows to add
+	// them back after the copy. Always false for move and datasync. Stored in
+	// defer_secondary_indexes, which is only read and written in Transient
+	// mode.
+	DeferSecondaryIndexes bool
 	// CutoverAt is when the forward cutover completed, used to compute the
 	// reverse-window deadline across a resume. Zero when not past cutover; stored
 	// in cutover_at as an RFC3339 string ("" when zero).
@@ -115,6 +137,10 @@ type Table struct {
 	db   *sql.DB
 	name string
 	mode Mode
+	// ddlHook, if set, is called with each DDL statement before it runs.
+	ddlHook func(stmt string)
+	// tableOptions, if set, are appended to the CREATE TABLE.
+	tableOptions string
 }
 
 // NewTable returns a handle to the checkpoint table name on db (in db's selected
@@ -123,6 +149,33 @@ func NewTable(db *sql.DB, name string, mode Mode) *Table {
 	return &Table{db: db, name: name, mode: mode}
 }
 
+// SetDDLHook sets a function that is called with each DDL statement Create
+// and Drop run, immediately before it is executed. A nil hook disables it.
+func (t *Table) SetDDLHook(hook func(stmt string)) {
+	t.ddlHook = hook
+}
+
+// SetTableOptions sets table options (e.g. "ROW_FORMAT=COMPRESSED") that
+// Create appends to the CREATE TABLE, for servers that require them. They
+// are not escaped: the caller is responsible for validating them.
+func (t *Table) SetTableOptions(opts string) {
+	t.tableOptions = opts
+}
+
+// execDDL formats and runs a DDL statement like dbconn.Exec, passing it to
+// the DDL hook first.
+func (t *Table) execDDL(ctx context.Context, stmt string, args ...any) error {
+	stmt, err := sqlescape.EscapeSQL(stmt, args...)
+	if err != nil {
+		return err
+	}
+	if t.ddlHook != nil {
+		t.ddlHook(stmt)
+	}
+	_, err = t.db.ExecContext(ctx, stmt)
+	return err
+}
+
 // tableDDL is the column list for CREATE TABLE. The schema is the union of what
 // the runners need; move and datasync leave statement and original_table_name
 // empty. id is the primary key single-owner modes REPLACE on to keep one row.
@@ -135,9 +188,22 @@ const tableDDL = `(
 	original_table_name VARCHAR(64) NOT NULL DEFAULT '',
 	move_phase VARCHAR(32) NOT NULL DEFAULT '',
 	cutover_at TEXT,
+	correlation_id VARCHAR(255) NOT NULL DEFAULT '',
+	new_table_name VARCHAR(64) NOT NULL DEFAULT '',
+	old_table_name VARCHAR(64) NOT NULL DEFAULT '',
+	defer_secondary_indexes TINYINT(1) NOT NULL DEFAULT 0,
 	created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
 )`
 
+// ddl returns tableDDL followed by the table options, if any. The result is
+// formatted by execDDL, so a % in the options is escaped.
+func (t *Table) ddl() string {
+	if t.tableOptions == "" {
+		return tableDDL
+	}
+	return tableDDL + " " + strings.ReplaceAll(t.tableOptions, "%", "%%")
+}
+
 // Create prepares the checkpoint table for a run. Behaviour depends on Mode:
 //
 //   - Transient (single-table / atomic multi-table migration, move): DROP +
@@ -150,12 +216,12 @@ const tableDDL = `(
 func (t *Table) Create(ctx context.Context) error {
 	switch t.mode {
 	case Transient:
-		if err := dbconn.Exec(ctx, t.db, "DROP TABLE IF EXISTS %n", t.name); err != nil {
+		if err := t.execDDL(ctx, "DROP TABLE IF EXISTS %n", t.name); err != nil {
 			return err
 		}
-		return dbconn.Exec(ctx, t.db, "CREATE TABLE %n "+tableDDL, t.name)
+		return t.execDDL(ctx, "CREATE TABLE %n "+t.ddl(), t.name)
 	case Persistent:
-		return dbconn.Exec(ctx, t.db, "CREATE TABLE IF NOT EXISTS %n "+tableDDL, t.name)
+		return t.execDDL(ctx, "CREATE TABLE IF NOT EXISTS %n "+t.ddl(), t.name)
 	default:
 		return fmt.Errorf("checkpoint: unknown table mode %d", t.mode)
 	}
@@ -163,7 +229,7 @@ func (t *Table) Create(ctx context.Context) error {
 
 // Drop removes this run's checkpoint (DROP TABLE IF EXISTS).
 func (t *Table) Drop(ctx context.Context) error {
-	return dbconn.Exec(ctx, t.db, "DROP TABLE IF EXISTS %n", t.name)
+	return t.execDDL(ctx, "DROP TABLE IF EXISTS %n", t.name)
 }
 
 // Write records a checkpoint row, keeping a single row by overwriting it in
@@ -176,11 +242,19 @@ func (t *Table) Write(ctx context.Context, rec Record) error {
 	if !rec.CutoverAt.IsZero() {
 		cutoverAt = rec.CutoverAt.UTC().Format(time.RFC3339Nano)
 	}
+	if t.mode != Transient {
+		return dbconn.Exec(ctx, t.db,
+			"REPLACE INTO %n (id, copier_watermark, checksum_watermark, binlog_position, statement, original_table_name, move_phase, cutover_at) VALUES (1, %?, %?, %?, %?, %?, %?, %?)",
+			t.name,
+			rec.CopierWatermark, rec.ChecksumWatermark, rec.Position, rec.Statement, rec.OriginalTableName,
+			rec.Phase, cutoverAt,
+		)
+	}
 	return dbconn.Exec(ctx, t.db,
-		"REPLACE INTO %n (id, copier_watermark, checksum_watermark, binlog_position, statement, original_table_name, move_phase, cutover_at) VALUES (1, %?, %?, %?, %?, %?, %?, %?)",
+		"REPLACE INTO %n (id, copier_watermark, checksum_watermark, binlog_position, statement, original_table_name, move_phase, cutover_at, correlation_id, new_table_name, old_table_name, defer_secondary_indexes) VALUES (1, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)",
 		t.name,
 		rec.CopierWatermark, rec.ChecksumWatermark, rec.Position, rec.Statement, rec.OriginalTableName,
-		rec.Phase, cutoverAt,
+		rec.Phase, cutoverAt, rec.CorrelationID, rec.NewTableName, rec.OldTableName, rec.DeferSecondaryIndexes,
 	)
 }
 
@@ -191,16 +265,22 @@ func (t *Table) Write(ctx context.Context, rec Record) error {
 // an incompatible spirit version that is missing a column surfaces as a read
 // error, so resume fails safely rather than silently misreading.
 func (t *Table) ReadLatest(ctx context.Context) (Record, error) {
+	// correlation_id, the table names and defer_secondary_indexes are
+	// Transient-only; see Record.CorrelationID.
+	transientColumns := "'' AS correlation_id, '' AS new_table_name, '' AS old_table_name, 0 AS defer_secondary_indexes"
+	if t.mode == Transient {
+		transientColumns = "correlation_id, new_table_name, old_table_name, defer_secondary_indexes"
+	}
 	query := fmt.Sprintf(
-		"SELECT copier_watermark, checksum_watermark, binlog_position, statement, original_table_name, move_phase, cutover_at, created_at FROM `%s` ORDER BY id DESC LIMIT 1",
-		t.name)
+		"SELECT copier_watermark, checksum_watermark, binlog_position, statement, original_table_name, move_phase, cutover_at, %s, created_at FROM `%s` ORDER BY id DESC LIMIT 1",
+		transientColumns, t.name)
 
 	var rec Record
 	var createdAt string
 	var cutoverAt sql.NullString
 	err := t.db.QueryRowContext(ctx, query).Scan(
 		&rec.CopierWatermark, &rec.ChecksumWatermark, &rec.Position, &rec.Statement, &rec.OriginalTableName,
-		&rec.Phase, &cutoverAt, &createdAt)
+		&rec.Phase, &cutoverAt, &rec.CorrelationID, &rec.NewTableName, &rec.OldTableName, &rec.DeferSecondaryIndexes, &createdAt)
 	if errors.Is(err, sql.ErrNoRows) {
 		return Record{}, ErrNotFound
 	}
@@ -44,11 +44,15 @@ func TestTableLifecycleTransient(t *testing.T) {
 
 	// Write a row and read every field back.
 	rec := checkpoint.Record{
-		CopierWatermark:   "cw1",
-		ChecksumWatermark: "sw1",
-		Position:          "pos1",
-		Statement:         "ALTER TABLE t ENGINE=InnoDB",
-		OriginalTableName: "t1",
+		CopierWatermark:       "cw1",
+		ChecksumWatermark:     "sw1",
+		Position:              "pos1",
+		Statement:             "ALTER TABLE t ENGINE=InnoDB",
+		OriginalTableName:     "t1",
+		CorrelationID:         "TICKET-123",
+		NewTableName:          "t1_shadow",
+		OldTableName:          "t1_backup",
+		DeferSecondaryIndexes: true,
 	}
 	require.NoError(t, tbl.Write(t.Context(), rec))
 	got, err := tbl.ReadLatest(t.Context())
@@ -58,6 +62,10 @@ func TestTableLifecycleTransient(t *testing.T) {
 	require.Equal(t, rec.Position, got.Position)
 	require.Equal(t, rec.Statement, got.Statement)
 	require.Equal(t, rec.OriginalTableName, got.OriginalTableName)
+	require.Equal(t, rec.CorrelationID, got.CorrelationID)
+	require.Equal(t, rec.NewTableName, got.NewTableName)
+	require.Equal(t, rec.OldTableName, got.OldTableName)
+	require.True(t, got.DeferSecondaryIndexes)
 	require.False(t, got.CreatedAt.IsZero())
 	require.Less(t, got.Age(), time.Hour, "a just-written checkpoint is fresh")
 
@@ -92,6 +100,22 @@ func TestCreateTransientIsFresh(t *testing.T) {
 	require.ErrorIs(t, err, checkpoint.ErrNotFound)
 }
 
+// TestCreateWithTableOptions verifies Create appends the table options to the
+// CREATE TABLE.
+func TestCreateWithTableOptions(t *testing.T) {
+	db, schema := setup(t)
+	name := "_ckpt_test_options"
+	t.Cleanup(func() { _ = dbconn.Exec(t.Context(), db, "DROP TABLE IF EXISTS %n.%n", schema, name) })
+	tbl := checkpoint.NewTable(db, name, checkpoint.Transient)
+	tbl.SetTableOptions("ROW_FORMAT=COMPRESSED")
+
+	require.NoError(t, tbl.Create(t.Context()))
+	var rowFormat string
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT ROW_FORMAT FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?`, schema, name).Scan(&rowFormat))
+	require.Equal(t, "Compressed", rowFormat)
+}
+
 // TestTablePersistent covers the datasync lifecycle: Create is idempotent and
 // never clears (so a re-create preserves the row a resume needs), Write keeps a
 // single row, and Exists is the resume signal — distinct from "has a row".
@@ -134,3 +158,30 @@ func TestTablePersistent(t *testing.T) {
 	require.NoError(t, err)
 	require.False(t, exists)
 }
+
+// TestTablePersistentPredatesCorrelationID verifies that a Persistent table
+// created by an older spirit version, before correlation_id existed, can still
+// be written and read: CREATE IF NOT EXISTS never adds the column to it.
+func TestTablePersistentPredatesCorrelationID(t *testing.T) {
+	db, schema := setup(t)
+	name := "_ckpt_test_persistent_old"
+	t.Cleanup(func() { _ = dbconn.Exec(t.Context(), db, "DROP TABLE IF EXISTS %n.%n", schema, name) })
+	require.NoError(t, dbconn.Exec(t.Context(), db, `CREATE TABLE %n (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		copier_watermark TEXT,
+		checksum_watermark TEXT,
+		binlog_position TEXT,
+		statement TEXT,
+		original_table_name VARCHAR(64) NOT NULL DEFAULT '',
+		move_phase VARCHAR(32) NOT NULL DEFAULT '',
+		cutover_at TEXT,
+		created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	)`, name))
+	tbl := checkpoint.NewTable(db, name, checkpoint.Persistent)
+	require.NoError(t, tbl.Create(t.Context()))
+	require.NoError(t, tbl.Write(t.Context(), checkpoint.Record{CopierWatermark: "cw1", Position: "pos1"}))
+	got, err := tbl.ReadLatest(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "cw1", got.CopierWatermark)
+	require.Empty(t, got.CorrelationID)
+}
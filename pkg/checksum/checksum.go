@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"time"
 
 	"github.com/block/spirit/pkg/applier"
@@ -13,14 +14,16 @@ import (
 	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/throttler"
 )
 
 var (
-	// Query template for row checksums. The first %s is the column expression
-	// list from table.ColumnMapping.ChecksumExprs(), which already interleaves
-	// a '#' separator between values so content cannot shift across column
+	// Query template for row checksums. The first %s is the row hash of
+	// rowHashExpr over the column expression list from
+	// table.ColumnMapping.ChecksumExprs(), which already interleaves a '#'
+	// separator between values so content cannot shift across column
 	// boundaries undetected.
-	queryTemplate = "SELECT CRC32(CONCAT(%s)) as row_checksum, CONCAT_WS(',', %s) as pk FROM %s WHERE %s"
+	queryTemplate = "SELECT %s as row_checksum, CONCAT_WS(',', %s) as pk FROM %s WHERE %s"
 
 	// ErrYieldTimeout is returned by runChecksum when the yield timeout expires.
 	// This is distinct from the parent context being canceled, and signals that
@@ -28,6 +31,11 @@ var (
 	// long-running transactions to reduce HLL (history list length) growth.
 	ErrYieldTimeout = errors.New("checksum yield timeout")
 
+	// ErrChecksumInProgress is returned by Run and ChecksumRange when the
+	// checker is already running one of them. Both use the checker's
+	// transaction pools, so they cannot run at the same time.
+	ErrChecksumInProgress = errors.New("a checksum is already in progress on this checker")
+
 	// DefaultYieldTimeout is the default maximum duration for a single checksum
 	// pass before yielding to release long-running REPEATABLE READ transactions.
 	DefaultYieldTimeout = 24 * time.Hour
@@ -106,6 +114,13 @@ type Checker interface {
 	// checksum loop uses it to decide whether a sentinel-drop swallow is
 	// safe.
 	DifferencesFound() uint64
+	// ChecksumRange checksums only the rows whose key falls between lower
+	// and upper, rather than the whole table. A nil bound is open. It
+	// returns ErrChecksumInProgress while Run is in progress.
+	ChecksumRange(ctx context.Context, key []string, lower, upper *table.Boundary) error
+	// SetThrottler replaces the throttler that paces Run. It must be called
+	// before Run, not while a pass is in progress.
+	SetThrottler(throttler throttler.Throttler)
 }
 
 type CheckerConfig struct {
@@ -118,6 +133,31 @@ type CheckerConfig struct {
 	MaxRetries      int
 	Applier         applier.Applier // optional; indicates it is a distributed checker
 	YieldTimeout    time.Duration   // maximum duration for a single checksum pass before yielding to release long-running transactions
+	// Throttler is optional. When set, each chunk waits for it before being
+	// checksummed, as in the copier, so the checksum also pauses while
+	// replicas lag. Defaults to a Noop throttler.
+	Throttler throttler.Throttler
+	// WhereCondition is optional. When set, it is a SQL condition (such as
+	// created_at > '2024-01-01') ANDed to each chunk's query, so only the
+	// rows matching it on both the source and target are compared. Create
+	// the chunker with the same condition as table.ChunkerConfig.Where, so
+	// that the chunk boundaries are also found among the matching rows;
+	// otherwise chunks are sized over the whole table. A filtered checksum
+	// is advisory: it says nothing about the rows outside the condition.
+	WhereCondition string
+	// HashFunction is the function each row is hashed with before the hashes
+	// are aggregated with BIT_XOR: HashCRC32 (the default when empty),
+	// HashMD5, HashSHA1 or HashSHA2. The same function is used on the source
+	// and the target. A stronger hash makes a collision unlikely when
+	// investigating a mismatch, at the cost of a slower checksum.
+	HashFunction string
+	// FloatEpsilon is optional. When positive, FLOAT and DOUBLE columns are
+	// compared to within it instead of exactly, so that values that differ
+	// only in the last bits, such as after a change between FLOAT and
+	// DOUBLE, do not fail the checksum. This weakens the checksum: a
+	// difference smaller than FloatEpsilon goes undetected. See
+	// table.ColumnMapping.WithFloatEpsilon.
+	FloatEpsilon float64
 }
 
 func NewCheckerDefaultConfig() *CheckerConfig {
@@ -155,6 +195,16 @@ func NewChecker(sourceDBs []*sql.DB, chunker table.Chunker, feeds []change.Sourc
 	if config.YieldTimeout == 0 {
 		config.YieldTimeout = DefaultYieldTimeout
 	}
+	if config.Throttler == nil {
+		config.Throttler = &throttler.Noop{}
+	}
+	hashFunction, err := normalizeHashFunction(config.HashFunction)
+	if err != nil {
+		return nil, err
+	}
+	if config.FloatEpsilon < 0 || math.IsNaN(config.FloatEpsilon) || math.IsInf(config.FloatEpsilon, 0) {
+		return nil, fmt.Errorf("float epsilon must be a finite number of at least 0, got %v", config.FloatEpsilon)
+	}
 	if config.Applier != nil {
 		return &DistributedChecker{
 			concurrency:    config.Concurrency,
@@ -167,6 +217,10 @@ func NewChecker(sourceDBs []*sql.DB, chunker table.Chunker, feeds []change.Sourc
 			maxRetries:     config.MaxRetries,
 			applier:        config.Applier,
 			yieldTimeout:   config.YieldTimeout,
+			throttler:      config.Throttler,
+			whereCondition: config.WhereCondition,
+			hashFunction:   hashFunction,
+			floatEpsilon:   config.FloatEpsilon,
 		}, nil
 	}
 	return &SingleChecker{
@@ -179,5 +233,98 @@ func NewChecker(sourceDBs []*sql.DB, chunker table.Chunker, feeds []change.Sourc
 		fixDifferences: config.FixDifferences,
 		maxRetries:     config.MaxRetries,
 		yieldTimeout:   config.YieldTimeout,
+		throttler:      config.Throttler,
+		whereCondition: config.WhereCondition,
+		hashFunction:   hashFunction,
+		floatEpsilon:   config.FloatEpsilon,
+	}, nil
+}
+
+// scopeChunk returns chunk restricted to the rows matching where, or chunk
+// itself when where is empty or the chunker already applies it. The chunk
+// is copied, since the chunker keeps the original to track its watermark.
+func scopeChunk(chunk *table.Chunk, where string) *table.Chunk {
+	if where == "" || chunk.AdditionalConditions == where {
+		return chunk
+	}
+	scoped := *chunk
+	if scoped.AdditionalConditions == "" {
+		scoped.AdditionalConditions = where
+	} else {
+		scoped.AdditionalConditions = "(" + scoped.AdditionalConditions + ") AND (" + where + ")"
+	}
+	return &scoped
+}
+
+// newRangeChunk builds the chunk for ChecksumRange. It needs the chunker's
+// column mapping, so only a chunker over a single table pair is supported.
+func newRangeChunk(chunker table.Chunker, key []string, lower, upper *table.Boundary) (*table.Chunk, error) {
+	mc, ok := chunker.(table.MappedChunker)
+	if !ok {
+		return nil, errors.New("checksum range requires a chunker over a single table")
+	}
+	if len(key) == 0 {
+		return nil, errors.New("checksum range requires a key")
+	}
+	for _, b := range []*table.Boundary{lower, upper} {
+		if b != nil && len(b.Value) != len(key) {
+			return nil, fmt.Errorf("checksum range boundary has %d values for a %d column key", len(b.Value), len(key))
+		}
+	}
+	tables := mc.Tables()
+	newTable := tables[0]
+	if len(tables) > 1 {
+		newTable = tables[1]
+	}
+	return &table.Chunk{
+		Key:           key,
+		LowerBound:    lower,
+		UpperBound:    upper,
+		Table:         tables[0],
+		NewTable:      newTable,
+		ColumnMapping: mc.ColumnMapping(),
 	}, nil
 }
+
+// resumeAtLowWatermark re-opens chunker at its low watermark, so that an
+// interrupted pass continues from the first chunk not yet checksummed. If no
+// chunk has been checksummed yet, the chunker starts again from the
+// beginning.
+func resumeAtLowWatermark(chunker table.Chunker) error {
+	watermark, err := chunker.GetLowWatermark()
+	if errors.Is(err, table.ErrWatermarkNotReady) {
+		if err := chunker.Reset(); err != nil {
+			return fmt.Errorf("failed to reset chunker: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get low watermark: %w", err)
+	}
+	if err := chunker.OpenAtWatermark(watermark); err != nil {
+		return fmt.Errorf("failed to resume chunker from watermark: %w", err)
+	}
+	return nil
+}
+
+// waitToRetryChunks reports whether a pass that failed with err should be
+// resumed from the low watermark, which re-checksums the chunk that failed,
+// and waits before returning true. Only transient errors such as a lost
+// connection during a failover are retried, and at most maxRetries times;
+// retries is the number already made. A checksum mismatch is never retried
+// here.
+//
+// The chunk cannot be retried on its own: the connection that was lost held
+// the transaction, and with it the read view that the rest of the pass uses.
+// Resuming takes the table lock again and opens fresh transactions.
+func waitToRetryChunks(ctx context.Context, err error, retries, maxRetries int) bool {
+	if retries >= maxRetries || ctx.Err() != nil || !dbconn.IsRetryableError(err) {
+		return false
+	}
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(time.Duration(retries+1) * time.Second):
+		return true
+	}
+}
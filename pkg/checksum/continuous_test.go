@@ -595,6 +595,8 @@ func (f *fakeFeed) CurrentPosition(context.Context) (string, error)
 func (f *fakeFeed) FlushUnderTableLock(context.Context, []*dbconn.TableLock) error     { return nil }
 func (f *fakeFeed) BlockWait(context.Context) error                                    { return nil }
 func (f *fakeFeed) GetDeltaLen() int                                                   { return 0 }
+func (f *fakeFeed) DeltaMemoryBytes() int64                                            { return 0 }
+func (f *fakeFeed) Lag() time.Duration                                                 { return 0 }
 func (f *fakeFeed) SetWatermarkOptimization(context.Context, bool) error               { return nil }
 func (f *fakeFeed) StartPeriodicFlush(context.Context, time.Duration)                  {}
 func (f *fakeFeed) StopPeriodicFlush()                                                 {}
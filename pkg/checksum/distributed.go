@@ -19,6 +19,7 @@ import (
 	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/throttler"
 	"github.com/block/spirit/pkg/utils"
 	"golang.org/x/sync/errgroup"
 )
@@ -51,13 +52,55 @@ type DistributedChecker struct {
 	maxRetries       int
 	yieldTimeout     time.Duration
 	yieldsPerformed  atomic.Uint64 // number of yield/resume cycles performed
+	inProgress       atomic.Bool   // set while Run or ChecksumRange is running
+	throttler        throttler.Throttler
+	whereCondition   string  // see CheckerConfig.WhereCondition
+	hashFunction     string  // see CheckerConfig.HashFunction
+	floatEpsilon     float64 // see CheckerConfig.FloatEpsilon
 }
 
 var _ Checker = (*DistributedChecker)(nil)
 
 func (c *DistributedChecker) ChecksumChunk(ctx context.Context, chunk *table.Chunk) error {
 	startTime := time.Now()
+	rows, err := c.checksumChunk(ctx, scopeChunk(chunk, c.whereCondition))
+	if err != nil {
+		return err
+	}
+	// When we give feedback, we need to say how many rows were in the chunk.
+	c.chunker.Feedback(chunk, time.Since(startTime), rows)
+	return nil
+}
+
+// ChecksumRange checksums only the rows whose key falls between lower and
+// upper across all sources and targets. See SingleChecker.ChecksumRange.
+func (c *DistributedChecker) ChecksumRange(ctx context.Context, key []string, lower, upper *table.Boundary) error {
+	chunk, err := newRangeChunk(c.chunker, key, lower, upper)
+	if err != nil {
+		return err
+	}
+	// initConnPool replaces the checker's transaction pools, which a Run in
+	// progress is still using.
+	if !c.inProgress.CompareAndSwap(false, true) {
+		return ErrChecksumInProgress
+	}
+	defer c.inProgress.Store(false)
+	if err := c.initConnPool(ctx); err != nil {
+		return err
+	}
+	_, err = c.checksumChunk(ctx, scopeChunk(chunk, c.whereCondition))
+	for _, sp := range c.sourcePools {
+		err = errors.Join(err, sp.trxPool.Close())
+	}
+	for _, pool := range c.targetTrxPools {
+		err = errors.Join(err, pool.Close())
+	}
+	return err
+}
 
+// checksumChunk compares chunk across all sources and targets, fixing or
+// returning a mismatch, and returns the number of target rows in the chunk.
+func (c *DistributedChecker) checksumChunk(ctx context.Context, chunk *table.Chunk) (uint64, error) {
 	c.logger.Debug("checksumming chunk", "chunk", chunk.String())
 
 	// Build the checksum query fragments. The same WHERE clause applies to
@@ -71,9 +114,9 @@ func (c *DistributedChecker) ChecksumChunk(ctx context.Context, chunk *table.Chu
 	// a future server version changed parse behavior (e.g. fixed the double
 	// misrounding bugs), a cross-version move of affected values would fail
 	// the checksum safely rather than pass silently.
-	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.ChecksumExprs()
+	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.WithFloatEpsilon(c.floatEpsilon).ChecksumExprs()
 	if err != nil {
-		return err
+		return 0, err
 	}
 	whereClause := chunk.String()
 
@@ -86,19 +129,15 @@ func (c *DistributedChecker) ChecksumChunk(ctx context.Context, chunk *table.Chu
 	for i := range c.sourcePools {
 		srcTrx, err := c.sourcePools[i].trxPool.Get()
 		if err != nil {
-			return fmt.Errorf("failed to get transaction for source %d: %w", i, err)
+			return 0, fmt.Errorf("failed to get transaction for source %d: %w", i, err)
 		}
 		defer c.sourcePools[i].trxPool.Put(srcTrx)
 
-		sourceQuery := fmt.Sprintf("SELECT BIT_XOR(CRC32(CONCAT(%s))) as checksum, count(*) as c FROM %s WHERE %s",
-			sourceChecksumCols,
-			chunk.Table.QuotedTableName,
-			whereClause,
-		)
+		sourceQuery := chunkChecksumQuery(c.hashFunction, sourceChecksumCols, chunk.Table.QuotedTableName, whereClause)
 		var cs int64
 		var cnt uint64
 		if err := srcTrx.QueryRowContext(ctx, sourceQuery).Scan(&cs, &cnt); err != nil {
-			return fmt.Errorf("failed to query source %d: %w", i, err)
+			return 0, fmt.Errorf("failed to query source %d: %w", i, err)
 		}
 		sourceChecksum ^= cs
 		sourceCount += cnt
@@ -112,19 +151,15 @@ func (c *DistributedChecker) ChecksumChunk(ctx context.Context, chunk *table.Chu
 	for i, targetTrxPool := range c.targetTrxPools {
 		targetTrx, err := targetTrxPool.Get()
 		if err != nil {
-			return fmt.Errorf("failed to get transaction for target %d: %w", i, err)
+			return 0, fmt.Errorf("failed to get transaction for target %d: %w", i, err)
 		}
 		defer targetTrxPool.Put(targetTrx)
 
-		targetQuery := fmt.Sprintf("SELECT BIT_XOR(CRC32(CONCAT(%s))) as checksum, count(*) as c FROM %s WHERE %s",
-			targetChecksumCols,
-			chunk.Table.QuotedTableName,
-			whereClause,
-		)
+		targetQuery := chunkChecksumQuery(c.hashFunction, targetChecksumCols, chunk.Table.QuotedTableName, whereClause)
 		var cs int64
 		var cnt uint64
 		if err := targetTrx.QueryRowContext(ctx, targetQuery).Scan(&cs, &cnt); err != nil {
-			return fmt.Errorf("failed to query target %d: %w", i, err)
+			return 0, fmt.Errorf("failed to query target %d: %w", i, err)
 		}
 		targetChecksum ^= cs
 		targetCount += cnt
@@ -159,16 +194,14 @@ func (c *DistributedChecker) ChecksumChunk(ctx context.Context, chunk *table.Chu
 		// Are we allowed to fix the differences? If not, return an error.
 		// This is mostly used by the test-suite.
 		if !c.fixDifferences {
-			return errors.New("checksum mismatch")
+			return 0, errors.New("checksum mismatch")
 		}
 		// Since we can fix differences, replace the chunk.
 		if err := c.replaceChunk(ctx, chunk); err != nil {
-			return err
+			return 0, err
 		}
 	}
-	// When we give feedback, we need to say how many rows were in the chunk.
-	c.chunker.Feedback(chunk, time.Since(startTime), targetCount)
-	return nil
+	return targetCount, nil
 }
 
 // GetProgress returns rows verified so far and the total to verify, proxied
@@ -330,6 +363,11 @@ func (c *DistributedChecker) ExecTime() time.Duration {
 // mismatch was detected in the most recent (or in-flight) pass. Used by
 // the continuous-checksum loop to decide whether a cancellation swallow
 // is safe.
+// SetThrottler replaces the throttler that paces Run.
+func (c *DistributedChecker) SetThrottler(throttler throttler.Throttler) {
+	c.throttler = throttler
+}
+
 func (c *DistributedChecker) DifferencesFound() uint64 {
 	return c.differencesFound.Load()
 }
@@ -476,6 +514,10 @@ func (c *DistributedChecker) initConnPool(ctx context.Context) error {
 }
 
 func (c *DistributedChecker) Run(ctx context.Context) error {
+	if !c.inProgress.CompareAndSwap(false, true) {
+		return ErrChecksumInProgress
+	}
+	defer c.inProgress.Store(false)
 	// Set startTime under lock to prevent race with StartTime() method
 	c.Lock()
 	c.startTime = time.Now()
@@ -557,40 +599,33 @@ func (c *DistributedChecker) Run(ctx context.Context) error {
 // when the yield timeout expires. Each yield releases the long-running
 // REPEATABLE READ transaction pools on sources and targets, then re-acquires
 // table locks and fresh snapshots before resuming from the low watermark.
+// A pass that fails with a transient error, such as a lost connection, is
+// resumed the same way, up to DBConfig.MaxRetries times (see
+// waitToRetryChunks).
 func (c *DistributedChecker) runChecksumWithYield(ctx context.Context) error {
+	var retries int
 	for {
 		err := c.runChecksum(ctx)
-		if !errors.Is(err, ErrYieldTimeout) {
+		switch {
+		case errors.Is(err, ErrYieldTimeout):
+			c.yieldsPerformed.Add(1)
+			c.logger.Info("distributed checksum yielding to release long-running transactions",
+				"yieldTimeout", c.yieldTimeout,
+			)
+		case waitToRetryChunks(ctx, err, retries, c.dbConfig.MaxRetries):
+			retries++
+			c.logger.Warn("distributed checksum failed with a transient error, resuming from the low watermark",
+				"error", err,
+				"attempt", retries,
+				"maxRetries", c.dbConfig.MaxRetries,
+			)
+		default:
 			return err
 		}
-		// The yield timeout fired. Get the low watermark so we can resume.
-		watermark, wmErr := c.chunker.GetLowWatermark()
-		if wmErr != nil {
-			// If the watermark isn't ready (e.g. the timeout fired before any
-			// chunks were processed), reset and start over rather than failing.
-			if errors.Is(wmErr, table.ErrWatermarkNotReady) {
-				c.yieldsPerformed.Add(1)
-				c.logger.Info("distributed checksum yielding but no watermark available, restarting from beginning",
-					"yieldTimeout", c.yieldTimeout,
-				)
-				c.setInvalid(false)
-				if resetErr := c.chunker.Reset(); resetErr != nil {
-					return fmt.Errorf("failed to reset chunker after yield: %w", resetErr)
-				}
-				continue
-			}
-			return fmt.Errorf("failed to get low watermark after yield: %w", wmErr)
-		}
-		c.yieldsPerformed.Add(1)
-		c.logger.Info("distributed checksum yielding to release long-running transactions",
-			"watermark", watermark,
-			"yieldTimeout", c.yieldTimeout,
-		)
 		// Reset the isInvalid flag since we are resuming, not failing.
 		c.setInvalid(false)
-		// Re-open the chunker at the watermark position.
-		if err := c.chunker.OpenAtWatermark(watermark); err != nil {
-			return fmt.Errorf("failed to resume chunker from watermark after yield: %w", err)
+		if err := resumeAtLowWatermark(c.chunker); err != nil {
+			return err
 		}
 		// Loop back to runChecksum which will re-acquire the table locks
 		// and create fresh REPEATABLE READ transactions.
@@ -631,6 +666,7 @@ func (c *DistributedChecker) runChecksum(ctx context.Context) error {
 	g, errGrpCtx := errgroup.WithContext(yieldCtx)
 	g.SetLimit(c.concurrency)
 	for !c.chunker.IsRead() && c.isHealthy(errGrpCtx) {
+		c.throttler.BlockWait(errGrpCtx)
 		g.Go(func() error {
 			chunk, err := c.chunker.Next()
 			if err != nil {
@@ -0,0 +1,60 @@
+package checksum
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The hash functions a Checker can checksum rows with; see
+// CheckerConfig.HashFunction.
+const (
+	// HashCRC32 is the default: cheap, and 32 bits is enough to detect the
+	// differences a copy can introduce.
+	HashCRC32 = "CRC32"
+	// HashMD5, HashSHA1 and HashSHA2 use the first 60 bits of the row's
+	// digest. They are slower, but a collision is far less likely, which
+	// helps rule out a CRC32 collision when investigating a mismatch.
+	HashMD5  = "MD5"
+	HashSHA1 = "SHA1"
+	HashSHA2 = "SHA2"
+)
+
+// normalizeHashFunction returns the canonical name of hash, with "" meaning
+// HashCRC32, or an error if MySQL cannot compute it.
+func normalizeHashFunction(hash string) (string, error) {
+	switch strings.ToUpper(hash) {
+	case "", HashCRC32:
+		return HashCRC32, nil
+	case HashMD5:
+		return HashMD5, nil
+	case HashSHA1:
+		return HashSHA1, nil
+	case HashSHA2:
+		return HashSHA2, nil
+	}
+	return "", fmt.Errorf("unsupported checksum hash function %q: must be one of %s, %s, %s or %s", hash, HashCRC32, HashMD5, HashSHA1, HashSHA2)
+}
+
+// rowHashExpr returns the SQL expression hashing the row whose columns are
+// concatenated by concatExpr. It is an unsigned integer, so that it can be
+// aggregated with BIT_XOR, and below 2^63, so that the aggregate scans into
+// an int64. hash must be normalized.
+func rowHashExpr(hash, concatExpr string) string {
+	switch hash {
+	case HashMD5:
+		return fmt.Sprintf("CAST(CONV(LEFT(MD5(%s), 15), 16, 10) AS UNSIGNED)", concatExpr)
+	case HashSHA1:
+		return fmt.Sprintf("CAST(CONV(LEFT(SHA1(%s), 15), 16, 10) AS UNSIGNED)", concatExpr)
+	case HashSHA2:
+		return fmt.Sprintf("CAST(CONV(LEFT(SHA2(%s, 256), 15), 16, 10) AS UNSIGNED)", concatExpr)
+	}
+	return fmt.Sprintf("CRC32(%s)", concatExpr)
+}
+
+// chunkChecksumQuery returns the query for the aggregate checksum and row
+// count of the rows of tableName matching where, with columns being the
+// table's checksum expressions from ColumnMapping.ChecksumExprs.
+func chunkChecksumQuery(hash, columns, tableName, where string) string {
+	return fmt.Sprintf("SELECT BIT_XOR(%s) as checksum, count(*) as c FROM %s WHERE %s",
+		rowHashExpr(hash, "CONCAT("+columns+")"), tableName, where)
+}
@@ -0,0 +1,44 @@
+package checksum
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/block/spirit/pkg/change"
+	"github.com/block/spirit/pkg/table"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeHashFunction(t *testing.T) {
+	for in, want := range map[string]string{"": HashCRC32, "crc32": HashCRC32, "MD5": HashMD5, "sha1": HashSHA1, "Sha2": HashSHA2} {
+		got, err := normalizeHashFunction(in)
+		require.NoError(t, err, in)
+		require.Equal(t, want, got, in)
+	}
+	_, err := normalizeHashFunction("XXHASH")
+	require.ErrorContains(t, err, `unsupported checksum hash function "XXHASH"`)
+}
+
+func TestChunkChecksumQuery(t *testing.T) {
+	require.Equal(t, "SELECT BIT_XOR(CRC32(CONCAT(`a`, '#', `b`))) as checksum, count(*) as c FROM `test`.`t1` WHERE `a` < 10",
+		chunkChecksumQuery(HashCRC32, "`a`, '#', `b`", "`test`.`t1`", "`a` < 10"))
+	require.Equal(t, "SELECT BIT_XOR(CAST(CONV(LEFT(SHA2(CONCAT(`a`), 256), 15), 16, 10) AS UNSIGNED)) as checksum, count(*) as c FROM `test`.`t1` WHERE 1=1",
+		chunkChecksumQuery(HashSHA2, "`a`", "`test`.`t1`", "1=1"))
+	require.Equal(t, "CAST(CONV(LEFT(MD5(x), 15), 16, 10) AS UNSIGNED)", rowHashExpr(HashMD5, "x"))
+	require.Equal(t, "CAST(CONV(LEFT(SHA1(x), 15), 16, 10) AS UNSIGNED)", rowHashExpr(HashSHA1, "x"))
+}
+
+func TestNewCheckerHashFunction(t *testing.T) {
+	tbl := table.NewTableInfo(nil, "test", "t1")
+	chunker, err := table.NewChunker(tbl, table.ChunkerConfig{})
+	require.NoError(t, err)
+	config := NewCheckerDefaultConfig()
+	config.HashFunction = "sha1"
+	checker, err := NewChecker([]*sql.DB{{}}, chunker, []change.Source{nil}, config)
+	require.NoError(t, err)
+	require.Equal(t, HashSHA1, checker.(*SingleChecker).hashFunction)
+
+	config.HashFunction = "XXHASH"
+	_, err = NewChecker([]*sql.DB{{}}, chunker, []change.Source{nil}, config)
+	require.Error(t, err)
+}
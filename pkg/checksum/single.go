@@ -18,6 +18,7 @@ import (
 	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/throttler"
 	"github.com/block/spirit/pkg/utils"
 	"golang.org/x/sync/errgroup"
 )
@@ -41,41 +42,83 @@ type SingleChecker struct {
 	maxRetries       int
 	yieldTimeout     time.Duration
 	yieldsPerformed  atomic.Uint64 // number of yield/resume cycles performed
+	inProgress       atomic.Bool   // set while Run or ChecksumRange is running
+	throttler        throttler.Throttler
+	whereCondition   string  // see CheckerConfig.WhereCondition
+	hashFunction     string  // see CheckerConfig.HashFunction
+	floatEpsilon     float64 // see CheckerConfig.FloatEpsilon
+
+	// chunkHook, when set, is called before each chunk is checksummed, and
+	// an error it returns fails the chunk. Tests use it to inject errors.
+	chunkHook func(chunk *table.Chunk) error
 }
 
 var _ Checker = (*SingleChecker)(nil)
 
 func (c *SingleChecker) ChecksumChunk(ctx context.Context, trxPool *dbconn.TrxPool, chunk *table.Chunk) error {
 	startTime := time.Now()
-	trx, err := trxPool.Get()
+	if c.chunkHook != nil {
+		if err := c.chunkHook(chunk); err != nil {
+			return err
+		}
+	}
+	rows, err := c.checksumChunk(ctx, trxPool, scopeChunk(chunk, c.whereCondition))
+	if err != nil {
+		return err
+	}
+	// When we give feedback, we need to say how many rows were in the chunk.
+	c.chunker.Feedback(chunk, time.Since(startTime), rows)
+	return nil
+}
+
+// ChecksumRange checksums only the rows whose key falls between lower and
+// upper, such as a range suspected of being corrupted, instead of the whole
+// table. A nil bound leaves that side of the range open. It takes its own
+// table lock to get a consistent snapshot, so the feed must be running, and
+// it returns ErrChecksumInProgress while Run or another ChecksumRange is in
+// progress. A mismatch is fixed or returned as an error, as in Run.
+func (c *SingleChecker) ChecksumRange(ctx context.Context, key []string, lower, upper *table.Boundary) error {
+	chunk, err := newRangeChunk(c.chunker, key, lower, upper)
 	if err != nil {
 		return err
 	}
+	// initConnPool replaces the checker's transaction pools, which a Run in
+	// progress is still using.
+	if !c.inProgress.CompareAndSwap(false, true) {
+		return ErrChecksumInProgress
+	}
+	defer c.inProgress.Store(false)
+	if err := c.initConnPool(ctx); err != nil {
+		return err
+	}
+	_, err = c.checksumChunk(ctx, c.trxPool, scopeChunk(chunk, c.whereCondition))
+	return errors.Join(err, c.trxPool.Close())
+}
+
+// checksumChunk compares chunk between the source and target, fixing or
+// returning a mismatch, and returns the number of target rows in the chunk.
+func (c *SingleChecker) checksumChunk(ctx context.Context, trxPool *dbconn.TrxPool, chunk *table.Chunk) (uint64, error) {
+	trx, err := trxPool.Get()
+	if err != nil {
+		return 0, err
+	}
 	defer trxPool.Put(trx)
 	c.logger.Debug("checksumming chunk", "chunk", chunk.String())
-	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.ChecksumExprs()
+	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.WithFloatEpsilon(c.floatEpsilon).ChecksumExprs()
 	if err != nil {
-		return err
+		return 0, err
 	}
-	source := fmt.Sprintf("SELECT BIT_XOR(CRC32(CONCAT(%s))) as checksum, count(*) as c FROM %s WHERE %s",
-		sourceChecksumCols,
-		chunk.Table.QuotedTableName,
-		chunk.String(),
-	)
-	target := fmt.Sprintf("SELECT BIT_XOR(CRC32(CONCAT(%s))) as checksum, count(*) as c FROM %s WHERE %s",
-		targetChecksumCols,
-		chunk.NewTable.QuotedTableName,
-		chunk.String(),
-	)
+	source := chunkChecksumQuery(c.hashFunction, sourceChecksumCols, chunk.Table.QuotedTableName, chunk.String())
+	target := chunkChecksumQuery(c.hashFunction, targetChecksumCols, chunk.NewTable.QuotedTableName, chunk.String())
 	var sourceChecksum, targetChecksum int64
 	var sourceCount, targetCount uint64
 	err = trx.QueryRowContext(ctx, source).Scan(&sourceChecksum, &sourceCount)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	err = trx.QueryRowContext(ctx, target).Scan(&targetChecksum, &targetCount)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	// Compare BOTH the checksum and the row count. The row count is already
 	// returned by the query above, so comparing it is free, and it closes a
@@ -88,21 +131,19 @@ func (c *SingleChecker) ChecksumChunk(ctx context.Context, trxPool *dbconn.TrxPo
 		c.differencesFound.Add(1)
 		c.logger.Warn("chunk verification failed", "chunk", chunk.String(), "reason", mismatch.reason(sourceCount, targetCount), "sourceChecksum", sourceChecksum, "targetChecksum", targetChecksum, "sourceCount", sourceCount, "targetCount", targetCount)
 		if err := c.inspectDifferences(ctx, trx, chunk); err != nil {
-			return err
+			return 0, err
 		}
 		// Are we allowed to fix the differences? If not, return an error.
 		// This is mostly used by the test-suite.
 		if !c.fixDifferences {
-			return errors.New("checksum mismatch")
+			return 0, errors.New("checksum mismatch")
 		}
 		// Since we can fix differences, replace the chunk.
 		if err = c.replaceChunk(ctx, chunk); err != nil {
-			return err
+			return 0, err
 		}
 	}
-	// When we give feedback, we need to say how many rows were in the chunk.
-	c.chunker.Feedback(chunk, time.Since(startTime), targetCount)
-	return nil
+	return targetCount, nil
 }
 
 // GetProgress returns rows verified so far and the total to verify, proxied
@@ -117,12 +158,12 @@ func (c *SingleChecker) GetProgress() status.ChecksumProgress {
 func (c *SingleChecker) inspectDifferences(ctx context.Context, trx *sql.Tx, chunk *table.Chunk) error {
 	c.logger.Info("inspecting differences for chunk", "chunk", chunk.String())
 
-	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.ChecksumExprs()
+	sourceChecksumCols, targetChecksumCols, err := chunk.ColumnMapping.WithFloatEpsilon(c.floatEpsilon).ChecksumExprs()
 	if err != nil {
 		return err
 	}
 	sourceRows, err := trx.QueryContext(ctx, fmt.Sprintf(queryTemplate,
-		sourceChecksumCols,
+		rowHashExpr(c.hashFunction, "CONCAT("+sourceChecksumCols+")"),
 		table.QuoteColumns(chunk.Table.KeyColumns),
 		chunk.Table.QuotedTableName,
 		chunk.String(),
@@ -146,7 +187,7 @@ func (c *SingleChecker) inspectDifferences(ctx context.Context, trx *sql.Tx, chu
 	}
 
 	targetRows, err := trx.QueryContext(ctx, fmt.Sprintf(queryTemplate,
-		targetChecksumCols,
+		rowHashExpr(c.hashFunction, "CONCAT("+targetChecksumCols+")"),
 		table.QuoteColumns(chunk.NewTable.KeyColumns),
 		chunk.NewTable.QuotedTableName,
 		chunk.String(),
@@ -327,6 +368,11 @@ func (c *SingleChecker) ExecTime() time.Duration {
 // mismatch was detected in the most recent (or in-flight) pass. Used by
 // the continuous-checksum loop to decide whether a cancellation swallow
 // is safe.
+// SetThrottler replaces the throttler that paces Run.
+func (c *SingleChecker) SetThrottler(throttler throttler.Throttler) {
+	c.throttler = throttler
+}
+
 func (c *SingleChecker) DifferencesFound() uint64 {
 	return c.differencesFound.Load()
 }
@@ -377,6 +423,10 @@ func (c *SingleChecker) initConnPool(ctx context.Context) error {
 }
 
 func (c *SingleChecker) Run(ctx context.Context) error {
+	if !c.inProgress.CompareAndSwap(false, true) {
+		return ErrChecksumInProgress
+	}
+	defer c.inProgress.Store(false)
 	// Set startTime under lock to prevent race with StartTime() method
 	c.Lock()
 	c.startTime = time.Now()
@@ -462,40 +512,33 @@ func (c *SingleChecker) Run(ctx context.Context) error {
 // when the yield timeout expires. Each yield releases the long-running
 // REPEATABLE READ transactions (reducing HLL pressure), then re-acquires a
 // table lock and fresh snapshot before resuming from the low watermark.
+// A pass that fails with a transient error, such as a lost connection, is
+// resumed the same way, up to DBConfig.MaxRetries times (see
+// waitToRetryChunks).
 func (c *SingleChecker) runChecksumWithYield(ctx context.Context) error {
+	var retries int
 	for {
 		err := c.runChecksum(ctx)
-		if !errors.Is(err, ErrYieldTimeout) {
+		switch {
+		case errors.Is(err, ErrYieldTimeout):
+			c.yieldsPerformed.Add(1)
+			c.logger.Info("checksum yielding to release long-running transactions",
+				"yieldTimeout", c.yieldTimeout,
+			)
+		case waitToRetryChunks(ctx, err, retries, c.dbConfig.MaxRetries):
+			retries++
+			c.logger.Warn("checksum failed with a transient error, resuming from the low watermark",
+				"error", err,
+				"attempt", retries,
+				"maxRetries", c.dbConfig.MaxRetries,
+			)
+		default:
 			return err
 		}
-		// The yield timeout fired. Get the low watermark so we can resume.
-		watermark, wmErr := c.chunker.GetLowWatermark()
-		if wmErr != nil {
-			// If the watermark isn't ready (e.g. the timeout fired before any
-			// chunks were processed), reset and start over rather than failing.
-			if errors.Is(wmErr, table.ErrWatermarkNotReady) {
-				c.yieldsPerformed.Add(1)
-				c.logger.Info("checksum yielding but no watermark available, restarting from beginning",
-					"yieldTimeout", c.yieldTimeout,
-				)
-				c.setInvalid(false)
-				if resetErr := c.chunker.Reset(); resetErr != nil {
-					return fmt.Errorf("failed to reset chunker after yield: %w", resetErr)
-				}
-				continue
-			}
-			return fmt.Errorf("failed to get low watermark after yield: %w", wmErr)
-		}
-		c.yieldsPerformed.Add(1)
-		c.logger.Info("checksum yielding to release long-running transactions",
-			"watermark", watermark,
-			"yieldTimeout", c.yieldTimeout,
-		)
 		// Reset the isInvalid flag since we are resuming, not failing.
 		c.setInvalid(false)
-		// Re-open the chunker at the watermark position.
-		if err := c.chunker.OpenAtWatermark(watermark); err != nil {
-			return fmt.Errorf("failed to resume chunker from watermark after yield: %w", err)
+		if err := resumeAtLowWatermark(c.chunker); err != nil {
+			return err
 		}
 		// Loop back to runChecksum which will re-acquire the table lock
 		// and create fresh REPEATABLE READ transactions.
@@ -528,6 +571,7 @@ func (c *SingleChecker) runChecksum(ctx context.Context) error {
 	g, errGrpCtx := errgroup.WithContext(yieldCtx)
 	g.SetLimit(c.concurrency)
 	for !c.chunker.IsRead() && c.isHealthy(errGrpCtx) {
+		c.throttler.BlockWait(errGrpCtx)
 		g.Go(func() error {
 			chunk, err := c.chunker.Next()
 			if err != nil {
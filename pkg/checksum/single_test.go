@@ -1,7 +1,11 @@
 package checksum
 
 import (
+	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +14,7 @@ import (
 	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/throttler"
 	"github.com/block/spirit/pkg/utils"
 	mysql "github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/require"
@@ -332,6 +337,178 @@ func TestCorruptChecksum(t *testing.T) {
 	require.ErrorContains(t, err, "checksum mismatch")
 }
 
+// TestChecksumRange checks that ChecksumRange verifies only the requested key
+// range: a range that avoids a planted difference passes, and one that covers
+// it fails.
+func TestChecksumRange(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS chkrange1, _chkrange1_new, _chkrange1_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE chkrange1 (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkrange1_new (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkrange1_chkpnt (a INT)") // for binlog advancement
+	testutils.RunSQL(t, "INSERT INTO chkrange1 VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6)")
+	testutils.RunSQL(t, "INSERT INTO _chkrange1_new SELECT * FROM chkrange1")
+	testutils.RunSQL(t, "UPDATE _chkrange1_new SET b = 50 WHERE a = 5") // corrupt
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "chkrange1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_chkrange1_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, chunker))
+	require.NoError(t, feed.Start(t.Context()))
+	require.NoError(t, chunker.Open())
+
+	checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, NewCheckerDefaultConfig())
+	require.NoError(t, err)
+
+	bound := func(v int, inclusive bool) *table.Boundary {
+		d, err := table.NewDatumFromValue(v, "int")
+		require.NoError(t, err)
+		return &table.Boundary{Value: []table.Datum{d}, Inclusive: inclusive}
+	}
+	key := []string{"a"}
+
+	// 1 <= a < 5 does not include the corrupt row.
+	require.NoError(t, checker.ChecksumRange(t.Context(), key, bound(1, true), bound(5, false)))
+	require.Zero(t, checker.DifferencesFound())
+
+	// 4 <= a <= 6 does.
+	err = checker.ChecksumRange(t.Context(), key, bound(4, true), bound(6, true))
+	require.ErrorContains(t, err, "checksum mismatch")
+	require.Equal(t, uint64(1), checker.DifferencesFound())
+
+	// So does the open-ended range a > 4.
+	require.Error(t, checker.ChecksumRange(t.Context(), key, bound(4, false), nil))
+
+	// The boundary must match the key.
+	require.ErrorContains(t, checker.ChecksumRange(t.Context(), []string{"a", "b"}, bound(1, true), nil), "boundary has 1 values")
+
+	// It does not replace the pools of a Run in progress.
+	checker.(*SingleChecker).inProgress.Store(true)
+	require.ErrorIs(t, checker.ChecksumRange(t.Context(), key, bound(1, true), bound(5, false)), ErrChecksumInProgress)
+}
+
+func TestScopeChunk(t *testing.T) {
+	chunk := &table.Chunk{Key: []string{"a"}}
+	require.Same(t, chunk, scopeChunk(chunk, ""))
+
+	scoped := scopeChunk(chunk, "b > 1")
+	require.Equal(t, "1=1 AND (b > 1)", scoped.String())
+	require.Empty(t, chunk.AdditionalConditions, "the chunker's chunk must not change")
+
+	// A chunker created with the same condition already applies it.
+	chunk.AdditionalConditions = "b > 1"
+	require.Same(t, chunk, scopeChunk(chunk, "b > 1"))
+
+	chunk.AdditionalConditions = "c = 2"
+	require.Equal(t, "1=1 AND ((c = 2) AND (b > 1))", scopeChunk(chunk, "b > 1").String())
+}
+
+// TestChecksumWhereCondition checks that a checksum with a WhereCondition
+// compares only the matching rows: a difference outside the condition is not
+// found, and one inside it is.
+func TestChecksumWhereCondition(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS chkwhere1, _chkwhere1_new, _chkwhere1_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE chkwhere1 (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkwhere1_new (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkwhere1_chkpnt (a INT)") // for binlog advancement
+	testutils.RunSQL(t, "INSERT INTO chkwhere1 VALUES (1, 1), (2, 2), (3, 3), (4, 4), (5, 5), (6, 6)")
+	testutils.RunSQL(t, "INSERT INTO _chkwhere1_new SELECT * FROM chkwhere1")
+	testutils.RunSQL(t, "UPDATE _chkwhere1_new SET b = 50 WHERE a = 5") // corrupt
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "chkwhere1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_chkwhere1_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	feedChunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, feedChunker))
+	require.NoError(t, feed.Start(t.Context()))
+
+	checksumWhere := func(where string) error {
+		chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2, Where: where})
+		require.NoError(t, err)
+		require.NoError(t, chunker.Open())
+		config := NewCheckerDefaultConfig()
+		config.WhereCondition = where
+		checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+		require.NoError(t, err)
+		return checker.(*SingleChecker).runChecksum(t.Context())
+	}
+
+	require.NoError(t, checksumWhere("a < 5"))
+	require.ErrorContains(t, checksumWhere("b >= 5"), "checksum mismatch")
+}
+
+// TestChecksumHashFunction checks that each hash function agrees on
+// identical tables and detects a corrupted row, with the source and target
+// both hashed by the configured function.
+func TestChecksumHashFunction(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS chkhash1, _chkhash1_new, _chkhash1_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE chkhash1 (a INT NOT NULL, b VARCHAR(255), PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkhash1_new (a INT NOT NULL, b VARCHAR(255), PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _chkhash1_chkpnt (a INT)") // for binlog advancement
+	testutils.RunSQL(t, "INSERT INTO chkhash1 VALUES (1, 'a'), (2, 'b'), (3, NULL), (4, REPEAT('x', 200))")
+	testutils.RunSQL(t, "INSERT INTO _chkhash1_new SELECT * FROM chkhash1")
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "chkhash1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_chkhash1_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	feedChunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, feedChunker))
+	require.NoError(t, feed.Start(t.Context()))
+
+	checksumWith := func(hash string) error {
+		chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+		require.NoError(t, err)
+		require.NoError(t, chunker.Open())
+		config := NewCheckerDefaultConfig()
+		config.HashFunction = hash
+		checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+		require.NoError(t, err)
+		return checker.(*SingleChecker).runChecksum(t.Context())
+	}
+
+	hashes := []string{"", HashCRC32, HashMD5, HashSHA1, HashSHA2}
+	for _, hash := range hashes {
+		require.NoError(t, checksumWith(hash), hash)
+	}
+	testutils.RunSQL(t, "UPDATE _chkhash1_new SET b = 'c' WHERE a = 2") // corrupt
+	for _, hash := range hashes {
+		require.ErrorContains(t, checksumWith(hash), "checksum mismatch", hash)
+	}
+}
+
 // TestCorruptBinaryChecksum tests that the checksum detects corruption in a
 // fixed-length BINARY(N) column. Previously the checksum cast binary columns
 // to binary(0), which truncates every value to zero bytes — so any two values
@@ -418,6 +595,53 @@ func TestBoundaryCases(t *testing.T) {
 	require.NoError(t, singleChecker.runChecksum(t.Context()))
 }
 
+// TestChecksumFloatEpsilon checks that near-equal doubles fail an exact
+// checksum but pass one with a FloatEpsilon larger than their difference.
+func TestChecksumFloatEpsilon(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS floateps, _floateps_new, _floateps_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE floateps (a INT NOT NULL, b DOUBLE, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _floateps_new (a INT NOT NULL, b DOUBLE, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _floateps_chkpnt (a INT NOT NULL)")
+	testutils.RunSQL(t, "INSERT INTO floateps VALUES (1, 1.0000001), (2, NULL), (3, -40.25)")
+	testutils.RunSQL(t, "INSERT INTO _floateps_new VALUES (1, 1.0000002), (2, NULL), (3, -40.25)")
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "floateps")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_floateps_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, chunker))
+	require.NoError(t, feed.Start(t.Context()))
+	require.NoError(t, chunker.Open())
+
+	// Compared exactly, the doubles differ.
+	checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, NewCheckerDefaultConfig())
+	require.NoError(t, err)
+	require.Error(t, checker.(*SingleChecker).runChecksum(t.Context()))
+
+	// Within 0.001 they do not.
+	require.NoError(t, chunker.Reset())
+	config := NewCheckerDefaultConfig()
+	config.FloatEpsilon = 0.001
+	checker, err = NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+	require.NoError(t, err)
+	require.NoError(t, checker.(*SingleChecker).runChecksum(t.Context()))
+
+	config.FloatEpsilon = -1
+	_, err = NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+	require.ErrorContains(t, err, "float epsilon")
+}
+
 func TestChangeDataTypeDatetime(t *testing.T) {
 	testutils.RunSQL(t, "DROP TABLE IF EXISTS tdatetime, _tdatetime_new")
 	testutils.RunSQL(t, `CREATE TABLE tdatetime (
@@ -528,6 +752,124 @@ func TestYieldTimeout(t *testing.T) {
 	t.Logf("yields performed: %d", singleChecker.yieldsPerformed.Load())
 }
 
+func TestChecksumRetriesTransientChunkError(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS transient_t1, _transient_t1_new, _transient_t1_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE transient_t1 (a INT NOT NULL AUTO_INCREMENT, b VARCHAR(255), PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _transient_t1_new (a INT NOT NULL AUTO_INCREMENT, b VARCHAR(255), PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _transient_t1_chkpnt (a INT)") // for binlog advancement
+	testutils.RunSQL(t, "INSERT INTO transient_t1 (b) SELECT REPEAT('x', 50) FROM information_schema.columns a, information_schema.columns b LIMIT 10000")
+	testutils.RunSQL(t, "INSERT INTO _transient_t1_new SELECT * FROM transient_t1")
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "transient_t1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_transient_t1_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, chunker))
+	require.NoError(t, feed.Start(t.Context()))
+	require.NoError(t, chunker.Open())
+
+	config := NewCheckerDefaultConfig()
+	config.Concurrency = 1
+	config.MaxRetries = 1 // a retry of the whole pass would hide the chunk retry
+	checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+	require.NoError(t, err)
+
+	// Fail the third chunk as if the connection was lost in a failover.
+	var chunks atomic.Int64
+	singleChecker := checker.(*SingleChecker)
+	singleChecker.chunkHook = func(*table.Chunk) error {
+		if chunks.Add(1) == 3 {
+			return driver.ErrBadConn
+		}
+		return nil
+	}
+	require.NoError(t, checker.Run(t.Context()))
+	require.Greater(t, chunks.Load(), int64(3))
+	require.Zero(t, singleChecker.DifferencesFound())
+}
+
+func TestWaitToRetryChunks(t *testing.T) {
+	// A mismatch or other permanent error is never retried.
+	require.False(t, waitToRetryChunks(t.Context(), errors.New("checksum mismatch"), 0, 3))
+	// Retries are limited to maxRetries.
+	require.False(t, waitToRetryChunks(t.Context(), driver.ErrBadConn, 3, 3))
+	// A cancelled context is not retried.
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	require.False(t, waitToRetryChunks(ctx, driver.ErrBadConn, 0, 3))
+	// A transient error is retried after a backoff.
+	require.True(t, waitToRetryChunks(t.Context(), driver.ErrBadConn, 0, 3))
+	require.True(t, waitToRetryChunks(t.Context(), &mysql.MySQLError{Number: 1205}, 1, 3))
+}
+
+// pausingThrottler is always throttled, and counts and sleeps through each
+// BlockWait, so a test can see that the checksum waited on it.
+type pausingThrottler struct {
+	throttler.Noop
+	waits atomic.Int64
+}
+
+func (p *pausingThrottler) IsThrottled() bool { return true }
+
+func (p *pausingThrottler) BlockWait(ctx context.Context) {
+	p.waits.Add(1)
+	select {
+	case <-ctx.Done():
+	case <-time.After(200 * time.Millisecond):
+	}
+}
+
+func TestChecksumWaitsForThrottler(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS throttled_checksum, _throttled_checksum_new, _throttled_checksum_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE throttled_checksum (a INT NOT NULL, b INT, c INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _throttled_checksum_new (a INT NOT NULL, b INT, c INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE _throttled_checksum_chkpnt (a INT)") // for binlog advancement
+	testutils.RunSQL(t, "INSERT INTO throttled_checksum VALUES (1, 2, 3), (2, 3, 4)")
+	testutils.RunSQL(t, "INSERT INTO _throttled_checksum_new VALUES (1, 2, 3), (2, 3, 4)")
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "throttled_checksum")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "_throttled_checksum_new")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t2, chunker))
+	require.NoError(t, feed.Start(t.Context()))
+	require.NoError(t, chunker.Open())
+
+	throttle := &pausingThrottler{}
+	config := NewCheckerDefaultConfig()
+	config.Throttler = throttle
+	checker, err := NewChecker([]*sql.DB{db}, chunker, []change.Source{feed}, config)
+	require.NoError(t, err)
+
+	start := time.Now()
+	require.NoError(t, checker.Run(t.Context()))
+	// Every chunk waits on the throttler before it is checksummed.
+	require.Positive(t, throttle.waits.Load())
+	require.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+}
+
 func TestFromWatermark(t *testing.T) {
 	testutils.RunSQL(t, "DROP TABLE IF EXISTS tfromwatermark, _tfromwatermark_new, _tfromwatermark_chkpnt")
 	testutils.RunSQL(t, "CREATE TABLE tfromwatermark (a INT NOT NULL, b INT, c INT, PRIMARY KEY (a))")
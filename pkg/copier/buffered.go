@@ -44,6 +44,10 @@ type buffered struct {
 	metricsSink      metrics.Sink
 	copierEtaHistory *copierEtaHistory
 	autoscale        AutoscaleConfig
+	excludeColumns   []string
+	rateLimiter      *rowRateLimiter // enforces CopierConfig.MaxRowsPerSecond; nil means no limit
+	skipForceIndex   bool            // see CopierConfig.SkipForceIndex
+	chunkKey         string          // see CopierConfig.ChunkKey
 }
 
 // Assert that buffered implements the Copier interface
@@ -51,14 +55,7 @@ var _ Copier = (*buffered)(nil)
 
 // readChunkData reads all rows from a chunk into memory
 func (c *buffered) readChunkData(ctx context.Context, chunk *table.Chunk) ([][]any, error) {
-	// Build the SELECT query to read full row data
-	columnList, _ := chunk.ColumnMapping.Columns()
-	query := fmt.Sprintf("SELECT %s FROM %s FORCE INDEX (PRIMARY) WHERE %s",
-		columnList,
-		chunk.Table.QuotedTableName,
-		chunk.String(),
-	)
-
+	query := c.readChunkQuery(chunk)
 	c.logger.Debug("reading chunk data", "chunk", chunk.String(), "query", query)
 
 	// Use the chunk's table DB connection so each chunk reads from its own source.
@@ -145,6 +142,26 @@ func datumByteSize(v any) uint64 {
 	}
 }
 
+// readChunkQuery returns the SELECT that reads the full row data of chunk.
+func (c *buffered) readChunkQuery(chunk *table.Chunk) string {
+	columnList, _ := chunk.ColumnMapping.Columns()
+	return fmt.Sprintf("SELECT %s FROM %s%s WHERE %s",
+		columnList,
+		chunk.Table.QuotedTableName,
+		chunkIndexHint(c.skipForceIndex, c.chunkKey),
+		chunk.String(),
+	)
+}
+
+// PreviewChunks returns the SELECT that reads each of the first n chunks
+// and the last chunk, without copying anything. See Copier.PreviewChunks.
+func (c *buffered) PreviewChunks(ctx context.Context, n int) ([]string, error) {
+	if !c.StartTime().IsZero() {
+		return nil, errors.New("cannot preview chunks after the copy has started")
+	}
+	return previewChunks(ctx, c.chunker, c.excludeColumns, n, c.readChunkQuery)
+}
+
 func (c *buffered) isHealthy(ctx context.Context) bool {
 	if ctx.Err() != nil {
 		return false
@@ -251,6 +268,7 @@ func (c *buffered) readWorker(ctx context.Context) error {
 
 	for !c.chunker.IsRead() && c.isHealthy(ctx) {
 		c.throttler.BlockWait(ctx)
+		c.rateLimiter.wait(ctx)
 
 		c.logger.Debug("readWorker calling chunker.Next()")
 		chunk, err := c.chunker.Next()
@@ -264,6 +282,7 @@ func (c *buffered) readWorker(ctx context.Context) error {
 			return err
 		}
 		c.logger.Debug("readWorker got chunk", "chunk", chunk.String())
+		chunk.ColumnMapping = chunk.ColumnMapping.Exclude(c.excludeColumns)
 
 		// Start timing from the beginning of the chunk processing (read + write)
 		chunkStartTime := time.Now()
@@ -278,6 +297,7 @@ func (c *buffered) readWorker(ctx context.Context) error {
 		// size the next chunk against a byte budget (memory-based dynamic
 		// chunking). Harmless when the chunker is in time mode — it ignores it.
 		chunk.ActualBytes = rowsByteSize(rows)
+		c.rateLimiter.take(uint64(len(rows)))
 
 		// Handle empty chunks immediately
 		if len(rows) == 0 {
@@ -477,6 +497,14 @@ func (c *buffered) GetChunker() table.Chunker {
 	return c.chunker
 }
 
+func (c *buffered) CurrentChunkSize() uint64 {
+	return chunkSizer(c.chunker).ChunkSize()
+}
+
+func (c *buffered) ChunkPanicShrinks() uint64 {
+	return chunkSizer(c.chunker).PanicShrinks()
+}
+
 func (c *buffered) GetThrottler() throttler.Throttler {
 	c.Lock()
 	defer c.Unlock()
@@ -7,6 +7,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 	"log/slog"
 	"math"
 	"time"
@@ -62,10 +63,28 @@ type Copier interface {
 	// and duration are always consistent.
 	GetETAState() status.ETA
 	GetChunker() table.Chunker
+	// CurrentChunkSize returns the number of rows the chunker sizes the next
+	// chunk for, and ChunkPanicShrinks how many chunks so far overshot their
+	// target by more than table.DynamicPanicFactor, shrinking the chunk size
+	// at once. Both are 0 if the chunker does not implement table.ChunkSizer.
+	CurrentChunkSize() uint64
+	ChunkPanicShrinks() uint64
 	SetThrottler(throttler throttler.Throttler)
 	GetThrottler() throttler.Throttler
 	StartTime() time.Time
 	GetProgress() string
+	// PreviewChunks returns the SQL the copier would run for the first n
+	// chunks and for the last chunk, in order, without copying anything. It
+	// is for sanity-checking the key and predicate form the chunker picked
+	// on an unusual table (for example a composite or binary key). It must
+	// be called before Run, on a chunker that has not been resumed from a
+	// watermark; the chunker is Reset afterwards.
+	//
+	// Only the first chunk's boundaries match the copy exactly: chunk sizes
+	// normally adapt to Feedback during the copy, which the preview does not
+	// give. Finding the last chunk walks the whole key space at the starting
+	// chunk size, which for the composite chunker is a query per chunk.
+	PreviewChunks(ctx context.Context, n int) ([]string, error)
 }
 
 type CopierConfig struct {
@@ -88,12 +107,108 @@ type CopierConfig struct {
 	// Applier. NewCopierDefaultConfig leaves this false (buffered), matching the
 	// production default.
 	Unbuffered bool
+	// Outfile makes the unbuffered copier copy each chunk with
+	// SELECT .. INTO OUTFILE followed by LOAD DATA INFILE instead of
+	// INSERT .. SELECT. It is intended for same-host migrations: the server
+	// must have a non-NULL secure_file_priv, the user needs the FILE
+	// privilege, and spirit must be able to delete the files mysqld writes.
+	// All three are checked when the copier starts, and if any fails the
+	// copier falls back to INSERT .. SELECT. Ignored by the
+	// buffered copier.
+	Outfile bool
 	// Autoscale configures experimental dynamic write-thread scaling. When
 	// disabled (the default) the copier behaves exactly as before. See
 	// AutoscaleConfig and issue #831.
 	Autoscale AutoscaleConfig
+	// ExcludeColumns are columns whose values are not copied, even if they
+	// exist in both tables: they are left at the new table's default (for
+	// example, to blank a sensitive column by re-adding it as NULLable). The
+	// copier removes them from each chunk's ColumnMapping (see
+	// table.ColumnMapping.Exclude); callers must exclude them from the
+	// mapping used by replication and the checksum as well, or the copy
+	// will not verify.
+	ExcludeColumns []string
+	// MaxRowsPerSecond caps the rate rows are copied at, regardless of what
+	// the throttler reports. The limit applies across all workers; chunks
+	// already in flight can take the rate briefly above it. Zero (the
+	// default) means no limit.
+	MaxRowsPerSecond uint64
+	// SkipForceIndex drops the FORCE INDEX (PRIMARY) hint from the SELECT
+	// that reads each chunk. The chunks are ranges of the primary key, so
+	// the hint keeps the optimizer from choosing a secondary index or a
+	// full scan for them; it can be skipped for the rare table where the
+	// optimizer's own plan is better.
+	SkipForceIndex bool
+	// ChunkKey is the index the chunker's chunks are ranges of, when it is
+	// not the primary key (see table.ChunkerConfig.Key). The SELECT that
+	// reads each chunk then forces this index instead.
+	ChunkKey string
 }
 
+// chunkIndexHint returns the index hint for the SELECT that reads a chunk:
+// FORCE INDEX on the index that chunks are ranges of (the primary key when
+// key is empty), or nothing when skip is set (see CopierConfig.SkipForceIndex
+// and CopierConfig.ChunkKey).
+func chunkIndexHint(skip bool, key string) string {
+	if skip {
+		return ""
+	}
+	if key == "" {
+		return " FORCE INDEX (PRIMARY)"
+	}
+	return " FORCE INDEX (" + table.QuoteColumns([]string{key}) + ")"
+}
+
+// previewChunks implements Copier.PreviewChunks for both copiers, which
+// differ only in the query they run for each chunk.
+func previewChunks(ctx context.Context, chunker table.Chunker, excludeColumns []string, n int, query func(*table.Chunk) string) (previews []string, err error) {
+	if rowsRead, chunksCopied, _ := chunker.Progress(); rowsRead > 0 || chunksCopied > 0 {
+		return nil, errors.New("cannot preview chunks: the chunker has already made progress")
+	}
+	defer func() {
+		if resetErr := chunker.Reset(); resetErr != nil && err == nil {
+			previews, err = nil, fmt.Errorf("failed to reset chunker after preview: %w", resetErr)
+		}
+	}()
+	var last string
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		chunk, err := chunker.Next()
+		if errors.Is(err, table.ErrTableIsRead) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		chunk.ColumnMapping = chunk.ColumnMapping.Exclude(excludeColumns)
+		last = query(chunk)
+		if i < n {
+			previews = append(previews, last)
+			last = ""
+		}
+	}
+	if last != "" {
+		previews = append(previews, last)
+	}
+	return previews, nil
+}
+
+// chunkSizer returns chunker as a table.ChunkSizer, or a sizer that reports
+// zeros if it is not one.
+func chunkSizer(chunker table.Chunker) table.ChunkSizer {
+	if sizer, ok := chunker.(table.ChunkSizer); ok {
+		return sizer
+	}
+	return noChunkSizer{}
+}
+
+type noChunkSizer struct{}
+
+func (noChunkSizer) ChunkSize() uint64    { return 0 }
+func (noChunkSizer) PanicShrinks() uint64 { return 0 }
+
 // AutoscaleConfig controls the experimental write-thread autoscaler driven by
 // throttler utilization. It only applies to the buffered copier whose Applier
 // implements the dynamic-scaling capability (SingleTargetApplier).
@@ -134,6 +249,10 @@ func NewCopier(db *sql.DB, chunker table.Chunker, config *CopierConfig) (Copier,
 	if config.DBConfig == nil {
 		return nil, errors.New("dbConfig must be non-nil")
 	}
+	if len(config.ExcludeColumns) > 0 {
+		config.Logger.Warn("excluding columns from the copy: their values in the new table will NOT match the original table",
+			"columns", config.ExcludeColumns)
+	}
 	if config.Unbuffered {
 		return &Unbuffered{
 			db:               db,
@@ -144,6 +263,11 @@ func NewCopier(db *sql.DB, chunker table.Chunker, config *CopierConfig) (Copier,
 			metricsSink:      config.MetricsSink,
 			dbConfig:         config.DBConfig,
 			copierEtaHistory: newcopierEtaHistory(),
+			outfile:          config.Outfile,
+			excludeColumns:   config.ExcludeColumns,
+			rateLimiter:      newRowRateLimiter(config.MaxRowsPerSecond),
+			skipForceIndex:   config.SkipForceIndex,
+			chunkKey:         config.ChunkKey,
 		}, nil
 	}
 	if config.Applier == nil {
@@ -160,5 +284,9 @@ func NewCopier(db *sql.DB, chunker table.Chunker, config *CopierConfig) (Copier,
 		copierEtaHistory: newcopierEtaHistory(),
 		applier:          config.Applier,
 		autoscale:        config.Autoscale,
+		excludeColumns:   config.ExcludeColumns,
+		rateLimiter:      newRowRateLimiter(config.MaxRowsPerSecond),
+		skipForceIndex:   config.SkipForceIndex,
+		chunkKey:         config.ChunkKey,
 	}, nil
 }
@@ -3,6 +3,7 @@ package copier
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"log/slog"
 	"sync"
 	"testing"
@@ -163,6 +164,40 @@ func TestCopierUniqueDestination(t *testing.T) {
 	require.Equal(t, 0, db.Stats().InUse)       // no connections in use.
 }
 
+func TestCopierExcludeColumns(t *testing.T) {
+	for _, unbuffered := range []bool{false, true} {
+		testutils.RunSQL(t, "DROP TABLE IF EXISTS copierexcl1, copierexcl2")
+		testutils.RunSQL(t, "CREATE TABLE copierexcl1 (a INT NOT NULL, b INT, ssn VARCHAR(11), PRIMARY KEY (a))")
+		testutils.RunSQL(t, "CREATE TABLE copierexcl2 (a INT NOT NULL, b INT, ssn VARCHAR(11) DEFAULT 'redacted', PRIMARY KEY (a))")
+		testutils.RunSQL(t, "INSERT INTO copierexcl1 VALUES (1, 2, '123-45-6789'), (2, 3, '987-65-4321')")
+
+		db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+		require.NoError(t, err)
+
+		t1 := table.NewTableInfo(db, "test", "copierexcl1")
+		require.NoError(t, t1.SetInfo(t.Context()))
+		t2 := table.NewTableInfo(db, "test", "copierexcl2")
+		require.NoError(t, t2.SetInfo(t.Context()))
+
+		cfg := bufferedConfig(t, db)
+		cfg.Unbuffered = unbuffered
+		cfg.ExcludeColumns = []string{"SSN"}
+		chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2, TargetChunkTime: cfg.TargetChunkTime, Logger: cfg.Logger})
+		require.NoError(t, err)
+		require.NoError(t, chunker.Open())
+		copier, err := NewCopier(db, chunker, cfg)
+		require.NoError(t, err)
+		require.NoError(t, copier.Run(t.Context()))
+
+		// The other columns are copied; ssn is left at its default.
+		var count int
+		require.NoError(t, db.QueryRowContext(t.Context(),
+			"SELECT COUNT(*) FROM copierexcl2 WHERE ssn = 'redacted' AND b = a + 1").Scan(&count))
+		require.Equal(t, 2, count, "unbuffered=%v", unbuffered)
+		utils.CloseAndLog(db)
+	}
+}
+
 func TestCopierLossyDataTypeConversion(t *testing.T) {
 	testutils.RunSQL(t, "DROP TABLE IF EXISTS datatpt1, datatpt2")
 	testutils.RunSQL(t, "CREATE TABLE datatpt1 (a INT NOT NULL, b INT, c VARCHAR(255), PRIMARY KEY (a))")
@@ -440,3 +475,158 @@ func TestRangeOptimizationMustApply(t *testing.T) {
 	err = copier.Run(t.Context())
 	require.NoError(t, err) // works now.
 }
+
+// queryRecorder is a slog.Handler that keeps the "query" attribute of each
+// record logged with the given message.
+type queryRecorder struct {
+	sync.Mutex
+
+	message string
+	queries []string
+}
+
+func (h *queryRecorder) Enabled(context.Context, slog.Level) bool { return true }
+func (h *queryRecorder) WithAttrs([]slog.Attr) slog.Handler       { return h }
+func (h *queryRecorder) WithGroup(string) slog.Handler            { return h }
+
+func (h *queryRecorder) Handle(_ context.Context, r slog.Record) error {
+	if r.Message != h.message {
+		return nil
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		if a.Key == "query" {
+			h.Lock()
+			h.queries = append(h.queries, a.Value.String())
+			h.Unlock()
+		}
+		return true
+	})
+	return nil
+}
+
+func TestPreviewChunks(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS previewt1, _previewt1_new")
+	testutils.RunSQL(t, "CREATE TABLE previewt1 (a INT NOT NULL, b VARBINARY(16) NOT NULL, c INT, PRIMARY KEY (a, b))")
+	testutils.RunSQL(t, "CREATE TABLE _previewt1_new (a INT NOT NULL, b VARBINARY(16) NOT NULL, c INT, PRIMARY KEY (a, b))")
+	testutils.RunSQL(t, "INSERT INTO previewt1 WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 2500) SELECT i, UNHEX(MD5(i)), i FROM n")
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, "test", "previewt1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t1new := table.NewTableInfo(db, "test", "_previewt1_new")
+	require.NoError(t, t1new.SetInfo(t.Context()))
+
+	recorder := &queryRecorder{message: "running chunk"}
+	cfg := unbufferedConfig()
+	cfg.Concurrency = 1
+	cfg.Logger = slog.New(recorder)
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t1new, TargetChunkTime: cfg.TargetChunkTime, Logger: cfg.Logger})
+	require.NoError(t, err)
+	// With a fixed chunk size every boundary the preview finds is also the
+	// one the copy uses.
+	chunker.(interface{ SetDynamicChunking(bool) }).SetDynamicChunking(false)
+	require.NoError(t, chunker.Open())
+	copier, err := NewCopier(db, chunker, cfg)
+	require.NoError(t, err)
+
+	first, err := copier.PreviewChunks(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, first, 2) // the first chunk and the last chunk
+	all, err := copier.PreviewChunks(t.Context(), 100)
+	require.NoError(t, err)
+	require.Greater(t, len(all), 2)
+	require.Equal(t, first, []string{all[0], all[len(all)-1]})
+
+	require.NoError(t, copier.Run(t.Context()))
+	require.Equal(t, all, recorder.queries)
+	_, err = copier.PreviewChunks(t.Context(), 1)
+	require.ErrorContains(t, err, "copy has started")
+}
+
+func TestCopierMaxRowsPerSecond(t *testing.T) {
+	const (
+		rows        = 10000
+		chunkSize   = 1000
+		concurrency = 2
+		maxRate     = 5000
+	)
+	for _, unbuffered := range []bool{false, true} {
+		t.Run(fmt.Sprintf("unbuffered=%t", unbuffered), func(t *testing.T) {
+			testutils.RunSQL(t, "DROP TABLE IF EXISTS ratelimitt1, _ratelimitt1_new")
+			testutils.RunSQL(t, "CREATE TABLE ratelimitt1 (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+			testutils.RunSQL(t, "CREATE TABLE _ratelimitt1_new (a INT NOT NULL, b INT, PRIMARY KEY (a))")
+			testutils.RunSQL(t, fmt.Sprintf("INSERT INTO ratelimitt1 WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < %d) SELECT i, i FROM n", rows))
+
+			db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+			require.NoError(t, err)
+			defer utils.CloseAndLog(db)
+
+			t1 := table.NewTableInfo(db, "test", "ratelimitt1")
+			require.NoError(t, t1.SetInfo(t.Context()))
+			t1new := table.NewTableInfo(db, "test", "_ratelimitt1_new")
+			require.NoError(t, t1new.SetInfo(t.Context()))
+
+			cfg := unbufferedConfig()
+			if !unbuffered {
+				cfg = bufferedConfig(t, db)
+			}
+			cfg.Concurrency = concurrency
+			cfg.MaxRowsPerSecond = maxRate
+			chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t1new, TargetChunkTime: cfg.TargetChunkTime, Logger: cfg.Logger})
+			require.NoError(t, err)
+			chunker.(interface{ SetDynamicChunking(bool) }).SetDynamicChunking(false)
+			require.NoError(t, chunker.Open())
+			copier, err := NewCopier(db, chunker, cfg)
+			require.NoError(t, err)
+
+			start := time.Now()
+			require.NoError(t, copier.Run(t.Context()))
+			elapsed := time.Since(start)
+
+			var count int
+			require.NoError(t, db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM _ratelimitt1_new").Scan(&count))
+			require.Equal(t, rows, count)
+			// One chunk per worker can start before the limiter has anything
+			// to wait for; every row after those is paid for at maxRate.
+			sustained := float64(rows-concurrency*chunkSize) / elapsed.Seconds()
+			require.LessOrEqual(t, sustained, float64(maxRate), "copied %d rows in %s", rows, elapsed)
+		})
+	}
+}
+
+// TestCopyQueryForceIndex checks that the SELECT each copier reads a chunk
+// with forces the primary key the chunks range over, or the ChunkKey, unless
+// SkipForceIndex is set.
+func TestCopyQueryForceIndex(t *testing.T) {
+	t1 := table.NewTableInfo(nil, "test", "forceidx")
+	t1new := table.NewTableInfo(nil, "test", "_forceidx_new")
+	t1.NonGeneratedColumns = []string{"id", "b"}
+	t1new.NonGeneratedColumns = []string{"id", "b"}
+	chunk := &table.Chunk{
+		Key:           []string{"id"},
+		Table:         t1,
+		NewTable:      t1new,
+		ColumnMapping: table.NewColumnMapping(t1, t1new, nil),
+	}
+	where := chunk.String()
+
+	for _, skip := range []bool{false, true} {
+		hint := " FORCE INDEX (PRIMARY)"
+		if skip {
+			hint = ""
+		}
+		buffered := &buffered{skipForceIndex: skip}
+		require.Equal(t, "SELECT `id`, `b` FROM `forceidx`"+hint+" WHERE "+where, buffered.readChunkQuery(chunk))
+		unbuffered := &Unbuffered{skipForceIndex: skip}
+		require.Equal(t, "INSERT IGNORE INTO `_forceidx_new` (`id`, `b`) SELECT `id`, `b` FROM `forceidx`"+hint+" WHERE "+where, unbuffered.insertSelectQuery(chunk))
+	}
+
+	// With a ChunkKey, the chunks are ranges of that index instead.
+	buffered := &buffered{chunkKey: "uk_b"}
+	require.Equal(t, "SELECT `id`, `b` FROM `forceidx` FORCE INDEX (`uk_b`) WHERE "+where, buffered.readChunkQuery(chunk))
+	unbuffered := &Unbuffered{chunkKey: "uk_b"}
+	require.Equal(t, "INSERT IGNORE INTO `_forceidx_new` (`id`, `b`) SELECT `id`, `b` FROM `forceidx` FORCE INDEX (`uk_b`) WHERE "+where, unbuffered.insertSelectQuery(chunk))
+}
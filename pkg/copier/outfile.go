@@ -0,0 +1,134 @@
+package copier
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/block/spirit/pkg/dbconn"
+	"github.com/block/spirit/pkg/dbconn/sqlescape"
+	"github.com/block/spirit/pkg/table"
+	"github.com/google/uuid"
+)
+
+// detectOutfileDir checks whether the server allows the unbuffered copier to
+// copy chunks with SELECT .. INTO OUTFILE followed by LOAD DATA INFILE. Both
+// statements read and write files on the *server* host, so this requires
+// secure_file_priv to be non-NULL and the connected user to hold the global
+// FILE privilege. Chunk files are created by mysqld but removed by spirit, so
+// it also exports a probe file and checks that spirit can delete it; this
+// fails when spirit is not on the database host or cannot remove files owned
+// by the mysql user, and without it every chunk file would be left behind on
+// the server. It returns the directory chunk files should be written to, or
+// an error describing why the capability is not available.
+func detectOutfileDir(ctx context.Context, db *sql.DB) (string, error) {
+	var secureFilePriv, tmpDir sql.NullString
+	if err := db.QueryRowContext(ctx, "SELECT @@secure_file_priv, @@tmpdir").Scan(&secureFilePriv, &tmpDir); err != nil {
+		return "", err
+	}
+	if !secureFilePriv.Valid || strings.EqualFold(secureFilePriv.String, "NULL") {
+		return "", errors.New("secure_file_priv is NULL, which disables SELECT INTO OUTFILE and LOAD DATA INFILE")
+	}
+	dir := secureFilePriv.String
+	if dir == "" {
+		// An empty secure_file_priv permits any directory the server can
+		// write to. Use the server's tmpdir since it is guaranteed to exist.
+		dir = tmpDir.String
+	}
+	if dir == "" {
+		return "", errors.New("could not determine a server directory for OUTFILE copy")
+	}
+	rows, err := db.QueryContext(ctx, "SHOW GRANTS")
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+	var grants []string
+	for rows.Next() {
+		var grant string
+		if err := rows.Scan(&grant); err != nil {
+			return "", err
+		}
+		grants = append(grants, grant)
+	}
+	if err := rows.Err(); err != nil {
+		return "", err
+	}
+	if !hasFilePrivilege(grants) {
+		return "", errors.New("the FILE privilege is required for OUTFILE copy")
+	}
+	probe := path.Join(dir, fmt.Sprintf("spirit_probe_%s.txt", uuid.New().String()))
+	if _, err := db.ExecContext(ctx, "SELECT 1 INTO OUTFILE "+sqlescape.MustEscapeSQL("%?", probe)); err != nil {
+		return "", fmt.Errorf("could not write a probe file to %s: %w", dir, err)
+	}
+	if err := os.Remove(probe); err != nil {
+		return "", fmt.Errorf("could not remove probe file %s written by the server; OUTFILE copy requires spirit to run on the database host with permission to delete files in %s: %w", probe, dir, err)
+	}
+	return dir, nil
+}
+
+// hasFilePrivilege returns true if any of the SHOW GRANTS rows grants FILE
+// (or ALL PRIVILEGES) on *.*. FILE is a global privilege, so grants scoped to
+// a schema or table never satisfy it.
+func hasFilePrivilege(grants []string) bool {
+	for _, grant := range grants {
+		upper := strings.ToUpper(grant)
+		if !strings.HasPrefix(upper, "GRANT ") || !strings.Contains(upper, " ON *.* TO ") {
+			continue
+		}
+		privs := strings.TrimPrefix(upper[:strings.Index(upper, " ON *.* TO ")], "GRANT ")
+		for priv := range strings.SplitSeq(privs, ",") {
+			switch strings.TrimSpace(priv) {
+			case "FILE", "ALL", "ALL PRIVILEGES":
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// copyChunkViaOutfile copies a chunk by exporting it to a file on the server
+// with SELECT .. INTO OUTFILE and then importing it with LOAD DATA INFILE.
+// The file is written with CHARACTER SET binary in both directions so values
+// round-trip byte-for-byte without charset conversion. LOAD DATA uses IGNORE
+// for the same reason CopyChunk uses INSERT IGNORE: resuming from a checkpoint
+// may re-apply chunks that were already copied.
+func (c *Unbuffered) copyChunkViaOutfile(ctx context.Context, chunk *table.Chunk) (int64, error) {
+	sourceColumns, targetColumns := chunk.ColumnMapping.Columns()
+	// A unique file per call: OUTFILE refuses to overwrite an existing file,
+	// so a chunk that is copied again must never reuse a name.
+	file := path.Join(c.outfileDir, fmt.Sprintf("spirit_%s_%s.txt", chunk.Table.TableName, uuid.New().String()))
+	defer func() {
+		// detectOutfileDir already confirmed spirit can remove files the
+		// server writes here, so a failure is unexpected but not fatal.
+		if err := os.Remove(file); err != nil && !errors.Is(err, os.ErrNotExist) {
+			c.logger.Warn("could not remove OUTFILE chunk file", "file", file, "error", err)
+		}
+	}()
+	exportQuery := fmt.Sprintf("SELECT %s FROM %s%s WHERE %s INTO OUTFILE %s CHARACTER SET binary",
+		sourceColumns,
+		chunk.Table.QuotedTableName,
+		chunkIndexHint(c.skipForceIndex, c.chunkKey),
+		chunk.String(),
+		sqlescape.MustEscapeSQL("%?", file),
+	)
+	c.logger.Debug("exporting chunk", "chunk", chunk.String(), "query", exportQuery)
+	// The export goes through the same retry path as the import. A failed
+	// OUTFILE statement does not leave a file behind, so a retry can reuse
+	// the name. Export and import run as separate transactions so that a
+	// retried import never re-runs the export into an existing file.
+	if _, err := dbconn.RetryableTransaction(ctx, c.db, dbconn.IgnoreDupKeyWarnings, c.dbConfig, exportQuery); err != nil {
+		return 0, err
+	}
+	importQuery := fmt.Sprintf("LOAD DATA INFILE %s IGNORE INTO TABLE %s CHARACTER SET binary (%s)",
+		sqlescape.MustEscapeSQL("%?", file),
+		chunk.NewTable.QuotedTableName,
+		targetColumns,
+	)
+	c.logger.Debug("importing chunk", "chunk", chunk.String(), "query", importQuery)
+	return dbconn.RetryableTransaction(ctx, c.db, dbconn.IgnoreDupKeyWarnings, c.dbConfig, importQuery)
+}
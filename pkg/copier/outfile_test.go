@@ -0,0 +1,143 @@
+package copier
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/dbconn"
+	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHasFilePrivilege(t *testing.T) {
+	require.True(t, hasFilePrivilege([]string{"GRANT FILE ON *.* TO `spirit`@`%`"}))
+	require.True(t, hasFilePrivilege([]string{"GRANT SELECT, RELOAD, FILE, PROCESS ON *.* TO `spirit`@`%`"}))
+	require.True(t, hasFilePrivilege([]string{"GRANT ALL PRIVILEGES ON *.* TO `root`@`localhost` WITH GRANT OPTION"}))
+	require.True(t, hasFilePrivilege([]string{
+		"GRANT USAGE ON *.* TO `spirit`@`%`",
+		"GRANT FILE ON *.* TO `spirit`@`%`",
+	}))
+
+	require.False(t, hasFilePrivilege(nil))
+	require.False(t, hasFilePrivilege([]string{"GRANT USAGE ON *.* TO `spirit`@`%`"}))
+	require.False(t, hasFilePrivilege([]string{"GRANT SELECT, INSERT ON *.* TO `spirit`@`%`"}))
+	// FILE is a global privilege; ALL on a schema does not include it.
+	require.False(t, hasFilePrivilege([]string{"GRANT ALL PRIVILEGES ON `test`.* TO `spirit`@`%`"}))
+	// Dynamic privileges whose names merely contain FILE do not count.
+	require.False(t, hasFilePrivilege([]string{"GRANT BACKUP_ADMIN, FILE_ADMIN ON *.* TO `spirit`@`%`"}))
+}
+
+// TestOutfileCopier copies the same table with the INSERT .. SELECT copier
+// and the OUTFILE copier and checks both produce identical tables. The data
+// deliberately includes NULLs, tabs, newlines, backslashes and binary so the
+// export/import round trip is exercised. It is skipped when the test server
+// does not permit OUTFILE (secure_file_priv=NULL or no FILE privilege).
+func TestOutfileCopier(t *testing.T) {
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	if _, err := detectOutfileDir(t.Context(), db); err != nil {
+		t.Skipf("OUTFILE copy is not available on the test server: %v", err)
+	}
+
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS outfilet1, outfilet2, outfilet3")
+	tbl := `CREATE TABLE %s (
+		id INT NOT NULL AUTO_INCREMENT,
+		name VARCHAR(255) CHARACTER SET utf8mb4,
+		data VARBINARY(255),
+		note TEXT,
+		amount DECIMAL(10,2),
+		created DATETIME(6),
+		PRIMARY KEY (id)
+	)`
+	testutils.RunSQL(t, fmt.Sprintf(tbl, "outfilet1"))
+	testutils.RunSQL(t, fmt.Sprintf(tbl, "outfilet2"))
+	testutils.RunSQL(t, fmt.Sprintf(tbl, "outfilet3"))
+	testutils.RunSQL(t, `INSERT INTO outfilet1 (name, data, note, amount, created) VALUES
+		('plain', 0x00FF10, 'a note', 1.23, '2024-01-01 00:00:00.123456'),
+		(NULL, NULL, NULL, NULL, NULL),
+		('tab\there', 0x5C4E, 'line\nbreak', -4.56, NOW(6)),
+		('back\\slash \\N', 0x09, '\\N', 0, NOW(6)),
+		('ünïcødé 🚀', '', '', 99999999.99, NOW(6))`)
+	// Grow the table so it spans multiple chunks.
+	for range 8 {
+		testutils.RunSQL(t, "INSERT INTO outfilet1 (name, data, note, amount, created) SELECT name, data, note, amount, created FROM outfilet1")
+	}
+
+	t1 := table.NewTableInfo(db, "test", "outfilet1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+
+	copyInto := func(newTableName string, outfile bool) time.Duration {
+		newTable := table.NewTableInfo(db, "test", newTableName)
+		require.NoError(t, newTable.SetInfo(t.Context()))
+		cfg := unbufferedConfig()
+		cfg.Outfile = outfile
+		chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: newTable, TargetChunkTime: cfg.TargetChunkTime, Logger: cfg.Logger})
+		require.NoError(t, err)
+		require.NoError(t, chunker.Open())
+		copier, err := NewCopier(db, chunker, cfg)
+		require.NoError(t, err)
+		startTime := time.Now()
+		require.NoError(t, copier.Run(t.Context()))
+		if outfile {
+			require.NotEmpty(t, copier.(*Unbuffered).outfileDir, "expected the OUTFILE path to be used")
+		}
+		return time.Since(startTime)
+	}
+	insertSelectTime := copyInto("outfilet2", false)
+	outfileTime := copyInto("outfilet3", true)
+	t.Logf("INSERT .. SELECT copy took %s, OUTFILE copy took %s", insertSelectTime, outfileTime)
+
+	checksum := func(tableName string) (count int, crc uint64) {
+		err := db.QueryRowContext(t.Context(), fmt.Sprintf(`SELECT COUNT(*), COALESCE(BIT_XOR(CRC32(CONCAT_WS('#',
+			id, ISNULL(name), name, ISNULL(data), HEX(data), ISNULL(note), note, ISNULL(amount), amount, ISNULL(created), created))), 0)
+			FROM %s`, tableName)).Scan(&count, &crc)
+		require.NoError(t, err)
+		return count, crc
+	}
+	srcCount, srcCRC := checksum("outfilet1")
+	insertCount, insertCRC := checksum("outfilet2")
+	outfileCount, outfileCRC := checksum("outfilet3")
+	require.Equal(t, srcCount, insertCount)
+	require.Equal(t, srcCount, outfileCount)
+	require.Equal(t, srcCRC, insertCRC)
+	require.Equal(t, srcCRC, outfileCRC)
+}
+
+func TestOutfileCopierFallback(t *testing.T) {
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	if _, err := detectOutfileDir(t.Context(), db); err == nil {
+		t.Skip("OUTFILE copy is available on the test server; fallback is not exercised")
+	}
+
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS outfilefbt1, outfilefbt2")
+	testutils.RunSQL(t, "CREATE TABLE outfilefbt1 (a INT NOT NULL, b INT, c INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "CREATE TABLE outfilefbt2 (a INT NOT NULL, b INT, c INT, PRIMARY KEY (a))")
+	testutils.RunSQL(t, "INSERT INTO outfilefbt1 VALUES (1, 2, 3), (2, NULL, 4)")
+
+	t1 := table.NewTableInfo(db, "test", "outfilefbt1")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t2 := table.NewTableInfo(db, "test", "outfilefbt2")
+	require.NoError(t, t2.SetInfo(t.Context()))
+
+	cfg := unbufferedConfig()
+	cfg.Outfile = true
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t2, TargetChunkTime: cfg.TargetChunkTime, Logger: cfg.Logger})
+	require.NoError(t, err)
+	require.NoError(t, chunker.Open())
+	copier, err := NewCopier(db, chunker, cfg)
+	require.NoError(t, err)
+	require.NoError(t, copier.Run(t.Context()))
+	require.Empty(t, copier.(*Unbuffered).outfileDir)
+
+	var count int
+	require.NoError(t, db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM outfilefbt2").Scan(&count))
+	require.Equal(t, 2, count)
+}
@@ -0,0 +1,78 @@
+package copier
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// rowRateLimiter caps the rate rows are copied at, as a token bucket that
+// refills at rowsPerSecond and holds at most one second's worth of rows.
+// The number of rows in a chunk is only known once it has been read, so
+// workers wait for the bucket to be non-negative before a chunk and take
+// its rows afterwards, which can leave the bucket in debt. The sustained
+// rate stays at or below the cap; the overshoot is bounded by the chunks
+// in flight. A nil *rowRateLimiter does not limit.
+type rowRateLimiter struct {
+	sync.Mutex
+
+	rowsPerSecond float64
+	tokens        float64
+	last          time.Time
+}
+
+// newRowRateLimiter returns a limiter for rowsPerSecond, or nil (no limit)
+// when rowsPerSecond is zero. The bucket starts empty, so a copy cannot
+// start with a burst.
+func newRowRateLimiter(rowsPerSecond uint64) *rowRateLimiter {
+	if rowsPerSecond == 0 {
+		return nil
+	}
+	return &rowRateLimiter{
+		rowsPerSecond: float64(rowsPerSecond),
+		last:          time.Now(),
+	}
+}
+
+// refill adds the tokens accrued since the last refill. The caller must
+// hold the lock.
+func (l *rowRateLimiter) refill() {
+	now := time.Now()
+	l.tokens = min(l.rowsPerSecond, l.tokens+now.Sub(l.last).Seconds()*l.rowsPerSecond)
+	l.last = now
+}
+
+// wait blocks until the rows taken so far have been paid for, or ctx is
+// cancelled.
+func (l *rowRateLimiter) wait(ctx context.Context) {
+	if l == nil {
+		return
+	}
+	for {
+		l.Lock()
+		l.refill()
+		deficit := -l.tokens
+		l.Unlock()
+		if deficit <= 0 {
+			return
+		}
+		timer := time.NewTimer(time.Duration(deficit / l.rowsPerSecond * float64(time.Second)))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// take records that rows have been copied.
+func (l *rowRateLimiter) take(rows uint64) {
+	if l == nil {
+		return
+	}
+	l.Lock()
+	defer l.Unlock()
+	l.refill()
+	l.tokens -= float64(rows)
+}
@@ -0,0 +1,42 @@
+package copier
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRowRateLimiter(t *testing.T) {
+	var unlimited *rowRateLimiter
+	require.Nil(t, newRowRateLimiter(0))
+	unlimited.wait(t.Context()) // returns immediately
+	unlimited.take(1000)
+
+	// The first take is free; each one after it waits for the previous one
+	// to be paid for at 20000 rows/s.
+	l := newRowRateLimiter(20000)
+	start := time.Now()
+	for range 5 {
+		l.wait(t.Context())
+		l.take(2000)
+	}
+	require.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// A cancelled context releases wait while the bucket is in debt.
+	l.take(1000000)
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	start = time.Now()
+	l.wait(ctx)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestRowRateLimiterBurst(t *testing.T) {
+	// An idle limiter accrues at most one second of rows.
+	l := newRowRateLimiter(1000)
+	l.last = time.Now().Add(-time.Minute)
+	l.take(0)
+	require.InDelta(t, 1000, l.tokens, 1)
+}
@@ -32,6 +32,20 @@ type Unbuffered struct {
 	logger           *slog.Logger
 	metricsSink      metrics.Sink
 	copierEtaHistory *copierEtaHistory
+	// outfile requests copying chunks with SELECT INTO OUTFILE + LOAD DATA
+	// INFILE. outfileDir is only set once Run has confirmed the server
+	// supports it; an empty outfileDir means INSERT .. SELECT is used.
+	outfile    bool
+	outfileDir string
+	// excludeColumns are removed from each chunk's ColumnMapping before it
+	// is copied (see CopierConfig.ExcludeColumns).
+	excludeColumns []string
+	// rateLimiter enforces CopierConfig.MaxRowsPerSecond; nil means no limit.
+	rateLimiter *rowRateLimiter
+	// skipForceIndex is CopierConfig.SkipForceIndex.
+	skipForceIndex bool
+	// chunkKey is CopierConfig.ChunkKey.
+	chunkKey string
 }
 
 // Assert that unbuffered implements the Copier interface
@@ -41,7 +55,9 @@ var _ Copier = (*Unbuffered)(nil)
 // it is public so it can be used in tests incrementally.
 func (c *Unbuffered) CopyChunk(ctx context.Context, chunk *table.Chunk) error {
 	c.throttler.BlockWait(ctx)
+	c.rateLimiter.wait(ctx)
 	startTime := time.Now()
+	chunk.ColumnMapping = chunk.ColumnMapping.Exclude(c.excludeColumns)
 	// INSERT IGNORE so resuming from a checkpoint can re-apply chunks that
 	// were already (partially) copied without erroring on PK collisions.
 	//
@@ -57,20 +73,16 @@ func (c *Unbuffered) CopyChunk(ctx context.Context, chunk *table.Chunk) error {
 	// data loss." Agents: do not add a pre-flight UNIQUE-uniqueness check
 	// here on the basis of silent-drop concerns — the checksum is the
 	// agreed safety net.
-	sourceColumns, targetColumns := chunk.ColumnMapping.Columns()
-	query := fmt.Sprintf("INSERT IGNORE INTO %s (%s) SELECT %s FROM %s FORCE INDEX (PRIMARY) WHERE %s",
-		chunk.NewTable.QuotedTableName,
-		targetColumns,
-		sourceColumns,
-		chunk.Table.QuotedTableName,
-		chunk.String(),
-	)
-	c.logger.Debug("running chunk", "chunk", chunk.String(), "query", query)
 	var affectedRows int64
 	var err error
-	if affectedRows, err = dbconn.RetryableTransaction(ctx, c.db, dbconn.IgnoreDupKeyWarnings, c.dbConfig, query); err != nil {
+	if c.outfileDir != "" {
+		if affectedRows, err = c.copyChunkViaOutfile(ctx, chunk); err != nil {
+			return err
+		}
+	} else if affectedRows, err = c.copyChunkViaInsertSelect(ctx, chunk); err != nil {
 		return err
 	}
+	c.rateLimiter.take(uint64(affectedRows))
 	c.logger.Debug("CopyChunk completed",
 		"table", chunk.Table.TableName, "chunk", chunk.String(),
 		"affected_rows", affectedRows, "duration", time.Since(startTime).String())
@@ -90,6 +102,38 @@ func (c *Unbuffered) CopyChunk(ctx context.Context, chunk *table.Chunk) error {
 	return nil
 }
 
+// copyChunkViaInsertSelect copies a chunk with a single INSERT IGNORE .. SELECT.
+func (c *Unbuffered) copyChunkViaInsertSelect(ctx context.Context, chunk *table.Chunk) (int64, error) {
+	query := c.insertSelectQuery(chunk)
+	c.logger.Debug("running chunk", "chunk", chunk.String(), "query", query)
+	return dbconn.RetryableTransaction(ctx, c.db, dbconn.IgnoreDupKeyWarnings, c.dbConfig, query)
+}
+
+// insertSelectQuery returns the INSERT IGNORE .. SELECT that copies chunk.
+func (c *Unbuffered) insertSelectQuery(chunk *table.Chunk) string {
+	sourceColumns, targetColumns := chunk.ColumnMapping.Columns()
+	return fmt.Sprintf("INSERT IGNORE INTO %s (%s) SELECT %s FROM %s%s WHERE %s",
+		chunk.NewTable.QuotedTableName,
+		targetColumns,
+		sourceColumns,
+		chunk.Table.QuotedTableName,
+		chunkIndexHint(c.skipForceIndex, c.chunkKey),
+		chunk.String(),
+	)
+}
+
+// PreviewChunks returns the INSERT IGNORE .. SELECT for the first n chunks
+// and the last chunk, without copying anything. When the copier is set to
+// use outfiles it still previews INSERT .. SELECT: whether the server
+// supports outfiles is only known once Run starts, and both forms use the
+// same WHERE clause. See Copier.PreviewChunks.
+func (c *Unbuffered) PreviewChunks(ctx context.Context, n int) ([]string, error) {
+	if !c.StartTime().IsZero() {
+		return nil, errors.New("cannot preview chunks after the copy has started")
+	}
+	return previewChunks(ctx, c.chunker, c.excludeColumns, n, c.insertSelectQuery)
+}
+
 func (c *Unbuffered) isHealthy(ctx context.Context) bool {
 	c.Lock()
 	defer c.Unlock()
@@ -109,6 +153,15 @@ func (c *Unbuffered) Run(ctx context.Context) error {
 	c.Lock()
 	c.startTime = time.Now()
 	c.Unlock()
+	if c.outfile {
+		dir, err := detectOutfileDir(ctx, c.db)
+		if err != nil {
+			c.logger.Warn("OUTFILE copy is not available, falling back to INSERT .. SELECT", "error", err)
+		} else {
+			c.logger.Info("copying chunks with SELECT INTO OUTFILE and LOAD DATA INFILE", "dir", dir)
+			c.outfileDir = dir
+		}
+	}
 	go c.estimateRowsPerSecondLoop(ctx) // estimate rows while copying
 	g, errGrpCtx := errgroup.WithContext(ctx)
 	g.SetLimit(c.concurrency)
@@ -255,6 +308,14 @@ func (c *Unbuffered) GetChunker() table.Chunker {
 	return c.chunker
 }
 
+func (c *Unbuffered) CurrentChunkSize() uint64 {
+	return chunkSizer(c.chunker).ChunkSize()
+}
+
+func (c *Unbuffered) ChunkPanicShrinks() uint64 {
+	return chunkSizer(c.chunker).PanicShrinks()
+}
+
 func (c *Unbuffered) GetThrottler() throttler.Throttler {
 	c.Lock()
 	defer c.Unlock()
@@ -33,6 +33,7 @@ type AdvisoryLock struct {
 	refreshInterval time.Duration
 	db              *sql.DB
 	lockNames       []string // Multiple lock names for multiple tables
+	namespace       string   // DBConfig.LockNamespace, mixed into every lock name
 	// newDBConn optionally overrides how the dedicated pool is established.
 	// It is a test seam (set via an option func) so tests can simulate
 	// reconnection failures deterministically; production leaves it nil.
@@ -48,6 +49,7 @@ func NewAdvisoryLock(ctx context.Context, dsn string, tables []*table.TableInfo,
 	lock := &AdvisoryLock{
 		refreshInterval: refreshInterval,
 		lockNames:       make([]string, 0, len(tables)),
+		namespace:       config.LockNamespace,
 	}
 
 	// Apply option functions
@@ -57,7 +59,7 @@ func NewAdvisoryLock(ctx context.Context, dsn string, tables []*table.TableInfo,
 
 	// Compute lock names for all tables
 	for _, tbl := range tables {
-		lock.lockNames = append(lock.lockNames, computeLockName(tbl))
+		lock.lockNames = append(lock.lockNames, computeLockName(lock.namespace, tbl))
 	}
 
 	// Setup the dedicated connection for this lock
@@ -279,7 +281,7 @@ func (m *AdvisoryLock) CloseDBConnection(logger *slog.Logger) error {
 // names; it is held and released on the same dedicated session as the rest.
 func WithMultiTableSchemaLock(schemaName string) func(*AdvisoryLock) {
 	return func(m *AdvisoryLock) {
-		m.lockNames = append(m.lockNames, computeMultiTableLockName(schemaName))
+		m.lockNames = append(m.lockNames, computeMultiTableLockName(m.namespace, schemaName))
 	}
 }
 
@@ -287,18 +289,22 @@ func WithMultiTableSchemaLock(schemaName string) func(*AdvisoryLock) {
 // serializes atomic multi-table migrations within a schema. It can't collide
 // with a per-table lock from computeLockName: the hash input carries a salt no
 // table name contains, so the same schema's table locks hash differently.
-func computeMultiTableLockName(schemaName string) string {
+func computeMultiTableLockName(namespace, schemaName string) string {
 	schemaNamePart := schemaName
 	if len(schemaNamePart) > 20 {
 		schemaNamePart = schemaNamePart[:20]
 	}
 	hash := sha1.New()
-	hash.Write([]byte(schemaName + "\x00spirit-atomic-multi-table"))
+	hash.Write([]byte(lockNamespacePrefix(namespace) + schemaName + "\x00spirit-atomic-multi-table"))
 	hashPart := hex.EncodeToString(hash.Sum(nil))[:8]
 	return fmt.Sprintf("%s.atomic-multi-table-%s", schemaNamePart, hashPart)
 }
 
-func computeLockName(table *table.TableInfo) string {
+// computeLockName returns the GET_LOCK name that serializes migrations of
+// table in namespace. The readable part is the schema and table name; the
+// hash part also covers the namespace, so the name stays within MySQL's
+// 64-character limit.
+func computeLockName(namespace string, table *table.TableInfo) string {
 	schemaNamePart := table.SchemaName
 	if len(schemaNamePart) > 20 {
 		schemaNamePart = schemaNamePart[:20]
@@ -309,7 +315,13 @@ func computeLockName(table *table.TableInfo) string {
 	// Two migrations whose auxiliary tables would collide under truncation
 	// produce the same lock name and serialize. For tables short enough that
 	// no truncation occurs, this is identical to the original table name.
-	auxPrefix := utils.TruncateTableName(table.TableName, 1+len("_chkpnt"))
+	// A namespace goes before the suffix of the auxiliary tables, so it
+	// truncates the prefix further.
+	auxSuffix := "_chkpnt"
+	if namespace != "" {
+		auxSuffix = "_" + namespace + auxSuffix
+	}
+	auxPrefix := utils.TruncateTableName(table.TableName, 1+len(auxSuffix))
 
 	tableNamePart := auxPrefix
 	if len(tableNamePart) > 32 {
@@ -317,8 +329,19 @@ func computeLockName(table *table.TableInfo) string {
 	}
 
 	hash := sha1.New()
-	hash.Write([]byte(table.SchemaName + auxPrefix))
+	hash.Write([]byte(lockNamespacePrefix(namespace) + table.SchemaName + auxPrefix))
 	hashPart := hex.EncodeToString(hash.Sum(nil))[:8]
 
 	return fmt.Sprintf("%s.%s-%s", schemaNamePart, tableNamePart, hashPart)
 }
+
+// lockNamespacePrefix returns what namespace adds to the hash input of a lock
+// name. The empty namespace adds nothing, so the default lock names are the
+// ones every earlier version of spirit uses, and runs of different versions
+// still serialize.
+func lockNamespacePrefix(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return namespace + "\x00"
+}
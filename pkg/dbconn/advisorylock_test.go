@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"errors"
 	"log/slog"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -119,7 +120,7 @@ func TestComputeLockName(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		lockName := computeLockName(test.table)
+		lockName := computeLockName("", test.table)
 		require.Contains(t, lockName, test.expected, "Lock name should contain the expected prefix")
 		require.Len(t, lockName, len(test.expected)+8, "Lock name should have the correct length")
 	}
@@ -136,16 +137,56 @@ func TestComputeLockNameAuxPrefixCollision(t *testing.T) {
 	common := "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa" // 56 'a'
 	a := &table.TableInfo{SchemaName: "test", TableName: common + "_one"}
 	b := &table.TableInfo{SchemaName: "test", TableName: common + "_two"}
-	require.Equal(t, computeLockName(a), computeLockName(b),
+	require.Equal(t, computeLockName("", a), computeLockName("", b),
 		"truncation-colliding tables must share an advisory lock name")
 
 	// Tables that diverge before the 56-char boundary must not collide.
 	c := &table.TableInfo{SchemaName: "test", TableName: "first_" + common}
 	d := &table.TableInfo{SchemaName: "test", TableName: "second" + common}
-	require.NotEqual(t, computeLockName(c), computeLockName(d),
+	require.NotEqual(t, computeLockName("", c), computeLockName("", d),
 		"non-colliding tables must keep distinct advisory lock names")
 }
 
+// TestComputeLockNameNamespace checks that a namespace changes the lock names
+// while the empty namespace keeps the default ones.
+func TestComputeLockNameNamespace(t *testing.T) {
+	tbl := &table.TableInfo{SchemaName: "test", TableName: "t1"}
+	require.NotEqual(t, computeLockName("", tbl), computeLockName("tool-a", tbl))
+	require.NotEqual(t, computeLockName("tool-a", tbl), computeLockName("tool-b", tbl))
+	require.Equal(t, computeLockName("tool-a", tbl), computeLockName("tool-a", tbl))
+	require.True(t, strings.HasPrefix(computeLockName("tool-a", tbl), "test.t1-"), "the readable part is unchanged")
+	require.Len(t, computeLockName("sixteen-chars-ns", tbl), len("test.t1-")+8, "the longest namespace does not truncate a short table name")
+
+	require.NotEqual(t, computeMultiTableLockName("", "test"), computeMultiTableLockName("tool-a", "test"))
+}
+
+// TestAdvisoryLockNamespace checks that locks on the same table in the same
+// namespace serialize, and that locks in different namespaces don't.
+func TestAdvisoryLockNamespace(t *testing.T) {
+	tables := []*table.TableInfo{{SchemaName: "test", TableName: "locknamespace"}}
+	logger := slog.Default()
+	config := func(namespace string) *DBConfig {
+		c := NewDBConfig()
+		c.LockNamespace = namespace
+		return c
+	}
+
+	lock, err := NewAdvisoryLock(t.Context(), testutils.DSN(), tables, config("tool-a"), logger)
+	require.NoError(t, err)
+	defer utils.CloseAndLog(lock)
+
+	_, err = NewAdvisoryLock(t.Context(), testutils.DSN(), tables, config("tool-a"), logger)
+	require.ErrorContains(t, err, "lock is held by another connection")
+
+	other, err := NewAdvisoryLock(t.Context(), testutils.DSN(), tables, config("tool-b"), logger)
+	require.NoError(t, err)
+	require.NoError(t, other.Close())
+
+	unnamespaced, err := NewAdvisoryLock(t.Context(), testutils.DSN(), tables, config(""), logger)
+	require.NoError(t, err)
+	require.NoError(t, unnamespaced.Close())
+}
+
 // TestAdvisoryLockAuxPrefixCollision verifies the contention behavior end-to-end:
 // concurrent attempts on truncation-colliding tables fail with the standard
 // "lock is held" error rather than racing through to aux-table creation.
@@ -230,7 +271,7 @@ func TestAdvisoryLockSurvivesConnMaxLifetime(t *testing.T) {
 	require.NoError(t, err)
 	defer utils.CloseAndLog(observer)
 
-	lockName := computeLockName(&lockTableInfo)
+	lockName := computeLockName("", &lockTableInfo)
 	stmt := sqlescape.MustEscapeSQL("SELECT IS_USED_LOCK(%?)", lockName)
 
 	// The advisory lock connection stays idle (refresh is parked an hour out), so once it
@@ -277,7 +318,7 @@ func TestAdvisoryLockRefreshRenewsLock(t *testing.T) {
 	closeLock := closeOnce(lock)
 	t.Cleanup(func() { _ = closeLock() })
 
-	lockName := computeLockName(&lockTableInfo)
+	lockName := computeLockName("", &lockTableInfo)
 
 	// Simulate three refresh ticks on the same session. Each renews the lock
 	// (and, by design, stacks a reference); the session must stay the holder.
@@ -330,7 +371,7 @@ func TestAdvisoryLockCloseReleasesStackedLock(t *testing.T) {
 	// Manually stack two extra references on the dedicated session
 	// (MaxOpenConnections=1 guarantees the same session), simulating what
 	// the refresh ticker used to do once per minute.
-	lockName := computeLockName(&lockTableInfo)
+	lockName := computeLockName("", &lockTableInfo)
 	for range 2 {
 		var answer int
 		stmt := sqlescape.MustEscapeSQL("SELECT GET_LOCK(%?, %?)", lockName, getLockTimeout.Seconds())
@@ -427,7 +468,7 @@ func TestAdvisoryLockRefreshSurvivesReconnectFailure(t *testing.T) {
 	require.NoError(t, err)
 	defer utils.CloseAndLog(observer)
 
-	lockName := computeLockName(&lockTableInfo)
+	lockName := computeLockName("", &lockTableInfo)
 	stmt := sqlescape.MustEscapeSQL("SELECT IS_USED_LOCK(%?)", lockName)
 	require.Eventually(t, func() bool {
 		var owner sql.NullInt64
@@ -0,0 +1,154 @@
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// budgetMaxIdleTime is how long a pool opened under a ConnBudget keeps an
+// idle connection. An idle connection still holds a slot, so it is closed
+// soon to let other pools sharing the budget use the slot.
+const budgetMaxIdleTime = time.Second
+
+// ErrConnBudgetExhausted is returned when a connection under a ConnBudget
+// could not be opened within its wait timeout.
+var ErrConnBudgetExhausted = errors.New("connection budget exhausted")
+
+// ConnBudget caps the number of connections that several pools may have
+// open at once, so that migrations running in the same process do not
+// exhaust max_connections between them. Every pool opened with the same
+// DBConfig.ConnBudget counts against it: when it is exhausted, opening a
+// new connection blocks until a connection under the budget is closed, the
+// context is cancelled, or the wait timeout set with SetWaitTimeout expires.
+//
+// A pool still opens connections up to its own MaxOpenConnections, so the
+// budget must be at least as large as the largest pool, plus any
+// connections held for a whole migration (such as its advisory lock).
+// Otherwise a single pool can wait forever on connections it holds itself.
+type ConnBudget struct {
+	slots       chan struct{}
+	maxInUse    atomic.Int64
+	waitTimeout time.Duration
+}
+
+// NewConnBudget returns a budget of limit connections. The limit must be
+// positive.
+func NewConnBudget(limit int) *ConnBudget {
+	return &ConnBudget{slots: make(chan struct{}, limit)}
+}
+
+// Limit returns the number of connections the budget allows.
+func (b *ConnBudget) Limit() int {
+	return cap(b.slots)
+}
+
+// InUse returns the number of connections currently open under the budget.
+func (b *ConnBudget) InUse() int {
+	return len(b.slots)
+}
+
+// SetWaitTimeout sets how long opening a connection waits for a slot before
+// failing with ErrConnBudgetExhausted. Zero, the default, waits until the
+// context is cancelled. It must be set before any pool uses the budget.
+func (b *ConnBudget) SetWaitTimeout(d time.Duration) {
+	b.waitTimeout = d
+}
+
+// MaxInUse returns the largest number of connections that have been open
+// under the budget at the same time.
+func (b *ConnBudget) MaxInUse() int {
+	return int(b.maxInUse.Load())
+}
+
+func (b *ConnBudget) acquire(ctx context.Context) error {
+	var timeout <-chan time.Time
+	if b.waitTimeout > 0 {
+		timer := time.NewTimer(b.waitTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case b.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timeout:
+		return fmt.Errorf("%w: no connection was freed within %s (limit %d)", ErrConnBudgetExhausted, b.waitTimeout, b.Limit())
+	}
+	inUse := int64(len(b.slots))
+	for {
+		peak := b.maxInUse.Load()
+		if inUse <= peak || b.maxInUse.CompareAndSwap(peak, inUse) {
+			return nil
+		}
+	}
+}
+
+func (b *ConnBudget) release() {
+	<-b.slots
+}
+
+// budgetConnector opens connections with connector, holding a slot of
+// budget for the lifetime of each connection.
+type budgetConnector struct {
+	driver.Connector
+
+	budget *ConnBudget
+}
+
+// openWithBudget is like sql.OpenDB(connector), except that every
+// connection the pool opens counts against budget.
+func openWithBudget(connector driver.Connector, budget *ConnBudget) *sql.DB {
+	db := sql.OpenDB(&budgetConnector{Connector: connector, budget: budget})
+	db.SetConnMaxIdleTime(budgetMaxIdleTime)
+	return db
+}
+
+func (c *budgetConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	if err := c.budget.acquire(ctx); err != nil {
+		return nil, err
+	}
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		c.budget.release()
+		return nil, err
+	}
+	dc, ok := conn.(budgetDriverConn)
+	if !ok {
+		c.budget.release()
+		return nil, errors.Join(errors.New("driver connection does not support the interfaces database/sql uses"), conn.Close())
+	}
+	return &budgetConn{budgetDriverConn: dc, release: sync.OnceFunc(c.budget.release)}, nil
+}
+
+// budgetDriverConn is the set of optional driver interfaces a
+// go-sql-driver/mysql connection implements. budgetConn embeds it so that
+// database/sql still finds them on the wrapped connection.
+type budgetDriverConn interface {
+	driver.Conn
+	driver.ConnBeginTx
+	driver.ConnPrepareContext
+	driver.ExecerContext
+	driver.QueryerContext
+	driver.Pinger
+	driver.SessionResetter
+	driver.Validator
+	driver.NamedValueChecker
+}
+
+// budgetConn is a connection that gives its budget slot back when closed.
+type budgetConn struct {
+	budgetDriverConn
+
+	release func()
+}
+
+func (c *budgetConn) Close() error {
+	defer c.release()
+	return c.budgetDriverConn.Close()
+}
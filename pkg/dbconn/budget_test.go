@@ -0,0 +1,94 @@
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fakeConnector opens fakeConns, so the budget can be tested without a
+// server.
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) { return fakeConn{}, nil }
+func (fakeConnector) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{}
+
+func (fakeConn) Prepare(string) (driver.Stmt, error) { return nil, driver.ErrSkip }
+func (fakeConn) Close() error                        { return nil }
+func (fakeConn) Begin() (driver.Tx, error)           { return nil, driver.ErrSkip }
+func (fakeConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return nil, driver.ErrSkip
+}
+func (fakeConn) PrepareContext(context.Context, string) (driver.Stmt, error) {
+	return nil, driver.ErrSkip
+}
+func (fakeConn) ExecContext(context.Context, string, []driver.NamedValue) (driver.Result, error) {
+	return driver.ResultNoRows, nil
+}
+func (fakeConn) QueryContext(context.Context, string, []driver.NamedValue) (driver.Rows, error) {
+	return nil, driver.ErrSkip
+}
+func (fakeConn) Ping(context.Context) error               { return nil }
+func (fakeConn) ResetSession(context.Context) error       { return nil }
+func (fakeConn) IsValid() bool                            { return true }
+func (fakeConn) CheckNamedValue(*driver.NamedValue) error { return nil }
+
+func TestConnBudget(t *testing.T) {
+	budget := NewConnBudget(2)
+	require.Equal(t, 2, budget.Limit())
+	// Two pools share the budget.
+	db1 := sql.OpenDB(&budgetConnector{Connector: fakeConnector{}, budget: budget})
+	defer db1.Close()
+	db2 := sql.OpenDB(&budgetConnector{Connector: fakeConnector{}, budget: budget})
+	defer db2.Close()
+
+	conn1, err := db1.Conn(t.Context())
+	require.NoError(t, err)
+	conn2, err := db2.Conn(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, 2, budget.InUse())
+
+	// The budget is exhausted, so a third connection has to wait.
+	ctx, cancel := context.WithTimeout(t.Context(), 50*time.Millisecond)
+	defer cancel()
+	_, err = db1.Conn(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// Returning a connection to its pool keeps it open, and its slot taken.
+	require.NoError(t, conn2.Close())
+	require.Equal(t, 2, budget.InUse())
+	// Closing it frees the slot for the other pool.
+	db2.SetMaxIdleConns(0)
+	require.Equal(t, 1, budget.InUse())
+	conn3, err := db1.Conn(t.Context())
+	require.NoError(t, err)
+	_, err = conn3.ExecContext(t.Context(), "SELECT 1")
+	require.NoError(t, err, "the wrapped connection keeps the driver's ExecerContext")
+
+	require.NoError(t, conn1.Close())
+	require.NoError(t, conn3.Close())
+	require.NoError(t, db1.Close())
+	require.Zero(t, budget.InUse())
+	require.Equal(t, 2, budget.MaxInUse())
+}
+
+func TestConnBudgetWaitTimeout(t *testing.T) {
+	budget := NewConnBudget(1)
+	budget.SetWaitTimeout(50 * time.Millisecond)
+	db := sql.OpenDB(&budgetConnector{Connector: fakeConnector{}, budget: budget})
+	defer db.Close()
+
+	conn, err := db.Conn(t.Context())
+	require.NoError(t, err)
+	// The budget is exhausted and nothing frees a slot, so the wait fails
+	// instead of blocking until the context is cancelled.
+	_, err = db.Conn(t.Context())
+	require.ErrorIs(t, err, ErrConnBudgetExhausted)
+	require.NoError(t, conn.Close())
+}
@@ -1,6 +1,8 @@
 package dbconn
 
 import (
+	"cmp"
+	"context"
 	"crypto/tls"
 	"crypto/x509"
 	"database/sql"
@@ -27,7 +29,8 @@ const (
 	maxIdleConns          = 10
 )
 
-// maxConnLifetime is the default maximum lifetime for pooled connections.
+// maxConnLifetime is the default maximum lifetime for pooled connections
+// (see DBConfig.MaxConnLifetime).
 // It is a var (not a const) only so tests can shorten it; production code
 // must not modify it. Note that pools holding session-scoped state (such as
 // the advisory lock's GET_LOCK) are deliberately exempted from this limit —
@@ -120,7 +123,10 @@ func NewCustomTLSConfig(certData []byte, sslMode string) *tls.Config {
 			},
 		}
 	case "VERIFY_IDENTITY":
-		// Full verification including hostname
+		// Full verification including hostname. ServerName is left empty:
+		// the config is registered once under a shared name, and the driver
+		// sets ServerName to the host of each DSN that uses it (see
+		// TestNewDSNVerifyIdentityServerName).
 		return &tls.Config{
 			RootCAs:            caCertPool,
 			InsecureSkipVerify: false,
@@ -330,6 +336,45 @@ func isTLSUnsupportedByServer(err error) bool {
 	return errors.Is(err, mysql.ErrNoTLS)
 }
 
+// openDB opens a pool for dsn, counting its connections against
+// config.ConnBudget and taking their credentials from
+// config.CredentialProvider when those are set.
+func openDB(dsn string, config *DBConfig) (*sql.DB, error) {
+	if config == nil || (config.ConnBudget == nil && config.CredentialProvider == nil) {
+		return sql.Open("mysql", dsn)
+	}
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return nil, err
+	}
+	if config.CredentialProvider != nil {
+		if err := cfg.Apply(mysql.BeforeConnect(refreshCredentials(config.CredentialProvider))); err != nil {
+			return nil, err
+		}
+	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if config.ConnBudget != nil {
+		return openWithBudget(connector, config.ConnBudget), nil
+	}
+	return sql.OpenDB(connector), nil
+}
+
+// refreshCredentials returns a BeforeConnect function that sets the user and
+// password of each new connection to those returned by provider.
+func refreshCredentials(provider func() (string, string, error)) func(context.Context, *mysql.Config) error {
+	return func(_ context.Context, cfg *mysql.Config) error {
+		user, password, err := provider()
+		if err != nil {
+			return fmt.Errorf("failed to get database credentials: %w", err)
+		}
+		cfg.User, cfg.Passwd = user, password
+		return nil
+	}
+}
+
 // New is similar to sql.Open except we take the inputDSN and
 // append additional options to it to standardize the connection.
 // It will also ping the connection to ensure it is valid.
@@ -357,14 +402,14 @@ func NewWithConnectionType(inputDSN string, config *DBConfig, connectionType str
 			// There are many different ways we create a DB connection.
 			// Ensure we change conn settings in all code paths.
 			db.SetMaxOpenConns(config.MaxOpenConnections)
-			db.SetConnMaxLifetime(maxConnLifetime)
-			db.SetMaxIdleConns(maxIdleConns)
+			db.SetConnMaxLifetime(cmp.Or(config.MaxConnLifetime, maxConnLifetime))
+			db.SetMaxIdleConns(cmp.Or(config.MaxIdleConns, maxIdleConns))
 		}
 	}()
 	// For PREFERRED mode, implement fallback behavior
 	if config.TLSMode == "PREFERRED" {
 		// First try with TLS
-		db, err := sql.Open("mysql", dsn)
+		db, err := openDB(dsn, config)
 		if err == nil {
 			//nolint: noctx // requires too much refactoring
 			if pingErr := db.Ping(); pingErr == nil {
@@ -402,7 +447,7 @@ func NewWithConnectionType(inputDSN string, config *DBConfig, connectionType str
 			return nil, fmt.Errorf("failed to create fallback DSN for %s connection: %w", connectionType, err)
 		}
 
-		db, err = sql.Open("mysql", fallbackDSN)
+		db, err = openDB(fallbackDSN, config)
 		if err != nil {
 			return nil, fmt.Errorf("failed to open fallback %s connection: %w", connectionType, err)
 		}
@@ -415,7 +460,7 @@ func NewWithConnectionType(inputDSN string, config *DBConfig, connectionType str
 	}
 
 	// For all other modes, use standard connection
-	db, err = sql.Open("mysql", dsn)
+	db, err = openDB(dsn, config)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open %s connection: %w", connectionType, err)
 	}
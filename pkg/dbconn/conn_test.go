@@ -3,9 +3,13 @@ package dbconn
 import (
 	"context"
 	"crypto/x509"
+	"database/sql"
 	"encoding/pem"
+	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/block/spirit/pkg/testutils"
 	"github.com/block/spirit/pkg/utils"
@@ -153,6 +157,73 @@ func TestNewConn(t *testing.T) {
 	require.Nil(t, db)
 }
 
+// TestNewConnPoolSettings checks that DBConfig.MaxIdleConns and
+// MaxConnLifetime are applied to the pool New returns.
+func TestNewConnPoolSettings(t *testing.T) {
+	config := NewDBConfig()
+	config.MaxIdleConns = 1
+	config.MaxConnLifetime = time.Second
+	db, err := New(testutils.DSN(), config)
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	// Of three connections returned to the pool, only one is kept idle.
+	conns := make([]*sql.Conn, 3)
+	for i := range conns {
+		conns[i], err = db.Conn(t.Context())
+		require.NoError(t, err)
+	}
+	for _, conn := range conns {
+		require.NoError(t, conn.Close())
+	}
+	require.Equal(t, 1, db.Stats().Idle)
+	require.GreaterOrEqual(t, db.Stats().MaxIdleClosed, int64(2))
+
+	// The idle one is closed once it is older than a second.
+	require.Eventually(t, func() bool {
+		return db.Stats().MaxLifetimeClosed > 0
+	}, 10*time.Second, 100*time.Millisecond)
+}
+
+// TestNewConnCredentialProvider checks that the pool takes the credentials of
+// each new connection from DBConfig.CredentialProvider, not the DSN.
+func TestNewConnCredentialProvider(t *testing.T) {
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+	user, password := cfg.User, cfg.Passwd
+	cfg.Passwd = "expired-token"
+
+	var calls atomic.Int64
+	config := NewDBConfig()
+	config.CredentialProvider = func() (string, string, error) {
+		calls.Add(1)
+		return user, password, nil
+	}
+	db, err := New(cfg.FormatDSN(), config)
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+	conn, err := db.Conn(t.Context())
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+	require.Positive(t, calls.Load())
+}
+
+// TestOpenDBCredentialProviderError checks that an error from the credential
+// provider is returned when a connection is opened, without connecting.
+func TestOpenDBCredentialProviderError(t *testing.T) {
+	providerErr := errors.New("token service unavailable")
+	config := NewDBConfig()
+	config.CredentialProvider = func() (string, string, error) {
+		return "", "", providerErr
+	}
+	db, err := openDB("spirit:expired@tcp(127.0.0.1:1)/test", config)
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+	err = db.PingContext(t.Context())
+	require.ErrorIs(t, err, providerErr)
+	require.ErrorContains(t, err, "failed to get database credentials")
+}
+
 func TestNewConnRejectsReadOnlyConnections(t *testing.T) {
 	// Database connection check
 	db, err := New(testutils.DSN(), NewDBConfig())
@@ -10,6 +10,7 @@ import (
 	"io"
 	"log/slog"
 	"math/rand/v2"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -51,8 +52,20 @@ type DBConfig struct {
 	MaxRetries               int
 	MaxOpenConnections       int
 	RangeOptimizerMaxMemSize int64
-	InterpolateParams        bool
-	ForceKill                bool // If true, kill locking transactions to acquire metadata locks (default: true)
+	// MaxConnLifetime and MaxIdleConns are applied to the pools opened by
+	// New. Behind a proxy such as ProxySQL that closes connections early, a
+	// lifetime below the proxy's avoids "invalid connection" errors
+	// mid-query. Zero means the defaults: 3 minutes and 10 idle connections.
+	MaxConnLifetime time.Duration
+	MaxIdleConns    int
+	// InterpolateParams maps to the go-sql-driver interpolateParams option:
+	// statements with arguments are expanded client-side instead of being
+	// prepared on the server, saving a round trip. Either way, the values
+	// spirit writes into statements itself (chunk boundaries, key values)
+	// are already escaped literals, which rely on the sql_mode and charset
+	// set by newDSN (default: false).
+	InterpolateParams bool
+	ForceKill         bool // If true, kill locking transactions to acquire metadata locks (default: true)
 	// RejectReadOnly maps to the go-sql-driver rejectReadOnly option: a
 	// statement that fails with a read-only error (1290/1792/1836) is turned
 	// into driver.ErrBadConn so database/sql throws the connection away and
@@ -67,6 +80,35 @@ type DBConfig struct {
 	// TLS Configuration
 	TLSMode            string // TLS connection mode (DISABLED, PREFERRED, REQUIRED, VERIFY_CA, VERIFY_IDENTITY)
 	TLSCertificatePath string // Path to custom TLS certificate file
+	// QueryComment, when set, is prepended as a /* ... */ comment to every
+	// statement run by RetryableTransaction (the copy and apply writes), so
+	// they can be attributed to a migration in the processlist, slow log and
+	// performance_schema. See CommentedStatement.
+	QueryComment string
+	// IgnoreShowWarningsErrors, when true, lets RetryableTransaction commit
+	// even if the SHOW WARNINGS it runs after each statement fails, as it
+	// can behind some proxies, logging the error instead. The warnings of
+	// that statement are then not checked, so an unsafe one goes unnoticed.
+	// A warning that is read is still checked as usual (default: false).
+	IgnoreShowWarningsErrors bool
+	// ConnBudget, when set, is shared with other pools: the connections of
+	// every pool opened with the same budget count against its limit (see
+	// ConnBudget). Nil means the pool is limited only by MaxOpenConnections.
+	ConnBudget *ConnBudget
+	// CredentialProvider, when set, is called before each new connection in
+	// the pool is opened, and the credentials it provides replace those in
+	// the DSN. It is for credentials that expire, such as RDS IAM auth
+	// tokens, which would otherwise fail every connection opened after the
+	// token in the DSN expired. The binlog and GTID change sources call it
+	// too, before they reconnect. It may be called concurrently.
+	CredentialProvider func() (user, password string, err error)
+	// LockNamespace, when set, is mixed into the names of the advisory
+	// locks (see AdvisoryLock), so that another tool taking GET_LOCK names
+	// of the same form doesn't block spirit, or vice versa. Runs with the
+	// same namespace on the same table still serialize; runs in different
+	// namespaces don't, and use their own auxiliary tables. Empty keeps the
+	// default names.
+	LockNamespace string
 }
 
 func NewDBConfig() *DBConfig {
@@ -118,6 +160,13 @@ func IsConnectionLossError(err error) bool {
 	}
 }
 
+// IsRetryableError reports whether err is a transient failure that
+// RetryableTransaction would retry, such as a lost connection, a deadlock
+// or a lock wait timeout, rather than a permanent one.
+func IsRetryableError(err error) bool {
+	return canRetryError(err)
+}
+
 // canRetryError looks at the MySQL error and decides if it is considered
 // a permanent failure or not. For simplicity a "retryable" error means
 // rollback the transaction and start the transaction again.
@@ -165,6 +214,16 @@ const (
 	IgnoreDupKeyWarnings
 )
 
+// CommentedStatement prepends comment to stmt as a /* ... */ SQL comment. The
+// comment is neutralized so it cannot close the comment early and inject SQL.
+// An empty comment returns stmt unchanged.
+func CommentedStatement(comment, stmt string) string {
+	if comment == "" {
+		return stmt
+	}
+	return "/* " + strings.ReplaceAll(comment, "*/", "* /") + " */ " + stmt
+}
+
 // RetryableTransaction retries all statements in a transaction, retrying if a statement
 // errors, or there is a deadlock. It will retry up to maxRetries times.
 func RetryableTransaction(ctx context.Context, db *sql.DB, dupKeyHandling DupKeyHandling, config *DBConfig, stmts ...string) (int64, error) {
@@ -202,7 +261,7 @@ func RetryableTransaction(ctx context.Context, db *sql.DB, dupKeyHandling DupKey
 					continue
 				}
 				var res sql.Result
-				if res, err = trx.ExecContext(ctx, stmt); err != nil {
+				if res, err = trx.ExecContext(ctx, CommentedStatement(config.QueryComment, stmt)); err != nil {
 					if !canRetryError(err) {
 						isFatal = true
 					}
@@ -210,27 +269,24 @@ func RetryableTransaction(ctx context.Context, db *sql.DB, dupKeyHandling DupKey
 				}
 				// Even though there was no ERROR we still need to inspect SHOW WARNINGS
 				// This is because many of the statements use INSERT IGNORE.
-				var warningRes *sql.Rows
-				warningRes, err = trx.QueryContext(ctx, "SHOW WARNINGS")
-				if err != nil {
-					return
-				}
-				defer utils.CloseAndLog(warningRes)
-				var level, message string
-				var code int
-				for warningRes.Next() {
-					err = warningRes.Scan(&level, &code, &message)
-					if err != nil {
+				var warnings []warning
+				if warnings, err = showWarnings(ctx, trx); err != nil {
+					if !config.IgnoreShowWarningsErrors {
 						return
 					}
+					slog.Warn("could not read the warnings of a statement; committing without checking them",
+						"error", err)
+					err = nil
+				}
+				for _, w := range warnings {
 					// We won't receive out of range warnings (1264)
 					// because the SQL mode has been unset. This is important
 					// because a historical value like 0000-00-00 00:00:00
 					// might exist in the table and needs to be copied.
 					switch {
-					case code == errFoundDuppKey && dupKeyHandling == IgnoreDupKeyWarnings:
+					case w.code == errFoundDuppKey && dupKeyHandling == IgnoreDupKeyWarnings:
 						continue // ignore duplicate key warnings
-					case code == errCapacityExceeded:
+					case w.code == errCapacityExceeded:
 						// "Memory capacity of 8388608 bytes for 'range_optimizer_max_mem_size' exceeded.
 						// Range optimization was not done for this query."
 						// i.e. the query can still execute, but it won't be efficient. Prior to
@@ -242,14 +298,10 @@ func RetryableTransaction(ctx context.Context, db *sql.DB, dupKeyHandling DupKey
 						return
 					default:
 						isFatal = true
-						err = fmt.Errorf("unsafe warning: %s", message)
+						err = fmt.Errorf("unsafe warning: %s", w.message)
 						return
 					}
 				}
-				if warningRes.Err() != nil {
-					err = warningRes.Err()
-					return
-				}
 				// As long as it is a statement that supports affected rows (err == nil)
 				// Get the number of rows affected and add it to the total balance.
 				// This uses errC because some statements don't support affected rows,
@@ -278,6 +330,31 @@ func RetryableTransaction(ctx context.Context, db *sql.DB, dupKeyHandling DupKey
 	return rowsAffected, err
 }
 
+// warning is a row of SHOW WARNINGS.
+type warning struct {
+	code    int
+	message string
+}
+
+// showWarnings returns the warnings of the last statement run in trx.
+func showWarnings(ctx context.Context, trx *sql.Tx) ([]warning, error) {
+	rows, err := trx.QueryContext(ctx, "SHOW WARNINGS")
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseAndLog(rows)
+	var warnings []warning
+	for rows.Next() {
+		var level string
+		var w warning
+		if err := rows.Scan(&level, &w.code, &w.message); err != nil {
+			return nil, err
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings, rows.Err()
+}
+
 // backoffDuration returns the delay before a retry for the given 0-based
 // attempt: a short, jittered interval that grows with the attempt. The
 // (attempt+1) factor and the +1 on the jitter guarantee that every retry —
@@ -294,6 +371,35 @@ func backoff(attempt int) {
 	time.Sleep(backoffDuration(attempt))
 }
 
+// RetryableSetInfo loads the table's metadata with SetInfo, retrying up to
+// config.MaxRetries times when it fails with a retryable error (see
+// canRetryError). SetInfo only reads, so it is always safe to repeat, and a
+// brief connection loss or failover during setup no longer fails a migration
+// that may have been about to run for days.
+func RetryableSetInfo(ctx context.Context, t *table.TableInfo, config *DBConfig) error {
+	return retryTransient(ctx, config.MaxRetries, t.SetInfo)
+}
+
+// retryTransient calls fn until it succeeds, returns an error that
+// canRetryError considers permanent, or has been tried maxRetries times.
+// It always tries at least once.
+func retryTransient(ctx context.Context, maxRetries int, fn func(context.Context) error) error {
+	var err error
+	for i := range max(maxRetries, 1) {
+		if err = fn(ctx); err == nil || !canRetryError(err) {
+			return err
+		}
+		if i < maxRetries-1 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(backoffDuration(i)):
+			}
+		}
+	}
+	return err
+}
+
 // ForceExec is like Exec but it has some added logic to force kill
 // any connections that are holding up metadata locks preventing this from
 // succeeding.
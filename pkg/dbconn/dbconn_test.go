@@ -9,6 +9,7 @@ import (
 	"io"
 	"log/slog"
 	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -149,6 +150,59 @@ func TestRetryableTrx(t *testing.T) {
 	require.NoError(t, err)
 }
 
+// noWarningsConnector opens connections on which SHOW WARNINGS fails, as it
+// can behind some proxies, and counts the transactions committed.
+type noWarningsConnector struct {
+	commits *atomic.Int64
+}
+
+func (c noWarningsConnector) Connect(context.Context) (driver.Conn, error) {
+	return noWarningsConn{commits: c.commits}, nil
+}
+func (noWarningsConnector) Driver() driver.Driver { return nil }
+
+type noWarningsConn struct {
+	fakeConn
+
+	commits *atomic.Int64
+}
+
+func (c noWarningsConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	return noWarningsTx(c), nil
+}
+func (noWarningsConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	if query == "SHOW WARNINGS" {
+		return nil, errors.New("SHOW WARNINGS is not supported")
+	}
+	return nil, driver.ErrSkip
+}
+
+type noWarningsTx noWarningsConn
+
+func (t noWarningsTx) Commit() error {
+	t.commits.Add(1)
+	return nil
+}
+func (noWarningsTx) Rollback() error { return nil }
+
+func TestRetryableTrxShowWarningsError(t *testing.T) {
+	var commits atomic.Int64
+	db := sql.OpenDB(noWarningsConnector{commits: &commits})
+	defer utils.CloseAndLog(db)
+
+	// By default a statement whose warnings cannot be read is not committed.
+	config := NewDBConfig()
+	_, err := RetryableTransaction(t.Context(), db, IgnoreDupKeyWarnings, config, "INSERT INTO t1 VALUES (1)")
+	require.ErrorContains(t, err, "SHOW WARNINGS is not supported")
+	require.Zero(t, commits.Load())
+
+	// With IgnoreShowWarningsErrors it is.
+	config.IgnoreShowWarningsErrors = true
+	_, err = RetryableTransaction(t.Context(), db, IgnoreDupKeyWarnings, config, "INSERT INTO t1 VALUES (1)")
+	require.NoError(t, err)
+	require.Equal(t, int64(1), commits.Load())
+}
+
 func TestCanRetryError(t *testing.T) {
 	// Server-side errors that are retryable.
 	require.True(t, canRetryError(&mysql.MySQLError{Number: 1205})) // lock wait timeout
@@ -290,6 +344,62 @@ func TestShouldRetryForceExecAfterKill(t *testing.T) {
 		&mysql.MySQLError{Number: errLockWaitTimeout}), true))
 }
 
+func TestRetryTransient(t *testing.T) {
+	// A SetInfo that fails once with a connection loss, as it would during a
+	// failover, succeeds on the retry.
+	calls := 0
+	err := retryTransient(t.Context(), 3, func(context.Context) error {
+		calls++
+		if calls == 1 {
+			return driver.ErrBadConn
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, 2, calls)
+
+	// A permanent error is returned straight away.
+	calls = 0
+	err = retryTransient(t.Context(), 3, func(context.Context) error {
+		calls++
+		return errors.New("table test.t1 does not exist")
+	})
+	require.ErrorContains(t, err, "does not exist")
+	require.Equal(t, 1, calls)
+
+	// A transient error that persists gives up after maxRetries attempts.
+	calls = 0
+	err = retryTransient(t.Context(), 3, func(context.Context) error {
+		calls++
+		return &mysql.MySQLError{Number: errLockWaitTimeout}
+	})
+	require.Error(t, err)
+	require.Equal(t, 3, calls)
+
+	// Zero retries still makes one attempt.
+	calls = 0
+	require.NoError(t, retryTransient(t.Context(), 0, func(context.Context) error {
+		calls++
+		return nil
+	}))
+	require.Equal(t, 1, calls)
+}
+
+func TestRetryableSetInfo(t *testing.T) {
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS setinforetry")
+	testutils.RunSQL(t, "CREATE TABLE setinforetry (id INT NOT NULL PRIMARY KEY, b INT)")
+	db, err := New(testutils.DSN(), NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	tbl := table.NewTableInfo(db, "test", "setinforetry")
+	require.NoError(t, RetryableSetInfo(t.Context(), tbl, NewDBConfig()))
+	require.Equal(t, []string{"id", "b"}, tbl.Columns)
+
+	missing := table.NewTableInfo(db, "test", "setinforetry_missing")
+	require.ErrorContains(t, RetryableSetInfo(t.Context(), missing, NewDBConfig()), "does not exist")
+}
+
 func TestForceExec(t *testing.T) {
 	config := NewDBConfig()
 	config.LockWaitTimeout = 1 // as short as possible.
@@ -335,3 +445,10 @@ func TestStandardTrx(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, connID, observedConnID)
 }
+
+func TestCommentedStatement(t *testing.T) {
+	require.Equal(t, "SELECT 1", CommentedStatement("", "SELECT 1"))
+	require.Equal(t, "/* correlation_id=CHG-1 */ SELECT 1", CommentedStatement("correlation_id=CHG-1", "SELECT 1"))
+	// A comment containing */ cannot terminate the comment early.
+	require.Equal(t, "/* a* / DROP TABLE t; /* */ SELECT 1", CommentedStatement("a*/ DROP TABLE t; /*", "SELECT 1"))
+}
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/block/spirit/pkg/utils"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/require"
 )
 
@@ -322,6 +323,25 @@ func TestNewDSNWithTLSModes(t *testing.T) {
 	}
 }
 
+// TestNewDSNVerifyIdentityServerName checks that a VERIFY_IDENTITY connection
+// to a non-RDS host verifies the certificate against that host, including
+// when pools to two hosts share the registered verify_identity config.
+func TestNewDSNVerifyIdentityServerName(t *testing.T) {
+	config := NewDBConfig()
+	config.TLSMode = "VERIFY_IDENTITY"
+	for _, host := range []string{"db1.example.com", "db2.example.com"} {
+		dsn, err := newDSN("root:password@tcp("+host+":3306)/test", config)
+		require.NoError(t, err)
+		require.Contains(t, dsn, "tls=verify_identity")
+
+		cfg, err := mysql.ParseDSN(dsn)
+		require.NoError(t, err)
+		require.NotNil(t, cfg.TLS)
+		require.False(t, cfg.TLS.InsecureSkipVerify)
+		require.Equal(t, host, cfg.TLS.ServerName)
+	}
+}
+
 func TestDBConfigTLSModeDefaults(t *testing.T) {
 	config := NewDBConfig()
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 
 	"github.com/block/spirit/pkg/statement"
 )
@@ -18,6 +19,12 @@ type LintCmd struct {
 
 	// Filtering
 	IgnoreTables string `help:"Regex pattern of table names to ignore" default:""`
+
+	// Linter settings
+	Config []string `help:"Linter setting as linter.key=value, e.g. large_varchar.threshold=2048 (repeatable)" placeholder:"LINTER.KEY=VALUE"`
+
+	// Output
+	Format string `help:"Output format for violations (text, json or sarif)" enum:"text,json,sarif" default:"text"`
 }
 
 // Run executes the lint command. It is called by Kong.
@@ -33,6 +40,9 @@ func (cmd *LintCmd) Run() error {
 
 	// 2. Build config — lint everything, no LintOnlyChanges
 	config, err := buildIgnoreTablesConfig(cmd.IgnoreTables, source)
+	if err == nil {
+		config.Settings, err = parseLinterSettings(cmd.Config)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error building config: %s\n", err)
 		os.Exit(2)
@@ -46,7 +56,18 @@ func (cmd *LintCmd) Run() error {
 	}
 
 	// 4. Print violations
-	printViolations(violations)
+	switch cmd.Format {
+	case "json":
+		err = printViolationsAsJSON(os.Stdout, violations)
+	case "sarif":
+		err = printViolationsAsSARIF(os.Stdout, violations, source)
+	default:
+		printViolations(violations)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error writing violations: %s\n", err)
+		os.Exit(2)
+	}
 
 	// 5. Exit code
 	if HasErrors(violations) {
@@ -85,3 +106,28 @@ func buildIgnoreTablesConfig(pattern string, source []*statement.CreateTable) (C
 
 	return config, nil
 }
+
+// parseLinterSettings turns linter.key=value entries into Config.Settings.
+// The linter must be registered; the key and value are checked by the
+// linter's Configure when it runs.
+func parseLinterSettings(entries []string) (map[string]map[string]string, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	settings := make(map[string]map[string]string)
+	for _, entry := range entries {
+		name, value, ok := strings.Cut(entry, "=")
+		linterName, key, hasKey := strings.Cut(name, ".")
+		if !ok || !hasKey || linterName == "" || key == "" {
+			return nil, fmt.Errorf("invalid --config %q: expected linter.key=value", entry)
+		}
+		if _, err := Get(linterName); err != nil {
+			return nil, fmt.Errorf("invalid --config %q: %w", entry, err)
+		}
+		if settings[linterName] == nil {
+			settings[linterName] = make(map[string]string)
+		}
+		settings[linterName][key] = value
+	}
+	return settings, nil
+}
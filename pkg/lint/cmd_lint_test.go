@@ -123,3 +123,27 @@ func TestLintCmd_IgnoreTablesFiltersViolations(t *testing.T) {
 	orderViolations := filterByTable(violations, "orders")
 	require.NotEmpty(t, orderViolations, "expected violations for orders table")
 }
+
+func TestLintCmd_ParseLinterSettings(t *testing.T) {
+	settings, err := parseLinterSettings(nil)
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	settings, err = parseLinterSettings([]string{
+		"large_varchar.threshold=2048",
+		"auto_inc_capacity.threshold=90",
+		"large_varchar.threshold=4096",
+	})
+	require.NoError(t, err)
+	require.Equal(t, map[string]map[string]string{
+		"large_varchar":     {"threshold": "4096"},
+		"auto_inc_capacity": {"threshold": "90"},
+	}, settings)
+
+	for _, entry := range []string{"large_varchar", "threshold=1", "large_varchar.=1", ".threshold=1"} {
+		_, err = parseLinterSettings([]string{entry})
+		require.ErrorContains(t, err, "expected linter.key=value", entry)
+	}
+	_, err = parseLinterSettings([]string{"no_such_linter.threshold=1"})
+	require.ErrorContains(t, err, `linter "no_such_linter" not found`)
+}
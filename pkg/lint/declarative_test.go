@@ -137,6 +137,7 @@ func TestPlanChanges_StructuredViolations(t *testing.T) {
 		{Name: "child", Schema: `CREATE TABLE child (
 			id BIGINT PRIMARY KEY,
 			parent_id BIGINT,
+			KEY fk_parent (parent_id),
 			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent(id)
 		)`},
 	}
@@ -146,6 +147,7 @@ func TestPlanChanges_StructuredViolations(t *testing.T) {
 			id BIGINT PRIMARY KEY,
 			parent_id BIGINT,
 			name VARCHAR(100),
+			KEY fk_parent (parent_id),
 			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent(id)
 		)`},
 	}
@@ -211,6 +213,7 @@ func TestPlanChanges_WithLintConfig(t *testing.T) {
 		{Name: "t1", Schema: `CREATE TABLE t1 (
 			id BIGINT PRIMARY KEY,
 			parent_id BIGINT,
+			KEY fk_parent (parent_id),
 			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent(id)
 		)`},
 	}
@@ -219,6 +222,7 @@ func TestPlanChanges_WithLintConfig(t *testing.T) {
 			id BIGINT PRIMARY KEY,
 			parent_id BIGINT,
 			name VARCHAR(100),
+			KEY fk_parent (parent_id),
 			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parent(id)
 		)`},
 	}
@@ -82,6 +82,11 @@ type Config struct {
 
 	// IgnoreTables can be used to discard violations for specific tables
 	IgnoreTables map[string]bool
+
+	// OnlyNewViolations discards violations that already exist in the
+	// existing schema, so only those introduced by the changes are returned.
+	// This is useful when reviewing a change against a legacy schema.
+	OnlyNewViolations bool
 }
 
 // IsEnabled checks the config as well as the registry to see if
@@ -117,15 +122,34 @@ func (c *Config) IsEnabled(linterName string) bool {
 //
 // If a linter implements ConfigurableLinter and has settings in config.Settings,
 // those settings are applied before running the linter.
+//
+// If config.OnlyNewViolations is set, the linters are also run against the
+// existing schema without the changes, and any violation already present
+// there is removed from the result. Errors from that run are returned
+// along with the errors from linting the changes.
 func RunLinters(existingSchema []*statement.CreateTable, changes []*statement.AbstractStatement, config Config) ([]Violation, error) {
-	var errs []error
-
 	// Acquired as a writer (not a reader) because Configure() mutates the
 	// globally-registered linter singletons. Concurrent RunLinters calls
 	// would otherwise race on those fields — see issue #747.
 	lock.Lock()
 	defer lock.Unlock()
 
+	violations, err := runLinters(existingSchema, changes, config)
+	if !config.OnlyNewViolations || len(violations) == 0 {
+		return violations, err
+	}
+	// The baseline lints the whole existing schema, so LintOnlyChanges
+	// must not filter it by the (empty) set of changed tables.
+	baselineConfig := config
+	baselineConfig.LintOnlyChanges = false
+	baseline, baselineErr := runLinters(existingSchema, nil, baselineConfig)
+	return newViolations(baseline, violations), errors.Join(err, baselineErr)
+}
+
+// runLinters runs the enabled linters and applies the config filters.
+// The caller must hold lock.
+func runLinters(existingSchema []*statement.CreateTable, changes []*statement.AbstractStatement, config Config) ([]Violation, error) {
+	var errs []error
 	var violations []Violation
 
 	for name, linter := range linters {
@@ -202,6 +226,35 @@ func RunLinters(existingSchema []*statement.CreateTable, changes []*statement.Ab
 	return violations, errors.Join(errs...)
 }
 
+// newViolations returns the violations in after that are not in before.
+// Violations are matched by linter, severity, message and location. Each
+// violation in before matches at most one in after, so a duplicate that
+// is introduced by a change is still reported.
+func newViolations(before, after []Violation) []Violation {
+	seen := make(map[string]int, len(before))
+	for _, v := range before {
+		seen[violationKey(v)]++
+	}
+	var out []Violation
+	for _, v := range after {
+		key := violationKey(v)
+		if seen[key] > 0 {
+			seen[key]--
+			continue
+		}
+		out = append(out, v)
+	}
+	return out
+}
+
+func violationKey(v Violation) string {
+	key := fmt.Sprintf("%s|%s|%s", v.Linter.Name(), v.Severity, v.Message)
+	if v.Location != nil {
+		key += "|" + v.Location.String()
+	}
+	return key
+}
+
 func extractTablesFromChanges(changes []*statement.AbstractStatement) (map[string]struct{}, error) {
 	tables := make(map[string]struct{})
 	for _, stmt := range changes {
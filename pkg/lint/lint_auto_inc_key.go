@@ -0,0 +1,111 @@
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+type AutoIncKeyLinter struct{}
+
+func init() {
+	Register(&AutoIncKeyLinter{})
+}
+
+func (l *AutoIncKeyLinter) String() string {
+	return Stringer(l)
+}
+
+func (l *AutoIncKeyLinter) Name() string {
+	return "auto_inc_key"
+}
+
+func (l *AutoIncKeyLinter) Description() string {
+	return "Detects AUTO_INCREMENT columns that are not indexed, or are not the leading column of their index"
+}
+
+// Lint operates on a post-state view of the schema, so an AUTO_INCREMENT
+// column added or modified with ALTER TABLE is checked against the indexes
+// the table will have once the ALTER is applied.
+//
+// MySQL refuses to create a table whose AUTO_INCREMENT column is not indexed.
+// InnoDB additionally needs the column to be the leading column of some index,
+// because it reads the index to find the next value on startup; only MyISAM
+// allows it to be a later column of a composite key, where it then counts
+// per group of the preceding columns. Both are reported: the unindexed case
+// as an error, the non-leading case as a warning.
+func (l *AutoIncKeyLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		indexes := ct.GetIndexes()
+		for _, col := range ct.Columns {
+			if !col.AutoInc {
+				continue
+			}
+			var containing []statement.Index
+			leading := false
+			for _, idx := range indexes {
+				pos := columnPosition(idx.Columns, col.Name)
+				if pos < 0 {
+					continue
+				}
+				containing = append(containing, idx)
+				if pos == 0 {
+					leading = true
+				}
+			}
+			switch {
+			case len(containing) == 0:
+				violations = append(violations, l.unindexed(ct.TableName, col.Name))
+			case !leading:
+				idx := containing[0]
+				// Name the PRIMARY KEY when the column is part of it, since
+				// that is where an AUTO_INCREMENT column is usually meant to
+				// lead.
+				if pk := indexes.Primary(); pk != nil && columnPosition(pk.Columns, col.Name) >= 0 {
+					idx = *pk
+				}
+				violations = append(violations, l.nonLeading(ct.TableName, col.Name, idx))
+			}
+		}
+	}
+	return violations
+}
+
+// columnPosition returns the position of column in columns, compared
+// case-insensitively, or -1 if it is not there.
+func columnPosition(columns []string, column string) int {
+	return slices.IndexFunc(columns, func(c string) bool { return strings.EqualFold(c, column) })
+}
+
+func (l *AutoIncKeyLinter) unindexed(tableName, column string) Violation {
+	suggestion := fmt.Sprintf("Make %q the PRIMARY KEY, or the leading column of an index", column)
+	return Violation{
+		Linter:     l,
+		Severity:   SeverityError,
+		Message:    fmt.Sprintf("AUTO_INCREMENT column %q in table %q is not part of any index", column, tableName),
+		Location:   &Location{Table: tableName, Column: &column},
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"column": column,
+		},
+	}
+}
+
+func (l *AutoIncKeyLinter) nonLeading(tableName, column string, idx statement.Index) Violation {
+	suggestion := fmt.Sprintf("Move %q to the first position of index %q, or add an index that starts with it", column, idx.Name)
+	return Violation{
+		Linter:   l,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("AUTO_INCREMENT column %q in table %q is not the leading column of index %q (%s); InnoDB requires it to lead an index",
+			column, tableName, idx.Name, strings.Join(idx.Columns, ", ")),
+		Location:   &Location{Table: tableName, Column: &column, Index: &idx.Name},
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"column":        column,
+			"index_name":    idx.Name,
+			"index_columns": idx.Columns,
+		},
+	}
+}
@@ -0,0 +1,103 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoIncKeyLinter_CreateTable_Leading(t *testing.T) {
+	for _, sql := range []string{
+		`CREATE TABLE t1 (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY, name VARCHAR(100))`,
+		`CREATE TABLE t2 (id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, name VARCHAR(100), PRIMARY KEY (ID))`,
+		// A secondary index that leads with the column is enough for InnoDB.
+		`CREATE TABLE t3 (
+			tenant_id INT NOT NULL,
+			id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+			PRIMARY KEY (tenant_id, id),
+			KEY id (id)
+		)`,
+	} {
+		stmts, err := statement.New(sql)
+		require.NoError(t, err)
+		require.Empty(t, (&AutoIncKeyLinter{}).Lint(nil, stmts), sql)
+	}
+}
+
+func TestAutoIncKeyLinter_CreateTable_Unindexed(t *testing.T) {
+	sql := `CREATE TABLE events (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		uuid BINARY(16) NOT NULL,
+		PRIMARY KEY (uuid)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&AutoIncKeyLinter{}).Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "auto_inc_key", violations[0].Linter.Name())
+	require.Equal(t, SeverityError, violations[0].Severity)
+	require.Equal(t, "events", violations[0].Location.Table)
+	require.Equal(t, "id", *violations[0].Location.Column)
+	require.Nil(t, violations[0].Location.Index)
+	require.Contains(t, violations[0].Message, "not part of any index")
+	require.NotNil(t, violations[0].Suggestion)
+}
+
+func TestAutoIncKeyLinter_CreateTable_NonLeading(t *testing.T) {
+	sql := `CREATE TABLE events (
+		tenant_id INT NOT NULL,
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		KEY tenant_id (tenant_id, id),
+		PRIMARY KEY (tenant_id, id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&AutoIncKeyLinter{}).Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "id", *violations[0].Location.Column)
+	// The PRIMARY KEY is named even though another index also contains the
+	// column.
+	require.Equal(t, "PRIMARY", *violations[0].Location.Index)
+	require.Contains(t, violations[0].Message, `not the leading column of index "PRIMARY" (tenant_id, id)`)
+}
+
+func TestAutoIncKeyLinter_CreateTable_NonLeadingSecondaryIndex(t *testing.T) {
+	sql := `CREATE TABLE events (
+		uuid BINARY(16) NOT NULL PRIMARY KEY,
+		tenant_id INT NOT NULL,
+		seq BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+		KEY tenant_seq (tenant_id, seq)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&AutoIncKeyLinter{}).Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "tenant_seq", *violations[0].Location.Index)
+}
+
+func TestAutoIncKeyLinter_AlterTable(t *testing.T) {
+	existing, err := statement.ParseCreateTable(`CREATE TABLE events (
+		uuid BINARY(16) NOT NULL PRIMARY KEY,
+		name VARCHAR(100)
+	)`)
+	require.NoError(t, err)
+
+	// Adding the column without an index is reported against the post-state.
+	stmts, err := statement.New(`ALTER TABLE events ADD COLUMN id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT`)
+	require.NoError(t, err)
+	violations := (&AutoIncKeyLinter{}).Lint([]*statement.CreateTable{existing}, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityError, violations[0].Severity)
+	require.Equal(t, "id", *violations[0].Location.Column)
+
+	// Adding an index for it in the same ALTER resolves it.
+	stmts, err = statement.New(`ALTER TABLE events ADD COLUMN id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT, ADD UNIQUE KEY id (id)`)
+	require.NoError(t, err)
+	require.Empty(t, (&AutoIncKeyLinter{}).Lint([]*statement.CreateTable{existing}, stmts))
+}
@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+func init() {
+	Register(&AutoIncrementRangeLinter{})
+}
+
+// AutoIncrementRangeLinter detects AUTO_INCREMENT columns whose integer type
+// is narrower than BIGINT. Unlike auto_inc_capacity, which needs the table's
+// current AUTO_INCREMENT value, it flags the type itself, so a table that
+// will one day run out of values is reported when it is created rather than
+// when it is nearly full.
+type AutoIncrementRangeLinter struct{}
+
+func (l *AutoIncrementRangeLinter) String() string {
+	return Stringer(l)
+}
+
+func (l *AutoIncrementRangeLinter) Name() string {
+	return "auto_increment_range"
+}
+
+func (l *AutoIncrementRangeLinter) Description() string {
+	return "Detects AUTO_INCREMENT columns whose type is smaller than BIGINT"
+}
+
+// Lint operates on a post-state view of the schema, so an ALTER that widens
+// the column to BIGINT is not reported. Every violation is a warning: an INT
+// is plenty for many tables, but only the application knows which ones.
+func (l *AutoIncrementRangeLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		for _, col := range ct.Columns {
+			if !col.AutoInc || col.Raw == nil || col.Raw.Tp == nil {
+				continue
+			}
+			maxValue, ok := autoIncrementMaxValue(col)
+			if !ok {
+				continue
+			}
+			colName := col.Name
+			colType := strings.ToUpper(col.Type)
+			if col.Unsigned != nil && *col.Unsigned {
+				colType += " UNSIGNED"
+			}
+			violations = append(violations, Violation{
+				Linter:   l,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf("AUTO_INCREMENT column %q in table %q is %s, which cannot hold values above %d",
+					colName, ct.TableName, colType, maxValue),
+				Location:   &Location{Table: ct.TableName, Column: &colName},
+				Suggestion: new(fmt.Sprintf("Use BIGINT UNSIGNED for %q if the table has a high write rate", colName)),
+				Context: map[string]any{
+					"column_type": colType,
+					"max_value":   maxValue,
+				},
+			})
+		}
+	}
+	return violations
+}
+
+// autoIncrementMaxValue returns the largest value col's type can hold, and
+// false if it is BIGINT or not an integer type.
+func autoIncrementMaxValue(col statement.Column) (uint64, bool) {
+	var bits int
+	switch col.Raw.Tp.GetType() {
+	case mysql.TypeTiny:
+		bits = 8
+	case mysql.TypeShort:
+		bits = 16
+	case mysql.TypeInt24:
+		bits = 24
+	case mysql.TypeLong:
+		bits = 32
+	default:
+		return 0, false
+	}
+	if col.Unsigned == nil || !*col.Unsigned {
+		bits--
+	}
+	return 1<<bits - 1, true
+}
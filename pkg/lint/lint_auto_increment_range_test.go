@@ -0,0 +1,72 @@
+package lint
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutoIncrementRangeLinter_IntTypes(t *testing.T) {
+	tests := []struct {
+		columnType string
+		wantType   string
+		wantMax    uint64
+	}{
+		{"TINYINT", "TINYINT", 127},
+		{"TINYINT UNSIGNED", "TINYINT UNSIGNED", 255},
+		{"SMALLINT", "SMALLINT", 32767},
+		{"SMALLINT UNSIGNED", "SMALLINT UNSIGNED", 65535},
+		{"MEDIUMINT", "MEDIUMINT", 8388607},
+		{"MEDIUMINT UNSIGNED", "MEDIUMINT UNSIGNED", 16777215},
+		{"INT", "INT", 2147483647},
+		{"INT UNSIGNED", "INT UNSIGNED", 4294967295},
+	}
+	for _, tt := range tests {
+		t.Run(tt.columnType, func(t *testing.T) {
+			stmts, err := statement.New("CREATE TABLE t1 (id " + tt.columnType + " NOT NULL AUTO_INCREMENT PRIMARY KEY, b INT)")
+			require.NoError(t, err)
+
+			violations := (&AutoIncrementRangeLinter{}).Lint(nil, stmts)
+
+			require.Len(t, violations, 1)
+			require.Equal(t, "auto_increment_range", violations[0].Linter.Name())
+			require.Equal(t, SeverityWarning, violations[0].Severity)
+			require.Equal(t, "t1", violations[0].Location.Table)
+			require.Equal(t, "id", *violations[0].Location.Column)
+			require.Equal(t, `AUTO_INCREMENT column "id" in table "t1" is `+tt.wantType+`, which cannot hold values above `+
+				strconv.FormatUint(tt.wantMax, 10), violations[0].Message)
+			require.Equal(t, tt.wantMax, violations[0].Context["max_value"])
+			require.NotNil(t, violations[0].Suggestion)
+			require.Contains(t, *violations[0].Suggestion, "BIGINT")
+		})
+	}
+}
+
+func TestAutoIncrementRangeLinter_Bigint(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		b INT NOT NULL
+	)`)
+	require.NoError(t, err)
+
+	require.Empty(t, (&AutoIncrementRangeLinter{}).Lint(nil, stmts))
+}
+
+func TestAutoIncrementRangeLinter_ExistingTable(t *testing.T) {
+	ct, err := statement.ParseCreateTable(`CREATE TABLE t1 (
+		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		b INT NOT NULL
+	)`)
+	require.NoError(t, err)
+
+	violations := (&AutoIncrementRangeLinter{}).Lint([]*statement.CreateTable{ct}, nil)
+	require.Len(t, violations, 1)
+	require.Equal(t, "id", *violations[0].Location.Column)
+
+	// Widening the column to BIGINT in an ALTER resolves it.
+	stmts, err := statement.New("ALTER TABLE t1 MODIFY id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT")
+	require.NoError(t, err)
+	require.Empty(t, (&AutoIncrementRangeLinter{}).Lint([]*statement.CreateTable{ct}, stmts))
+}
@@ -0,0 +1,70 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&CharsetUtf8mb3Linter{})
+}
+
+// CharsetUtf8mb3Linter flags the deprecated utf8 (an alias for utf8mb3)
+// character set, which stores at most three bytes per character and so
+// cannot hold emoji or other supplementary characters.
+type CharsetUtf8mb3Linter struct{}
+
+func (l *CharsetUtf8mb3Linter) Name() string {
+	return "charset_utf8mb3"
+}
+
+func (l *CharsetUtf8mb3Linter) Description() string {
+	return "Detects the utf8 (utf8mb3) character set on tables and columns"
+}
+
+func (l *CharsetUtf8mb3Linter) String() string {
+	return Stringer(l)
+}
+
+// Lint walks the post-state of the schema so converting a table or column to
+// utf8mb4 clears its violation. The table option and each column override are
+// reported separately, with the offending value in Context["charset"].
+func (l *CharsetUtf8mb3Linter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		if charset, ok := ct.GetTableOptions()["charset"].(string); ok && isUtf8mb3(charset) {
+			violations = append(violations, Violation{
+				Linter:     l,
+				Location:   &Location{Table: ct.TableName},
+				Message:    fmt.Sprintf("Table %q uses character set %q, which is utf8mb3 and cannot store 4-byte characters such as emoji", ct.TableName, charset),
+				Severity:   SeverityWarning,
+				Suggestion: new("Use CHARSET=utf8mb4 instead"),
+				Context: map[string]any{
+					"charset": charset,
+				},
+			})
+		}
+		for _, column := range ct.Columns {
+			if column.Charset == nil || !isUtf8mb3(*column.Charset) {
+				continue
+			}
+			violations = append(violations, Violation{
+				Linter:     l,
+				Location:   &Location{Table: ct.TableName, Column: &column.Name},
+				Message:    fmt.Sprintf("Column %q in table %q uses character set %q, which is utf8mb3 and cannot store 4-byte characters such as emoji", column.Name, ct.TableName, *column.Charset),
+				Severity:   SeverityWarning,
+				Suggestion: new("Use CHARACTER SET utf8mb4 instead"),
+				Context: map[string]any{
+					"charset": *column.Charset,
+				},
+			})
+		}
+	}
+	return violations
+}
+
+func isUtf8mb3(charset string) bool {
+	charset = strings.ToLower(charset)
+	return charset == "utf8" || charset == "utf8mb3"
+}
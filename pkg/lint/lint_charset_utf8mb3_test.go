@@ -0,0 +1,59 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCharsetUtf8mb3_Utf8mb4(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(100) CHARACTER SET utf8mb4) CHARSET=utf8mb4")
+	require.NoError(t, err)
+
+	linter := &CharsetUtf8mb3Linter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestCharsetUtf8mb3_TableOption(t *testing.T) {
+	for _, charset := range []string{"utf8", "UTF8", "utf8mb3"} {
+		t.Run(charset, func(t *testing.T) {
+			stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY) CHARSET=" + charset)
+			require.NoError(t, err)
+
+			linter := &CharsetUtf8mb3Linter{}
+			violations := linter.Lint(nil, stmts)
+			require.Len(t, violations, 1)
+			require.Equal(t, SeverityWarning, violations[0].Severity)
+			require.Equal(t, "t1", violations[0].Location.Table)
+			require.Nil(t, violations[0].Location.Column)
+			require.Contains(t, *violations[0].Suggestion, "utf8mb4")
+			require.NotEmpty(t, violations[0].Context["charset"])
+		})
+	}
+}
+
+func TestCharsetUtf8mb3_Column(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(100) CHARACTER SET utf8, bio TEXT) CHARSET=utf8mb4")
+	require.NoError(t, err)
+
+	linter := &CharsetUtf8mb3Linter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "t1", violations[0].Location.Table)
+	require.NotNil(t, violations[0].Location.Column)
+	require.Equal(t, "name", *violations[0].Location.Column)
+	require.Equal(t, "utf8", violations[0].Context["charset"])
+}
+
+func TestCharsetUtf8mb3_AlterConverts(t *testing.T) {
+	existing, err := statement.ParseCreateTable("CREATE TABLE t1 (id INT PRIMARY KEY, name VARCHAR(100)) CHARSET=utf8")
+	require.NoError(t, err)
+
+	linter := &CharsetUtf8mb3Linter{}
+	require.Len(t, linter.Lint([]*statement.CreateTable{existing}, nil), 1)
+
+	stmts, err := statement.New("ALTER TABLE t1 CHARSET=utf8mb4")
+	require.NoError(t, err)
+	require.Empty(t, linter.Lint([]*statement.CreateTable{existing}, stmts))
+}
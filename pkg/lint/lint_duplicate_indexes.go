@@ -0,0 +1,107 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	RegisterDisabled(&DuplicateIndexLinter{})
+}
+
+// DuplicateIndexLinter checks for secondary indexes that are declared more
+// than once in a table, such as INDEX idx_a (a) twice, or an unnamed INDEX
+// (a) alongside it. Indexes are grouped by type and by their ordered key
+// parts (see indexColumnsEqual); names are ignored, and an unnamed index is
+// reported under the name the server would give it. The type is part of
+// the comparison because a UNIQUE index enforces a constraint that a plain
+// index on the same columns does not, so neither one can be dropped in
+// favor of the other.
+//
+// RedundantIndexLinter also reports exact duplicates, alongside prefix and
+// PRIMARY KEY redundancy. This linter is the unambiguous subset, for
+// configurations that disable the broader checks, so it is disabled by
+// default: with both enabled, every duplicate would be reported twice.
+//
+// Like RedundantIndexLinter it evaluates the post-state of the schema.
+type DuplicateIndexLinter struct{}
+
+func (l *DuplicateIndexLinter) Name() string {
+	return "duplicate_indexes"
+}
+
+func (l *DuplicateIndexLinter) Description() string {
+	return "Detects secondary indexes of the same type declared more than once on the same columns"
+}
+
+func (l *DuplicateIndexLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) []Violation {
+	var violations []Violation
+	for _, table := range PostState(existingTables, changes) {
+		for _, group := range duplicateIndexGroups(table.GetIndexes()) {
+			for _, duplicate := range group[1:] {
+				violations = append(violations, createDuplicateIndexViolation(table.GetTableName(), duplicate, group[0]))
+			}
+		}
+	}
+	return violations
+}
+
+func (l *DuplicateIndexLinter) String() string {
+	return Stringer(l)
+}
+
+// duplicateIndexGroups groups the non-PRIMARY indexes by type and key parts,
+// in declaration order, and returns the groups with more than one index.
+func duplicateIndexGroups(indexes statement.Indexes) [][]statement.Index {
+	var groups [][]statement.Index
+	for _, index := range indexes {
+		if index.Type == "PRIMARY KEY" || len(indexParts(index)) == 0 {
+			continue
+		}
+		matched := false
+		for i, group := range groups {
+			if group[0].Type == index.Type && indexColumnsEqual(indexParts(group[0]), indexParts(index)) {
+				groups[i] = append(group, index)
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			groups = append(groups, []statement.Index{index})
+		}
+	}
+	var duplicates [][]statement.Index
+	for _, group := range groups {
+		if len(group) > 1 {
+			duplicates = append(duplicates, group)
+		}
+	}
+	return duplicates
+}
+
+// createDuplicateIndexViolation creates a violation for duplicate, an index
+// that repeats the earlier index original.
+func createDuplicateIndexViolation(tableName string, duplicate, original statement.Index) Violation {
+	message := fmt.Sprintf("Index '%s' duplicates index '%s': both are %s on columns (%s)",
+		duplicate.Name,
+		original.Name,
+		duplicate.Type,
+		renderIndexColumns(duplicate),
+	)
+	suggestion := fmt.Sprintf("Drop index '%s'. Duplicate indexes waste space and slow down writes with no benefit.",
+		duplicate.Name,
+	)
+	return Violation{
+		Linter:     &DuplicateIndexLinter{},
+		Severity:   SeverityWarning,
+		Message:    message,
+		Location:   &Location{Table: tableName, Index: &duplicate.Name},
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"duplicate_index": duplicate.Name,
+			"original_index":  original.Name,
+			"index_type":      duplicate.Type,
+		},
+	}
+}
@@ -0,0 +1,146 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDuplicateIndexLinter_Name(t *testing.T) {
+	linter := &DuplicateIndexLinter{}
+	require.Equal(t, "duplicate_indexes", linter.Name())
+	require.NotEmpty(t, linter.Description())
+}
+
+func TestDuplicateIndexLinter_NamedDuplicate(t *testing.T) {
+	sql := `CREATE TABLE users (
+		id INT PRIMARY KEY,
+		a INT NOT NULL,
+		INDEX idx_a (a),
+		INDEX idx_a_again (a)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&DuplicateIndexLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 1)
+	require.Equal(t, "duplicate_indexes", violations[0].Linter.Name())
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "users", violations[0].Location.Table)
+	require.NotNil(t, violations[0].Location.Index)
+	require.Equal(t, "idx_a_again", *violations[0].Location.Index)
+	require.Equal(t, "Index 'idx_a_again' duplicates index 'idx_a': both are INDEX on columns (a)", violations[0].Message)
+	require.NotNil(t, violations[0].Suggestion)
+}
+
+func TestDuplicateIndexLinter_UnnamedDuplicate(t *testing.T) {
+	sql := `CREATE TABLE users (
+		id INT PRIMARY KEY,
+		a INT NOT NULL,
+		b INT NOT NULL,
+		INDEX idx_ab (a, b),
+		INDEX (a, b)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&DuplicateIndexLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 1)
+	// The unnamed index is reported under the name MySQL gives it.
+	require.Equal(t, "a", *violations[0].Location.Index)
+	require.Equal(t, "Index 'a' duplicates index 'idx_ab': both are INDEX on columns (a, b)", violations[0].Message)
+}
+
+func TestDuplicateIndexLinter_ThreeCopies(t *testing.T) {
+	sql := `CREATE TABLE users (
+		id INT PRIMARY KEY,
+		email VARCHAR(100) NOT NULL,
+		UNIQUE KEY u1 (email),
+		UNIQUE KEY u2 (email),
+		UNIQUE KEY u3 (email)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&DuplicateIndexLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 2)
+	require.Equal(t, "u2", *violations[0].Location.Index)
+	require.Equal(t, "u3", *violations[1].Location.Index)
+	require.Equal(t, "u1", violations[1].Context["original_index"])
+}
+
+func TestDuplicateIndexLinter_NoViolations(t *testing.T) {
+	tests := map[string]string{
+		"unique and plain index on the same columns": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			a INT NOT NULL,
+			UNIQUE KEY u_a (a),
+			INDEX idx_a (a)
+		)`,
+		"different column order": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			a INT NOT NULL,
+			b INT NOT NULL,
+			INDEX idx_ab (a, b),
+			INDEX idx_ba (b, a)
+		)`,
+		"prefix of another index": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			a INT NOT NULL,
+			b INT NOT NULL,
+			INDEX idx_a (a),
+			INDEX idx_ab (a, b)
+		)`,
+		"different prefix lengths": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			name VARCHAR(100) NOT NULL,
+			INDEX idx_name10 (name(10)),
+			INDEX idx_name (name)
+		)`,
+		"different direction": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			a INT NOT NULL,
+			b INT NOT NULL,
+			INDEX idx_ab (a, b),
+			INDEX idx_ab_desc (a, b DESC)
+		)`,
+		"secondary index on the primary key columns": `CREATE TABLE users (
+			id INT NOT NULL,
+			PRIMARY KEY (id),
+			INDEX idx_id (id)
+		)`,
+	}
+	for name, sql := range tests {
+		t.Run(name, func(t *testing.T) {
+			stmts, err := statement.New(sql)
+			require.NoError(t, err)
+			require.Empty(t, (&DuplicateIndexLinter{}).Lint(nil, stmts))
+		})
+	}
+}
+
+func TestDuplicateIndexLinter_PostState(t *testing.T) {
+	existing, err := statement.ParseCreateTable(`CREATE TABLE users (
+		id INT PRIMARY KEY,
+		a INT NOT NULL,
+		INDEX idx_a (a)
+	)`)
+	require.NoError(t, err)
+	existingTables := []*statement.CreateTable{existing}
+
+	// Adding a copy of an existing index is flagged.
+	stmts, err := statement.New("ALTER TABLE users ADD INDEX idx_a2 (a)")
+	require.NoError(t, err)
+	violations := (&DuplicateIndexLinter{}).Lint(existingTables, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "idx_a2", *violations[0].Location.Index)
+
+	// Replacing the index with one on the same columns is not.
+	stmts, err = statement.New("ALTER TABLE users DROP INDEX idx_a, ADD INDEX idx_a2 (a)")
+	require.NoError(t, err)
+	require.Empty(t, (&DuplicateIndexLinter{}).Lint(existingTables, stmts))
+}
@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&EnumSetValuesLinter{})
+}
+
+// EnumSetValuesLinter checks the permitted values of ENUM and SET columns for
+// characters that are easy to get wrong. A comma in a SET value is an error:
+// the comma is SET's element separator, so the value can never be stored or
+// matched as one element. A comma in an ENUM value is legal but confusing, so
+// it is only a warning. Leading or trailing whitespace is also a warning, since
+// it is almost always a typo and is invisible in most output. Trailing spaces
+// specifically are stripped by MySQL (and by the parser, which mirrors it), so
+// the permitted value silently differs from the one written; because they are
+// already gone from the parsed values, only other trailing whitespace such as
+// tabs can be reported.
+type EnumSetValuesLinter struct{}
+
+func (l *EnumSetValuesLinter) Name() string {
+	return "enum_set_values"
+}
+
+func (l *EnumSetValuesLinter) Description() string {
+	return "Checks ENUM and SET values for commas and leading/trailing whitespace"
+}
+
+func (l *EnumSetValuesLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint operates on a post-state view of the schema so that ALTERs which fix
+// the values don't produce false positives on the pre-state.
+func (l *EnumSetValuesLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		for _, column := range ct.Columns {
+			for _, value := range column.EnumValues {
+				violations = append(violations, l.checkValue(ct.TableName, column.Name, "ENUM", value)...)
+			}
+			for _, value := range column.SetValues {
+				violations = append(violations, l.checkValue(ct.TableName, column.Name, "SET", value)...)
+			}
+		}
+	}
+	return violations
+}
+
+func (l *EnumSetValuesLinter) checkValue(tableName, columnName, kind, value string) (violations []Violation) {
+	if strings.Contains(value, ",") {
+		severity := SeverityWarning
+		message := fmt.Sprintf("ENUM column %q has value %q containing a comma, which is easily confused with a list of values", columnName, value)
+		if kind == "SET" {
+			severity = SeverityError
+			message = fmt.Sprintf("SET column %q has value %q containing a comma; commas separate SET elements, so this value cannot be stored", columnName, value)
+		}
+		violations = append(violations, Violation{
+			Linter:     l,
+			Location:   &Location{Table: tableName, Column: &columnName},
+			Message:    message,
+			Severity:   severity,
+			Suggestion: new("Remove the comma from the value"),
+		})
+	}
+	if strings.TrimSpace(value) != value {
+		violations = append(violations, Violation{
+			Linter:     l,
+			Location:   &Location{Table: tableName, Column: &columnName},
+			Message:    fmt.Sprintf("%s column %q has value %q with leading or trailing whitespace", kind, columnName, value),
+			Severity:   SeverityWarning,
+			Suggestion: new(fmt.Sprintf("Use %q instead", strings.TrimSpace(value))),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnumSetValues_Clean(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		status ENUM('active', 'inactive'),
+		flags SET('a', 'b', 'c')
+	)`)
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestEnumSetValues_SetWithComma(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		flags SET('a,b', 'c')
+	)`)
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityError, violations[0].Severity)
+	require.Equal(t, "flags", *violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "SET")
+	require.Contains(t, violations[0].Message, "comma")
+}
+
+func TestEnumSetValues_EnumWithComma(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		size ENUM('small, medium', 'large')
+	)`)
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Contains(t, violations[0].Message, "ENUM")
+}
+
+func TestEnumSetValues_Whitespace(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (\n" +
+		"id INT PRIMARY KEY,\n" +
+		"status ENUM('active\t', ' inactive'),\n" +
+		"flags SET('a', ' b')\n" +
+		")")
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 3)
+	for _, v := range violations {
+		require.Equal(t, SeverityWarning, v.Severity)
+		require.Contains(t, v.Message, "whitespace")
+	}
+	require.Contains(t, *violations[0].Suggestion, `"active"`)
+}
+
+func TestEnumSetValues_TrailingSpacesAreStripped(t *testing.T) {
+	// Like MySQL, the parser strips trailing spaces from ENUM and SET values,
+	// so they are no longer visible to the linter.
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		status ENUM('active ', 'inactive')
+	)`)
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestEnumSetValues_AlterFixesValue(t *testing.T) {
+	existing, err := statement.ParseCreateTable(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		flags SET('a,b', 'c')
+	)`)
+	require.NoError(t, err)
+	stmts, err := statement.New("ALTER TABLE t1 MODIFY COLUMN flags SET('a', 'b', 'c')")
+	require.NoError(t, err)
+
+	linter := &EnumSetValuesLinter{}
+	require.Empty(t, linter.Lint([]*statement.CreateTable{existing}, stmts))
+}
@@ -0,0 +1,66 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&ExplicitCharsetLinter{})
+}
+
+// ExplicitCharsetLinter warns when a table does not pin its character set and
+// collation in its table options. Without them the table inherits the
+// schema/server defaults at creation time, which differ between environments
+// (e.g. default_collation_for_utf8mb4), so the same CREATE TABLE produces
+// different tables in different places.
+type ExplicitCharsetLinter struct{}
+
+func (l *ExplicitCharsetLinter) Name() string {
+	return "explicit_charset"
+}
+
+func (l *ExplicitCharsetLinter) Description() string {
+	return "Checks that tables specify an explicit character set and collation"
+}
+
+func (l *ExplicitCharsetLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint only checks tables created by the changes: the defaults are resolved
+// when a table is created, so existing tables (whose SHOW CREATE TABLE output
+// always carries both options) are never at risk. A COLLATE on its own is
+// sufficient because it implies the character set; a CHARSET on its own is
+// not, because the collation is then picked from the server default for that
+// character set.
+func (l *ExplicitCharsetLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	newTables := newTablesInChanges(changes)
+	for _, ct := range PostState(existingTables, changes) {
+		if !newTables[strings.ToLower(ct.TableName)] {
+			continue
+		}
+		options := ct.GetTableOptions()
+		_, hasCharset := options["charset"]
+		_, hasCollation := options["collation"]
+		var message string
+		switch {
+		case hasCollation:
+			continue
+		case hasCharset:
+			message = fmt.Sprintf("Table %q specifies a character set but no collation; the collation will be the server default", ct.TableName)
+		default:
+			message = fmt.Sprintf("Table %q has no explicit character set or collation; it will use the schema default", ct.TableName)
+		}
+		violations = append(violations, Violation{
+			Linter:     l,
+			Location:   &Location{Table: ct.TableName},
+			Message:    message,
+			Severity:   SeverityWarning,
+			Suggestion: new("Add CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci to the table options"),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,74 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplicitCharset_CharsetAndCollation(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		name VARCHAR(255)
+	) CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci`)
+	require.NoError(t, err)
+
+	linter := &ExplicitCharsetLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestExplicitCharset_CollationOnly(t *testing.T) {
+	// COLLATE implies the character set.
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	) COLLATE=utf8mb4_0900_ai_ci`)
+	require.NoError(t, err)
+
+	linter := &ExplicitCharsetLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestExplicitCharset_Missing(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		name VARCHAR(255)
+	) ENGINE=InnoDB`)
+	require.NoError(t, err)
+
+	linter := &ExplicitCharsetLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "t1", violations[0].Location.Table)
+	require.Contains(t, violations[0].Message, "no explicit character set or collation")
+	require.NotNil(t, violations[0].Suggestion)
+	require.Contains(t, *violations[0].Suggestion, "COLLATE=utf8mb4_0900_ai_ci")
+}
+
+func TestExplicitCharset_CharsetOnly(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	) DEFAULT CHARACTER SET utf8mb4`)
+	require.NoError(t, err)
+
+	linter := &ExplicitCharsetLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Contains(t, violations[0].Message, "no collation")
+}
+
+func TestExplicitCharset_ExistingTableAlter(t *testing.T) {
+	// Only tables created in the changes are linted. An existing table
+	// without a charset or collation is not flagged when it is altered.
+	existing, err := statement.ParseCreateTable(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	) ENGINE=InnoDB`)
+	require.NoError(t, err)
+	stmts, err := statement.New("ALTER TABLE t1 ADD COLUMN name VARCHAR(255)")
+	require.NoError(t, err)
+
+	linter := &ExplicitCharsetLinter{}
+	require.Empty(t, linter.Lint([]*statement.CreateTable{existing}, stmts))
+}
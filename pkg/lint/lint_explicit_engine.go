@@ -0,0 +1,53 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&ExplicitEngineLinter{})
+}
+
+// ExplicitEngineLinter warns when a table does not name its storage engine in
+// its table options. Without ENGINE= the table is created with the server's
+// default_storage_engine, which is not guaranteed to be InnoDB everywhere the
+// same CREATE TABLE runs.
+type ExplicitEngineLinter struct{}
+
+func (l *ExplicitEngineLinter) Name() string {
+	return "explicit_engine"
+}
+
+func (l *ExplicitEngineLinter) Description() string {
+	return "Checks that tables specify an explicit storage engine"
+}
+
+func (l *ExplicitEngineLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint only checks tables created by the changes, as explicit_charset does:
+// the engine is resolved when a table is created, and SHOW CREATE TABLE
+// always reports it for existing tables.
+func (l *ExplicitEngineLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	newTables := newTablesInChanges(changes)
+	for _, ct := range PostState(existingTables, changes) {
+		if !newTables[strings.ToLower(ct.TableName)] {
+			continue
+		}
+		if _, hasEngine := ct.GetTableOptions()["engine"]; hasEngine {
+			continue
+		}
+		violations = append(violations, Violation{
+			Linter:     l,
+			Location:   &Location{Table: ct.TableName},
+			Message:    fmt.Sprintf("Table %q has no explicit storage engine; it will use the server default", ct.TableName),
+			Severity:   SeverityWarning,
+			Suggestion: new("Add ENGINE=InnoDB to the table options"),
+		})
+	}
+	return violations
+}
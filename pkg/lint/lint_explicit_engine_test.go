@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplicitEngine_Present(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	) ENGINE=InnoDB`)
+	require.NoError(t, err)
+
+	linter := &ExplicitEngineLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestExplicitEngine_Missing(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	)`)
+	require.NoError(t, err)
+
+	linter := &ExplicitEngineLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "t1", violations[0].Location.Table)
+	require.Contains(t, violations[0].Message, "no explicit storage engine")
+	require.NotNil(t, violations[0].Suggestion)
+	require.Contains(t, *violations[0].Suggestion, "ENGINE=InnoDB")
+}
+
+func TestExplicitEngine_CharsetOnly(t *testing.T) {
+	// Other table options do not stand in for the engine.
+	stmts, err := statement.New(`CREATE TABLE t1 (
+		id INT PRIMARY KEY
+	) DEFAULT CHARSET=utf8mb4`)
+	require.NoError(t, err)
+
+	linter := &ExplicitEngineLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Contains(t, violations[0].Message, "no explicit storage engine")
+}
+
+func TestExplicitEngine_ExistingTable(t *testing.T) {
+	// Only tables created by the changes are checked.
+	existing, err := statement.ParseCreateTable(`CREATE TABLE t1 (id INT PRIMARY KEY)`)
+	require.NoError(t, err)
+	stmts, err := statement.New(`ALTER TABLE t1 ADD COLUMN b INT`)
+	require.NoError(t, err)
+
+	linter := &ExplicitEngineLinter{}
+	require.Empty(t, linter.Lint([]*statement.CreateTable{existing}, stmts))
+}
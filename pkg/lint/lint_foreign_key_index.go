@@ -0,0 +1,100 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+type ForeignKeyIndexLinter struct{}
+
+func init() {
+	Register(&ForeignKeyIndexLinter{})
+}
+
+func (l *ForeignKeyIndexLinter) String() string {
+	return Stringer(l)
+}
+
+func (l *ForeignKeyIndexLinter) Name() string {
+	return "foreign_key_index"
+}
+
+func (l *ForeignKeyIndexLinter) Description() string {
+	return "Detects FOREIGN KEY constraints whose columns are not the leftmost prefix of an index"
+}
+
+// Lint operates on a post-state view of the schema, so a FOREIGN KEY added
+// with ALTER TABLE ... ADD CONSTRAINT is checked against the indexes the
+// existing table will have once the ALTER is applied, including any index
+// the same ALTER adds or drops.
+//
+// InnoDB needs an index whose leading columns are the referencing columns, in
+// order, to look up child rows when a parent row changes. If the columns only
+// appear later in a composite index, that index cannot be used for the lookup.
+// MySQL then creates an implicit index of its own, which does not appear in
+// the declared schema. FULLTEXT and SPATIAL indexes do not count.
+func (l *ForeignKeyIndexLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		indexes := ct.GetIndexes()
+		for _, constraint := range ct.Constraints {
+			if constraint.Type != "FOREIGN KEY" || len(constraint.Columns) == 0 {
+				continue
+			}
+			if hasLeftmostPrefixIndex(indexes, constraint.Columns) {
+				continue
+			}
+			violations = append(violations, l.violation(ct.TableName, constraint))
+		}
+	}
+	return violations
+}
+
+// hasLeftmostPrefixIndex reports whether columns, in order, are the leading
+// columns of any B-tree index. Column names are compared case-insensitively.
+func hasLeftmostPrefixIndex(indexes statement.Indexes, columns []string) bool {
+	for _, idx := range indexes {
+		if !indexUsesBTreeSemantics(idx) || len(idx.Columns) < len(columns) {
+			continue
+		}
+		matches := true
+		for i, col := range columns {
+			if !strings.EqualFold(idx.Columns[i], col) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *ForeignKeyIndexLinter) violation(tableName string, constraint statement.Constraint) Violation {
+	// Unnamed constraints get a server-assigned name, which the schema does
+	// not know yet; describe them by their columns instead.
+	columns := strings.Join(constraint.Columns, ", ")
+	label := fmt.Sprintf("FOREIGN KEY %q", constraint.Name)
+	loc := &Location{Table: tableName}
+	if constraint.Name == "" {
+		label = fmt.Sprintf("unnamed FOREIGN KEY on (%s)", columns)
+	} else {
+		name := constraint.Name
+		loc.Constraint = &name
+	}
+	suggestion := fmt.Sprintf("Add an index whose leading columns are (%s), in that order", columns)
+	return Violation{
+		Linter:   l,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("Table %q has %s whose columns (%s) are not the leftmost prefix of any index",
+			tableName, label, columns),
+		Location:   loc,
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"constraint_name": constraint.Name,
+			"columns":         constraint.Columns,
+		},
+	}
+}
@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeyIndexLinter_CreateTable_LeftmostPrefix(t *testing.T) {
+	sql := `CREATE TABLE order_items (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		order_id BIGINT UNSIGNED NOT NULL,
+		product_id BIGINT UNSIGNED NOT NULL,
+		KEY order_product (order_id, product_id),
+		KEY product (PRODUCT_ID),
+		CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders (id),
+		CONSTRAINT fk_product FOREIGN KEY (product_id) REFERENCES products (id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&ForeignKeyIndexLinter{}).Lint(nil, stmts)
+	require.Empty(t, violations)
+}
+
+func TestForeignKeyIndexLinter_CreateTable_WrongPosition(t *testing.T) {
+	sql := `CREATE TABLE order_items (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		order_id BIGINT UNSIGNED NOT NULL,
+		product_id BIGINT UNSIGNED NOT NULL,
+		KEY order_product (order_id, product_id),
+		CONSTRAINT fk_product FOREIGN KEY (product_id) REFERENCES products (id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&ForeignKeyIndexLinter{}).Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "foreign_key_index", violations[0].Linter.Name())
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "order_items", violations[0].Location.Table)
+	require.Equal(t, "fk_product", *violations[0].Location.Constraint)
+	require.Contains(t, violations[0].Message, "(product_id)")
+	require.NotNil(t, violations[0].Suggestion)
+}
+
+func TestForeignKeyIndexLinter_CreateTable_CompositeForeignKey(t *testing.T) {
+	sql := `CREATE TABLE shipments (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		tenant_id INT NOT NULL,
+		order_id BIGINT UNSIGNED NOT NULL,
+		KEY order_tenant (order_id, tenant_id),
+		FULLTEXT KEY ft (tenant_id),
+		CONSTRAINT fk_order FOREIGN KEY (tenant_id, order_id) REFERENCES orders (tenant_id, id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	// The columns are all indexed, but in the wrong order, and a FULLTEXT
+	// index does not count.
+	violations := (&ForeignKeyIndexLinter{}).Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Contains(t, violations[0].Message, "(tenant_id, order_id)")
+}
+
+func TestForeignKeyIndexLinter_CreateTable_PrimaryKeyPrefix(t *testing.T) {
+	sql := `CREATE TABLE order_items (
+		order_id BIGINT UNSIGNED NOT NULL,
+		line INT NOT NULL,
+		PRIMARY KEY (order_id, line),
+		CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders (id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&ForeignKeyIndexLinter{}).Lint(nil, stmts)
+	require.Empty(t, violations)
+}
+
+func TestForeignKeyIndexLinter_AlterTable_AddConstraint(t *testing.T) {
+	existing, err := statement.ParseCreateTable(`CREATE TABLE order_items (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		order_id BIGINT UNSIGNED NOT NULL,
+		product_id BIGINT UNSIGNED NOT NULL,
+		KEY order_product (order_id, product_id)
+	)`)
+	require.NoError(t, err)
+	existingTables := []*statement.CreateTable{existing}
+
+	// The FK column is only the second column of the existing index.
+	stmts, err := statement.New("ALTER TABLE order_items ADD CONSTRAINT fk_product FOREIGN KEY (product_id) REFERENCES products (id)")
+	require.NoError(t, err)
+	violations := (&ForeignKeyIndexLinter{}).Lint(existingTables, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "fk_product", *violations[0].Location.Constraint)
+
+	// The FK column leads the existing index.
+	stmts, err = statement.New("ALTER TABLE order_items ADD CONSTRAINT fk_order FOREIGN KEY (order_id) REFERENCES orders (id)")
+	require.NoError(t, err)
+	require.Empty(t, (&ForeignKeyIndexLinter{}).Lint(existingTables, stmts))
+
+	// The same ALTER adds a supporting index.
+	stmts, err = statement.New("ALTER TABLE order_items ADD INDEX product (product_id), ADD FOREIGN KEY (product_id) REFERENCES products (id)")
+	require.NoError(t, err)
+	require.Empty(t, (&ForeignKeyIndexLinter{}).Lint(existingTables, stmts))
+
+	// An unnamed FK has no constraint name to report.
+	stmts, err = statement.New("ALTER TABLE order_items ADD FOREIGN KEY (product_id) REFERENCES products (id)")
+	require.NoError(t, err)
+	violations = (&ForeignKeyIndexLinter{}).Lint(existingTables, stmts)
+	require.Len(t, violations, 1)
+	require.Nil(t, violations[0].Location.Constraint)
+	require.Contains(t, violations[0].Message, "unnamed FOREIGN KEY on (product_id)")
+}
@@ -0,0 +1,209 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&IndexByteLengthLinter{})
+}
+
+const (
+	// maxIndexKeyBytes is InnoDB's limit on the length of an index key with
+	// the DYNAMIC and COMPRESSED row formats (the default since MySQL 5.7).
+	maxIndexKeyBytes = 3072
+	// warnIndexKeyBytes is the key length above which an index is reported
+	// as a warning: it is within the limit, but large enough that every
+	// secondary index entry, and every comparison during a lookup, is costly.
+	warnIndexKeyBytes = 1000
+)
+
+// IndexByteLengthLinter computes the maximum byte length of each index key
+// from the column types, counting each character at the most bytes its
+// character set uses (4 for utf8mb4) and using a key part's prefix length
+// when one is given. An index over 3072 bytes is an error, since InnoDB
+// refuses to create it, and one over 1000 bytes is a warning. A single
+// VARCHAR(255) utf8mb4 column is already 1020 bytes.
+//
+// The key parts InnoDB appends to a secondary index from the PRIMARY KEY
+// do not count against the limit and are not added. Expression key parts,
+// and TEXT or BLOB key parts without a prefix length (which MySQL rejects),
+// are skipped. FULLTEXT and SPATIAL indexes are not checked.
+type IndexByteLengthLinter struct{}
+
+func (l *IndexByteLengthLinter) Name() string {
+	return "index_byte_length"
+}
+
+func (l *IndexByteLengthLinter) Description() string {
+	return "Detects indexes whose maximum key length is near or over InnoDB's 3072-byte limit"
+}
+
+func (l *IndexByteLengthLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint walks the post-state of the schema, so an ALTER that adds an index or
+// widens an indexed column is checked against the resulting table.
+func (l *IndexByteLengthLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		for _, index := range ct.GetIndexes() {
+			if index.Type == "FULLTEXT" || index.Type == "SPATIAL" {
+				continue
+			}
+			keyBytes := indexKeyBytes(ct, index)
+			if keyBytes <= warnIndexKeyBytes {
+				continue
+			}
+			severity := SeverityWarning
+			message := fmt.Sprintf("Index '%s' on table '%s' can be up to %d bytes long, more than %d bytes",
+				index.Name, ct.TableName, keyBytes, warnIndexKeyBytes)
+			if keyBytes > maxIndexKeyBytes {
+				severity = SeverityError
+				message = fmt.Sprintf("Index '%s' on table '%s' can be up to %d bytes long, more than InnoDB's limit of %d bytes",
+					index.Name, ct.TableName, keyBytes, maxIndexKeyBytes)
+			}
+			indexName := index.Name
+			suggestion := "Index a prefix of long string columns, e.g. name(50), or shorten the columns"
+			violations = append(violations, Violation{
+				Linter:     l,
+				Severity:   severity,
+				Message:    message,
+				Location:   &Location{Table: ct.TableName, Index: &indexName},
+				Suggestion: &suggestion,
+				Context: map[string]any{
+					"key_bytes": keyBytes,
+					"columns":   renderIndexColumns(index),
+				},
+			})
+		}
+	}
+	return violations
+}
+
+// indexKeyBytes returns the maximum length in bytes of index's key.
+func indexKeyBytes(ct *statement.CreateTable, index statement.Index) int {
+	var total int
+	for _, part := range indexParts(index) {
+		if part.Expression != nil {
+			continue
+		}
+		for _, col := range ct.Columns {
+			if strings.EqualFold(col.Name, part.Name) {
+				total += keyPartBytes(ct, col, part.Length)
+				break
+			}
+		}
+	}
+	return total
+}
+
+// keyPartBytes returns the maximum length in bytes of col in an index key,
+// given the key part's prefix length (nil for the whole column). Prefix
+// lengths count characters for string columns and bytes for binary ones.
+func keyPartBytes(ct *statement.CreateTable, col statement.Column, prefix *int) int {
+	length := 0
+	if col.Length != nil {
+		length = *col.Length
+	}
+	if prefix != nil {
+		length = *prefix
+	}
+	switch strings.ToLower(col.Type) {
+	case "char", "varchar", "tinytext", "text", "mediumtext", "longtext":
+		return length * charsetMaxBytes(columnCharset(ct, col))
+	case "binary", "varbinary", "tinyblob", "blob", "mediumblob", "longblob":
+		return length
+	case "tinyint", "year":
+		return 1
+	case "smallint":
+		return 2
+	case "mediumint", "date":
+		return 3
+	case "int", "integer", "float":
+		return 4
+	case "bigint", "double", "real", "set":
+		return 8
+	case "enum":
+		return 2
+	case "bit":
+		return (length + 7) / 8
+	case "decimal", "numeric":
+		return decimalBytes(col)
+	case "time", "datetime", "timestamp":
+		return temporalBytes(col)
+	}
+	return 0
+}
+
+// decimalBytes returns the storage size of a DECIMAL column: four bytes for
+// every nine digits on either side of the decimal point, and fewer for the
+// digits left over.
+func decimalBytes(col statement.Column) int {
+	precision, scale := 10, 0
+	if col.Length != nil {
+		precision = *col.Length
+	}
+	if col.Raw != nil && col.Raw.Tp != nil && col.Raw.Tp.GetDecimal() > 0 {
+		scale = col.Raw.Tp.GetDecimal()
+	}
+	leftover := [...]int{0, 1, 1, 2, 2, 3, 3, 4, 4, 4}
+	digits := func(n int) int { return n/9*4 + leftover[n%9] }
+	return digits(precision-scale) + digits(scale)
+}
+
+// temporalBytes returns the storage size of a TIME, DATETIME or TIMESTAMP
+// column, including its fractional seconds.
+func temporalBytes(col statement.Column) int {
+	base := map[string]int{"time": 3, "datetime": 5, "timestamp": 4}[strings.ToLower(col.Type)]
+	fsp := 0
+	if col.Raw != nil && col.Raw.Tp != nil && col.Raw.Tp.GetDecimal() > 0 {
+		fsp = col.Raw.Tp.GetDecimal()
+	}
+	return base + (fsp+1)/2
+}
+
+// columnCharset returns the character set of a string column: its own, the
+// one implied by its collation, or the table's, defaulting to utf8mb4.
+func columnCharset(ct *statement.CreateTable, col statement.Column) string {
+	if col.Charset != nil {
+		return *col.Charset
+	}
+	if col.Collation != nil {
+		return charsetOfCollation(*col.Collation)
+	}
+	options := ct.GetTableOptions()
+	if charset, ok := options["charset"].(string); ok && charset != "" {
+		return charset
+	}
+	if collation, ok := options["collation"].(string); ok && collation != "" {
+		return charsetOfCollation(collation)
+	}
+	return "utf8mb4"
+}
+
+// charsetOfCollation returns the character set a collation belongs to,
+// which is the collation name up to its first underscore.
+func charsetOfCollation(collation string) string {
+	charset, _, _ := strings.Cut(collation, "_")
+	return charset
+}
+
+// charsetMaxBytes returns the most bytes a character takes in charset.
+// Character sets not listed are counted at 4 bytes, the most any uses.
+func charsetMaxBytes(charset string) int {
+	switch strings.ToLower(charset) {
+	case "ascii", "binary", "latin1", "latin2", "latin5", "latin7", "cp1250", "cp1251", "cp1256", "cp1257",
+		"cp850", "cp852", "cp866", "dec8", "greek", "hebrew", "hp8", "keybcs2", "koi8r", "koi8u", "swe7",
+		"tis620", "armscii8", "geostd8", "macce", "macroman":
+		return 1
+	case "big5", "cp932", "euckr", "gb2312", "gbk", "sjis", "ucs2":
+		return 2
+	case "utf8", "utf8mb3", "ujis", "eucjpms":
+		return 3
+	}
+	return 4
+}
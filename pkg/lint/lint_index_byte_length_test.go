@@ -0,0 +1,116 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIndexByteLengthLinter_Name(t *testing.T) {
+	linter := &IndexByteLengthLinter{}
+	require.Equal(t, "index_byte_length", linter.Name())
+	require.NotEmpty(t, linter.Description())
+}
+
+func TestIndexByteLengthLinter(t *testing.T) {
+	tests := []struct {
+		name     string
+		sql      string
+		severity Severity
+		keyBytes int // 0 when no violation is expected
+	}{
+		{
+			name: "short utf8mb4 varchar",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(100), INDEX idx_name (name))",
+		},
+		{
+			name:     "varchar 255 utf8mb4 is over the warning threshold",
+			sql:      "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(255), INDEX idx_name (name))",
+			severity: SeverityWarning,
+			keyBytes: 1020,
+		},
+		{
+			name: "varchar 255 latin1 from the table charset",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(255), INDEX idx_name (name)) DEFAULT CHARSET=latin1",
+		},
+		{
+			name: "varchar 255 utf8mb3 from the column collation",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(255) COLLATE utf8mb3_bin, INDEX idx_name (name))",
+		},
+		{
+			name:     "over the InnoDB limit",
+			sql:      "CREATE TABLE t (id INT PRIMARY KEY, a VARCHAR(500), b VARCHAR(300), INDEX idx_ab (a, b))",
+			severity: SeverityError,
+			keyBytes: 3200,
+		},
+		{
+			name: "prefix lengths are used",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, a VARCHAR(500), b VARCHAR(300), INDEX idx_ab (a(100), b(100)))",
+		},
+		{
+			name:     "text prefix counts characters",
+			sql:      "CREATE TABLE t (id INT PRIMARY KEY, body TEXT, INDEX idx_body (body(800)))",
+			severity: SeverityError,
+			keyBytes: 3200,
+		},
+		{
+			name: "binary columns count bytes",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, hash VARBINARY(1000), INDEX idx_hash (hash))",
+		},
+		{
+			name:     "fixed-size columns are added",
+			sql:      "CREATE TABLE t (id BIGINT PRIMARY KEY, name VARCHAR(250), created_at DATETIME(6), INDEX idx_name (name, id, created_at))",
+			severity: SeverityWarning,
+			keyBytes: 1000 + 8 + 8,
+		},
+		{
+			name: "fulltext indexes are not checked",
+			sql:  "CREATE TABLE t (id INT PRIMARY KEY, body VARCHAR(1000), FULLTEXT INDEX ft_body (body))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stmts, err := statement.New(tt.sql)
+			require.NoError(t, err)
+
+			violations := (&IndexByteLengthLinter{}).Lint(nil, stmts)
+
+			if tt.keyBytes == 0 {
+				require.Empty(t, violations)
+				return
+			}
+			require.Len(t, violations, 1)
+			require.Equal(t, tt.severity, violations[0].Severity)
+			require.Equal(t, "t", violations[0].Location.Table)
+			require.Equal(t, tt.keyBytes, violations[0].Context["key_bytes"])
+			require.NotNil(t, violations[0].Suggestion)
+		})
+	}
+}
+
+func TestIndexByteLengthLinter_AlterAddIndex(t *testing.T) {
+	existing := parseCreateTables(t, "CREATE TABLE users (id INT PRIMARY KEY, email VARCHAR(320))")
+	stmts, err := statement.New("ALTER TABLE users ADD INDEX idx_email (email)")
+	require.NoError(t, err)
+
+	violations := (&IndexByteLengthLinter{}).Lint(existing, stmts)
+
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "idx_email", *violations[0].Location.Index)
+	require.Equal(t, "Index 'idx_email' on table 'users' can be up to 1280 bytes long, more than 1000 bytes", violations[0].Message)
+}
+
+func TestDecimalBytes(t *testing.T) {
+	for sql, want := range map[string]int{
+		"CREATE TABLE t (c DECIMAL(10,2))":  4 + 1,
+		"CREATE TABLE t (c DECIMAL(18,9))":  4 + 4,
+		"CREATE TABLE t (c DECIMAL(20,0))":  9,
+		"CREATE TABLE t (c DECIMAL(65,30))": 16 + 14,
+	} {
+		ct, err := statement.ParseCreateTable(sql)
+		require.NoError(t, err)
+		require.Equal(t, want, decimalBytes(ct.Columns[0]), sql)
+	}
+}
@@ -0,0 +1,108 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+)
+
+func init() {
+	Register(&LargeVarcharLinter{threshold: 1024})
+}
+
+// LargeVarcharLinter flags VARCHAR columns declared longer than a threshold,
+// in characters. Values that long are rarely looked up or indexed as a whole,
+// and the declared length counts against InnoDB's 65,535-byte row size limit
+// (four bytes per character with utf8mb4), so TEXT is usually the better fit.
+type LargeVarcharLinter struct {
+	threshold int
+}
+
+func (l *LargeVarcharLinter) Name() string {
+	return "large_varchar"
+}
+
+func (l *LargeVarcharLinter) Description() string {
+	return "Detects VARCHAR columns longer than a threshold that should be TEXT"
+}
+
+func (l *LargeVarcharLinter) Configure(config map[string]string) error {
+	for k, v := range config {
+		if k == "threshold" {
+			threshold, err := ConfigInt(v, k)
+			if err != nil {
+				return err
+			}
+			if threshold <= 0 {
+				return fmt.Errorf("threshold value must be positive, got %d", threshold)
+			}
+			l.threshold = threshold
+		}
+	}
+	return nil
+}
+
+func (l *LargeVarcharLinter) DefaultConfig() map[string]string {
+	return map[string]string{
+		"threshold": "1024",
+	}
+}
+
+func (l *LargeVarcharLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint walks the post-state of the schema, so an ALTER that shortens a
+// column or converts it to TEXT clears the violation, and an ALTER that adds
+// or widens a VARCHAR column is checked against its new length.
+func (l *LargeVarcharLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	if l.threshold == 0 {
+		// Constructed directly rather than obtained via Get(); see the same
+		// guard in AutoIncCapacityLinter.Lint.
+		if err := l.Configure(l.DefaultConfig()); err != nil {
+			panic(err)
+		}
+	}
+	for _, ct := range PostState(existingTables, changes) {
+		for _, col := range ct.Columns {
+			length, ok := varcharLength(col)
+			if !ok || length <= l.threshold {
+				continue
+			}
+			colName := col.Name
+			suggestion := fmt.Sprintf("Use TEXT for %q, or reduce its length to at most %d characters", colName, l.threshold)
+			violations = append(violations, Violation{
+				Linter:   l,
+				Severity: SeverityWarning,
+				Message: fmt.Sprintf("Column %q in table %q is VARCHAR(%d), which is longer than the %d character threshold",
+					colName, ct.TableName, length, l.threshold),
+				Location:   &Location{Table: ct.TableName, Column: &colName},
+				Suggestion: &suggestion,
+				Context: map[string]any{
+					"length":    length,
+					"threshold": l.threshold,
+				},
+			})
+		}
+	}
+	return violations
+}
+
+// varcharLength returns the declared length of a VARCHAR column. VARBINARY,
+// which the parser also reports as a VARCHAR with the binary character set,
+// is not matched: its length is in bytes and TEXT is not its replacement.
+func varcharLength(col statement.Column) (int, bool) {
+	if col.Raw != nil && col.Raw.Tp != nil {
+		tp := col.Raw.Tp
+		if tp.GetType() != mysql.TypeVarchar || strings.EqualFold(tp.GetCharset(), "binary") {
+			return 0, false
+		}
+		return tp.GetFlen(), true
+	}
+	if !strings.EqualFold(col.Type, "varchar") || col.Length == nil {
+		return 0, false
+	}
+	return *col.Length, true
+}
@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLargeVarcharLinter_CreateTable(t *testing.T) {
+	sql := `CREATE TABLE notes (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		title VARCHAR(1024),
+		body VARCHAR(21000),
+		checksum VARBINARY(4096),
+		summary TEXT
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&LargeVarcharLinter{}).Lint(nil, stmts)
+
+	// VARCHAR(1024) is at the threshold, VARBINARY and TEXT are not VARCHAR.
+	require.Len(t, violations, 1)
+	require.Equal(t, "large_varchar", violations[0].Linter.Name())
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "notes", violations[0].Location.Table)
+	require.Equal(t, "body", *violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "VARCHAR(21000)")
+	require.Contains(t, *violations[0].Suggestion, "TEXT")
+	require.Equal(t, 21000, violations[0].Context["length"])
+}
+
+func TestLargeVarcharLinter_AlterTable(t *testing.T) {
+	existing, err := statement.ParseCreateTable(`CREATE TABLE notes (
+		id BIGINT UNSIGNED PRIMARY KEY,
+		body VARCHAR(5000)
+	)`)
+	require.NoError(t, err)
+	existingTables := []*statement.CreateTable{existing}
+
+	stmts, err := statement.New("ALTER TABLE notes ADD COLUMN title VARCHAR(2000)")
+	require.NoError(t, err)
+	violations := (&LargeVarcharLinter{}).Lint(existingTables, stmts)
+	require.Len(t, violations, 2)
+
+	// Converting the existing column to TEXT clears its violation.
+	stmts, err = statement.New("ALTER TABLE notes MODIFY COLUMN body TEXT")
+	require.NoError(t, err)
+	require.Empty(t, (&LargeVarcharLinter{}).Lint(existingTables, stmts))
+}
+
+func TestLargeVarcharLinter_Configure(t *testing.T) {
+	linter := &LargeVarcharLinter{}
+	require.NoError(t, linter.Configure(map[string]string{"threshold": "255"}))
+
+	stmts, err := statement.New("CREATE TABLE t (id INT PRIMARY KEY, name VARCHAR(256), code VARCHAR(255))")
+	require.NoError(t, err)
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "name", *violations[0].Location.Column)
+
+	require.ErrorContains(t, linter.Configure(map[string]string{"threshold": "big"}), "expected an integer")
+	require.ErrorContains(t, linter.Configure(map[string]string{"threshold": "0"}), "must be positive")
+}
+
+func TestLargeVarcharLinter_RunLintersSettings(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t (id BIGINT UNSIGNED PRIMARY KEY, name VARCHAR(2000))")
+	require.NoError(t, err)
+
+	violations, err := RunLinters(nil, stmts, Config{})
+	require.NoError(t, err)
+	require.Len(t, FilterByLinter(violations, "large_varchar"), 1)
+
+	violations, err = RunLinters(nil, stmts, Config{
+		Settings: map[string]map[string]string{"large_varchar": {"threshold": "4096"}},
+	})
+	require.NoError(t, err)
+	require.Empty(t, FilterByLinter(violations, "large_varchar"))
+}
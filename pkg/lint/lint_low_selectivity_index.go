@@ -0,0 +1,127 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+// maxLowSelectivityEnumValues is the most values an ENUM can permit and still
+// be treated as a low-cardinality column.
+const maxLowSelectivityEnumValues = 3
+
+type LowSelectivityIndexLinter struct{}
+
+func init() {
+	RegisterDisabled(&LowSelectivityIndexLinter{})
+}
+
+func (l *LowSelectivityIndexLinter) String() string {
+	return Stringer(l)
+}
+
+func (l *LowSelectivityIndexLinter) Name() string {
+	return "low_selectivity_index"
+}
+
+func (l *LowSelectivityIndexLinter) Description() string {
+	return "Detects composite indexes that lead with a low-cardinality column such as a BOOL or small ENUM"
+}
+
+// Lint operates on a post-state view of the schema. For each composite index
+// (>=2 columns) on each table, it flags indexes whose first column has a type
+// that can hold only a handful of values (BOOL/TINYINT(1), BIT(1), or an ENUM
+// with at most maxLowSelectivityEnumValues values) and which is followed by at
+// least one column that is not of such a type.
+//
+// Rationale: a leading column with two or three distinct values does little
+// to narrow a lookup, and the index can't be used at all by queries that
+// don't constrain it, even if they constrain the more selective columns that
+// follow. Putting the selective column first is usually what was intended.
+//
+// This is a heuristic based on the column type alone, with no visibility
+// into the data distribution or the query workload: a skewed flag (e.g. a
+// few is_active=0 rows among millions) can make the leading column selective
+// for the rare value, and queries that always filter on the flag are served
+// fine. For that reason the linter is disabled by default and always emits
+// SeverityWarning. FULLTEXT and SPATIAL indexes are skipped.
+func (l *LowSelectivityIndexLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		colTypes := lowSelectivityColumnTypes(ct)
+		if len(colTypes) == 0 {
+			continue
+		}
+		for _, idx := range ct.GetIndexes() {
+			if !indexUsesBTreeSemantics(idx) {
+				continue
+			}
+			if len(idx.Columns) < 2 {
+				continue
+			}
+			typeStr, ok := colTypes[strings.ToLower(idx.Columns[0])]
+			if !ok {
+				continue
+			}
+			for _, colName := range idx.Columns[1:] {
+				if _, lowSelectivity := colTypes[strings.ToLower(colName)]; !lowSelectivity {
+					violations = append(violations, l.violation(ct.TableName, idx, typeStr))
+					break
+				}
+			}
+		}
+	}
+	return violations
+}
+
+// lowSelectivityColumnTypes returns a map from lowercased column name to a
+// type label for every column whose type suggests it holds only a few
+// distinct values.
+func lowSelectivityColumnTypes(ct *statement.CreateTable) map[string]string {
+	out := make(map[string]string)
+	for _, col := range ct.Columns {
+		switch {
+		case col.Type == "tinyint" && col.Length != nil && *col.Length == 1:
+			out[strings.ToLower(col.Name)] = "TINYINT(1)"
+		case col.Type == "bit" && col.Length != nil && *col.Length == 1:
+			out[strings.ToLower(col.Name)] = "BIT(1)"
+		case col.Type == "enum" && len(col.EnumValues) <= maxLowSelectivityEnumValues:
+			out[strings.ToLower(col.Name)] = fmt.Sprintf("ENUM with %d values", len(col.EnumValues))
+		}
+	}
+	return out
+}
+
+func (l *LowSelectivityIndexLinter) violation(tableName string, idx statement.Index, typeStr string) Violation {
+	label := indexLabel(idx)
+	colName := idx.Columns[0]
+	suggestion := fmt.Sprintf(
+		"Consider moving %q after the more selective columns in %s, or dropping it from the index. "+
+			"This is a heuristic — the current order may be intentional if queries always filter on %q, "+
+			"or if its values are heavily skewed and queries look up the rare value.",
+		colName, label, colName,
+	)
+	loc := &Location{Table: tableName, Column: &colName}
+	if idx.Name != "" {
+		name := idx.Name
+		loc.Index = &name
+	}
+	return Violation{
+		Linter:   l,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf(
+			"%s leads with low-cardinality column %q (%s). A leading column with few distinct "+
+				"values does little to narrow a lookup, and queries that don't filter on it "+
+				"can't use the index for the columns that follow.",
+			capitalize(label), colName, typeStr,
+		),
+		Location:   loc,
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"index_name":    idx.Name,
+			"column_name":   colName,
+			"column_type":   typeStr,
+			"index_columns": idx.Columns,
+		},
+	}
+}
@@ -0,0 +1,130 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLowSelectivityIndexLinter_CreateTable_BoolLeading(t *testing.T) {
+	sql := `CREATE TABLE users (
+		id INT PRIMARY KEY,
+		is_active BOOL NOT NULL,
+		user_id INT NOT NULL,
+		INDEX active_user (is_active, user_id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&LowSelectivityIndexLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 1)
+	require.Equal(t, "low_selectivity_index", violations[0].Linter.Name())
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "users", violations[0].Location.Table)
+	require.NotNil(t, violations[0].Location.Index)
+	require.Equal(t, "active_user", *violations[0].Location.Index)
+	require.NotNil(t, violations[0].Location.Column)
+	require.Equal(t, "is_active", *violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "TINYINT(1)")
+	require.NotNil(t, violations[0].Suggestion)
+}
+
+func TestLowSelectivityIndexLinter_CreateTable_SmallEnumLeading(t *testing.T) {
+	sql := `CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		state ENUM('open', 'closed') NOT NULL,
+		customer_id INT NOT NULL,
+		KEY (state, customer_id)
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&LowSelectivityIndexLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 1)
+	require.Equal(t, "state", *violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "ENUM with 2 values")
+}
+
+func TestLowSelectivityIndexLinter_NoViolations(t *testing.T) {
+	tests := map[string]string{
+		"flag last": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			is_active BOOL NOT NULL,
+			user_id INT NOT NULL,
+			INDEX user_active (user_id, is_active)
+		)`,
+		"single column index": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			is_active BOOL NOT NULL,
+			INDEX (is_active)
+		)`,
+		"only low-cardinality columns": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			is_active BOOL NOT NULL,
+			is_admin BIT(1) NOT NULL,
+			INDEX (is_active, is_admin)
+		)`,
+		"wider tinyint": `CREATE TABLE users (
+			id INT PRIMARY KEY,
+			tier TINYINT NOT NULL,
+			user_id INT NOT NULL,
+			INDEX (tier, user_id)
+		)`,
+		"large enum": `CREATE TABLE orders (
+			id INT PRIMARY KEY,
+			state ENUM('new', 'paid', 'shipped', 'delivered') NOT NULL,
+			customer_id INT NOT NULL,
+			INDEX (state, customer_id)
+		)`,
+	}
+	for name, sql := range tests {
+		t.Run(name, func(t *testing.T) {
+			stmts, err := statement.New(sql)
+			require.NoError(t, err)
+			require.Empty(t, (&LowSelectivityIndexLinter{}).Lint(nil, stmts))
+		})
+	}
+}
+
+func TestLowSelectivityIndexLinter_AlterAddIndex(t *testing.T) {
+	ct, err := statement.ParseCreateTable(`CREATE TABLE users (
+		id INT PRIMARY KEY,
+		is_active TINYINT(1) NOT NULL,
+		user_id INT NOT NULL
+	)`)
+	require.NoError(t, err)
+	stmts, err := statement.New(`ALTER TABLE users ADD INDEX (is_active, user_id)`)
+	require.NoError(t, err)
+
+	violations := (&LowSelectivityIndexLinter{}).Lint([]*statement.CreateTable{ct}, stmts)
+
+	require.Len(t, violations, 1)
+	require.Nil(t, violations[0].Location.Index, "an unnamed index has no index location")
+	require.Contains(t, violations[0].Message, "Unnamed index on (is_active, user_id)")
+}
+
+func TestLowSelectivityIndexLinter_DisabledByDefault(t *testing.T) {
+	resetForTest(t)
+	RegisterDisabled(&LowSelectivityIndexLinter{})
+
+	stmts, err := statement.New(`CREATE TABLE users (
+		id INT PRIMARY KEY,
+		is_active BOOL NOT NULL,
+		user_id INT NOT NULL,
+		INDEX (is_active, user_id)
+	)`)
+	require.NoError(t, err)
+
+	violations, err := RunLinters(nil, stmts, Config{})
+	require.NoError(t, err)
+	require.Empty(t, violations)
+
+	violations, err = RunLinters(nil, stmts, Config{
+		Enabled: map[string]bool{"low_selectivity_index": true},
+	})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+}
@@ -0,0 +1,65 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+func init() {
+	Register(&NonInnoDBEngineLinter{})
+}
+
+// nonTransactionalEngines are engines that Spirit cannot migrate safely: they
+// have no row locking or transactions, so the copy and the replayed binlog
+// changes cannot be kept consistent. Other non-InnoDB engines are only a
+// warning, since Spirit has not been tested against them.
+var nonTransactionalEngines = map[string]struct{}{
+	"myisam": {},
+	"memory": {},
+	"heap":   {}, // legacy alias for MEMORY
+	"csv":    {},
+}
+
+// NonInnoDBEngineLinter flags tables that do not use the InnoDB storage engine.
+// Unlike allow_engine, it is not a policy check: it reports engines that break
+// the assumptions Spirit's online schema changes depend on.
+type NonInnoDBEngineLinter struct{}
+
+func (l *NonInnoDBEngineLinter) Name() string {
+	return "non_innodb_engine"
+}
+
+func (l *NonInnoDBEngineLinter) Description() string {
+	return "Detects tables using MyISAM or other non-InnoDB storage engines"
+}
+
+func (l *NonInnoDBEngineLinter) String() string {
+	return Stringer(l)
+}
+
+// Lint walks the post-state of the schema so an ALTER TABLE ENGINE=InnoDB
+// clears a violation on a legacy table.
+func (l *NonInnoDBEngineLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	for _, ct := range PostState(existingTables, changes) {
+		engine, ok := ct.GetTableOptions()["engine"].(string)
+		if !ok || strings.EqualFold(engine, "innodb") {
+			continue
+		}
+		severity := SeverityWarning
+		message := fmt.Sprintf("Table %q uses the %s engine; Spirit is only tested with InnoDB", ct.TableName, engine)
+		if _, ok := nonTransactionalEngines[strings.ToLower(engine)]; ok {
+			severity = SeverityError
+			message = fmt.Sprintf("Table %q uses the non-transactional %s engine, which Spirit cannot migrate safely", ct.TableName, engine)
+		}
+		violations = append(violations, Violation{
+			Linter:     l,
+			Location:   &Location{Table: ct.TableName},
+			Message:    message,
+			Severity:   severity,
+			Suggestion: new("Convert the table to InnoDB with ALTER TABLE ... ENGINE=InnoDB"),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,71 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNonInnoDBEngine_InnoDB(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY) ENGINE=InnoDB")
+	require.NoError(t, err)
+
+	linter := &NonInnoDBEngineLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestNonInnoDBEngine_NoEngine(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY)")
+	require.NoError(t, err)
+
+	linter := &NonInnoDBEngineLinter{}
+	require.Empty(t, linter.Lint(nil, stmts))
+}
+
+func TestNonInnoDBEngine_MyISAM(t *testing.T) {
+	stmts, err := statement.New("CREATE TABLE t1 (id INT PRIMARY KEY) ENGINE=MyISAM")
+	require.NoError(t, err)
+
+	linter := &NonInnoDBEngineLinter{}
+	violations := linter.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, SeverityError, violations[0].Severity)
+	require.Equal(t, "t1", violations[0].Location.Table)
+	require.Contains(t, violations[0].Message, "MyISAM")
+}
+
+func TestNonInnoDBEngine_Severity(t *testing.T) {
+	tests := []struct {
+		engine   string
+		severity Severity
+	}{
+		{"MEMORY", SeverityError},
+		{"CSV", SeverityError},
+		{"ARCHIVE", SeverityWarning},
+		{"BLACKHOLE", SeverityWarning},
+	}
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			stmts, err := statement.New("CREATE TABLE t1 (id INT NOT NULL) ENGINE=" + tt.engine)
+			require.NoError(t, err)
+
+			linter := &NonInnoDBEngineLinter{}
+			violations := linter.Lint(nil, stmts)
+			require.Len(t, violations, 1)
+			require.Equal(t, tt.severity, violations[0].Severity)
+		})
+	}
+}
+
+func TestNonInnoDBEngine_AlterToInnoDB(t *testing.T) {
+	ct, err := statement.ParseCreateTable("CREATE TABLE t1 (id INT PRIMARY KEY) ENGINE=MyISAM")
+	require.NoError(t, err)
+
+	linter := &NonInnoDBEngineLinter{}
+	require.Len(t, linter.Lint([]*statement.CreateTable{ct}, nil), 1)
+
+	stmts, err := statement.New("ALTER TABLE t1 ENGINE=InnoDB")
+	require.NoError(t, err)
+	require.Empty(t, linter.Lint([]*statement.CreateTable{ct}, stmts))
+}
@@ -55,7 +55,7 @@ func (l *RedundantIndexLinter) String() string {
 func (l *RedundantIndexLinter) checkTableIndexes(table *statement.CreateTable) []Violation {
 	var violations []Violation
 	indexes := table.GetIndexes()
-	primaryKey := indexes.ByName("PRIMARY")
+	primaryKey := indexes.Primary()
 
 	// Track which indexes we've already reported as redundant
 	// to avoid duplicate violations
@@ -79,7 +79,7 @@ var mysqlReservedWords = map[string]bool{
 	"XOR": true, "YEAR_MONTH": true, "ZEROFILL": true,
 }
 
-// ReservedWordsLinter checks for usage of MySQL reserved words in table and column names.
+// ReservedWordsLinter checks for usage of MySQL reserved words in table, column and index names.
 // Using reserved words as identifiers can cause syntax errors and requires quoting.
 type ReservedWordsLinter struct{}
 
@@ -88,7 +88,7 @@ func (l *ReservedWordsLinter) Name() string {
 }
 
 func (l *ReservedWordsLinter) Description() string {
-	return "Checks for usage of MySQL reserved words in table and column names"
+	return "Checks for usage of MySQL reserved words in table, column and index names"
 }
 
 func (l *ReservedWordsLinter) String() string {
@@ -129,6 +129,24 @@ func (l *ReservedWordsLinter) Lint(existingTables []*statement.CreateTable, chan
 				})
 			}
 		}
+
+		for _, index := range ct.Indexes {
+			// The primary key is always named PRIMARY, which is itself reserved.
+			if index.Type == "PRIMARY KEY" || !l.isReservedWord(index.Name) {
+				continue
+			}
+			indexName := index.Name
+			violations = append(violations, Violation{
+				Linter:   l,
+				Severity: SeverityWarning,
+				Location: &Location{
+					Table: ct.TableName,
+					Index: &indexName,
+				},
+				Message:    fmt.Sprintf("Index name %q is a MySQL reserved word", index.Name),
+				Suggestion: new(fmt.Sprintf("Use backticks when referencing this index: `%s` or choose a different name", index.Name)),
+			})
+		}
 	}
 
 	return violations
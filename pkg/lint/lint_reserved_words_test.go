@@ -367,3 +367,46 @@ func TestReservedWordsLinter_NoViolationsForSafeNames(t *testing.T) {
 	violations := linter.Lint([]*statement.CreateTable{ct}, nil)
 	require.Empty(t, violations, "Expected no violations for safe names")
 }
+
+func TestReservedWordsLinter_IndexName(t *testing.T) {
+	linter := &ReservedWordsLinter{}
+
+	sql := "CREATE TABLE orders (id INT PRIMARY KEY, placed_at DATETIME, INDEX `order` (placed_at), UNIQUE KEY `Rank` (placed_at, id))"
+	ct, err := statement.ParseCreateTable(sql)
+	require.NoError(t, err)
+
+	violations := linter.Lint([]*statement.CreateTable{ct}, nil)
+	require.Len(t, violations, 2, "PRIMARY must not be flagged")
+	for _, v := range violations {
+		require.NotNil(t, v.Location.Index)
+		require.Nil(t, v.Location.Column)
+		require.Equal(t, "orders", v.Location.Table)
+		require.Contains(t, v.Message, "Index name")
+	}
+	require.Equal(t, "order", *violations[0].Location.Index)
+	require.Equal(t, "Rank", *violations[1].Location.Index)
+}
+
+func TestReservedWordsLinter_AlterTableAddIndex(t *testing.T) {
+	linter := &ReservedWordsLinter{}
+
+	ct, err := statement.ParseCreateTable("CREATE TABLE orders (id INT PRIMARY KEY, placed_at DATETIME)")
+	require.NoError(t, err)
+	stmts, err := statement.New("ALTER TABLE orders ADD INDEX `groups` (placed_at)")
+	require.NoError(t, err)
+
+	violations := linter.Lint([]*statement.CreateTable{ct}, stmts)
+	require.Len(t, violations, 1)
+	require.Equal(t, "groups", *violations[0].Location.Index)
+}
+
+func TestReservedWordsLinter_NonReservedKeywords(t *testing.T) {
+	linter := &ReservedWordsLinter{}
+
+	// STATUS, COMMENT and NAME are keywords, but not reserved ones.
+	sql := "CREATE TABLE events (id INT PRIMARY KEY, status INT, comment TEXT, name VARCHAR(100), INDEX status (status))"
+	ct, err := statement.ParseCreateTable(sql)
+	require.NoError(t, err)
+
+	require.Empty(t, linter.Lint([]*statement.CreateTable{ct}, nil))
+}
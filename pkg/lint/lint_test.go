@@ -1015,3 +1015,40 @@ func TestRunLinters_LintOnlyChanges_And_IgnoreTables_NoResults(t *testing.T) {
 
 	require.Empty(t, violations)
 }
+
+func TestRunLinters_OnlyNewViolations(t *testing.T) {
+	resetForTest(t)
+	Register(&RedundantIndexLinter{})
+
+	ct, err := statement.ParseCreateTable(`CREATE TABLE users (
+		id INT NOT NULL PRIMARY KEY,
+		a INT,
+		b INT,
+		KEY idx_a (a),
+		KEY idx_ab (a, b)
+	)`)
+	require.NoError(t, err)
+	existing := []*statement.CreateTable{ct}
+
+	changes, err := statement.New("ALTER TABLE users ADD INDEX idx_b (b), ADD INDEX idx_ba (b, a)")
+	require.NoError(t, err)
+
+	// Without OnlyNewViolations, the pre-existing idx_a violation is reported too.
+	violations, err := RunLinters(existing, changes, Config{})
+	require.NoError(t, err)
+	require.Len(t, violations, 2)
+
+	// With OnlyNewViolations, only the violation introduced by the ALTER remains.
+	violations, err = RunLinters(existing, changes, Config{OnlyNewViolations: true})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	require.NotNil(t, violations[0].Location.Index)
+	require.Equal(t, "idx_b", *violations[0].Location.Index)
+
+	// An ALTER that introduces nothing new reports nothing.
+	changes, err = statement.New("ALTER TABLE users ADD COLUMN c INT")
+	require.NoError(t, err)
+	violations, err = RunLinters(existing, changes, Config{OnlyNewViolations: true, LintOnlyChanges: true})
+	require.NoError(t, err)
+	require.Empty(t, violations)
+}
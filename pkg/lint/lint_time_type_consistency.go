@@ -0,0 +1,134 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+type TimeTypeConsistencyLinter struct {
+	// preferredType is the type that *_at columns should use: "timestamp",
+	// "datetime", or "none" to not check them.
+	preferredType string
+}
+
+func init() {
+	RegisterDisabled(&TimeTypeConsistencyLinter{preferredType: "timestamp"})
+}
+
+func (l *TimeTypeConsistencyLinter) String() string {
+	return Stringer(l)
+}
+
+func (l *TimeTypeConsistencyLinter) Name() string {
+	return "time_type_consistency"
+}
+
+func (l *TimeTypeConsistencyLinter) Description() string {
+	return "Detects tables that mix DATETIME and TIMESTAMP columns, and *_at columns that do not use the preferred type"
+}
+
+func (l *TimeTypeConsistencyLinter) Configure(config map[string]string) error {
+	for k, v := range config {
+		switch k {
+		case "preferredType":
+			switch preferred := strings.ToLower(v); preferred {
+			case "timestamp", "datetime", "none":
+				l.preferredType = preferred
+			default:
+				return fmt.Errorf("preferredType must be one of timestamp, datetime or none, got %q", v)
+			}
+		default:
+			return fmt.Errorf("unknown config key for %s: %s", l.Name(), k)
+		}
+	}
+	return nil
+}
+
+func (l *TimeTypeConsistencyLinter) DefaultConfig() map[string]string {
+	return map[string]string{
+		"preferredType": "timestamp",
+	}
+}
+
+var _ ConfigurableLinter = &TimeTypeConsistencyLinter{}
+
+// Lint operates on a post-state view of the schema. It reports each table
+// that has both DATETIME and TIMESTAMP columns, and each column named *_at
+// whose type is DATETIME or TIMESTAMP but not the preferred one.
+//
+// Rationale: MySQL converts TIMESTAMP values from the session time zone to
+// UTC when storing them and back when reading them, but stores DATETIME
+// values as given. Columns that hold the same kind of value (created_at,
+// updated_at, deleted_at) but use different types are read back differently
+// by clients in different time zones, which is a common source of
+// off-by-some-hours bugs.
+//
+// Whether TIMESTAMP or DATETIME is the right choice depends on the
+// application (has_timestamp recommends DATETIME for its wider range), so
+// the linter is disabled by default and always emits SeverityWarning.
+func (l *TimeTypeConsistencyLinter) Lint(existingTables []*statement.CreateTable, changes []*statement.AbstractStatement) (violations []Violation) {
+	if l.preferredType == "" {
+		// Constructed directly rather than obtained via Get(); see the same
+		// guard in AutoIncCapacityLinter.Lint.
+		if err := l.Configure(l.DefaultConfig()); err != nil {
+			panic(err)
+		}
+	}
+	for _, ct := range PostState(existingTables, changes) {
+		var datetimeCols, timestampCols []string
+		for _, col := range ct.Columns {
+			colType := strings.ToLower(col.Type)
+			switch colType {
+			case "datetime":
+				datetimeCols = append(datetimeCols, col.Name)
+			case "timestamp":
+				timestampCols = append(timestampCols, col.Name)
+			default:
+				continue
+			}
+			if l.preferredType != "none" && colType != l.preferredType && strings.HasSuffix(strings.ToLower(col.Name), "_at") {
+				violations = append(violations, l.preferredTypeViolation(ct.TableName, col.Name, colType))
+			}
+		}
+		if len(datetimeCols) > 0 && len(timestampCols) > 0 {
+			violations = append(violations, l.mixedViolation(ct.TableName, datetimeCols, timestampCols))
+		}
+	}
+	return violations
+}
+
+func (l *TimeTypeConsistencyLinter) mixedViolation(tableName string, datetimeCols, timestampCols []string) Violation {
+	suggestion := "Use the same type for all columns that hold points in time, so they are converted between time zones the same way"
+	return Violation{
+		Linter:   l,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("Table %q mixes DATETIME columns (%s) and TIMESTAMP columns (%s). TIMESTAMP values are converted "+
+			"to and from the session time zone, while DATETIME values are not.",
+			tableName, strings.Join(datetimeCols, ", "), strings.Join(timestampCols, ", ")),
+		Location:   &Location{Table: tableName},
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"datetime_columns":  datetimeCols,
+			"timestamp_columns": timestampCols,
+		},
+	}
+}
+
+func (l *TimeTypeConsistencyLinter) preferredTypeViolation(tableName, colName, colType string) Violation {
+	preferred := strings.ToUpper(l.preferredType)
+	suggestion := fmt.Sprintf("Change %q to %s", colName, preferred)
+	return Violation{
+		Linter:   l,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf("Column %q in table %q is %s, but *_at columns are expected to be %s",
+			colName, tableName, strings.ToUpper(colType), preferred),
+		Location:   &Location{Table: tableName, Column: &colName},
+		Suggestion: &suggestion,
+		Context: map[string]any{
+			"column_type":    strings.ToUpper(colType),
+			"preferred_type": preferred,
+		},
+	}
+}
@@ -0,0 +1,147 @@
+package lint
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeTypeConsistencyLinter_MixedTypes(t *testing.T) {
+	sql := `CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		created_at TIMESTAMP NOT NULL,
+		shipped_on DATETIME NULL
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&TimeTypeConsistencyLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 1)
+	require.Equal(t, "time_type_consistency", violations[0].Linter.Name())
+	require.Equal(t, SeverityWarning, violations[0].Severity)
+	require.Equal(t, "orders", violations[0].Location.Table)
+	require.Nil(t, violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "DATETIME columns (shipped_on)")
+	require.Contains(t, violations[0].Message, "TIMESTAMP columns (created_at)")
+	require.NotNil(t, violations[0].Suggestion)
+}
+
+func TestTimeTypeConsistencyLinter_AtColumnUsesDatetime(t *testing.T) {
+	sql := `CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		updated_at DATETIME NOT NULL
+	)`
+	stmts, err := statement.New(sql)
+	require.NoError(t, err)
+
+	violations := (&TimeTypeConsistencyLinter{}).Lint(nil, stmts)
+
+	require.Len(t, violations, 2)
+	for i, col := range []string{"created_at", "updated_at"} {
+		require.Equal(t, SeverityWarning, violations[i].Severity)
+		require.NotNil(t, violations[i].Location.Column)
+		require.Equal(t, col, *violations[i].Location.Column)
+		require.Contains(t, violations[i].Message, "is DATETIME, but *_at columns are expected to be TIMESTAMP")
+	}
+}
+
+func TestTimeTypeConsistencyLinter_PreferDatetime(t *testing.T) {
+	stmts, err := statement.New(`CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		created_at DATETIME NOT NULL,
+		deleted_at TIMESTAMP NULL
+	)`)
+	require.NoError(t, err)
+
+	l := &TimeTypeConsistencyLinter{}
+	require.NoError(t, l.Configure(map[string]string{"preferredType": "DATETIME"}))
+	violations := l.Lint(nil, stmts)
+
+	require.Len(t, violations, 2)
+	require.Equal(t, "deleted_at", *violations[0].Location.Column)
+	require.Contains(t, violations[0].Message, "is TIMESTAMP, but *_at columns are expected to be DATETIME")
+	require.Nil(t, violations[1].Location.Column)
+	require.Contains(t, violations[1].Message, "mixes DATETIME columns")
+
+	require.NoError(t, l.Configure(map[string]string{"preferredType": "none"}))
+	violations = l.Lint(nil, stmts)
+	require.Len(t, violations, 1)
+	require.Contains(t, violations[0].Message, "mixes DATETIME columns")
+}
+
+func TestTimeTypeConsistencyLinter_NoViolations(t *testing.T) {
+	tests := map[string]string{
+		"consistent timestamps": `CREATE TABLE orders (
+			id INT PRIMARY KEY,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			expires TIMESTAMP NULL
+		)`,
+		"datetime not named *_at": `CREATE TABLE events (
+			id INT PRIMARY KEY,
+			starts DATETIME NOT NULL,
+			ends DATETIME NOT NULL
+		)`,
+		"other temporal types": `CREATE TABLE events (
+			id INT PRIMARY KEY,
+			created_at TIMESTAMP NOT NULL,
+			happened_on DATE NOT NULL,
+			starts_at_time TIME NOT NULL
+		)`,
+	}
+	for name, sql := range tests {
+		t.Run(name, func(t *testing.T) {
+			stmts, err := statement.New(sql)
+			require.NoError(t, err)
+			require.Empty(t, (&TimeTypeConsistencyLinter{}).Lint(nil, stmts))
+		})
+	}
+}
+
+func TestTimeTypeConsistencyLinter_AlterAddColumn(t *testing.T) {
+	ct, err := statement.ParseCreateTable(`CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		created_at TIMESTAMP NOT NULL
+	)`)
+	require.NoError(t, err)
+	stmts, err := statement.New(`ALTER TABLE orders ADD COLUMN paid_at DATETIME NULL`)
+	require.NoError(t, err)
+
+	violations := (&TimeTypeConsistencyLinter{}).Lint([]*statement.CreateTable{ct}, stmts)
+
+	require.Len(t, violations, 2)
+	require.Equal(t, "paid_at", *violations[0].Location.Column)
+	require.Contains(t, violations[1].Message, "mixes DATETIME columns (paid_at) and TIMESTAMP columns (created_at)")
+}
+
+func TestTimeTypeConsistencyLinter_Configure(t *testing.T) {
+	l := &TimeTypeConsistencyLinter{}
+	require.NoError(t, l.Configure(l.DefaultConfig()))
+	require.ErrorContains(t, l.Configure(map[string]string{"preferredType": "date"}), "preferredType must be one of")
+	require.ErrorContains(t, l.Configure(map[string]string{"unknown": "x"}), "unknown config key for time_type_consistency: unknown")
+}
+
+func TestTimeTypeConsistencyLinter_DisabledByDefault(t *testing.T) {
+	resetForTest(t)
+	RegisterDisabled(&TimeTypeConsistencyLinter{preferredType: "timestamp"})
+
+	stmts, err := statement.New(`CREATE TABLE orders (
+		id INT PRIMARY KEY,
+		created_at TIMESTAMP NOT NULL,
+		shipped_on DATETIME NULL
+	)`)
+	require.NoError(t, err)
+
+	violations, err := RunLinters(nil, stmts, Config{})
+	require.NoError(t, err)
+	require.Empty(t, violations)
+
+	violations, err = RunLinters(nil, stmts, Config{
+		Enabled: map[string]bool{"time_type_consistency": true},
+	})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+}
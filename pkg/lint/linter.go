@@ -2,6 +2,7 @@ package lint
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/block/spirit/pkg/statement"
@@ -55,3 +56,13 @@ func ConfigBool(value string, key string) (bool, error) {
 
 	return false, fmt.Errorf("invalid value for %s: %s (expected 'true' or 'false')", key, value)
 }
+
+// ConfigInt parses an integer configuration value from a string.
+// The key parameter is used in error messages to provide context.
+func ConfigInt(value string, key string) (int, error) {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		return 0, fmt.Errorf("invalid value for %s: %s (expected an integer)", key, value)
+	}
+	return n, nil
+}
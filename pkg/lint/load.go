@@ -14,6 +14,9 @@ import (
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// fileOriginPrefix prefixes the Origin of a table loaded from a file.
+const fileOriginPrefix = "file:"
+
 // LoadSchemaFromDSN connects to a MySQL server and retrieves all CREATE TABLE
 // statements from the connected database, parsed into structured CreateTable objects.
 func LoadSchemaFromDSN(ctx context.Context, dsn string) ([]*statement.CreateTable, error) {
@@ -42,6 +45,7 @@ func LoadSchemaFromDSN(ctx context.Context, dsn string) ([]*statement.CreateTabl
 
 // LoadSchemaFromDir reads all .sql files from a directory and parses them as
 // CREATE TABLE statements. Each file should contain exactly one CREATE TABLE statement.
+// Each table's Origin is set to "file:" followed by the path of its file.
 func LoadSchemaFromDir(dir string) ([]*statement.CreateTable, error) {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -67,6 +71,7 @@ func LoadSchemaFromDir(dir string) ([]*statement.CreateTable, error) {
 		if err != nil {
 			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
 		}
+		ct.Origin = fileOriginPrefix + path
 		tables = append(tables, ct)
 	}
 
@@ -34,6 +34,11 @@ func TestLoadSchemaFromDir_Basic(t *testing.T) {
 	}
 	require.True(t, names["users"])
 	require.True(t, names["orders"])
+
+	// Each table records the file it came from.
+	for _, ct := range tables {
+		require.Equal(t, "file:"+filepath.Join(dir, ct.TableName+".sql"), ct.Origin)
+	}
 }
 
 func TestLoadSchemaFromDir_SkipsNonSQL(t *testing.T) {
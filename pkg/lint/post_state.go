@@ -7,6 +7,7 @@ import (
 
 	"github.com/block/spirit/pkg/statement"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/parser/format"
 	"github.com/pingcap/tidb/pkg/parser/types"
 )
 
@@ -328,7 +329,13 @@ func applyTableOptions(existing *statement.TableOptions, opts []*ast.TableOption
 func nonIndexConstraint(c *ast.Constraint) (statement.Constraint, bool) {
 	switch c.Tp { //nolint:exhaustive
 	case ast.ConstraintForeignKey:
-		return statement.Constraint{Raw: c, Name: c.Name, Type: "FOREIGN KEY"}, true
+		cols := make([]string, 0, len(c.Keys))
+		for _, k := range c.Keys {
+			if k.Column != nil {
+				cols = append(cols, k.Column.Name.O)
+			}
+		}
+		return statement.Constraint{Raw: c, Name: c.Name, Type: "FOREIGN KEY", Columns: cols}, true
 	case ast.ConstraintCheck:
 		return statement.Constraint{Raw: c, Name: c.Name, Type: "CHECK"}, true
 	}
@@ -347,9 +354,9 @@ func removeConstraint(cs statement.Constraints, name, typeMatch string) statemen
 }
 
 // columnFromAst constructs a minimal statement.Column from an AST column def.
-// Raw (for type information), Name, the base Type string, and inline
-// PrimaryKey/Unique flags are populated — that's enough for the linters that
-// need post-state. The base Type mirrors CreateTable.parseColumn so that
+// Raw (for type information), Name, the base Type string, the AutoInc flag,
+// inline PrimaryKey/Unique flags, the generated-column expression and the
+// comment are populated — that's enough for the linters that need post-state. The base Type mirrors CreateTable.parseColumn so that
 // linters comparing against Column.Type (e.g. primary_key) work on
 // ADD/MODIFY/CHANGE COLUMN specs, not just fully-parsed existing tables.
 // Binary/spatial nuances aren't recovered here; linters that care read Raw.
@@ -363,15 +370,50 @@ func columnFromAst(colDef *ast.ColumnDef) statement.Column {
 	}
 	for _, opt := range colDef.Options {
 		switch opt.Tp { //nolint:exhaustive
+		case ast.ColumnOptionAutoIncrement:
+			col.AutoInc = true
 		case ast.ColumnOptionPrimaryKey:
 			col.PrimaryKey = true
 		case ast.ColumnOptionUniqKey:
 			col.Unique = true
+		case ast.ColumnOptionGenerated:
+			// Match ParseCreateTable, which stores the expression without
+			// its outer parentheses, so a generated column reads the same
+			// whether it came from an existing table or an ALTER.
+			if expr, ok := generatedExprText(opt.Expr); ok {
+				col.GeneratedExpr = &expr
+				col.GeneratedStored = opt.Stored
+			}
+		case ast.ColumnOptionComment:
+			// Match ParseCreateTable: the unescaped literal, and no
+			// comment at all for COMMENT ''.
+			if v, ok := opt.Expr.(ast.ValueExpr); ok && v.GetString() != "" {
+				comment := v.GetString()
+				col.Comment = &comment
+			}
 		}
 	}
 	return col
 }
 
+func generatedExprText(expr ast.ExprNode) (string, bool) {
+	if expr == nil {
+		return "", false
+	}
+	for {
+		paren, ok := expr.(*ast.ParenthesesExpr)
+		if !ok {
+			break
+		}
+		expr = paren.Expr
+	}
+	var sb strings.Builder
+	if err := expr.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreStringWithoutCharset, &sb)); err != nil {
+		return "", false
+	}
+	return sb.String(), true
+}
+
 func removeColumn(cols statement.Columns, name string) statement.Columns {
 	out := cols[:0]
 	for _, c := range cols {
@@ -448,11 +490,16 @@ func indexFromConstraint(c *ast.Constraint) (statement.Index, bool) {
 			cols = append(cols, k.Column.Name.O)
 		}
 	}
-	return statement.Index{
+	idx := statement.Index{
 		Name:    c.Name,
 		Type:    typeStr,
 		Columns: cols,
-	}, true
+	}
+	if c.Option != nil && c.Option.Comment != "" {
+		comment := c.Option.Comment
+		idx.Comment = &comment
+	}
+	return idx, true
 }
 
 func removeIndex(indexes statement.Indexes, name, typeMatch string) statement.Indexes {
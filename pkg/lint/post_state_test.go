@@ -115,6 +115,60 @@ func TestPostState_AddDropForeignKey(t *testing.T) {
 	require.Equal(t, 1, fkCount, "ADD CONSTRAINT FOREIGN KEY should appear in post-state")
 }
 
+// TestPostState_Comments verifies that columns and indexes added or changed by
+// an ALTER carry their comments into the post-state, the same as when they are
+// parsed from CREATE TABLE.
+func TestPostState_Comments(t *testing.T) {
+	existing, err := statement.ParseCreateTable("CREATE TABLE t1 (id INT PRIMARY KEY, email VARCHAR(255) COMMENT 'old')")
+	require.NoError(t, err)
+
+	alter, err := statement.New(`ALTER TABLE t1
+		ADD COLUMN phone VARCHAR(32) COMMENT 'pii:phone',
+		MODIFY COLUMN email VARCHAR(255) COMMENT 'pii:email',
+		ADD COLUMN plain INT COMMENT '',
+		ADD INDEX phone (phone) COMMENT 'it''s pii'`)
+	require.NoError(t, err)
+
+	t1 := findTable(PostState([]*statement.CreateTable{existing}, alter), "t1")
+	require.NotNil(t, t1)
+	comments := map[string]*string{}
+	for _, col := range t1.Columns {
+		comments[col.Name] = col.Comment
+	}
+	require.Equal(t, map[string]*string{
+		"id":    nil,
+		"email": new("pii:email"),
+		"phone": new("pii:phone"),
+		"plain": nil,
+	}, comments)
+	require.Len(t, t1.Indexes, 2)
+	require.Equal(t, "phone", t1.Indexes[1].Name)
+	require.Equal(t, new("it's pii"), t1.Indexes[1].Comment)
+}
+
+func TestPostState_GeneratedColumns(t *testing.T) {
+	existing, err := statement.ParseCreateTable("CREATE TABLE t1 (id INT PRIMARY KEY, a INT, b INT AS (a * 2) STORED)")
+	require.NoError(t, err)
+
+	alter, err := statement.New(`ALTER TABLE t1
+		ADD COLUMN v VARCHAR(20) GENERATED ALWAYS AS (concat('x', a)) VIRTUAL,
+		MODIFY COLUMN b INT AS (a * 3) STORED`)
+	require.NoError(t, err)
+
+	t1 := findTable(PostState([]*statement.CreateTable{existing}, alter), "t1")
+	require.NotNil(t, t1)
+	a := t1.Columns.ByName("a")
+	require.Nil(t, a.GeneratedExpr)
+	// The ALTER-added and existing columns carry the same expression form as
+	// ParseCreateTable produces.
+	b := t1.Columns.ByName("b")
+	require.Equal(t, new("`a`*3"), b.GeneratedExpr)
+	require.True(t, b.GeneratedStored)
+	v := t1.Columns.ByName("v")
+	require.Equal(t, new("CONCAT('x', `a`)"), v.GeneratedExpr)
+	require.False(t, v.GeneratedStored)
+}
+
 // TestPostState_MigratedLinters_FixingAltersSilenceWarnings verifies the
 // post-state convention across the six linters migrated in this change:
 // an ALTER that fixes the legacy issue should silence the warning.
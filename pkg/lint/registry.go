@@ -35,6 +35,16 @@ func Register(l Linter) {
 	}
 }
 
+// RegisterDisabled registers a linter that is disabled by default. It is
+// for opt-in linters whose heuristics are too noisy to run everywhere: they
+// run only when enabled with Enable or in Config.Enabled.
+func RegisterDisabled(l Linter) {
+	Register(l)
+	lock.Lock()
+	defer lock.Unlock()
+	linters[l.Name()].enabled = false
+}
+
 // Enable enables specific linters by name.
 // Returns an error if the linter is not found.
 func Enable(names ...string) error {
@@ -0,0 +1,185 @@
+package lint
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+// This file writes violations as a SARIF 2.1.0 log, the format GitHub code
+// scanning and other static analysis tools consume. Only the subset of the
+// format that spirit needs is modelled.
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation *sarifPhysicalLocation `json:"physicalLocation,omitempty"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind,omitempty"`
+}
+
+// sarifLevel maps a severity to a SARIF result level.
+func sarifLevel(s Severity) string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// printViolationsAsSARIF writes violations to w as a SARIF log with a single
+// run. Every registered linter is listed as a rule, whether or not it
+// reported anything. A violation on a table loaded from a file (see
+// LoadSchemaFromDir) gets a physical location pointing at the line of its
+// CREATE TABLE statement; every violation with a Location also gets a logical
+// location naming the table and, if set, the column, index or constraint.
+// Used by the lint command with --format=sarif.
+func printViolationsAsSARIF(w io.Writer, violations []Violation, tables []*statement.CreateTable) error {
+	var rules []sarifRule
+	ruleIndex := make(map[string]int)
+	for _, name := range List() {
+		l, err := Get(name)
+		if err != nil {
+			return err
+		}
+		ruleIndex[name] = len(rules)
+		rules = append(rules, sarifRule{ID: name, ShortDescription: sarifMessage{Text: l.Description()}})
+	}
+
+	origins := make(map[string]*statement.CreateTable, len(tables))
+	for _, ct := range tables {
+		origins[ct.TableName] = ct
+	}
+
+	results := []sarifResult{}
+	for _, v := range sortViolations(violations) {
+		result := sarifResult{
+			RuleID:    v.Linter.Name(),
+			RuleIndex: ruleIndex[v.Linter.Name()],
+			Level:     sarifLevel(v.Severity),
+			Message:   sarifMessage{Text: v.Message},
+		}
+		if v.Suggestion != nil {
+			result.Message.Text += " Suggestion: " + *v.Suggestion
+		}
+		if v.Location != nil {
+			loc := sarifLocation{LogicalLocations: logicalLocations(v.Location)}
+			if ct, ok := origins[v.Location.Table]; ok {
+				loc.PhysicalLocation = physicalLocation(ct)
+			}
+			result.Locations = []sarifLocation{loc}
+		}
+		results = append(results, result)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "spirit",
+				InformationURI: "https://github.com/block/spirit",
+				Rules:          rules,
+			}},
+			Results: results,
+		}},
+	})
+}
+
+// physicalLocation returns the file location of a table loaded from a file,
+// or nil if it was not. The line is that of the CREATE TABLE statement; it is
+// omitted if the file can no longer be read.
+func physicalLocation(ct *statement.CreateTable) *sarifPhysicalLocation {
+	path, ok := strings.CutPrefix(ct.Origin, fileOriginPrefix)
+	if !ok {
+		return nil
+	}
+	loc := &sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(path)}}
+	if content, err := os.ReadFile(path); err == nil && ct.Offset <= len(content) {
+		loc.Region = &sarifRegion{StartLine: 1 + strings.Count(string(content[:ct.Offset]), "\n")}
+	}
+	return loc
+}
+
+// logicalLocations names the schema objects a violation is about, the most
+// specific first.
+func logicalLocations(l *Location) []sarifLogicalLocation {
+	var locs []sarifLogicalLocation
+	for _, obj := range []struct {
+		name *string
+		kind string
+	}{{l.Column, "column"}, {l.Index, "index"}, {l.Constraint, "constraint"}} {
+		if obj.name != nil {
+			locs = append(locs, sarifLogicalLocation{Name: *obj.name, FullyQualifiedName: l.Table + "." + *obj.name, Kind: obj.kind})
+		}
+	}
+	return append(locs, sarifLogicalLocation{Name: l.Table, Kind: "table"})
+}
@@ -0,0 +1,89 @@
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintViolationsAsSARIF(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "users.sql", `-- Users of the application.
+
+CREATE TABLE users (
+	id bigint unsigned NOT NULL AUTO_INCREMENT,
+	balance float NOT NULL,
+	PRIMARY KEY (id)
+);`)
+	tables, err := LoadSchemaFromDir(dir)
+	require.NoError(t, err)
+
+	hasFloat, err := Get("has_float")
+	require.NoError(t, err)
+	violations := []Violation{
+		{
+			Linter:     hasFloat,
+			Severity:   SeverityWarning,
+			Message:    "float column",
+			Location:   &Location{Table: "users", Column: new("balance")},
+			Suggestion: new("Use DECIMAL"),
+		},
+		{
+			Linter:   hasFloat,
+			Severity: SeverityInfo,
+			Message:  "not loaded from a file",
+			Location: &Location{Table: "orders"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printViolationsAsSARIF(&buf, violations, tables))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	require.Equal(t, "spirit", run.Tool.Driver.Name)
+
+	// Every registered linter is a rule, in registry order.
+	require.Len(t, run.Tool.Driver.Rules, len(List()))
+	for i, name := range List() {
+		require.Equal(t, name, run.Tool.Driver.Rules[i].ID)
+	}
+
+	require.Len(t, run.Results, 2)
+	orders, users := run.Results[0], run.Results[1]
+
+	require.Equal(t, "has_float", users.RuleID)
+	require.Equal(t, "has_float", run.Tool.Driver.Rules[users.RuleIndex].ID)
+	require.Equal(t, hasFloat.Description(), run.Tool.Driver.Rules[users.RuleIndex].ShortDescription.Text)
+	require.Equal(t, "warning", users.Level)
+	require.Equal(t, "float column Suggestion: Use DECIMAL", users.Message.Text)
+	require.Len(t, users.Locations, 1)
+	physical := users.Locations[0].PhysicalLocation
+	require.NotNil(t, physical)
+	require.Equal(t, filepath.ToSlash(filepath.Join(dir, "users.sql")), physical.ArtifactLocation.URI)
+	require.Equal(t, 3, physical.Region.StartLine)
+	require.Equal(t, []sarifLogicalLocation{
+		{Name: "balance", FullyQualifiedName: "users.balance", Kind: "column"},
+		{Name: "users", Kind: "table"},
+	}, users.Locations[0].LogicalLocations)
+
+	// A table that was not loaded from a file only has a logical location.
+	require.Equal(t, "note", orders.Level)
+	require.Nil(t, orders.Locations[0].PhysicalLocation)
+	require.Equal(t, []sarifLogicalLocation{{Name: "orders", Kind: "table"}}, orders.Locations[0].LogicalLocations)
+
+	// No violations still writes a run, with an empty results array.
+	buf.Reset()
+	require.NoError(t, printViolationsAsSARIF(&buf, nil, nil))
+	var empty map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &empty))
+	results := empty["runs"].([]any)[0].(map[string]any)["results"]
+	require.NotNil(t, results)
+	require.Empty(t, results)
+}
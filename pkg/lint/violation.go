@@ -2,7 +2,9 @@ package lint
 
 import (
 	"cmp"
+	"encoding/json"
 	"fmt"
+	"io"
 	"slices"
 )
 
@@ -69,19 +71,36 @@ func (v Violation) String() string {
 	return msg
 }
 
+// MarshalJSON encodes the violation with the linter's name and the
+// severity's string form, so the output does not depend on Go types.
+func (v Violation) MarshalJSON() ([]byte, error) {
+	var linter string
+	if v.Linter != nil {
+		linter = v.Linter.Name()
+	}
+	return json.Marshal(struct {
+		Linter     string         `json:"linter"`
+		Severity   string         `json:"severity"`
+		Message    string         `json:"message"`
+		Location   *Location      `json:"location,omitempty"`
+		Suggestion *string        `json:"suggestion,omitempty"`
+		Context    map[string]any `json:"context,omitempty"`
+	}{linter, v.Severity.String(), v.Message, v.Location, v.Suggestion, v.Context})
+}
+
 // Location provides information about where a violation occurred
 type Location struct {
 	// Table is the name of the table where the violation occurred
-	Table string
+	Table string `json:"table"`
 
 	// Column is the name of the column (if applicable)
-	Column *string
+	Column *string `json:"column,omitempty"`
 
 	// Index is the name of the index (if applicable)
-	Index *string
+	Index *string `json:"index,omitempty"`
 
 	// Constraint is the name of the constraint (if applicable)
-	Constraint *string
+	Constraint *string `json:"constraint,omitempty"`
 }
 
 func (l *Location) String() string {
@@ -136,3 +155,16 @@ func printViolations(violations []Violation) {
 		fmt.Println(v.String())
 	}
 }
+
+// printViolationsAsJSON writes violations to w as a JSON array, in the same
+// order as printViolations. No violations is written as an empty array.
+// Used by the lint command with --format=json.
+func printViolationsAsJSON(w io.Writer, violations []Violation) error {
+	sorted := sortViolations(violations)
+	if sorted == nil {
+		sorted = []Violation{}
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(sorted)
+}
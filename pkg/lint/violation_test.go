@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -314,3 +316,51 @@ func TestViolation_StructInitialization(t *testing.T) {
 		require.Equal(t, SeverityError, v.Severity)
 	})
 }
+
+// TestPrintViolationsAsJSON tests the --format=json output of the lint command
+func TestPrintViolationsAsJSON(t *testing.T) {
+	violations := []Violation{
+		{
+			Linter:   &mockLinter{name: "has_float"},
+			Severity: SeverityWarning,
+			Message:  "float column",
+			Location: &Location{Table: "users", Column: new("balance")},
+		},
+		{
+			Linter:     &mockLinter{name: "primary_key"},
+			Severity:   SeverityError,
+			Message:    "bad primary key",
+			Location:   &Location{Table: "users", Index: new("PRIMARY")},
+			Suggestion: new("Use BIGINT UNSIGNED"),
+			Context:    map[string]any{"type": "int"},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, printViolationsAsJSON(&buf, violations))
+	require.JSONEq(t, `[
+		{
+			"linter": "primary_key",
+			"severity": "ERROR",
+			"message": "bad primary key",
+			"location": {"table": "users", "index": "PRIMARY"},
+			"suggestion": "Use BIGINT UNSIGNED",
+			"context": {"type": "int"}
+		},
+		{
+			"linter": "has_float",
+			"severity": "WARNING",
+			"message": "float column",
+			"location": {"table": "users", "column": "balance"}
+		}
+	]`, buf.String())
+
+	// No violations is an empty array rather than null, so consumers can
+	// always iterate the result.
+	buf.Reset()
+	require.NoError(t, printViolationsAsJSON(&buf, nil))
+	var decoded []map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	require.NotNil(t, decoded)
+	require.Empty(t, decoded)
+}
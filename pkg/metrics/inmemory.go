@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"context"
+	"maps"
+	"sync"
+)
+
+// InMemorySink accumulates metrics in process so they can be read from Go
+// without an external metrics backend. COUNTER values are summed, and
+// GAUGE (and UNKNOWN) values keep the most recent value. Labels are
+// ignored: values are keyed by metric name only.
+//
+// It is safe for concurrent use. The zero value is ready to use.
+type InMemorySink struct {
+	mu     sync.Mutex
+	values map[string]float64
+}
+
+var _ Sink = &InMemorySink{}
+
+// NewInMemorySink returns an empty InMemorySink.
+func NewInMemorySink() *InMemorySink {
+	return &InMemorySink{}
+}
+
+func (s *InMemorySink) Send(ctx context.Context, m *Metrics) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.values == nil {
+		s.values = make(map[string]float64)
+	}
+	for _, v := range m.Values {
+		if v.Type == COUNTER {
+			s.values[v.Name] += v.Value
+			continue
+		}
+		s.values[v.Name] = v.Value
+	}
+	return nil
+}
+
+// Snapshot returns a copy of the current value of every metric received.
+func (s *InMemorySink) Snapshot() map[string]float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make(map[string]float64, len(s.values))
+	maps.Copy(out, s.values)
+	return out
+}
@@ -0,0 +1,55 @@
+package metrics
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInMemorySink(t *testing.T) {
+	sink := NewInMemorySink()
+	require.Empty(t, sink.Snapshot())
+
+	// Simulate a migration: copier threads send the per-chunk counters and
+	// the processing-time gauge concurrently, and the caller counts retries.
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Go(func() {
+			errs[i] = sink.Send(t.Context(), &Metrics{
+				Values: []MetricValue{
+					{Name: ChunkProcessingTimeMetricName, Type: GAUGE, Value: 42},
+					{Name: ChunkLogicalRowsCountMetricName, Type: COUNTER, Value: 1000},
+					{Name: ChunkAffectedRowsCountMetricName, Type: COUNTER, Value: 990},
+				},
+			})
+		})
+	}
+	wg.Wait()
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+	require.NoError(t, sink.Send(t.Context(), &Metrics{
+		Values: []MetricValue{{Name: "retries", Type: COUNTER, Value: 2}},
+	}))
+	require.NoError(t, sink.Send(t.Context(), &Metrics{
+		Values: []MetricValue{{Name: "retries", Type: COUNTER, Value: 1}},
+	}))
+
+	snapshot := sink.Snapshot()
+	require.InDelta(t, 10000, snapshot[ChunkLogicalRowsCountMetricName], 0)
+	require.InDelta(t, 9900, snapshot[ChunkAffectedRowsCountMetricName], 0)
+	require.InDelta(t, 42, snapshot[ChunkProcessingTimeMetricName], 0)
+	require.InDelta(t, 3, snapshot["retries"], 0)
+
+	// The snapshot is a copy.
+	snapshot["retries"] = 100
+	require.InDelta(t, 3, sink.Snapshot()["retries"], 0)
+
+	// Gauges keep the latest value.
+	require.NoError(t, sink.Send(t.Context(), &Metrics{
+		Values: []MetricValue{{Name: ChunkProcessingTimeMetricName, Type: GAUGE, Value: 7}},
+	}))
+	require.InDelta(t, 7, sink.Snapshot()[ChunkProcessingTimeMetricName], 0)
+}
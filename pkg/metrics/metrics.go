@@ -4,6 +4,7 @@ package metrics
 
 import (
 	"context"
+	"maps"
 	"time"
 )
 
@@ -37,11 +38,48 @@ const (
 	ApplierQueueWaitP90MetricName  = "applier_queue_wait_ms_p90"
 	ApplierWriteTimeP50MetricName  = "applier_write_time_ms_p50"
 	ApplierWriteTimeP90MetricName  = "applier_write_time_ms_p90"
+
+	// Change source gauges. DeltaLen and DeltaMemoryBytes are the changes
+	// read but not yet applied to the new table; BinlogLagSeconds is how far
+	// the binlog reader is behind the source (see change.Source Lag).
+	DeltaLenMetricName         = "delta_len"
+	DeltaMemoryBytesMetricName = "delta_memory_bytes"
+	BinlogLagSecondsMetricName = "binlog_lag_seconds"
+
+	// Migration progress gauges. CopyRowsCopied and CopyRowsTotal are the
+	// chunker's estimate (see table.Chunker Progress); ChecksumDifferences is
+	// the number of chunks the checksum has found to differ.
+	CopyRowsCopiedMetricName      = "copy_rows_copied"
+	CopyRowsTotalMetricName       = "copy_rows_total_estimate"
+	CopyChunksCopiedMetricName    = "copy_chunks_copied"
+	ChecksumDifferencesMetricName = "checksum_differences"
+
+	// Copy chunk sizing. CopyChunkSize is a gauge of the number of rows the
+	// dynamic chunker sizes the next chunk for, and CopyChunkPanicShrinks a
+	// counter of the chunks that took more than DynamicPanicFactor times the
+	// target, each of which shrank the chunk size at once. A rising
+	// CopyChunkPanicShrinks means chunks keep overshooting the target chunk
+	// time.
+	CopyChunkSizeMetricName         = "copy_chunk_size"
+	CopyChunkPanicShrinksMetricName = "copy_chunk_panic_shrinks"
+
+	// Connection pool gauges, from sql.DB Stats, labeled with the pool they
+	// describe. InUse at MaxOpen means the pool is saturated, and a rising
+	// WaitCount or WaitSeconds (both totals since the pool was opened) means
+	// statements are queueing for a connection.
+	DBConnsMaxOpenMetricName    = "db_conns_max_open"
+	DBConnsInUseMetricName      = "db_conns_in_use"
+	DBConnsIdleMetricName       = "db_conns_idle"
+	DBConnWaitCountMetricName   = "db_conn_wait_count"
+	DBConnWaitSecondsMetricName = "db_conn_wait_seconds"
 )
 
 // Metrics are collection of MetricValues.
 type Metrics struct {
 	Values []MetricValue
+	// Labels are dimensions that apply to every value, such as the
+	// correlation ID of the migration that produced them. Nil when unset.
+	Labels map[string]string
 }
 
 type MetricValue struct {
@@ -58,6 +96,9 @@ type MetricValue struct {
 // Sink sends metrics to an external destination.
 type Sink interface {
 	// Send sends metrics to the sink. It must respect the context timeout, if any.
+	// Implementations should attach metrics.Labels, when set, as dimensions of
+	// every value: this is how a migration's correlation_id reaches the
+	// metrics backend.
 	Send(ctx context.Context, metrics *Metrics) error
 }
 
@@ -69,3 +110,26 @@ func (s *NoopSink) Send(ctx context.Context, m *Metrics) error {
 }
 
 var _ Sink = &NoopSink{}
+
+// labelSink adds a fixed set of labels to every Metrics sent through it.
+type labelSink struct {
+	sink   Sink
+	labels map[string]string
+}
+
+// WithLabels returns a Sink that adds labels to every Metrics before passing
+// it to sink. Labels already set on a Metrics take precedence. If labels is
+// empty, sink is returned unchanged.
+func WithLabels(sink Sink, labels map[string]string) Sink {
+	if len(labels) == 0 {
+		return sink
+	}
+	return &labelSink{sink: sink, labels: labels}
+}
+
+func (s *labelSink) Send(ctx context.Context, m *Metrics) error {
+	merged := make(map[string]string, len(s.labels)+len(m.Labels))
+	maps.Copy(merged, s.labels)
+	maps.Copy(merged, m.Labels)
+	return s.sink.Send(ctx, &Metrics{Values: m.Values, Labels: merged})
+}
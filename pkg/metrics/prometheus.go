@@ -0,0 +1,156 @@
+package metrics
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// PrometheusSink keeps the metrics sent to it and serves them in the
+// Prometheus text exposition format, so a long-running process can be
+// scraped instead of pushing to a metrics backend. It is an http.Handler for
+// the caller to mount, typically at /metrics:
+//
+//	sink := metrics.NewPrometheusSink("spirit")
+//	http.Handle("/metrics", sink)
+//	runner.SetMetricsSink(sink)
+//
+// COUNTER values are summed and exposed with a _total suffix; GAUGE values
+// keep the most recent value; UNKNOWN values are exposed as untyped. Each
+// distinct set of Metrics.Labels is its own series, so several migrations
+// can share one sink as long as their labels differ (the migration runner
+// labels its metrics with the schema and table it migrates).
+//
+// Series are kept for the life of the sink. It is safe for concurrent use.
+type PrometheusSink struct {
+	namespace string
+
+	mu     sync.Mutex
+	series map[string]*promSeries // keyed by exposed name and labels
+}
+
+type promSeries struct {
+	name   string // exposed name, including namespace and _total suffix
+	labels string // rendered {k="v",...}, or "" without labels
+	typ    byte
+	value  float64
+}
+
+var (
+	_ Sink         = &PrometheusSink{}
+	_ http.Handler = &PrometheusSink{}
+)
+
+// NewPrometheusSink returns an empty PrometheusSink. Metric names are
+// prefixed with namespace and an underscore, unless namespace is empty.
+func NewPrometheusSink(namespace string) *PrometheusSink {
+	return &PrometheusSink{
+		namespace: namespace,
+		series:    make(map[string]*promSeries),
+	}
+}
+
+func (s *PrometheusSink) Send(ctx context.Context, m *Metrics) error {
+	labels := promLabels(m.Labels)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, v := range m.Values {
+		name := s.promName(v.Name, v.Type)
+		key := name + labels
+		series, ok := s.series[key]
+		if !ok {
+			series = &promSeries{name: name, labels: labels, typ: v.Type}
+			s.series[key] = series
+		}
+		if v.Type == COUNTER {
+			series.value += v.Value
+			continue
+		}
+		series.value = v.Value
+	}
+	return nil
+}
+
+// ServeHTTP writes every series in the Prometheus text exposition format.
+func (s *PrometheusSink) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	s.mu.Lock()
+	// Sort by name and then labels, so each name's series are together
+	// under a single TYPE line.
+	all := slices.SortedFunc(maps.Values(s.series), func(a, b *promSeries) int {
+		return cmp.Or(strings.Compare(a.name, b.name), strings.Compare(a.labels, b.labels))
+	})
+	var b strings.Builder
+	lastName := ""
+	for _, series := range all {
+		if series.name != lastName {
+			fmt.Fprintf(&b, "# TYPE %s %s\n", series.name, promType(series.typ))
+			lastName = series.name
+		}
+		fmt.Fprintf(&b, "%s%s %s\n", series.name, series.labels, strconv.FormatFloat(series.value, 'g', -1, 64))
+	}
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = w.Write([]byte(b.String()))
+}
+
+// promName returns the exposed name of a metric: namespaced, restricted to
+// the characters Prometheus allows, and with a _total suffix for counters.
+func (s *PrometheusSink) promName(name string, typ byte) string {
+	if s.namespace != "" {
+		name = s.namespace + "_" + name
+	}
+	name = promSanitize(name)
+	if typ == COUNTER && !strings.HasSuffix(name, "_total") {
+		name += "_total"
+	}
+	return name
+}
+
+func promType(typ byte) string {
+	switch typ {
+	case COUNTER:
+		return "counter"
+	case GAUGE:
+		return "gauge"
+	default:
+		return "untyped"
+	}
+}
+
+// promLabels renders labels sorted by name, so the same labels always
+// produce the same series.
+func promLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, k := range slices.Sorted(maps.Keys(labels)) {
+		pairs = append(pairs, promSanitize(k)+`="`+promEscaper.Replace(labels[k])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var promEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// promSanitize replaces characters that are not allowed in metric and label
+// names with underscores.
+func promSanitize(name string) string {
+	var b strings.Builder
+	for i, r := range name {
+		switch {
+		case r == '_', r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			r = '_'
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
@@ -0,0 +1,68 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, sink *PrometheusSink) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestPrometheusSink(t *testing.T) {
+	sink := NewPrometheusSink("spirit")
+	require.Empty(t, scrape(t, sink))
+
+	// Two migrations share the sink, told apart by their labels.
+	t1 := map[string]string{"schema": "test", "table": "t1"}
+	t2 := map[string]string{"table": "t2", "schema": "test"}
+	for range 2 {
+		require.NoError(t, sink.Send(t.Context(), &Metrics{
+			Labels: t1,
+			Values: []MetricValue{
+				{Name: ChunkLogicalRowsCountMetricName, Type: COUNTER, Value: 1000},
+				{Name: DeltaLenMetricName, Type: GAUGE, Value: 5},
+			},
+		}))
+	}
+	require.NoError(t, sink.Send(t.Context(), &Metrics{
+		Labels: t2,
+		Values: []MetricValue{
+			{Name: ChunkLogicalRowsCountMetricName, Type: COUNTER, Value: 10},
+			{Name: DeltaLenMetricName, Type: GAUGE, Value: 1.5},
+		},
+	}))
+	require.NoError(t, sink.Send(t.Context(), &Metrics{
+		Values: []MetricValue{
+			{Name: "odd-name", Type: UNKNOWN, Value: 1},
+			{Name: DeltaLenMetricName + "_max", Type: GAUGE, Value: 7},
+		},
+	}))
+
+	require.Equal(t, `# TYPE spirit_chunk_num_logical_rows_total counter
+spirit_chunk_num_logical_rows_total{schema="test",table="t1"} 2000
+spirit_chunk_num_logical_rows_total{schema="test",table="t2"} 10
+# TYPE spirit_delta_len gauge
+spirit_delta_len{schema="test",table="t1"} 5
+spirit_delta_len{schema="test",table="t2"} 1.5
+# TYPE spirit_delta_len_max gauge
+spirit_delta_len_max 7
+# TYPE spirit_odd_name untyped
+spirit_odd_name 1
+`, scrape(t, sink))
+}
+
+func TestPrometheusLabels(t *testing.T) {
+	require.Empty(t, promLabels(nil))
+	require.Equal(t, `{a="x\"y\\z\n",b_c="1"}`, promLabels(map[string]string{"b-c": "1", "a": "x\"y\\z\n"}))
+	require.Equal(t, "_lives", promSanitize("9lives"))
+}
@@ -189,7 +189,7 @@ func TestE2EBinlogSubscribingCompositeKey(t *testing.T) {
 	require.Equal(t, 1, m.replClient.GetDeltaLen())
 
 	testutils.RunSQL(t, `delete from e2et1 where id1 = 1`)
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(1))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{1}))
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
 	// id1=1 is below high watermark (1 < 1001), so it's kept
 	require.Equal(t, 2, m.replClient.GetDeltaLen())
@@ -197,7 +197,7 @@ func TestE2EBinlogSubscribingCompositeKey(t *testing.T) {
 	// Some data is inserted later, even though the last chunk is done.
 	// We still care to pick it up because it could be inserted during checkpoint.
 	testutils.RunSQL(t, `insert into e2et1 (id1, id2) values (5000, 1)`)
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(int64(math.MaxInt64)))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{int64(math.MaxInt64)}))
 
 	// Now that copy rows is done, we flush the changeset until trivial.
 	// and perform the optional checksum.
@@ -749,7 +749,7 @@ func TestE2EBinlogSubscribingNonCompositeKey(t *testing.T) {
 	// This will be ignored by the binlog subscription.
 	// Because it's ahead of the high watermark.
 	testutils.RunSQL(t, `insert into e2et2 (id) values (4)`)
-	require.True(t, m.changes[0].chunker.KeyAboveHighWatermark(4))
+	require.True(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{4}))
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
 	require.Equal(t, 0, m.replClient.GetDeltaLen())
 
@@ -765,7 +765,7 @@ func TestE2EBinlogSubscribingNonCompositeKey(t *testing.T) {
 	// but until we copy the chunk it is *not* below the low watermark
 	// and can't be flushed.
 	testutils.RunSQL(t, `insert into e2et2 (id) values (5)`)
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(5))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{5}))
 	require.False(t, m.changes[0].chunker.KeyBelowLowWatermark(5))
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
 	require.Equal(t, 1, m.replClient.GetDeltaLen())
@@ -782,7 +782,7 @@ func TestE2EBinlogSubscribingNonCompositeKey(t *testing.T) {
 
 	// delete some data.
 	testutils.RunSQL(t, `delete from e2et2 where id = 1`)
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(1))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{1}))
 	require.True(t, m.changes[0].chunker.KeyBelowLowWatermark(1))
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
 	require.Equal(t, 1, m.replClient.GetDeltaLen())
@@ -804,7 +804,7 @@ func TestE2EBinlogSubscribingNonCompositeKey(t *testing.T) {
 	testutils.RunSQL(t, `insert into e2et2 (id) values (6)`)
 	// the pointer should be at maxint64 for safety. this ensures
 	// that any keyAboveHighWatermark checks return false
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(int64(math.MaxInt64)))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{int64(math.MaxInt64)}))
 
 	// Now that copy rows is done, we flush the changeset until trivial.
 	// and perform the optional checksum.
@@ -966,7 +966,7 @@ func TestE2EBinlogSubscribingRogueValues(t *testing.T) {
 	// This means the binlog event is discarded (not buffered), and the row will
 	// be copied in later chunks or fixed during checksum.
 	testutils.RunSQL(t, `insert into e2erogue values ("zz'z\"z", 2)`)
-	require.True(t, m.changes[0].chunker.KeyAboveHighWatermark("zz'z\"z"))
+	require.True(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{"zz'z\"z"}))
 
 	// Wait for the binlog event to be processed/discarded
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
@@ -982,7 +982,7 @@ func TestE2EBinlogSubscribingRogueValues(t *testing.T) {
 	// Note: "zz'z\"z" was discarded (KeyAboveHighWatermark=true), not buffered,
 	// so delta count is 1 (only this insert), not 2.
 	testutils.RunSQL(t, `insert into e2erogue values (5, 2)`)
-	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark(5))
+	require.False(t, m.changes[0].chunker.KeyAboveHighWatermark([]any{5}))
 	require.NoError(t, m.replClient.BlockWait(t.Context()))
 	require.Equal(t, 1, m.replClient.GetDeltaLen())
 
@@ -3,9 +3,12 @@ package migration
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/block/spirit/pkg/dbconn"
+	"github.com/block/spirit/pkg/dbconn/sqlescape"
 	"github.com/block/spirit/pkg/statement"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/utils"
@@ -28,19 +31,77 @@ type tableChange struct {
 }
 
 func (c *tableChange) createNewTable(ctx context.Context) error {
-	newName := utils.NewTableName(c.table.TableName)
+	newName := c.newTableName()
 	// drop the newName if we've decided to call this func.
-	if err := dbconn.Exec(ctx, c.runner.db, "DROP TABLE IF EXISTS %n", newName); err != nil {
+	if err := c.runner.execDDL(ctx, "DROP TABLE IF EXISTS %n", newName); err != nil {
 		return err
 	}
-	if err := dbconn.Exec(ctx, c.runner.db, "CREATE TABLE %n LIKE %n",
+	if err := c.runner.execDDL(ctx, "CREATE TABLE %n LIKE %n",
 		newName, c.table.TableName); err != nil {
 		return err
 	}
-	c.newTable = table.NewTableInfo(c.runner.db, c.stmt.Schema, newName)
-	if err := c.newTable.SetInfo(ctx); err != nil {
+	if err := c.preserveTableOptions(ctx, newName); err != nil {
 		return err
 	}
+	if opts := c.runner.migration.ArtifactTableOptions; opts != "" {
+		if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+strings.ReplaceAll(opts, "%", "%%"), newName); err != nil {
+			return fmt.Errorf("failed to apply --artifact-table-options to new table: %w", err)
+		}
+	}
+	if err := c.convertCharset(ctx, newName); err != nil {
+		return err
+	}
+	c.newTable = c.runner.newTableInfo(c.stmt.Schema, newName)
+	if err := dbconn.RetryableSetInfo(ctx, c.newTable, c.runner.dbConfig); err != nil {
+		return err
+	}
+	return nil
+}
+
+// preserveTableOptions applies the COMPRESSION and ENCRYPTION options of the
+// original table to the new table. This runs before --artifact-table-options
+// and alterNewTable, so either can still override them.
+func (c *tableChange) preserveTableOptions(ctx context.Context, newName string) error {
+	ct, err := c.runner.getCreateTable(ctx, c.stmt.Schema, c.table.TableName)
+	if err != nil {
+		return err
+	}
+	opts := ct.TableOptions
+	if opts == nil {
+		return nil
+	}
+	if opts.Compression != nil {
+		if err := c.runner.execDDL(ctx, "ALTER TABLE %n COMPRESSION = %?",
+			newName, *opts.Compression); err != nil {
+			return fmt.Errorf("failed to set COMPRESSION on new table: %w", err)
+		}
+	}
+	if opts.Encryption != nil {
+		if err := c.runner.execDDL(ctx, "ALTER TABLE %n ENCRYPTION = %?",
+			newName, *opts.Encryption); err != nil {
+			return fmt.Errorf("failed to set ENCRYPTION on new table: %w", err)
+		}
+	}
+	return nil
+}
+
+// convertCharset converts the empty new table to --new-table-charset (and
+// --new-table-collation), if set. Like preserveTableOptions it runs before
+// alterNewTable, so a character set in the ALTER takes precedence.
+func (c *tableChange) convertCharset(ctx context.Context, newName string) error {
+	m := c.runner.migration
+	if m.NewTableCharset == "" {
+		return nil
+	}
+	stmt := "ALTER TABLE %n CONVERT TO CHARACTER SET %?"
+	args := []any{newName, m.NewTableCharset}
+	if m.NewTableCollation != "" {
+		stmt += " COLLATE %?"
+		args = append(args, m.NewTableCollation)
+	}
+	if err := c.runner.execDDL(ctx, stmt, args...); err != nil {
+		return fmt.Errorf("failed to convert new table to character set %s: %w", m.NewTableCharset, err)
+	}
 	return nil
 }
 
@@ -49,18 +110,18 @@ func (c *tableChange) createNewTable(ctx context.Context) error {
 // We first attempt to do this using ALGORITHM=COPY so we don't burn
 // an INSTANT version. But surprisingly this is not supported for all DDLs (issue #277)
 func (c *tableChange) alterNewTable(ctx context.Context) error {
-	if err := dbconn.Exec(ctx, c.runner.db, "ALTER TABLE %n "+c.stmt.TrimAlter()+", ALGORITHM=COPY",
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+c.stmt.TrimAlter()+", ALGORITHM=COPY",
 		c.newTable.TableName); err != nil {
 		// Retry without the ALGORITHM=COPY. If there is a second error, then the DDL itself
 		// is not supported. It could be a syntax error, in which case we return the second error,
 		// which will probably be easier to read because it is unaltered.
-		if err := dbconn.Exec(ctx, c.runner.db, "ALTER TABLE %n "+c.stmt.Alter, c.newTable.TableName); err != nil {
+		if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+c.stmt.Alter, c.newTable.TableName); err != nil {
 			return err
 		}
 	}
 	// Call GetInfo on the table again, since the columns
 	// might have changed and this will affect the row copiers intersect func.
-	if err := c.newTable.SetInfo(ctx); err != nil {
+	if err := dbconn.RetryableSetInfo(ctx, c.newTable, c.runner.dbConfig); err != nil {
 		return err
 	}
 
@@ -71,6 +132,187 @@ func (c *tableChange) alterNewTable(ctx context.Context) error {
 	return c.preserveAutoIncrement(ctx)
 }
 
+// indexTargetSuffix names the scratch table addDeferredIndexes builds to
+// learn which indexes the ALTER produces.
+const indexTargetSuffix = "_idx"
+
+// deferrableIndexes returns the secondary indexes of ct that can be built
+// after the copy. Only plain INDEX keys qualify. UNIQUE indexes decide which
+// rows the copy keeps, so building them afterwards could fail or change the
+// result. FULLTEXT and SPATIAL indexes cannot be added with LOCK=NONE, which
+// addDeferredIndexes requires.
+func deferrableIndexes(ct *statement.CreateTable) statement.Indexes {
+	var indexes statement.Indexes
+	for _, idx := range ct.Indexes {
+		if idx.Type == "INDEX" {
+			indexes = append(indexes, idx)
+		}
+	}
+	return indexes
+}
+
+// dropDeferrableIndexes drops the plain secondary indexes from the new
+// table, so the copy does not have to maintain them for every row.
+// addDeferredIndexes adds them back once the copy is complete.
+func (c *tableChange) dropDeferrableIndexes(ctx context.Context) error {
+	ct, err := c.runner.getCreateTable(ctx, c.stmt.Schema, c.newTable.TableName)
+	if err != nil {
+		return err
+	}
+	indexes := deferrableIndexes(ct)
+	if len(indexes) == 0 {
+		return nil
+	}
+	clauses := make([]string, 0, len(indexes))
+	for _, idx := range indexes {
+		clauses = append(clauses, "DROP INDEX "+sqlescape.EscapeIdentifier(idx.Name))
+	}
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+strings.Join(clauses, ", "),
+		c.newTable.TableName); err != nil {
+		return fmt.Errorf("failed to drop deferred indexes from new table: %w", err)
+	}
+	c.runner.logger.Info("deferred secondary indexes until after copy",
+		"table", c.table.TableName,
+		"indexes", len(indexes),
+	)
+	return dbconn.RetryableSetInfo(ctx, c.newTable, c.runner.dbConfig)
+}
+
+// addDeferredIndexes adds the secondary indexes the new table is missing
+// compared to the table the ALTER produces. The target is read from an empty
+// scratch table with the ALTER applied, rather than remembered from
+// dropDeferrableIndexes, so a migration resumed from a checkpoint that
+// recorded DeferSecondaryIndexes also finds them. It is a no-op when
+// nothing is missing.
+//
+// The ALTER runs while the replication client is streaming, so the client
+// is told to ignore DDL on the new table until it has read past it.
+func (c *tableChange) addDeferredIndexes(ctx context.Context) error {
+	target, err := c.targetCreateTable(ctx)
+	if err != nil {
+		return err
+	}
+	current, err := c.runner.getCreateTable(ctx, c.stmt.Schema, c.newTable.TableName)
+	if err != nil {
+		return err
+	}
+	var clauses []string
+	for _, idx := range deferrableIndexes(target) {
+		if current.Indexes.ByName(idx.Name) == nil {
+			// The clause is appended to the format string, so escape any %.
+			clauses = append(clauses, strings.ReplaceAll(idx.AddClause(), "%", "%%"))
+		}
+	}
+	if len(clauses) == 0 {
+		return nil
+	}
+	ignorer, ok := c.runner.replClient.(interface {
+		IgnoreDDL(schema, table string) (restore func())
+	})
+	if !ok {
+		return errors.New("the replication client does not support ignoring DDL on the new table, so deferred indexes cannot be added")
+	}
+	restore := ignorer.IgnoreDDL(c.newTable.SchemaName, c.newTable.TableName)
+	defer restore()
+	c.runner.logger.Info("adding deferred secondary indexes",
+		"table", c.table.TableName,
+		"indexes", len(clauses),
+	)
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+strings.Join(clauses, ", ")+", ALGORITHM=INPLACE, LOCK=NONE",
+		c.newTable.TableName); err != nil {
+		return fmt.Errorf("failed to add deferred indexes to new table: %w", err)
+	}
+	// Wait for the client to read past the ALTER before restoring DDL detection.
+	if err := c.runner.replClient.BlockWait(ctx); err != nil {
+		return err
+	}
+	return dbconn.RetryableSetInfo(ctx, c.newTable, c.runner.dbConfig)
+}
+
+// targetCreateTable returns the definition the ALTER produces, by applying
+// it to an empty copy of the original table that is dropped afterwards.
+func (c *tableChange) targetCreateTable(ctx context.Context) (*statement.CreateTable, error) {
+	name, drop, err := c.createScratchTable(ctx, indexTargetSuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer drop()
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+c.stmt.Alter, name); err != nil {
+		return nil, err
+	}
+	return c.runner.getCreateTable(ctx, c.stmt.Schema, name)
+}
+
+// validateAlterSuffix names the scratch table validateAlter applies the
+// ALTER to.
+const validateAlterSuffix = "_val"
+
+// validateAlter applies the ALTER to an empty copy of the table, converted
+// to --new-table-charset like the new table, and drops it again. An ALTER
+// that MySQL rejects, such as one with an unknown column type or a
+// duplicate index name, then fails in seconds, before any new table or
+// checkpoint is created and long before the copy.
+func (c *tableChange) validateAlter(ctx context.Context) error {
+	name, drop, err := c.createScratchTable(ctx, validateAlterSuffix)
+	if err != nil {
+		return err
+	}
+	defer drop()
+	if err := c.convertCharset(ctx, name); err != nil {
+		return err
+	}
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+c.stmt.Alter, name); err != nil {
+		return fmt.Errorf("the ALTER cannot be applied to table %s: %w", c.stmt.Table, err)
+	}
+	return nil
+}
+
+// scratchTableSuffixes are the suffixes of every scratch table
+// createScratchTable may be asked to create.
+var scratchTableSuffixes = []string{validateAlterSuffix, indexTargetSuffix, dryRunSuffix}
+
+// scratchTableNames returns the names of the scratch tables this change may
+// create.
+func (c *tableChange) scratchTableNames() []string {
+	names := make([]string, 0, len(scratchTableSuffixes))
+	for _, suffix := range scratchTableSuffixes {
+		names = append(names, c.auxTableName(suffix))
+	}
+	return names
+}
+
+// createScratchTable creates an empty copy of the table, named with suffix,
+// on which the ALTER can be tried without touching the table itself. A table
+// already using the name is dropped first, unless OnExistingArtifacts is
+// ArtifactPolicyFail, in which case ErrExistingArtifacts is returned. The
+// returned func drops it again, even if ctx has been cancelled, and only
+// logs a failure to do so.
+func (c *tableChange) createScratchTable(ctx context.Context, suffix string) (string, func(), error) {
+	name := c.auxTableName(suffix)
+	if c.runner.migration.OnExistingArtifacts == ArtifactPolicyFail {
+		exists, err := c.tableExists(ctx, name)
+		if err != nil {
+			return "", nil, err
+		}
+		if exists {
+			return "", nil, fmt.Errorf("%w: %s; drop it or use --on-existing-artifacts=%s",
+				ErrExistingArtifacts, name, ArtifactPolicyDropAndRecreate)
+		}
+	} else if err := c.runner.execDDL(ctx, "DROP TABLE IF EXISTS %n", name); err != nil {
+		return "", nil, err
+	}
+	// CREATE TABLE is atomic, so if it fails there is nothing to drop.
+	if err := c.runner.execDDL(ctx, "CREATE TABLE %n LIKE %n", name, c.table.TableName); err != nil {
+		return "", nil, err
+	}
+	drop := func() {
+		if err := c.runner.execDDL(context.WithoutCancel(ctx), "DROP TABLE IF EXISTS %n", name); err != nil {
+			c.runner.logger.Warn("could not drop scratch table", "table", name, "error", err)
+		}
+	}
+	return name, drop, nil
+}
+
 func (c *tableChange) preserveAutoIncrement(ctx context.Context) error {
 	// Get AUTO_INCREMENT from the original table.
 	var originalAutoInc sql.NullInt64
@@ -102,7 +344,7 @@ func (c *tableChange) preserveAutoIncrement(ctx context.Context) error {
 		return nil
 	}
 
-	if err := dbconn.Exec(ctx, c.runner.db, "ALTER TABLE %n AUTO_INCREMENT = %?",
+	if err := c.runner.execDDL(ctx, "ALTER TABLE %n AUTO_INCREMENT = %?",
 		c.newTable.TableName, originalAutoInc.Int64); err != nil {
 		return fmt.Errorf("failed to set AUTO_INCREMENT on new table: %w", err)
 	}
@@ -113,50 +355,101 @@ func (c *tableChange) preserveAutoIncrement(ctx context.Context) error {
 }
 
 func (c *tableChange) dropOldTable(ctx context.Context) error {
-	return dbconn.Exec(ctx, c.runner.db, "DROP TABLE IF EXISTS %n", c.oldTableName())
+	return c.runner.execDDL(ctx, "DROP TABLE IF EXISTS %n", c.oldTableName())
+}
+
+// newTableName returns the name of the shadow table rows are copied into:
+// Migration.NewTableName if set, otherwise _<table>_new. Like oldTableName it
+// only depends on the statement, so it is valid before the table has been
+// introspected, and a resume finds the same table.
+func (c *tableChange) newTableName() string {
+	if c.runner.migration.NewTableName != "" {
+		return c.runner.migration.NewTableName
+	}
+	return c.auxTableName("_new")
+}
+
+// auxTableName returns the name of the auxiliary table of this change with
+// suffix, _<table><suffix>. With a LockNamespace the namespace goes before
+// the suffix, so that runs in different namespaces, which don't exclude each
+// other, don't share auxiliary tables either.
+func (c *tableChange) auxTableName(suffix string) string {
+	if namespace := c.runner.migration.LockNamespace; namespace != "" {
+		suffix = "_" + namespace + suffix
+	}
+	return utils.AuxTableName(c.stmt.Table, suffix)
+}
+
+// newTableExists reports whether the new table already exists in the
+// migrated schema.
+func (c *tableChange) newTableExists(ctx context.Context) (bool, error) {
+	return c.tableExists(ctx, c.newTableName())
+}
+
+// tableExists reports whether a table named name exists in the migrated
+// schema.
+func (c *tableChange) tableExists(ctx context.Context, name string) (bool, error) {
+	var one int
+	err := c.runner.db.QueryRowContext(ctx,
+		"SELECT 1 FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+		name).Scan(&one)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
 }
 
 func (c *tableChange) oldTableName() string {
+	if c.runner.migration.OldTableName != "" {
+		return c.runner.migration.OldTableName
+	}
 	if !c.runner.migration.SkipDropAfterCutover {
-		return utils.OldTableName(c.table.TableName)
+		return c.auxTableName("_old")
 	}
 	timestamp := c.runner.startTime.UTC().Format(utils.NameFormatTimestamp)
-	return utils.OldTableNameWithTimestamp(c.table.TableName, timestamp)
+	return c.auxTableName("_old_" + timestamp)
 }
 
 func (c *tableChange) attemptInstantDDL(ctx context.Context) error {
+	stmt := "ALTER TABLE %n ALGORITHM=INSTANT, " + c.stmt.Alter
 	if !c.runner.migration.SkipForceKill {
+		c.runner.recordDDL(stmt, c.table.TableName)
 		return dbconn.ForceExec(
 			ctx,
 			c.runner.db,
 			[]*table.TableInfo{c.table},
 			c.runner.dbConfig,
 			c.runner.logger,
-			"ALTER TABLE %n ALGORITHM=INSTANT, "+c.stmt.Alter,
+			stmt,
 			c.table.TableName,
 		)
 	}
-	return dbconn.Exec(ctx, c.runner.db, "ALTER TABLE %n ALGORITHM=INSTANT, "+c.stmt.Alter, c.table.TableName)
+	return c.runner.execDDL(ctx, stmt, c.table.TableName)
 }
 
 func (c *tableChange) attemptInplaceDDL(ctx context.Context) error {
+	stmt := "ALTER TABLE %n ALGORITHM=INPLACE, LOCK=NONE, " + c.stmt.Alter
 	if !c.runner.migration.SkipForceKill {
+		c.runner.recordDDL(stmt, c.table.TableName)
 		return dbconn.ForceExec(
 			ctx,
 			c.runner.db,
 			[]*table.TableInfo{c.table},
 			c.runner.dbConfig,
 			c.runner.logger,
-			"ALTER TABLE %n ALGORITHM=INPLACE, LOCK=NONE, "+c.stmt.Alter,
+			stmt,
 			c.table.TableName,
 		)
 	}
-	return dbconn.Exec(ctx, c.runner.db, "ALTER TABLE %n ALGORITHM=INPLACE, LOCK=NONE, "+c.stmt.Alter, c.table.TableName)
+	return c.runner.execDDL(ctx, stmt, c.table.TableName)
 }
 
 func (c *tableChange) cleanup(ctx context.Context) error {
 	if c.newTable != nil {
-		if err := dbconn.Exec(ctx, c.runner.db, "DROP TABLE IF EXISTS %n", c.newTable.TableName); err != nil {
+		if err := c.runner.execDDL(ctx, "DROP TABLE IF EXISTS %n", c.newTable.TableName); err != nil {
 			return err
 		}
 	}
@@ -169,10 +462,11 @@ func (c *tableChange) cleanup(ctx context.Context) error {
 // an error. It is important to let MySQL decide if it can handle the DDL
 // operation, because keeping track of which operations are "INSTANT"
 // is incredibly difficult. It will depend on MySQL minor version,
-// and could possibly be specific to the table.
+// and could possibly be specific to the table. The error returned joins the
+// reason INSTANT was refused with the reason INPLACE was.
 func (c *tableChange) attemptMySQLDDL(ctx context.Context) error {
-	err := c.attemptInstantDDL(ctx)
-	if err == nil {
+	instantErr := c.attemptInstantDDL(ctx)
+	if instantErr == nil {
 		c.runner.usedInstantDDL = true // success
 		return nil
 	}
@@ -184,7 +478,7 @@ func (c *tableChange) attemptMySQLDDL(ctx context.Context) error {
 	//
 	// Spirit automatically detects safe operations that can use
 	// the INPLACE algorithm without blocking read replicas.
-	err = c.stmt.AlgorithmInplaceConsideredSafe()
+	err := c.stmt.AlgorithmInplaceConsideredSafe()
 	if err == nil {
 		err = c.attemptInplaceDDL(ctx)
 		if err == nil {
@@ -192,12 +486,10 @@ func (c *tableChange) attemptMySQLDDL(ctx context.Context) error {
 			return nil
 		}
 	}
-	c.runner.logger.Info("unable to use INPLACE", "error", err)
-
-	// Failure is expected, since MySQL DDL only applies in limited scenarios
-	// Return the error, which will be ignored by the caller.
-	// Proceed with regular copy algorithm.
-	return err
+	// Failure is expected, since MySQL DDL only applies in limited scenarios.
+	// Return the errors, which the caller reports before proceeding
+	// with the regular copy algorithm.
+	return errors.Join(instantErr, err)
 }
 
 func (c *tableChange) Close() error {
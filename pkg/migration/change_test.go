@@ -7,6 +7,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/block/spirit/pkg/statement"
+	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/testutils"
 	"github.com/block/spirit/pkg/utils"
@@ -269,6 +271,168 @@ func TestModifyAddAutoIncrementPreservesZeroPK(t *testing.T) {
 	require.Equal(t, []int64{0, 1, 2}, ids, "row with gid=0 must survive the MODIFY ... AUTO_INCREMENT migration")
 }
 
+// TestPreserveCompressionEncryption tests that the COMPRESSION and ENCRYPTION
+// table options of the original table are applied to the new table.
+func TestPreserveCompressionEncryption(t *testing.T) {
+	t.Parallel()
+
+	testDB, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(testDB)
+
+	tests := []struct {
+		name      string
+		tableName string
+		option    string
+	}{
+		{name: "compression", tableName: "test_preserve_compression", option: "COMPRESSION='zlib'"},
+		{name: "encryption", tableName: "test_preserve_encryption", option: "ENCRYPTION='Y'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tt.tableName, tt.tableName, tt.tableName))
+			t.Cleanup(func() {
+				testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tt.tableName, tt.tableName, tt.tableName))
+			})
+			// ENCRYPTION requires a keyring component, which not every test server has.
+			if _, err := testDB.ExecContext(t.Context(), fmt.Sprintf(
+				`CREATE TABLE %s (id INT NOT NULL PRIMARY KEY, b INT) ENGINE=InnoDB %s`, tt.tableName, tt.option)); err != nil {
+				t.Skipf("server does not support %s: %v", tt.option, err)
+			}
+
+			r := NewTestRunner(t, tt.tableName, "ADD COLUMN c INT")
+			defer utils.CloseAndLog(r)
+			require.NoError(t, r.Run(t.Context()))
+
+			ct, err := r.getCreateTable(t.Context(), r.migration.Database, tt.tableName)
+			require.NoError(t, err)
+			require.NotNil(t, ct.TableOptions)
+			switch tt.name {
+			case "compression":
+				require.NotNil(t, ct.TableOptions.Compression)
+				require.Equal(t, "zlib", *ct.TableOptions.Compression)
+			case "encryption":
+				require.NotNil(t, ct.TableOptions.Encryption)
+				require.Equal(t, "Y", *ct.TableOptions.Encryption)
+			}
+		})
+	}
+}
+
+// TestDeferSecondaryIndexes tests that with DeferSecondaryIndexes the new
+// table is copied without its non-unique secondary indexes, and that they
+// (including the one added by the ALTER) exist after cutover.
+func TestDeferSecondaryIndexes(t *testing.T) {
+	t.Parallel()
+
+	tableName := "test_defer_indexes"
+	testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tableName, tableName, tableName))
+	t.Cleanup(func() {
+		testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tableName, tableName, tableName))
+	})
+	testutils.RunSQL(t, fmt.Sprintf(`
+		CREATE TABLE %s (
+			id INT NOT NULL AUTO_INCREMENT,
+			a INT NOT NULL,
+			b VARCHAR(255) NOT NULL,
+			PRIMARY KEY (id),
+			UNIQUE KEY uk_b (b),
+			KEY idx_a (a)
+		) ENGINE=InnoDB`, tableName))
+	testutils.RunSQL(t, fmt.Sprintf(`INSERT INTO %s (a, b) SELECT n, CONCAT('row', n) FROM (
+		SELECT a.N + b.N * 10 + c.N * 100 AS n FROM
+		(SELECT 0 AS N UNION SELECT 1 UNION SELECT 2 UNION SELECT 3 UNION SELECT 4 UNION SELECT 5 UNION SELECT 6 UNION SELECT 7 UNION SELECT 8 UNION SELECT 9) a,
+		(SELECT 0 AS N UNION SELECT 1 UNION SELECT 2 UNION SELECT 3 UNION SELECT 4 UNION SELECT 5 UNION SELECT 6 UNION SELECT 7 UNION SELECT 8 UNION SELECT 9) b,
+		(SELECT 0 AS N UNION SELECT 1 UNION SELECT 2 UNION SELECT 3 UNION SELECT 4 UNION SELECT 5 UNION SELECT 6 UNION SELECT 7 UNION SELECT 8 UNION SELECT 9) c
+	) numbers`, tableName))
+
+	testDB, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(testDB)
+	var checksumBefore int64
+	require.NoError(t, testDB.QueryRowContext(t.Context(),
+		fmt.Sprintf("SELECT SUM(CRC32(CONCAT(id, a, b))) FROM %s", tableName)).Scan(&checksumBefore))
+
+	// While copying, the new table has no non-unique secondary indexes.
+	r := NewTestRunner(t, tableName, "ADD COLUMN c INT, ADD INDEX idx_ab (a, b)",
+		WithDeferSecondaryIndexes(), WithThreads(1), WithTestThrottler())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- r.Run(t.Context())
+	}()
+	waitForStatus(t, r, status.CopyRows)
+	ct, err := r.getCreateTable(t.Context(), r.migration.Database, "_"+tableName+"_new")
+	require.NoError(t, err)
+	require.NotNil(t, ct.Indexes.ByName("uk_b"), "UNIQUE indexes are never deferred")
+	require.Nil(t, ct.Indexes.ByName("idx_a"))
+	require.Nil(t, ct.Indexes.ByName("idx_ab"))
+	r.Cancel()
+	<-runErr
+	require.NoError(t, r.Close())
+
+	// A full run adds them back after the copy.
+	r = NewTestRunner(t, tableName, "ADD COLUMN c INT, ADD INDEX idx_ab (a, b)", WithDeferSecondaryIndexes())
+	defer utils.CloseAndLog(r)
+	require.NoError(t, r.Run(t.Context()))
+
+	ct, err = r.getCreateTable(t.Context(), r.migration.Database, tableName)
+	require.NoError(t, err)
+	for _, name := range []string{"uk_b", "idx_a", "idx_ab"} {
+		require.NotNil(t, ct.Indexes.ByName(name), "index %s should exist after cutover", name)
+	}
+	var checksumAfter int64
+	require.NoError(t, testDB.QueryRowContext(t.Context(),
+		fmt.Sprintf("SELECT SUM(CRC32(CONCAT(id, a, b))) FROM %s", tableName)).Scan(&checksumAfter))
+	require.Equal(t, checksumBefore, checksumAfter)
+}
+
+// TestDeferSecondaryIndexesFulltext tests that a FULLTEXT index is kept on
+// the new table during the copy, since it cannot be added back with
+// LOCK=NONE, while the plain index next to it is still deferred.
+func TestDeferSecondaryIndexesFulltext(t *testing.T) {
+	t.Parallel()
+
+	tableName := "test_defer_fulltext"
+	testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tableName, tableName, tableName))
+	t.Cleanup(func() {
+		testutils.RunSQL(t, fmt.Sprintf(`DROP TABLE IF EXISTS %s, _%s_new, _%s_old`, tableName, tableName, tableName))
+	})
+	testutils.RunSQL(t, fmt.Sprintf(`
+		CREATE TABLE %s (
+			id INT NOT NULL AUTO_INCREMENT,
+			a INT NOT NULL,
+			body TEXT NOT NULL,
+			PRIMARY KEY (id),
+			KEY idx_a (a),
+			FULLTEXT KEY ft_body (body)
+		) ENGINE=InnoDB`, tableName))
+	testutils.RunSQL(t, fmt.Sprintf(`INSERT INTO %s (a, body) VALUES (1, 'one'), (2, 'two'), (3, 'three')`, tableName))
+
+	r := NewTestRunner(t, tableName, "ADD COLUMN c INT", WithDeferSecondaryIndexes(), WithThreads(1), WithTestThrottler())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- r.Run(t.Context())
+	}()
+	waitForStatus(t, r, status.CopyRows)
+	ct, err := r.getCreateTable(t.Context(), r.migration.Database, "_"+tableName+"_new")
+	require.NoError(t, err)
+	require.NotNil(t, ct.Indexes.ByName("ft_body"), "FULLTEXT indexes are never deferred")
+	require.Nil(t, ct.Indexes.ByName("idx_a"))
+	r.Cancel()
+	<-runErr
+	require.NoError(t, r.Close())
+
+	r = NewTestRunner(t, tableName, "ADD COLUMN c INT", WithDeferSecondaryIndexes())
+	defer utils.CloseAndLog(r)
+	require.NoError(t, r.Run(t.Context()))
+
+	ct, err = r.getCreateTable(t.Context(), r.migration.Database, tableName)
+	require.NoError(t, err)
+	for _, name := range []string{"idx_a", "ft_body"} {
+		require.NotNil(t, ct.Indexes.ByName(name), "index %s should exist after cutover", name)
+	}
+}
+
 func TestOldTableNameTruncation(t *testing.T) {
 	t.Parallel()
 	startTime := time.Date(2025, 6, 15, 10, 30, 45, 0, time.UTC)
@@ -320,6 +484,7 @@ func TestOldTableNameTruncation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &tableChange{
+				stmt: &statement.AbstractStatement{Table: tt.tableName},
 				table: &table.TableInfo{
 					TableName: tt.tableName,
 				},
@@ -368,10 +533,12 @@ func TestOldTableNameTruncationCollision(t *testing.T) {
 	prefix := strings.Repeat("x", 50)
 
 	c1 := &tableChange{
+		stmt:   &statement.AbstractStatement{Table: prefix + "_aaaaaaaaaaa"},
 		table:  &table.TableInfo{TableName: prefix + "_aaaaaaaaaaa"},
 		runner: &Runner{migration: &Migration{SkipDropAfterCutover: true}, startTime: startTime},
 	}
 	c2 := &tableChange{
+		stmt:   &statement.AbstractStatement{Table: prefix + "_bbbbbbbbbbb"},
 		table:  &table.TableInfo{TableName: prefix + "_bbbbbbbbbbb"},
 		runner: &Runner{migration: &Migration{SkipDropAfterCutover: true}, startTime: startTime},
 	}
@@ -47,14 +47,27 @@ type Resources struct {
 	Statement            *statement.AbstractStatement
 	TargetChunkTime      time.Duration
 	Threads              int
+	ChecksumThreads      int // 0 means the checksum uses Threads
 	ReplicaMaxLag        time.Duration
 	SkipDropAfterCutover bool
 	ForceKill            bool
+	// AllowTriggers turns the preflight failure for a table with triggers
+	// into a warning.
+	AllowTriggers bool
+	// CopyTriggers allows a table with triggers because the cutover
+	// recreates them on the new table.
+	CopyTriggers bool
+	// ChunkKey is the index the table is chunked on, when it is not the
+	// PRIMARY KEY.
+	ChunkKey string
 	// The following resources are only used by the
 	// pre-run checks
-	Host               string
-	Username           string
-	Password           string
+	Host     string
+	Username string
+	Password string
+	// CredentialProvider, if set, refreshes Username and Password before
+	// each connection (see dbconn.DBConfig.CredentialProvider).
+	CredentialProvider func() (user, password string, err error)
 	TLSMode            string
 	TLSCertificatePath string
 	// GTID, when true, opts the migration into the experimental GTID-based
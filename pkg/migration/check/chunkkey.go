@@ -0,0 +1,113 @@
+package check
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/block/spirit/pkg/utils"
+)
+
+func init() {
+	registerCheck("chunkkey", chunkKeyCheck, ScopePreflight)
+}
+
+// chunkKeyCheck verifies that the key the table is chunked on identifies
+// every row exactly once: it must be the columns of the PRIMARY KEY, or of a
+// UNIQUE index whose columns are all NOT NULL. The copier and checksum walk
+// the table in key ranges, so duplicate key values can be copied twice or
+// skipped at a chunk boundary, and a UNIQUE index still admits any number of
+// rows whose key is NULL, which no key range selects.
+//
+// When the migration names an index to chunk on (Resources.ChunkKey), that
+// index must exist and be UNIQUE with all of its columns NOT NULL. An index
+// with a functional key part is never usable: there is no column to chunk
+// on for it.
+func chunkKeyCheck(ctx context.Context, r Resources, logger *slog.Logger) error {
+	if len(r.Table.KeyColumns) == 0 {
+		return nil // no key yet; setting the table info refuses a table without a primary key
+	}
+	rows, err := r.DB.QueryContext(ctx, `SELECT index_name, column_name, non_unique, nullable
+	FROM information_schema.statistics WHERE table_schema=? AND table_name=?
+	ORDER BY index_name, seq_in_index`, r.Table.SchemaName, r.Table.TableName)
+	if err != nil {
+		return err
+	}
+	defer utils.CloseAndLog(rows)
+	type uniqueKey struct {
+		columns    []string
+		usable     bool // unique, with no nullable columns or expressions
+		unique     bool
+		nullable   bool
+		expression bool
+	}
+	var names []string
+	keys := make(map[string]*uniqueKey)
+	for rows.Next() {
+		var name, nullable string
+		var column sql.NullString // NULL for a functional key part
+		var nonUnique bool
+		if err := rows.Scan(&name, &column, &nonUnique, &nullable); err != nil {
+			return err
+		}
+		key, ok := keys[name]
+		if !ok {
+			key = &uniqueKey{usable: !nonUnique, unique: !nonUnique}
+			keys[name] = key
+			names = append(names, name)
+		}
+		if !column.Valid {
+			key.usable = false
+			key.expression = true
+			continue
+		}
+		key.columns = append(key.columns, strings.ToLower(column.String))
+		if nullable == "YES" {
+			key.usable = false
+			key.nullable = true
+		}
+	}
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+	if r.ChunkKey != "" {
+		i := slices.IndexFunc(names, func(name string) bool { return strings.EqualFold(name, r.ChunkKey) })
+		var reason string
+		switch {
+		case i < 0:
+			reason = "there is no such index"
+		case !keys[names[i]].unique:
+			reason = "the index is not UNIQUE"
+		case keys[names[i]].expression:
+			reason = "the index has a functional key part"
+		case keys[names[i]].nullable:
+			reason = "the index allows NULL, and rows with a NULL key are not unique"
+		default:
+			return nil
+		}
+		return fmt.Errorf("table %s.%s cannot be chunked on index %s: %s. Choose a UNIQUE index whose columns are all NOT NULL, or the PRIMARY KEY",
+			r.Table.SchemaName, r.Table.TableName, r.ChunkKey, reason)
+	}
+	want := make([]string, 0, len(r.Table.KeyColumns))
+	for _, col := range r.Table.KeyColumns {
+		want = append(want, strings.ToLower(col))
+	}
+	reason := "no PRIMARY KEY or UNIQUE index covers exactly these columns"
+	for _, name := range names {
+		key := keys[name]
+		if key.expression || !slices.Equal(key.columns, want) {
+			continue
+		}
+		if key.usable {
+			return nil
+		}
+		if key.nullable {
+			reason = fmt.Sprintf("index %s allows NULL, and rows with a NULL key are not unique", name)
+		}
+	}
+	return fmt.Errorf("table %s.%s cannot be chunked on (%s): %s. Add a PRIMARY KEY, or make the columns of a UNIQUE index NOT NULL and promote it to the PRIMARY KEY",
+		r.Table.SchemaName, r.Table.TableName, strings.Join(r.Table.KeyColumns, ", "), reason)
+}
@@ -0,0 +1,61 @@
+package check
+
+import (
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkKey(t *testing.T) {
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	testutils.RunSQL(t, `DROP TABLE IF EXISTS chunkkey_t1`)
+	testutils.RunSQL(t, `CREATE TABLE chunkkey_t1 (
+		id INT NOT NULL PRIMARY KEY,
+		email VARCHAR(100) NULL,
+		code INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		UNIQUE KEY (email),
+		UNIQUE KEY (code),
+		KEY (name),
+		UNIQUE KEY lower_name ((LOWER(name)))
+	)`)
+	t.Cleanup(func() {
+		testutils.RunSQL(t, `DROP TABLE IF EXISTS chunkkey_t1`)
+	})
+	r := Resources{DB: db}
+	chunkOn := func(cols ...string) error {
+		r.Table = &table.TableInfo{SchemaName: "test", TableName: "chunkkey_t1", KeyColumns: cols}
+		return chunkKeyCheck(t.Context(), r, slog.Default())
+	}
+
+	require.NoError(t, chunkOn("id"))   // the primary key
+	require.NoError(t, chunkOn("code")) // unique and NOT NULL
+
+	err = chunkOn("email") // unique, but NULLs are not unique
+	require.ErrorContains(t, err, "index email allows NULL")
+	require.ErrorContains(t, err, "Add a PRIMARY KEY")
+
+	require.ErrorContains(t, chunkOn("name"), "no PRIMARY KEY or UNIQUE index covers exactly these columns")
+	require.ErrorContains(t, chunkOn("id", "code"), "no PRIMARY KEY or UNIQUE index covers exactly these columns")
+
+	r.ChunkKey = "code" // a named index replaces the key columns
+	require.NoError(t, chunkOn("id"))
+	r.ChunkKey = "PRIMARY"
+	require.NoError(t, chunkOn("id"))
+	r.ChunkKey = "email"
+	require.ErrorContains(t, chunkOn("id"), "cannot be chunked on index email: the index allows NULL")
+	r.ChunkKey = "name"
+	require.ErrorContains(t, chunkOn("id"), "cannot be chunked on index name: the index is not UNIQUE")
+	r.ChunkKey = "lower_name"
+	require.ErrorContains(t, chunkOn("id"), "cannot be chunked on index lower_name: the index has a functional key part")
+	r.ChunkKey = "missing"
+	require.ErrorContains(t, chunkOn("id"), "cannot be chunked on index missing: there is no such index")
+}
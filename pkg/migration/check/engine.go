@@ -0,0 +1,32 @@
+package check
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+)
+
+func init() {
+	registerCheck("engine", engineCheck, ScopePreflight)
+}
+
+// engineCheck reads the engine of the live table and refuses anything but
+// InnoDB. Spirit copies rows while replaying binlog changes, which relies on
+// InnoDB's row locking and consistent transactions: on MyISAM and similar
+// engines the copy and the replay cannot be kept consistent. The lint
+// non_innodb_engine checks the declared engine; this check catches a live
+// table whose engine differs from its CREATE file.
+func engineCheck(ctx context.Context, r Resources, logger *slog.Logger) error {
+	var engine string
+	err := r.DB.QueryRowContext(ctx, "SELECT IFNULL(ENGINE, '') FROM information_schema.TABLES WHERE TABLE_SCHEMA = ? AND TABLE_NAME = ?",
+		r.Table.SchemaName, r.Table.TableName).Scan(&engine)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(engine, "innodb") {
+		return fmt.Errorf("table %s.%s uses the %s engine; only InnoDB tables can be migrated online, since the copy and binlog replay rely on its transactions and row locking",
+			r.Table.SchemaName, r.Table.TableName, engine)
+	}
+	return nil
+}
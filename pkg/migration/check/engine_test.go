@@ -0,0 +1,38 @@
+package check
+
+import (
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/block/spirit/pkg/statement"
+	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEngine(t *testing.T) {
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	testutils.RunSQL(t, `DROP TABLE IF EXISTS engine_myisam, engine_innodb`)
+	testutils.RunSQL(t, `CREATE TABLE engine_myisam (id INT NOT NULL PRIMARY KEY) ENGINE=MyISAM`)
+	testutils.RunSQL(t, `CREATE TABLE engine_innodb (id INT NOT NULL PRIMARY KEY) ENGINE=InnoDB`)
+	t.Cleanup(func() {
+		testutils.RunSQL(t, `DROP TABLE IF EXISTS engine_myisam, engine_innodb`)
+	})
+
+	r := Resources{
+		DB:        db,
+		Table:     &table.TableInfo{SchemaName: "test", TableName: "engine_myisam"},
+		Statement: statement.MustNew("ALTER TABLE engine_myisam ADD COLUMN b INT")[0],
+	}
+	err = engineCheck(t.Context(), r, slog.Default())
+	require.ErrorContains(t, err, "MyISAM engine")
+
+	r.Table = &table.TableInfo{SchemaName: "test", TableName: "engine_innodb"}
+	r.Statement = statement.MustNew("ALTER TABLE engine_innodb ADD COLUMN b INT")[0]
+	require.NoError(t, engineCheck(t.Context(), r, slog.Default()))
+}
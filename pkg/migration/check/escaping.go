@@ -0,0 +1,64 @@
+package check
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+func init() {
+	registerCheck("escaping", escapingCheck, ScopePreflight)
+}
+
+// escapingCheck warns when the session would misread the string literals
+// spirit writes into its statements. Chunk boundaries, and the key values
+// of rows the applier deletes or checksums, are rendered client-side as
+// double-quoted strings with backslash escapes, whether or not the driver
+// also interpolates parameters (DBConfig.InterpolateParams). dbconn pins
+// sql_mode and the connection charset so that these literals round-trip,
+// but a proxy or an init_connect can override them. The settings below
+// are the ones known to change how such a literal is parsed.
+func escapingCheck(ctx context.Context, r Resources, logger *slog.Logger) error {
+	var sqlMode, charsetClient string
+	if err := r.DB.QueryRowContext(ctx, `SELECT @@SESSION.sql_mode, @@SESSION.character_set_client`).Scan(&sqlMode, &charsetClient); err != nil {
+		return err
+	}
+	for _, mode := range unsafeEscapingModes(sqlMode) {
+		logger.Warn("the session sql_mode changes how spirit's escaped string literals are parsed, so chunk boundaries and key values may not match the rows they were taken from", "sql_mode", sqlMode, "mode", mode)
+	}
+	if unsafeEscapingCharset(charsetClient) {
+		logger.Warn("the session character_set_client allows a backslash inside a multi-byte character, so spirit's escaped string literals may be misparsed", "character_set_client", charsetClient)
+	}
+	return nil
+}
+
+// unsafeEscapingModes returns the modes in sqlMode that break spirit's
+// string literals: NO_BACKSLASH_ESCAPES reads a backslash literally, and
+// ANSI_QUOTES reads a double-quoted string as an identifier.
+func unsafeEscapingModes(sqlMode string) []string {
+	var unsafe []string
+	for mode := range strings.SplitSeq(strings.ToUpper(sqlMode), ",") {
+		mode = strings.TrimSpace(mode)
+		if mode == "ANSI" {
+			// ANSI is a combination mode that includes ANSI_QUOTES. The
+			// server expands it in @@sql_mode, but not in every proxy.
+			mode = "ANSI_QUOTES"
+		}
+		if (mode == "NO_BACKSLASH_ESCAPES" || mode == "ANSI_QUOTES") && !slices.Contains(unsafe, mode) {
+			unsafe = append(unsafe, mode)
+		}
+	}
+	return unsafe
+}
+
+// unsafeEscapingCharset reports whether charset has multi-byte characters
+// whose trailing byte can be 0x5C, the backslash. Escaping a string byte by
+// byte in such a charset can let a quote end the literal early.
+func unsafeEscapingCharset(charset string) bool {
+	switch strings.ToLower(charset) {
+	case "big5", "cp932", "gbk", "gb18030", "sjis":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package check
+
+import (
+	"database/sql"
+	"log/slog"
+	"testing"
+
+	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnsafeEscapingModes(t *testing.T) {
+	require.Empty(t, unsafeEscapingModes(""))
+	require.Empty(t, unsafeEscapingModes("NO_AUTO_VALUE_ON_ZERO"))
+	require.Empty(t, unsafeEscapingModes("STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION"))
+	require.Equal(t, []string{"NO_BACKSLASH_ESCAPES"}, unsafeEscapingModes("STRICT_TRANS_TABLES,NO_BACKSLASH_ESCAPES"))
+	require.Equal(t, []string{"ANSI_QUOTES"}, unsafeEscapingModes("REAL_AS_FLOAT,PIPES_AS_CONCAT,ANSI_QUOTES,IGNORE_SPACE,ONLY_FULL_GROUP_BY,ANSI"))
+	require.Equal(t, []string{"ANSI_QUOTES", "NO_BACKSLASH_ESCAPES"}, unsafeEscapingModes("ansi_quotes,no_backslash_escapes"))
+
+	require.False(t, unsafeEscapingCharset("utf8mb4"))
+	require.False(t, unsafeEscapingCharset("latin1"))
+	require.True(t, unsafeEscapingCharset("SJIS"))
+	require.True(t, unsafeEscapingCharset("gbk"))
+}
+
+func TestEscaping(t *testing.T) {
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	r := Resources{
+		DB:    db,
+		Table: &table.TableInfo{TableName: "test", SchemaName: "test"},
+	}
+	require.NoError(t, escapingCheck(t.Context(), r, slog.Default()))
+}
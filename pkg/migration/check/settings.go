@@ -17,6 +17,10 @@ func settingsCheck(ctx context.Context, r Resources, logger *slog.Logger) error
 	if r.Threads < 1 || r.Threads > 64 {
 		return errors.New("--threads must be in the range of 1-64")
 	}
+	// ChecksumThreads is optional, but has the same range as Threads
+	if r.ChecksumThreads < 0 || r.ChecksumThreads > 64 {
+		return errors.New("--checksum-threads must be in the range of 1-64")
+	}
 	// TargetChunkTime must be in the range of 100ms-5s
 	// Note to future self: if you increase this, make sure you also extend
 	// the timeouts for locks in dbconn/dbconn.go, otherwise you will encounter problems.
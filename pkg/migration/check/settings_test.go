@@ -41,6 +41,14 @@ func TestSettings(t *testing.T) {
 	err = settingsCheck(t.Context(), r, slog.Default())
 	require.NoError(t, err) // all looks good
 
+	r.ChecksumThreads = 65
+	err = settingsCheck(t.Context(), r, slog.Default())
+	require.Error(t, err)
+
+	r.ChecksumThreads = 16
+	err = settingsCheck(t.Context(), r, slog.Default())
+	require.NoError(t, err) // all looks good
+
 	r.TargetChunkTime = time.Second * 6
 	err = settingsCheck(t.Context(), r, slog.Default())
 	require.Error(t, err)
@@ -2,8 +2,9 @@ package check
 
 import (
 	"context"
-	"errors"
+	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/block/spirit/pkg/utils"
 )
@@ -12,20 +13,49 @@ func init() {
 	registerCheck("hastriggers", hasTriggersCheck, ScopePreflight)
 }
 
-// hasTriggersCheck check if table has triggers associated with it, which is not supported
+// hasTriggersCheck checks if the table has triggers associated with it. The
+// copy and the change feed handle them correctly, since their effects on this
+// table are in the row events, but a trigger belongs to the table it was
+// created on: at cutover it stays with the old table, and the new table has
+// none. So by default this is refused. With CopyTriggers the cutover moves
+// them to the new table, and with AllowTriggers it is a warning that the
+// triggers must be recreated.
 func hasTriggersCheck(ctx context.Context, r Resources, logger *slog.Logger) error {
-	sql := `SELECT * FROM information_schema.triggers WHERE 
-	(event_object_schema=? AND event_object_table=?)`
+	sql := `SELECT trigger_name FROM information_schema.triggers WHERE
+	(event_object_schema=? AND event_object_table=?) ORDER BY trigger_name`
 	rows, err := r.DB.QueryContext(ctx, sql, r.Table.SchemaName, r.Table.TableName)
 	if err != nil {
 		return err
 	}
 	defer utils.CloseAndLog(rows)
-	if rows.Next() {
-		return errors.New("tables with triggers associated are not supported")
+	var triggers []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return err
+		}
+		triggers = append(triggers, name)
 	}
 	if rows.Err() != nil {
 		return rows.Err()
 	}
+	if len(triggers) == 0 {
+		return nil
+	}
+	if r.CopyTriggers {
+		logger.Info("table has triggers, which will be recreated on the new table at cutover",
+			"table", r.Table.TableName,
+			"triggers", triggers,
+		)
+		return nil
+	}
+	if !r.AllowTriggers {
+		return fmt.Errorf("tables with triggers associated are not supported: %s has %s (use --copy-triggers to recreate them on the new table at cutover, or --allow-triggers to recreate them yourself)",
+			r.Table.TableName, strings.Join(triggers, ", "))
+	}
+	logger.Warn("table has triggers, which stay with the old table at cutover; they must be recreated on the new table",
+		"table", r.Table.TableName,
+		"triggers", triggers,
+	)
 	return nil
 }
@@ -1,6 +1,7 @@
 package check
 
 import (
+	"bytes"
 	"database/sql"
 	"log/slog"
 	"testing"
@@ -39,6 +40,28 @@ func TestHasTriggers(t *testing.T) {
 
 	err = hasTriggersCheck(t.Context(), r, slog.Default())
 	require.ErrorContains(t, err, "tables with triggers associated are not supported") // already has a trigger associated.
+	require.ErrorContains(t, err, "account has ins_sum")
+
+	// With AllowTriggers the check passes, but warns that the trigger must
+	// be recreated.
+	var logs bytes.Buffer
+	allowed := r
+	allowed.AllowTriggers = true
+	err = hasTriggersCheck(t.Context(), allowed, slog.New(slog.NewTextHandler(&logs, nil)))
+	require.NoError(t, err)
+	require.Contains(t, logs.String(), "level=WARN")
+	require.Contains(t, logs.String(), "must be recreated on the new table")
+	require.Contains(t, logs.String(), "ins_sum")
+
+	// With CopyTriggers the check passes without a warning, since the
+	// cutover recreates the trigger.
+	logs.Reset()
+	copying := r
+	copying.CopyTriggers = true
+	err = hasTriggersCheck(t.Context(), copying, slog.New(slog.NewTextHandler(&logs, nil)))
+	require.NoError(t, err)
+	require.NotContains(t, logs.String(), "level=WARN")
+	require.Contains(t, logs.String(), "recreated on the new table at cutover")
 
 	_, err = db.ExecContext(t.Context(), `drop trigger if exists ins_sum`)
 	require.NoError(t, err)
@@ -32,6 +32,7 @@ func versionCheck(ctx context.Context, r Resources, _ *slog.Logger) error {
 	dbConfig := dbconn.NewDBConfig()
 	dbConfig.TLSMode = r.TLSMode
 	dbConfig.TLSCertificatePath = r.TLSCertificatePath
+	dbConfig.CredentialProvider = r.CredentialProvider
 
 	db, err := dbconn.New(dsn, dbConfig)
 	if err != nil {
@@ -13,6 +13,7 @@ import (
 	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/throttler"
 	"github.com/block/spirit/pkg/utils"
 	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/require"
@@ -32,6 +33,10 @@ func (m *mockChecker) GetProgress() status.ChecksumProgress { return status.Chec
 func (m *mockChecker) StartTime() time.Time                 { return time.Now() }
 func (m *mockChecker) ExecTime() time.Duration              { return 0 }
 func (m *mockChecker) DifferencesFound() uint64             { return m.differencesFound.Load() }
+func (m *mockChecker) ChecksumRange(context.Context, []string, *table.Boundary, *table.Boundary) error {
+	return nil
+}
+func (m *mockChecker) SetThrottler(throttler.Throttler) {}
 
 // setupRunnerForChecksumTest creates a real table, runs the runner setup as
 // far as creating the checkpoint table on disk, and returns a Runner that can
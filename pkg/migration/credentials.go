@@ -0,0 +1,108 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CredentialSource says where to read the MySQL username and password from,
+// so that secrets do not have to be passed as literals (which leak into
+// process listings, shell history and orchestration configs). It is resolved
+// once, in NewRunner. Literal --username/--password values take precedence
+// over it, and it takes precedence over --conf and the built-in defaults.
+type CredentialSource struct {
+	UsernameEnv  string `name:"username-env" help:"Name of an environment variable to read the username from" optional:""`
+	PasswordEnv  string `name:"password-env" help:"Name of an environment variable to read the password from" optional:""`
+	PasswordFile string `name:"password-file" help:"Path to a file containing the password (a trailing newline is ignored)" optional:""`
+
+	// Provider, if set, supplies the credentials programmatically (for
+	// example from a secrets manager, or as an RDS IAM auth token). An empty
+	// username or a nil password means "not provided"; a non-nil empty
+	// password is a real (empty) password. The environment and file sources
+	// above override it. It is only called when a credential is still
+	// missing after the literal --username/--password flags. The
+	// credentials it supplied are fetched from it again before each new
+	// connection to the source, including the binlog stream's reconnects,
+	// so credentials that expire during a long migration keep working.
+	Provider func() (username string, password *string, err error) `kong:"-"`
+
+	// providedUsername and providedPassword record which credentials
+	// resolve took from Provider, so refresher only replaces those.
+	providedUsername bool
+	providedPassword bool
+}
+
+// refresher returns a dbconn.DBConfig.CredentialProvider that fetches again,
+// from Provider, the credentials Provider supplied when they were resolved,
+// and returns username and password for the others (or when Provider no
+// longer supplies them). It returns nil when Provider supplied none of them.
+func (c *CredentialSource) refresher(username, password string) func() (string, string, error) {
+	if c.Provider == nil || (!c.providedUsername && !c.providedPassword) {
+		return nil
+	}
+	provider, useUsername, usePassword := c.Provider, c.providedUsername, c.providedPassword
+	return func() (string, string, error) {
+		newUsername, newPassword, err := provider()
+		if err != nil {
+			return "", "", err
+		}
+		user, pass := username, password
+		if useUsername && newUsername != "" {
+			user = newUsername
+		}
+		if usePassword && newPassword != nil {
+			pass = *newPassword
+		}
+		return user, pass, nil
+	}
+}
+
+// resolve returns the username and password from the configured sources,
+// reading only the credentials that are still needed (so a literal
+// --username/--password is never overridden and its source never read). An
+// empty username or nil password means no source provided that credential.
+// A source that is configured but cannot be read is an error rather than a
+// silent fallback, since connecting with the wrong credentials is harder to
+// debug.
+func (c *CredentialSource) resolve(needUsername, needPassword bool) (username string, password *string, err error) {
+	if c.PasswordEnv != "" && c.PasswordFile != "" {
+		return "", nil, errors.New("only one of --password-env and --password-file can be specified")
+	}
+	if !needUsername && !needPassword {
+		return "", nil, nil
+	}
+	c.providedUsername, c.providedPassword = false, false
+	if c.Provider != nil {
+		username, password, err = c.Provider()
+		if err != nil {
+			return "", nil, fmt.Errorf("credential provider failed: %w", err)
+		}
+		c.providedUsername = needUsername && username != "" && c.UsernameEnv == ""
+		c.providedPassword = needPassword && password != nil && c.PasswordEnv == "" && c.PasswordFile == ""
+	}
+	if needUsername && c.UsernameEnv != "" {
+		user, ok := os.LookupEnv(c.UsernameEnv)
+		if !ok || user == "" {
+			return "", nil, fmt.Errorf("environment variable %q (from --username-env) is not set", c.UsernameEnv)
+		}
+		username = user
+	}
+	if needPassword && c.PasswordEnv != "" {
+		pass, ok := os.LookupEnv(c.PasswordEnv)
+		if !ok {
+			return "", nil, fmt.Errorf("environment variable %q (from --password-env) is not set", c.PasswordEnv)
+		}
+		password = &pass
+	}
+	if needPassword && c.PasswordFile != "" {
+		contents, err := os.ReadFile(c.PasswordFile)
+		if err != nil {
+			return "", nil, fmt.Errorf("could not read --password-file: %w", err)
+		}
+		pass := strings.TrimRight(string(contents), "\r\n")
+		password = &pass
+	}
+	return username, password, nil
+}
@@ -0,0 +1,155 @@
+package migration
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCredentialsFromEnvironment(t *testing.T) {
+	t.Setenv("SPIRIT_TEST_USER", "envuser")
+	t.Setenv("SPIRIT_TEST_PASSWORD", "envsecret")
+	m := &Migration{
+		Host:     "localhost:3306",
+		Database: "test",
+		Table:    "credt1",
+		Alter:    "ENGINE=InnoDB",
+		Credentials: CredentialSource{
+			UsernameEnv: "SPIRIT_TEST_USER",
+			PasswordEnv: "SPIRIT_TEST_PASSWORD",
+		},
+	}
+	r, err := NewRunner(m)
+	require.NoError(t, err)
+	require.Equal(t, "envuser", r.migration.Username)
+	require.Equal(t, "envsecret", *r.migration.Password)
+	require.Contains(t, r.dsn(), "envuser:envsecret@")
+}
+
+func TestCredentialsPrecedence(t *testing.T) {
+	t.Setenv("SPIRIT_TEST_USER", "envuser")
+	t.Setenv("SPIRIT_TEST_PASSWORD", "envsecret")
+
+	// Literal flags win over the credential source.
+	literal := "literal"
+	m := &Migration{
+		Username: "literaluser",
+		Password: &literal,
+		Credentials: CredentialSource{
+			UsernameEnv: "SPIRIT_TEST_USER",
+			PasswordEnv: "SPIRIT_TEST_PASSWORD",
+		},
+	}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, "literaluser", m.Username)
+	require.Equal(t, "literal", *m.Password)
+
+	// The environment wins over a provider, which fills in the rest.
+	m = &Migration{
+		Credentials: CredentialSource{
+			PasswordEnv: "SPIRIT_TEST_PASSWORD",
+			Provider: func() (string, *string, error) {
+				password := "providersecret"
+				return "provideruser", &password, nil
+			},
+		},
+	}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, "provideruser", m.Username)
+	require.Equal(t, "envsecret", *m.Password)
+
+	// A provider can supply an empty password.
+	m = &Migration{
+		Credentials: CredentialSource{
+			Provider: func() (string, *string, error) {
+				password := ""
+				return "provideruser", &password, nil
+			},
+		},
+	}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Empty(t, *m.Password)
+
+	// With literal flags set, the other sources are never read: neither an
+	// unset environment variable nor the provider are consulted.
+	m = &Migration{
+		Username: "literaluser",
+		Password: &literal,
+		Credentials: CredentialSource{
+			UsernameEnv: "SPIRIT_TEST_UNSET_VARIABLE",
+			PasswordEnv: "SPIRIT_TEST_UNSET_VARIABLE",
+			Provider: func() (string, *string, error) {
+				t.Fatal("the provider must not be called when the literal credentials are set")
+				return "", nil, nil
+			},
+		},
+	}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, "literal", *m.Password)
+
+	// Without any source the defaults still apply.
+	m = &Migration{}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, defaultUsername, m.Username)
+	require.Equal(t, defaultPassword, *m.Password)
+}
+
+// TestCredentialsRefresher checks that only the credentials the provider
+// supplied are fetched from it again for new connections, and the resolved
+// ones are returned for the others.
+func TestCredentialsRefresher(t *testing.T) {
+	t.Setenv("SPIRIT_TEST_PASSWORD", "envsecret")
+	calls := 0
+	provider := func() (string, *string, error) {
+		calls++
+		password := fmt.Sprintf("token%d", calls)
+		return "provideruser", &password, nil
+	}
+
+	m := &Migration{Credentials: CredentialSource{Provider: provider}}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, "token1", *m.Password)
+	user, password, err := m.Credentials.refresher(m.Username, *m.Password)()
+	require.NoError(t, err)
+	require.Equal(t, "provideruser", user)
+	require.Equal(t, "token2", password)
+
+	// A password from the environment is never replaced.
+	m = &Migration{Credentials: CredentialSource{PasswordEnv: "SPIRIT_TEST_PASSWORD", Provider: provider}}
+	require.NoError(t, m.normalizeConnectionOptions())
+	user, password, err = m.Credentials.refresher(m.Username, *m.Password)()
+	require.NoError(t, err)
+	require.Equal(t, "provideruser", user)
+	require.Equal(t, "envsecret", password)
+
+	// With literal credentials the provider supplied nothing.
+	literal := "literal"
+	m = &Migration{Username: "literaluser", Password: &literal, Credentials: CredentialSource{Provider: provider}}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Nil(t, m.Credentials.refresher(m.Username, *m.Password))
+}
+
+func TestCredentialsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	require.NoError(t, os.WriteFile(path, []byte("filesecret\n"), 0o600))
+	m := &Migration{Credentials: CredentialSource{PasswordFile: path}}
+	require.NoError(t, m.normalizeConnectionOptions())
+	require.Equal(t, "filesecret", *m.Password)
+}
+
+func TestCredentialsErrors(t *testing.T) {
+	m := &Migration{Credentials: CredentialSource{UsernameEnv: "SPIRIT_TEST_UNSET_VARIABLE"}}
+	require.ErrorContains(t, m.normalizeConnectionOptions(), "SPIRIT_TEST_UNSET_VARIABLE")
+
+	m = &Migration{Credentials: CredentialSource{PasswordEnv: "SPIRIT_TEST_UNSET_VARIABLE"}}
+	require.ErrorContains(t, m.normalizeConnectionOptions(), "SPIRIT_TEST_UNSET_VARIABLE")
+
+	m = &Migration{Credentials: CredentialSource{PasswordFile: filepath.Join(t.TempDir(), "missing")}}
+	require.ErrorContains(t, m.normalizeConnectionOptions(), "--password-file")
+
+	m = &Migration{Credentials: CredentialSource{PasswordEnv: "A", PasswordFile: "B"}}
+	require.ErrorContains(t, m.normalizeConnectionOptions(), "only one of")
+}
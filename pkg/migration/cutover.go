@@ -30,18 +30,37 @@ const (
 	// cancelled mid-cutover; without an explicit timeout the call could
 	// block indefinitely on an unhealthy connection.
 	cutoverUnlockTimeout = 30 * time.Second
+	// cutoverConvergenceThreshold is the number of pending changes at or
+	// below which converge considers the feed caught up enough to take the
+	// table lock. Flushing this many changes under the lock is quick.
+	cutoverConvergenceThreshold = 1000
 )
 
+// errTriggersNotRestored is returned when a cutover attempt fails after the
+// triggers were moved to the new table and they could not be moved back.
+// The cutover is not retried: the original table would keep running without
+// its triggers until the next attempt, and a retry that fails the same way
+// would leave them on the new table.
+var errTriggersNotRestored = errors.New("triggers could not be moved back to the original table")
+
 type CutOver struct {
 	db       *sql.DB
 	feed     change.Source
 	config   []*cutoverConfig
 	dbConfig *dbconn.DBConfig
 	logger   *slog.Logger
-	// testInjectRenameError is a test-only seam: when non-nil it is returned
-	// in place of a successful rename's nil result, simulating a connection
-	// that died after the server committed the RENAME TABLE but before the
-	// client read the OK packet.
+	// convergenceTimeout bounds how long each attempt flushes the feed
+	// before taking the table lock, waiting for the pending changes to
+	// converge. Zero flushes once, as before it was configurable.
+	convergenceTimeout time.Duration
+	// ddlHook, if set, is called with the RENAME TABLE and trigger
+	// statements before they run (see Runner.OnExecDDL).
+	ddlHook func(stmt string)
+	// testInjectRenameError is a test-only seam: when non-nil the lock's
+	// connection is killed after a successful rename and the error is
+	// returned in place of its nil result, simulating a connection that died
+	// after the server committed the RENAME TABLE but before the client read
+	// the OK packet.
 	testInjectRenameError error
 }
 
@@ -50,6 +69,9 @@ type cutoverConfig struct {
 	newTable       *table.TableInfo
 	oldTableName   string
 	useTestCutover bool
+	// triggers are moved from table to newTable under the table lock, right
+	// before the rename (--copy-triggers).
+	triggers []trigger
 }
 
 // NewCutOver contains the logic to perform the final cut over. It can cutover multiple tables
@@ -100,6 +122,9 @@ func (c *CutOver) Run(ctx context.Context) error {
 	// a rename that actually succeeded. Once set, every subsequent decision
 	// point first verifies the server state instead of blindly retrying.
 	renameMayHaveCommitted := false
+	// triggersNotRestored is set when an attempt failed and the triggers
+	// could not be moved back. The cutover is then not retried.
+	triggersNotRestored := false
 	for i := range max(1, c.dbConfig.MaxRetries) {
 		if ctx.Err() != nil {
 			return errors.Join(append(attemptErrs, ctx.Err())...)
@@ -132,7 +157,7 @@ func (c *CutOver) Run(ctx context.Context) error {
 		// Try and catch up before we attempt the cutover.
 		// since we will need to catch up again with the lock held
 		// and we want to minimize that.
-		if err := c.feed.Flush(ctx); err != nil {
+		if err := c.converge(ctx); err != nil {
 			return errors.Join(append(attemptErrs, err)...)
 		}
 		// We use maxCutoverRetries as our retrycount, but nested
@@ -160,11 +185,21 @@ func (c *CutOver) Run(ctx context.Context) error {
 				// deliberately skip this: for those the server positively
 				// reported the statement failed, so the normal retry path is
 				// correct.
+				// This comes before the errTriggersNotRestored check: a
+				// connection lost during the RENAME also fails restoring the
+				// triggers on the same connection, even if the rename was
+				// committed and the triggers are where they belong.
 				renameMayHaveCommitted = true
 				if c.confirmRenameCompleted(ctx) {
 					return nil
 				}
 			}
+			if errors.Is(err, errTriggersNotRestored) {
+				c.logger.Error("cutover failed and the triggers could not be restored; not retrying",
+					"error", err.Error())
+				triggersNotRestored = true
+				break
+			}
 			c.logger.Warn("cutover failed",
 				"error", err.Error(),
 				"next_backoff", backoff,
@@ -174,17 +209,48 @@ func (c *CutOver) Run(ctx context.Context) error {
 		c.logger.Warn("final cut over operation complete")
 		return nil
 	}
-	// Retries are exhausted. If any attempt failed ambiguously, give the
-	// state check one final chance before declaring failure: the server may
-	// have committed the rename only after the last in-loop verification ran
-	// (e.g. the dying rename was still waiting on metadata locks).
+	// Retries are exhausted, or the cutover is not retried because the
+	// triggers could not be restored. If any attempt failed ambiguously, give
+	// the state check one final chance before declaring failure: the server
+	// may have committed the rename only after the last in-loop verification
+	// ran (e.g. the dying rename was still waiting on metadata locks).
 	if renameMayHaveCommitted && c.confirmRenameCompleted(ctx) {
 		return nil
 	}
-	c.logger.Error("cutover failed, and retries exhausted")
+	if !triggersNotRestored {
+		c.logger.Error("cutover failed, and retries exhausted")
+	}
 	return errors.Join(attemptErrs...)
 }
 
+// converge flushes the feed until the pending changes are at most
+// cutoverConvergenceThreshold and did not grow during the last flush, so the
+// flush under the table lock has little left to do. On a busy table a single
+// flush can leave thousands of changes behind for the lock to wait on. If the
+// changes have not converged within convergenceTimeout the cutover proceeds
+// anyway: the flush under the lock is what guarantees consistency, it just
+// holds the lock for longer.
+func (c *CutOver) converge(ctx context.Context) error {
+	deadline := time.Now().Add(c.convergenceTimeout)
+	for {
+		before := c.feed.GetDeltaLen()
+		if err := c.feed.Flush(ctx); err != nil {
+			return err
+		}
+		pending := c.feed.GetDeltaLen()
+		if pending <= cutoverConvergenceThreshold && pending <= before {
+			return nil
+		}
+		if !time.Now().Before(deadline) {
+			c.logger.Warn("pending changes did not converge before cutover, proceeding anyway",
+				"pending_changes", pending,
+				"convergence_timeout", c.convergenceTimeout,
+			)
+			return nil
+		}
+	}
+}
+
 // confirmRenameCompleted wraps renameCompleted with logging for use in the
 // retry loop after an ambiguous connection-loss failure. It returns true only
 // if the server-side state proves the cutover rename was committed.
@@ -299,18 +365,59 @@ func (c *CutOver) executeRenameUnderLock(ctx context.Context, tablesToLock []*ta
 		return fmt.Errorf("%w, final flush might be broken", change.ErrChangesNotFlushed)
 	}
 
+	for _, cfg := range c.config {
+		if err := c.execDDLUnderLock(ctx, tableLock, moveTriggersStmts(cfg.triggers, cfg.newTable.TableName)...); err != nil {
+			err = fmt.Errorf("could not move triggers to %s: %w", cfg.newTable.TableName, err)
+			return errors.Join(err, c.restoreTriggers(ctx, tableLock))
+		}
+	}
+
 	renameStatement := "RENAME TABLE " + strings.Join(renameFragments, ", ")
-	if err := tableLock.ExecUnderLock(ctx, renameStatement); err != nil {
-		return err
+	err = c.execDDLUnderLock(ctx, tableLock, renameStatement)
+	if err == nil && c.testInjectRenameError != nil {
+		// Test-only seam: the rename was committed by the server, but the
+		// connection dies before the client reads the OK packet.
+		_ = tableLock.ExecUnderLock(ctx, "KILL CONNECTION_ID()")
+		err = c.testInjectRenameError
 	}
-	if c.testInjectRenameError != nil {
-		// Test-only seam: the rename was committed by the server, but we
-		// pretend the client never read the OK packet.
-		return c.testInjectRenameError
+	if err != nil {
+		return errors.Join(err, c.restoreTriggers(ctx, tableLock))
 	}
 	return nil
 }
 
+// execDDLUnderLock runs stmts under the table lock, passing the DDL among
+// them (everything but the SET statements that save and restore sql_mode
+// around the triggers) to ddlHook first.
+func (c *CutOver) execDDLUnderLock(ctx context.Context, tableLock *dbconn.TableLock, stmts ...string) error {
+	if c.ddlHook != nil {
+		for _, stmt := range stmts {
+			if !strings.HasPrefix(stmt, "SET ") {
+				c.ddlHook(stmt)
+			}
+		}
+	}
+	return tableLock.ExecUnderLock(ctx, stmts...)
+}
+
+// restoreTriggers moves any triggers back to the original tables after a
+// failed cutover attempt, so the table keeps its triggers if the cutover is
+// not retried. If any cannot be moved back it returns errTriggersNotRestored,
+// which fails the cutover instead of retrying it: where the triggers are is
+// then unknown, and they may need to be recreated by hand.
+func (c *CutOver) restoreTriggers(ctx context.Context, tableLock *dbconn.TableLock) error {
+	var errs []error
+	for _, cfg := range c.config {
+		if len(cfg.triggers) == 0 {
+			continue
+		}
+		if err := c.execDDLUnderLock(ctx, tableLock, moveTriggersStmts(cfg.triggers, cfg.table.TableName)...); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %s: %w", errTriggersNotRestored, cfg.table.TableName, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // partialRenameForTest performs a partial cutover (only renames original table to _old)
 // This is intended for testing the atomicity/consistency of the cutover.
 func (c *CutOver) partialRenameForTest(ctx context.Context) error {
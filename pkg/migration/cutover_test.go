@@ -223,6 +223,75 @@ func TestCutoverConnectionLossAfterRenameCommitted(t *testing.T) {
 	require.Equal(t, 0, count)
 }
 
+// TestCutoverConnectionLossWithTriggers is TestCutoverConnectionLossAfterRenameCommitted
+// with --copy-triggers: the connection is lost during the RENAME, so moving
+// the triggers back fails on the same dead connection. Run must still find
+// that the rename was committed instead of stopping on the triggers.
+func TestCutoverConnectionLossWithTriggers(t *testing.T) {
+	t.Parallel()
+	testutils.NewTestTable(t, "cutoverconnlosstrg", `CREATE TABLE cutoverconnlosstrg (
+		id int(11) NOT NULL AUTO_INCREMENT,
+		name varchar(255) NOT NULL,
+		PRIMARY KEY (id)
+	)`)
+	testutils.RunSQL(t, `CREATE TABLE _cutoverconnlosstrg_new (
+		id int(11) NOT NULL AUTO_INCREMENT,
+		name varchar(255) NOT NULL,
+		PRIMARY KEY (id)
+	)`)
+	testutils.RunSQL(t, `CREATE TABLE _cutoverconnlosstrg_chkpnt (a int)`) // for binlog advancement
+	testutils.RunSQL(t, `DROP TRIGGER IF EXISTS cutoverconnlosstrg_upper`)
+	testutils.RunSQL(t, `CREATE TRIGGER cutoverconnlosstrg_upper BEFORE INSERT ON cutoverconnlosstrg
+		FOR EACH ROW SET NEW.name = UPPER(NEW.name)`)
+
+	cfg, err := mysql.ParseDSN(testutils.DSN())
+	require.NoError(t, err)
+
+	db, err := dbconn.New(testutils.DSN(), dbconn.NewDBConfig())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	t1 := table.NewTableInfo(db, cfg.DBName, "cutoverconnlosstrg")
+	require.NoError(t, t1.SetInfo(t.Context()))
+	t1new := table.NewTableInfo(db, cfg.DBName, "_cutoverconnlosstrg_new")
+	triggers, err := readTriggers(t.Context(), db, cfg.DBName, "cutoverconnlosstrg")
+	require.NoError(t, err)
+	require.Len(t, triggers, 1)
+	logger := slog.Default()
+	feed := change.NewBinlogClient(db, cfg.Addr, cfg.User, cfg.Passwd, applier.NewSingleTargetForTest(t, db), change.NewClientDefaultConfig())
+	defer feed.Close()
+	chunker, err := table.NewChunker(t1, table.ChunkerConfig{NewTable: t1new})
+	require.NoError(t, err)
+	require.NoError(t, feed.AddSubscription(t1, t1new, chunker))
+	require.NoError(t, feed.Start(t.Context()))
+
+	cutover, err := NewCutOver(db, []*cutoverConfig{
+		{
+			table:        t1,
+			newTable:     t1new,
+			oldTableName: "_cutoverconnlosstrg_old",
+			triggers:     triggers,
+		},
+	}, feed, dbconn.NewDBConfig(), logger)
+	require.NoError(t, err)
+	cutover.testInjectRenameError = mysql.ErrInvalidConn
+
+	require.NoError(t, cutover.Run(t.Context()),
+		"a rename committed by the server must be reported as success even though the triggers could not be moved back")
+
+	// The triggers were moved before the rename, so they are on the table
+	// that now has the original name.
+	var triggerTable string
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT event_object_table FROM information_schema.triggers
+		WHERE trigger_schema = ? AND trigger_name = 'cutoverconnlosstrg_upper'`, cfg.DBName).Scan(&triggerTable))
+	require.Equal(t, "cutoverconnlosstrg", triggerTable)
+	var count int
+	require.NoError(t, db.QueryRowContext(t.Context(),
+		"SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = ? AND table_name = '_cutoverconnlosstrg_new'",
+		cfg.DBName).Scan(&count))
+	require.Equal(t, 0, count)
+}
+
 // TestCutoverDeterministicErrorDoesNotVerify is the negative counterpart of
 // TestCutoverConnectionLossAfterRenameCommitted: when an attempt fails with a
 // deterministic (non-connection) error, the state verification must NOT kick
@@ -1143,3 +1212,62 @@ func TestMultiTableMigrationBlockedPerSchema(t *testing.T) {
 	require.NoError(t, <-cA)
 	require.NoError(t, mA.Close())
 }
+
+// loadedFeed is a change.Source double simulating a table under write load:
+// each Flush applies every pending change, while arrivals[i] new changes come
+// in during flush i. Once arrivals runs out, the last value repeats.
+type loadedFeed struct {
+	change.Source // nil: only the methods below are called
+
+	pending  int
+	arrivals []int
+	flushes  int
+}
+
+func (f *loadedFeed) Flush(context.Context) error {
+	i := min(f.flushes, len(f.arrivals)-1)
+	f.pending = f.arrivals[i]
+	f.flushes++
+	return nil
+}
+
+func (f *loadedFeed) GetDeltaLen() int { return f.pending }
+
+// TestCutOverConverge checks that cutover only goes on to take the table
+// lock once the pending changes are below the threshold and not growing, or
+// the convergence timeout has passed.
+func TestCutOverConverge(t *testing.T) {
+	newCutOver := func(feed *loadedFeed, timeout time.Duration) *CutOver {
+		return &CutOver{feed: feed, logger: slog.Default(), convergenceTimeout: timeout}
+	}
+
+	t.Run("WaitsUntilConverged", func(t *testing.T) {
+		feed := &loadedFeed{pending: 10000, arrivals: []int{5000, 3000, 1500, 800, 400}}
+		require.NoError(t, newCutOver(feed, time.Minute).converge(t.Context()))
+		require.Equal(t, 4, feed.flushes)
+		require.LessOrEqual(t, feed.GetDeltaLen(), cutoverConvergenceThreshold)
+	})
+
+	t.Run("WaitsWhileGrowing", func(t *testing.T) {
+		// Already below the threshold, but the load is picking up.
+		feed := &loadedFeed{pending: 100, arrivals: []int{500, 900, 200}}
+		require.NoError(t, newCutOver(feed, time.Minute).converge(t.Context()))
+		require.Equal(t, 3, feed.flushes)
+		require.Equal(t, 200, feed.GetDeltaLen())
+	})
+
+	t.Run("GivesUpAfterTimeout", func(t *testing.T) {
+		feed := &loadedFeed{pending: 5000, arrivals: []int{5000}}
+		start := time.Now()
+		require.NoError(t, newCutOver(feed, 50*time.Millisecond).converge(t.Context()))
+		require.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		require.Greater(t, feed.flushes, 1)
+		require.Equal(t, 5000, feed.GetDeltaLen())
+	})
+
+	t.Run("ZeroTimeoutFlushesOnce", func(t *testing.T) {
+		feed := &loadedFeed{pending: 5000, arrivals: []int{5000}}
+		require.NoError(t, newCutOver(feed, 0).converge(t.Context()))
+		require.Equal(t, 1, feed.flushes)
+	})
+}
@@ -0,0 +1,58 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/block/spirit/pkg/statement"
+)
+
+// alterFromDesired replaces the CREATE TABLE from --desired with the ALTER
+// TABLE that transforms the live table into it, computed by
+// statement.CreateTable.Diff. It returns true if the live table already
+// matches, in which case there is nothing to migrate.
+//
+// The computed ALTER also becomes the migration's Statement, which is what a
+// checkpoint records: a resumed run computes the same ALTER from the same live
+// table, so it resumes as if the ALTER had been passed in.
+func (r *Runner) alterFromDesired(ctx context.Context) (bool, error) {
+	alter, err := r.desiredAlter(ctx)
+	if err != nil || alter == nil {
+		return alter == nil && err == nil, err
+	}
+	r.logger.Info("computed ALTER from --desired", "statement", alter.Statement)
+	r.changes[0].stmt = alter
+	r.migration.Statement = alter.Statement
+	return false, nil
+}
+
+// desiredAlter returns the ALTER TABLE that transforms the live table into
+// the CREATE TABLE from --desired, or nil if it already matches.
+func (r *Runner) desiredAlter(ctx context.Context) (*statement.AbstractStatement, error) {
+	change := r.changes[0]
+	desired, err := change.stmt.ParseCreateTable()
+	if err != nil {
+		return nil, err
+	}
+	live, err := r.getCreateTable(ctx, change.stmt.Schema, change.stmt.Table)
+	if err != nil {
+		return nil, fmt.Errorf("could not read table %q to diff against --desired: %w", change.stmt.Table, err)
+	}
+	alters, err := live.Diff(desired, statement.NewDiffOptions())
+	if err != nil {
+		return nil, err
+	}
+	switch len(alters) {
+	case 0:
+		return nil, nil
+	case 1:
+	default:
+		// A migration runs one ALTER per table. Diff returns more than one
+		// for changes such as a different partitioning type.
+		return nil, fmt.Errorf("--desired requires %d ALTER TABLE statements on %q, which must be run one at a time: %s",
+			len(alters), change.stmt.Table, alters[0].Statement)
+	}
+	alter := alters[0]
+	alter.Schema = change.stmt.Schema
+	return alter, nil
+}
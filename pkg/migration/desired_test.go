@@ -0,0 +1,73 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeDesired(t *testing.T) {
+	t.Parallel()
+	desired := "CREATE TABLE t1 (id int not null primary key, b int)"
+
+	// The table is taken from the CREATE TABLE.
+	m := &Migration{Desired: desired}
+	stmts, err := m.normalizeOptions()
+	require.NoError(t, err)
+	require.Len(t, stmts, 1)
+	require.True(t, stmts[0].IsCreateTable())
+	require.Equal(t, "t1", m.Table)
+	require.Equal(t, defaultDatabase, stmts[0].Schema)
+
+	// --table may be given, but must name the same table.
+	_, err = (&Migration{Desired: desired, Table: "t1"}).normalizeOptions()
+	require.NoError(t, err)
+	_, err = (&Migration{Desired: desired, Table: "t2"}).normalizeOptions()
+	require.ErrorContains(t, err, "not --table")
+
+	_, err = (&Migration{Desired: desired, Alter: "ADD COLUMN c int"}).normalizeOptions()
+	require.ErrorContains(t, err, "cannot be combined")
+	_, err = (&Migration{Desired: desired, Statement: "ALTER TABLE t1 ADD COLUMN c int"}).normalizeOptions()
+	require.ErrorContains(t, err, "cannot be combined")
+	_, err = (&Migration{Desired: "ALTER TABLE t1 ADD COLUMN c int"}).normalizeOptions()
+	require.ErrorContains(t, err, "single CREATE TABLE")
+	_, err = (&Migration{Desired: "CREATE TABLE otherdb.t1 (id int not null primary key)"}).normalizeOptions()
+	require.ErrorContains(t, err, "does not match --database")
+}
+
+func TestDesired(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "desiredt1", `CREATE TABLE desiredt1 (
+		id int not null primary key auto_increment,
+		b int not null,
+		c varchar(10)
+	)`)
+	tt.SeedRows(t, "INSERT INTO desiredt1 (b) SELECT 1 FROM dual", 1000)
+
+	// The desired state adds a column and an index, and drops c.
+	desired := `CREATE TABLE desiredt1 (
+		id int not null primary key auto_increment,
+		b int not null,
+		d int not null default 5,
+		KEY idx_b (b)
+	)`
+	m := NewTestRunner(t, "", "", WithDesired(desired))
+	require.NoError(t, m.Run(t.Context()))
+	require.True(t, m.changes[0].stmt.IsAlterTable())
+	require.Contains(t, m.migration.Statement, "DROP COLUMN `c`")
+	require.Contains(t, m.migration.Statement, "ADD COLUMN `d`")
+	require.Contains(t, m.migration.Statement, "ADD INDEX `idx_b`")
+	require.NoError(t, m.Close())
+
+	var count int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM desiredt1 WHERE d = 5").Scan(&count))
+	require.Equal(t, 1000, count)
+
+	// The table now matches, so a second run has nothing to do.
+	m = NewTestRunner(t, "", "", WithDesired(desired))
+	require.NoError(t, m.Run(t.Context()))
+	require.True(t, m.changes[0].stmt.IsCreateTable())
+	require.Empty(t, m.migration.Statement)
+	require.NoError(t, m.Close())
+}
@@ -0,0 +1,166 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/block/spirit/pkg/dbconn"
+	"github.com/block/spirit/pkg/dbconn/sqlescape"
+	"github.com/block/spirit/pkg/migration/check"
+)
+
+// dryRunSuffix names the scratch table a dry run applies the ALTER to.
+const dryRunSuffix = "_dry"
+
+// Algorithms a dry run can report for a change.
+const (
+	algorithmInstant = "INSTANT"
+	algorithmInplace = "INPLACE"
+	algorithmCopy    = "COPY"
+)
+
+// changePlan is what a dry run reports for one table.
+type changePlan struct {
+	Table         string
+	Statement     string
+	Algorithm     string // algorithmInstant, algorithmInplace or algorithmCopy
+	EstimatedRows uint64
+	CreateTable   string // the table's definition after the ALTER
+}
+
+// dryRun runs the preflight checks and works out how each change would be
+// applied, logs the plan and returns it. It does not create the new or
+// checkpoint tables, copy anything or cut over. The only writes are the
+// ANALYZE TABLE that refreshes the row estimate (as in a normal run) and an
+// empty scratch copy of each table (see tableChange.plan), which is dropped
+// before it returns.
+func (r *Runner) dryRun(ctx context.Context) ([]*changePlan, error) {
+	if len(r.changes) == 1 && !r.changes[0].stmt.IsAlterTable() {
+		r.logger.Info("dry run: would run the statement directly", "statement", r.changes[0].stmt.Statement)
+		return nil, nil
+	}
+	for _, change := range r.changes {
+		if err := change.stmt.AlterContainsUnsupportedClause(); err != nil {
+			return nil, err
+		}
+		change.table = r.newTableInfo(change.stmt.Schema, change.stmt.Table)
+		if err := dbconn.RetryableSetInfo(ctx, change.table, r.dbConfig); err != nil {
+			return nil, err
+		}
+	}
+	if err := r.runChecks(ctx, check.ScopePreflight); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrPreflight, err)
+	}
+	plans := make([]*changePlan, 0, len(r.changes))
+	for _, change := range r.changes {
+		plan, err := change.plan(ctx)
+		if err != nil {
+			return nil, err
+		}
+		r.logger.Info("dry run: migration plan",
+			"table", plan.Table,
+			"statement", plan.Statement,
+			"algorithm", plan.Algorithm,
+			"estimated-rows", plan.EstimatedRows,
+			"new-table", plan.CreateTable,
+		)
+		plans = append(plans, plan)
+	}
+	return plans, nil
+}
+
+// WouldUseInstantDDL reports whether MySQL would apply the migration with
+// ALGORITHM=INSTANT, which makes it a metadata-only change that completes
+// without copying rows. It lets a scheduler route such changes through a
+// fast lane. It connects if Run has not, and works out the algorithm as a
+// dry run does, against an empty scratch copy of the table that is dropped
+// before it returns, so the table itself is never altered. Unlike a dry run,
+// it does not run the preflight checks.
+//
+// It returns false for a statement other than ALTER TABLE, and for a
+// multi-table migration, which spirit always copies. With --desired it
+// returns true when the table already matches, since there is nothing to do.
+// See tableChange.plan for when INSTANT can be reported for a change that
+// is still copied.
+func (r *Runner) WouldUseInstantDDL(ctx context.Context) (bool, error) {
+	if err := r.connect(); err != nil {
+		return false, err
+	}
+	if len(r.changes) != 1 {
+		return false, nil
+	}
+	// Plan a copy of the change, so that a later Run starts from the
+	// change as it was given.
+	change := *r.changes[0]
+	if r.migration.Desired != "" && !change.stmt.IsAlterTable() {
+		alter, err := r.desiredAlter(ctx)
+		if err != nil || alter == nil {
+			return alter == nil && err == nil, err
+		}
+		change.stmt = alter
+	}
+	if !change.stmt.IsAlterTable() {
+		return false, nil
+	}
+	if err := change.stmt.AlterContainsUnsupportedClause(); err != nil {
+		return false, err
+	}
+	change.table = r.newTableInfo(change.stmt.Schema, change.stmt.Table)
+	if err := dbconn.RetryableSetInfo(ctx, change.table, r.dbConfig); err != nil {
+		return false, err
+	}
+	plan, err := change.plan(ctx)
+	if err != nil {
+		return false, err
+	}
+	return plan.Algorithm == algorithmInstant, nil
+}
+
+// plan works out how the change would be applied by running the ALTER
+// against an empty copy of the table: first with ALGORITHM=INSTANT, then
+// (if it is considered safe, see attemptMySQLDDL) with ALGORITHM=INPLACE,
+// and otherwise as a copy. MySQL decides INSTANT eligibility mostly from the
+// table definition, but an empty copy has no history of earlier INSTANT
+// changes, so a table that has reached MySQL's limit on those will report
+// INSTANT here and still be copied.
+func (c *tableChange) plan(ctx context.Context) (*changePlan, error) {
+	name, drop, err := c.createScratchTable(ctx, dryRunSuffix)
+	if err != nil {
+		return nil, err
+	}
+	defer drop()
+	if err := c.convertCharset(ctx, name); err != nil {
+		return nil, err
+	}
+	// The runner never uses MySQL's DDL for a multi-table migration, with
+	// --exclude-columns or with --new-table-charset (see
+	// Runner.attemptMySQLDDL).
+	canUseMySQLDDL := len(c.runner.changes) == 1 && len(c.runner.migration.ExcludeColumns) == 0 &&
+		c.runner.migration.NewTableCharset == ""
+	algorithm := algorithmCopy
+	switch {
+	case canUseMySQLDDL && c.runner.execDDL(ctx, "ALTER TABLE %n ALGORITHM=INSTANT, "+c.stmt.Alter, name) == nil:
+		algorithm = algorithmInstant
+	case canUseMySQLDDL && c.stmt.AlgorithmInplaceConsideredSafe() == nil &&
+		c.runner.execDDL(ctx, "ALTER TABLE %n ALGORITHM=INPLACE, LOCK=NONE, "+c.stmt.Alter, name) == nil:
+		algorithm = algorithmInplace
+	default:
+		if err := c.runner.execDDL(ctx, "ALTER TABLE %n "+c.stmt.Alter, name); err != nil {
+			return nil, err
+		}
+	}
+	var tableName, createTable string
+	if err := c.runner.db.QueryRowContext(ctx, "SHOW CREATE TABLE "+sqlescape.EscapeIdentifier(name)).Scan(&tableName, &createTable); err != nil {
+		return nil, err
+	}
+	return &changePlan{
+		Table:         c.stmt.Table,
+		Statement:     c.stmt.Statement,
+		Algorithm:     algorithm,
+		EstimatedRows: atomic.LoadUint64(&c.table.EstimatedRows),
+		// Show the definition under the name the table keeps after cutover.
+		CreateTable: strings.Replace(createTable, sqlescape.EscapeIdentifier(name), sqlescape.EscapeIdentifier(c.stmt.Table), 1),
+	}, nil
+}
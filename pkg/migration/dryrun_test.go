@@ -0,0 +1,81 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "dryrunt1", `CREATE TABLE dryrunt1 (
+		id int not null primary key auto_increment,
+		b int not null
+	)`)
+	tt.SeedRows(t, "INSERT INTO dryrunt1 (b) SELECT 1 FROM dual", 1000)
+
+	tableExists := func(name string) bool {
+		var n int
+		require.NoError(t, tt.DB.QueryRowContext(t.Context(),
+			"SELECT COUNT(*) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+			name).Scan(&n))
+		return n > 0
+	}
+	for _, test := range []struct {
+		alter     string
+		algorithm string
+		contains  string
+	}{
+		{"ADD COLUMN c int", algorithmInstant, "`c` int"},
+		{"ADD INDEX idx_b (b)", algorithmCopy, "KEY `idx_b` (`b`)"},
+	} {
+		m := NewTestRunner(t, "dryrunt1", test.alter, WithDryRun())
+		require.NoError(t, m.Run(t.Context()))
+		plans, err := m.dryRun(t.Context())
+		require.NoError(t, err)
+		require.Len(t, plans, 1)
+		require.Equal(t, test.algorithm, plans[0].Algorithm, test.alter)
+		require.Contains(t, plans[0].CreateTable, "CREATE TABLE `dryrunt1`")
+		require.Contains(t, plans[0].CreateTable, test.contains)
+		require.NotZero(t, plans[0].EstimatedRows)
+		require.NoError(t, m.Close())
+	}
+
+	// Nothing was changed or left behind.
+	var createTable string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SHOW CREATE TABLE dryrunt1").Scan(new(string), &createTable))
+	require.NotContains(t, createTable, "`c`")
+	require.NotContains(t, createTable, "idx_b")
+	require.False(t, tableExists(utils.NewTableName("dryrunt1")))
+	require.False(t, tableExists(utils.CheckpointTableName("dryrunt1")))
+	require.False(t, tableExists(utils.AuxTableName("dryrunt1", dryRunSuffix)))
+}
+
+// TestWouldUseInstantDDL checks that a trailing ADD COLUMN is reported as
+// INSTANT and a type narrowing is not, and that neither alters the table.
+func TestWouldUseInstantDDL(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "instantt1", `CREATE TABLE instantt1 (
+		id int not null primary key auto_increment,
+		b int not null
+	)`)
+	tt.SeedRows(t, "INSERT INTO instantt1 (b) SELECT 1 FROM dual", 100)
+
+	for alter, want := range map[string]bool{
+		"ADD COLUMN c int":           true,
+		"MODIFY b smallint NOT NULL": false,
+	} {
+		r := NewTestRunner(t, "instantt1", alter)
+		instant, err := r.WouldUseInstantDDL(t.Context())
+		require.NoError(t, err, alter)
+		require.Equal(t, want, instant, alter)
+		require.NoError(t, r.Close())
+	}
+
+	var createTable string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SHOW CREATE TABLE instantt1").Scan(new(string), &createTable))
+	require.NotContains(t, createTable, "`c`")
+	require.Contains(t, createTable, "`b` int NOT NULL")
+}
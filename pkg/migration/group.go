@@ -0,0 +1,72 @@
+package migration
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/block/spirit/pkg/dbconn"
+)
+
+// DefaultConnWaitTimeout is how long a migration in a MigrationGroup waits
+// for a connection under the group's budget before it fails, when
+// ConnWaitTimeout is not set.
+const DefaultConnWaitTimeout = 5 * time.Minute
+
+// MigrationGroup runs independent migrations concurrently in one process,
+// with the connections all of them open to the source counted against one
+// budget of MaxConnections. Each migration on its own sizes its pool from
+// its threads, so several of them together can otherwise exhaust the
+// server's max_connections.
+//
+// A migration that needs a connection while the budget is exhausted waits
+// for another to close one. Run rejects a MaxConnections below what the
+// largest migration holds at once: its pool (threads + write-threads + a few
+// control-plane connections) plus its advisory lock. Migrations can still
+// wait on each other, for example while each holds checksum transactions
+// open, so a wait longer than ConnWaitTimeout fails the migration with
+// dbconn.ErrConnBudgetExhausted rather than stalling the group.
+type MigrationGroup struct {
+	Migrations      []*Migration
+	MaxConnections  int
+	ConnWaitTimeout time.Duration
+}
+
+// Run runs every migration in the group to completion, concurrently, and
+// returns the errors of those that failed, joined. It sets ConnBudget on
+// each migration. It checks every migration against MaxConnections before
+// starting any of them.
+func (g *MigrationGroup) Run() error {
+	if g.MaxConnections <= 0 {
+		return fmt.Errorf("MigrationGroup.MaxConnections must be positive, got %d", g.MaxConnections)
+	}
+	budget := dbconn.NewConnBudget(g.MaxConnections)
+	waitTimeout := g.ConnWaitTimeout
+	if waitTimeout <= 0 {
+		waitTimeout = DefaultConnWaitTimeout
+	}
+	budget.SetWaitTimeout(waitTimeout)
+	runners := make([]*Runner, 0, len(g.Migrations))
+	for i, m := range g.Migrations {
+		m.ConnBudget = budget
+		r, err := NewRunner(m)
+		if err != nil {
+			return fmt.Errorf("migration %d: %w", i, err)
+		}
+		if required := r.requiredConnections(); required > g.MaxConnections {
+			return fmt.Errorf("MigrationGroup.MaxConnections is %d, but migration %d needs at least %d connections (threads + write-threads + %d control-plane connections + 1 advisory lock)",
+				g.MaxConnections, i, required, r.controlPlaneConns())
+		}
+		runners = append(runners, r)
+	}
+	errs := make([]error, len(runners))
+	var wg sync.WaitGroup
+	for i, r := range runners {
+		wg.Go(func() {
+			errs[i] = r.runToCompletion()
+		})
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,52 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrationGroupRequiresBudget(t *testing.T) {
+	g := &MigrationGroup{Migrations: []*Migration{{}}}
+	require.EqualError(t, g.Run(), "MigrationGroup.MaxConnections must be positive, got 0")
+}
+
+func TestMigrationGroupBudgetTooSmall(t *testing.T) {
+	g := &MigrationGroup{
+		Migrations: []*Migration{
+			{Database: "test", Table: "t1", Alter: "ENGINE=InnoDB", Threads: 1, WriteThreads: 1},
+			{Database: "test", Table: "t2", Alter: "ENGINE=InnoDB", Threads: 2, WriteThreads: 2},
+		},
+		MaxConnections: 7,
+	}
+	// The second migration needs threads + write-threads + 3 control-plane
+	// connections + its advisory lock = 8.
+	require.EqualError(t, g.Run(), "MigrationGroup.MaxConnections is 7, but migration 1 needs at least 8 connections (threads + write-threads + 3 control-plane connections + 1 advisory lock)")
+}
+
+// TestMigrationGroupConnBudget runs two migrations under a budget smaller
+// than their two pools combined, and checks that both complete without the
+// connections they held at once ever exceeding it.
+func TestMigrationGroupConnBudget(t *testing.T) {
+	for _, tbl := range []string{"groupbudget1", "groupbudget2"} {
+		testutils.NewTestTable(t, tbl, "CREATE TABLE "+tbl+" (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, pad VARBINARY(100))")
+		testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(100) FROM dual")
+		testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(100) FROM "+tbl+" a, "+tbl+" b, "+tbl+" c LIMIT 10000")
+	}
+	// Each migration's main pool alone is threads + write-threads + 3 = 7.
+	g := &MigrationGroup{
+		Migrations: []*Migration{
+			NewTestMigration(t, WithTable("groupbudget1"), WithAlter("ENGINE=InnoDB")),
+			NewTestMigration(t, WithTable("groupbudget2"), WithAlter("ENGINE=InnoDB")),
+		},
+		MaxConnections: 10,
+	}
+	require.NoError(t, g.Run())
+
+	budget := g.Migrations[0].ConnBudget
+	require.Same(t, budget, g.Migrations[1].ConnBudget)
+	require.Positive(t, budget.MaxInUse())
+	require.LessOrEqual(t, budget.MaxInUse(), 10)
+	require.Zero(t, budget.InUse(), "every connection is closed once the migrations finish")
+}
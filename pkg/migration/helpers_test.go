@@ -118,6 +118,27 @@ func WithStatement(s string) RunnerOption {
 	}
 }
 
+// WithDesired sets the desired CREATE TABLE for the migration.
+func WithDesired(s string) RunnerOption {
+	return func(m *Migration) {
+		m.Desired = s
+	}
+}
+
+// WithExcludeColumns sets the columns that are not copied to the new table.
+func WithExcludeColumns(cols ...string) RunnerOption {
+	return func(m *Migration) {
+		m.ExcludeColumns = cols
+	}
+}
+
+// WithChunkKey sets the index to chunk on instead of the PRIMARY KEY.
+func WithChunkKey(key string) RunnerOption {
+	return func(m *Migration) {
+		m.ChunkKey = key
+	}
+}
+
 // WithTargetChunkTime sets the target chunk time.
 func WithTargetChunkTime(d time.Duration) RunnerOption {
 	return func(m *Migration) {
@@ -125,6 +146,26 @@ func WithTargetChunkTime(d time.Duration) RunnerOption {
 	}
 }
 
+// WithTableTargetChunkTime sets the target chunk time of one table.
+func WithTableTargetChunkTime(tableName string, d time.Duration) RunnerOption {
+	return func(m *Migration) {
+		if m.TableTargetChunkTime == nil {
+			m.TableTargetChunkTime = make(map[string]time.Duration)
+		}
+		m.TableTargetChunkTime[tableName] = d
+	}
+}
+
+// WithTableTargetChunkSize sets the copy chunk byte budget of one table.
+func WithTableTargetChunkSize(tableName string, size uint64) RunnerOption {
+	return func(m *Migration) {
+		if m.TableTargetChunkSize == nil {
+			m.TableTargetChunkSize = make(map[string]uint64)
+		}
+		m.TableTargetChunkSize[tableName] = size
+	}
+}
+
 // WithBuffered enables (b=true) or disables (b=false) the buffered copier.
 // Buffered copy is the default, so this sets the inverse Unbuffered field;
 // WithBuffered(false) opts a test back into the legacy unbuffered copier.
@@ -149,6 +190,28 @@ func WithTestThrottler() RunnerOption {
 	}
 }
 
+// WithCorrelationID sets the external correlation ID of the migration.
+func WithCorrelationID(id string) RunnerOption {
+	return func(m *Migration) {
+		m.CorrelationID = id
+	}
+}
+
+// WithDeferSecondaryIndexes builds non-unique secondary indexes after the copy.
+func WithDeferSecondaryIndexes() RunnerOption {
+	return func(m *Migration) {
+		m.DeferSecondaryIndexes = true
+	}
+}
+
+// WithOnExistingArtifacts sets what a fresh migration does with an existing
+// _new or checkpoint table.
+func WithOnExistingArtifacts(p ArtifactPolicy) RunnerOption {
+	return func(m *Migration) {
+		m.OnExistingArtifacts = p
+	}
+}
+
 // WithDeferCutOver enables deferred cutover mode.
 func WithDeferCutOver() RunnerOption {
 	return func(m *Migration) {
@@ -184,6 +247,13 @@ func WithLintOnly() RunnerOption {
 	}
 }
 
+// WithDryRun enables dry-run mode (plan only, no migration).
+func WithDryRun() RunnerOption {
+	return func(m *Migration) {
+		m.DryRun = true
+	}
+}
+
 // WithHost overrides the host address.
 func WithHost(host string) RunnerOption {
 	return func(m *Migration) {
@@ -212,6 +282,45 @@ func WithSkipDropAfterCutover() RunnerOption {
 	}
 }
 
+// WithTableNames sets the names of the new and old tables.
+func WithTableNames(newName, oldName string) RunnerOption {
+	return func(m *Migration) {
+		m.NewTableName = newName
+		m.OldTableName = oldName
+	}
+}
+
+// WithNewTableCharset converts the new table to charset (and collation, if
+// not empty) before the ALTER is applied to it.
+func WithNewTableCharset(charset, collation string) RunnerOption {
+	return func(m *Migration) {
+		m.NewTableCharset = charset
+		m.NewTableCollation = collation
+	}
+}
+
+// WithArtifactTableOptions creates the new and checkpoint tables with the
+// table options opts.
+func WithArtifactTableOptions(opts string) RunnerOption {
+	return func(m *Migration) {
+		m.ArtifactTableOptions = opts
+	}
+}
+
+// WithCopyTriggers recreates the table's triggers on the new table at cutover.
+func WithCopyTriggers() RunnerOption {
+	return func(m *Migration) {
+		m.CopyTriggers = true
+	}
+}
+
+// WithExplainChunks warns before the copy if the chunk query needs a sort.
+func WithExplainChunks() RunnerOption {
+	return func(m *Migration) {
+		m.ExplainChunks = true
+	}
+}
+
 // newTestMigration creates a Migration with sensible defaults for integration tests.
 // It parses the test DSN and fills in Host/Username/Password/Database.
 // Callers must set either Table+Alter or Statement before calling Run().
@@ -5,13 +5,18 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"maps"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/block/spirit/pkg/checksum"
+	"github.com/block/spirit/pkg/dbconn"
 	"github.com/block/spirit/pkg/dbconn/sqlescape"
 	"github.com/block/spirit/pkg/migration/check"
 	"github.com/block/spirit/pkg/statement"
+	"github.com/block/spirit/pkg/status"
 	"github.com/block/spirit/pkg/table"
 	"github.com/block/spirit/pkg/utils"
 	"github.com/pingcap/tidb/pkg/parser"
@@ -24,6 +29,27 @@ var (
 	defaultPassword = "spirit"
 	defaultDatabase = "test"
 	defaultTLSMode  = "PREFERRED"
+
+	// lockNamespacePattern is what --lock-namespace must match. It becomes
+	// part of the auxiliary table names, so it is kept short and plain.
+	lockNamespacePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,16}$`)
+)
+
+// maxLockNamespaceLength is the longest --lock-namespace allowed, matching
+// lockNamespacePattern.
+const maxLockNamespaceLength = 16
+
+// ArtifactPolicy decides what a fresh migration does when a table it is
+// about to create already exists.
+type ArtifactPolicy string
+
+const (
+	// ArtifactPolicyDropAndRecreate drops an existing _new, checkpoint or
+	// scratch table and creates it again. This is the default.
+	ArtifactPolicyDropAndRecreate ArtifactPolicy = "drop-and-recreate"
+	// ArtifactPolicyFail refuses to start the migration instead, in case the
+	// table belongs to another tool or a concurrent spirit run.
+	ArtifactPolicyFail ArtifactPolicy = "fail"
 )
 
 type Migration struct {
@@ -34,9 +60,15 @@ type Migration struct {
 	ConfFile     string  `name:"conf" help:"MySQL conf file" optional:"" type:"existingfile"`
 	Table        string  `name:"table" help:"Table" optional:""`
 	Alter        string  `name:"alter" help:"The alter statement to run on the table" optional:""`
+	Desired      string  `name:"desired" help:"A CREATE TABLE statement with the desired state of the table. Spirit diffs it against the live table and runs the resulting ALTER" optional:""`
 	Threads      int     `name:"threads" help:"Number of concurrent threads for copy and checksum tasks" optional:"" default:"4"`
 	WriteThreads int     `name:"write-threads" help:"Number of concurrent apply (write) threads. 0 = auto: on Aurora this is set to the instance vCPU count minus 2 (min 1), leaving CPU headroom; on non-Aurora targets it falls back to the default" optional:"" default:"4"`
 
+	// ChecksumThreads is the checksum's concurrency, for when the thread
+	// count tuned for the copy is too much for the checksum. It defaults to
+	// Threads.
+	ChecksumThreads int `name:"checksum-threads" help:"Number of concurrent checksum threads. 0 = the same as --threads" optional:"" default:"0"`
+
 	// EnableExperimentalAutoscaling turns on dynamic write-thread scaling driven
 	// by throttler feedback; WriteThreads becomes the starting value and the
 	// cap is fixed at 2x that (deliberately not configurable for now, to keep
@@ -57,20 +89,59 @@ type Migration struct {
 	// that construct Migration programmatically don't have to set it.
 	// The Kong default below must stay equal to table.DefaultTargetChunkBytes.
 	TargetChunkSize      uint64        `name:"target-chunk-size" help:"In-memory byte budget per copy chunk for the default buffered copier (in bytes). No effect with --unbuffered." optional:"" default:"16777216"`
+	FixedChunkRows       uint64        `name:"fixed-chunk-rows" help:"Copy in chunks of exactly this many rows instead of sizing chunks dynamically. Overrides --target-chunk-size and --target-chunk-time for the copy; the checksum is unaffected." optional:""`
+	ExplainChunks        bool          `name:"explain-chunks" help:"Before copying, EXPLAIN the query that finds chunk boundaries and warn if it needs a filesort or temporary table" optional:""`
 	ReplicaDSN           string        `name:"replica-dsn" help:"DSN(s) for replica(s) used for lag checking. Multiple replicas can be comma-separated; Spirit throttles on the slowest." optional:""`
 	ReplicaMaxLag        time.Duration `name:"replica-max-lag" help:"The maximum lag allowed on the replica before the migration throttles. If lag becomes unobservable (lag polling keeps failing) the migration pauses (fails closed) until polling recovers; remove --replica-dsn to proceed without lag protection." optional:"" default:"120s"`
 	LockWaitTimeout      time.Duration `name:"lock-wait-timeout" help:"The DDL lock_wait_timeout required for checksum and cutover" optional:"" default:"30s"`
 	SkipDropAfterCutover bool          `name:"skip-drop-after-cutover" help:"Keep old table after completing cutover" optional:"" default:"false"`
 	DeferCutOver         bool          `name:"defer-cutover" help:"Defer cutover (and checksum) until sentinel table is dropped" optional:"" default:"false"`
 	SkipForceKill        bool          `name:"skip-force-kill" help:"Disable killing long-running transactions in order to acquire metadata lock (MDL) at checksum and cutover time" optional:"" default:"false"`
+	SkipForceIndex       bool          `name:"skip-force-index" help:"Read copy chunks without FORCE INDEX (PRIMARY), letting the optimizer choose the index" optional:"" default:"false"`
 	Statement            string        `name:"statement" help:"The SQL statement to run (replaces --table and --alter)" optional:"" default:""`
 	Lint                 bool          `name:"lint" help:"Run lint checks before running migration" optional:""`
 	LintOnly             bool          `name:"lint-only" help:"Run lint checks and exit without performing migration" optional:""`
 
+	// TableTargetChunkTime and TableTargetChunkSize override TargetChunkTime
+	// and TargetChunkSize for individual tables of a multi-table migration,
+	// keyed by table name. A table of small rows can then be copied in large
+	// chunks while one with BLOBs is copied in small ones.
+	TableTargetChunkTime map[string]time.Duration `name:"table-target-chunk-time" help:"Per-table --target-chunk-time, as table=duration pairs separated by ';'" optional:""`
+	TableTargetChunkSize map[string]uint64        `name:"table-target-chunk-size" help:"Per-table --target-chunk-size, as table=bytes pairs separated by ';'" optional:""`
+
+	// IgnoreShowWarningsErrors commits a copy or apply statement even if the
+	// SHOW WARNINGS that follows it fails, which some proxies cause. See
+	// dbconn.DBConfig.IgnoreShowWarningsErrors.
+	IgnoreShowWarningsErrors bool `name:"ignore-show-warnings-errors" help:"Commit a statement whose SHOW WARNINGS fails (e.g. behind a proxy) instead of failing it; its warnings are not checked" optional:""`
+
+	// LockNamespace is mixed into the names of the advisory locks that keep
+	// two migrations off the same table (see dbconn.DBConfig.LockNamespace),
+	// and into the names of the auxiliary tables, such as _<table>_<ns>_new
+	// and _<table>_<ns>_chkpnt, so that runs in different namespaces neither
+	// block nor clobber each other.
+	LockNamespace string `name:"lock-namespace" help:"Namespace for the advisory lock and auxiliary table names, so other tools using GET_LOCK don't collide with spirit" optional:""`
+
+	// DryRun reports the plan for the migration (whether MySQL can apply it
+	// with INSTANT or INPLACE DDL, the estimated rows to copy and the new
+	// table definition) after the preflight checks, and exits without
+	// creating the new table, copying or cutting over.
+	DryRun bool `name:"dry-run" help:"Run preflight checks and log the migration plan (DDL algorithm, estimated rows, new table definition), then exit without migrating" optional:""`
+
 	// TLS Configuration
 	TLSMode            string `name:"tls-mode" help:"TLS connection mode (case insensitive): DISABLED, PREFERRED (default), REQUIRED, VERIFY_CA, VERIFY_IDENTITY" optional:""`
 	TLSCertificatePath string `name:"tls-ca" help:"Path to custom TLS CA certificate file" optional:""`
 
+	// Credentials reads the username/password from the environment, a file,
+	// or a provider function instead of the literal --username/--password
+	// flags. See CredentialSource.
+	Credentials CredentialSource `embed:""`
+
+	// CorrelationID ties the migration to an external identifier such as a
+	// change ticket. It is added to every log line, stored in the checkpoint
+	// table, sent as a label with every metric, and prepended as a SQL comment
+	// to the copy and apply statements.
+	CorrelationID string `name:"correlation-id" help:"External identifier (e.g. a ticket) to attach to logs, metrics, the checkpoint and SQL comments" optional:""`
+
 	// Buffered copy (the default) uses the DBLog algorithm for copying and
 	// replication applying. It reads rows from the source and inserts them into
 	// the target, rather than using INSERT IGNORE .. SELECT, and is also required
@@ -78,20 +149,126 @@ type Migration struct {
 	// INSERT IGNORE .. SELECT copier.
 	Unbuffered bool `name:"unbuffered" help:"Use the legacy unbuffered copier (INSERT IGNORE .. SELECT) instead of the default buffered DBLog copier" optional:"" default:"false"`
 
+	// EnableExperimentalOutfileCopy makes the --unbuffered copier export and
+	// import each chunk with SELECT .. INTO OUTFILE / LOAD DATA INFILE. It only
+	// helps when spirit runs on the database host, and falls back to
+	// INSERT .. SELECT if secure_file_priv, the FILE privilege, or file
+	// ownership on the server prevent it.
+	EnableExperimentalOutfileCopy bool `name:"enable-experimental-outfile-copy" help:"EXPERIMENTAL: with --unbuffered, copy chunks using SELECT INTO OUTFILE and LOAD DATA INFILE when the server allows it" optional:"" default:"false"`
+
 	// EnableExperimentalGTID switches the change source from binlog file+position to MySQL GTIDs.
 	// EXPERIMENTAL — see pkg/change/gtid.go. Requires gtid_mode=ON and
 	// enforce_gtid_consistency=ON on the source.
 	EnableExperimentalGTID bool `name:"enable-experimental-gtid" help:"EXPERIMENTAL: use GTID-based change source instead of binlog file+position" optional:"" default:"false"`
 
+	// DeferSecondaryIndexes creates the new table without its non-unique
+	// secondary indexes and adds them in one ALTER after the copy, so the
+	// copy does not have to maintain them row by row. UNIQUE indexes are
+	// never deferred, since they decide which rows the copy keeps, and
+	// neither are FULLTEXT or SPATIAL indexes, which MySQL cannot add with
+	// LOCK=NONE.
+	DeferSecondaryIndexes bool `name:"defer-secondary-indexes" help:"Build non-unique secondary indexes on the new table after the copy instead of during it" optional:"" default:"false"`
+
+	// CutoverConvergenceTimeout bounds how long cutover keeps flushing
+	// changes, waiting for the backlog to become small, before it takes the
+	// table lock. Zero flushes once and takes the lock regardless.
+	CutoverConvergenceTimeout time.Duration `name:"cutover-convergence-timeout" help:"How long cutover waits for pending changes to converge before taking the table lock" optional:"" default:"30s"`
+
+	// OnExistingArtifacts decides what happens when there is no checkpoint to
+	// resume from but the _new or checkpoint table already exists, or when a
+	// scratch table the ALTER is tried on already exists. An empty value
+	// means ArtifactPolicyDropAndRecreate.
+	OnExistingArtifacts ArtifactPolicy `name:"on-existing-artifacts" help:"What to do when the _new, checkpoint or a scratch table already exists and there is no checkpoint to resume from: drop-and-recreate or fail" optional:"" enum:"drop-and-recreate,fail" default:"drop-and-recreate"`
+
+	// AllowTriggers lets a table with triggers be migrated. Triggers stay
+	// with the old table at cutover, so they must be recreated on the new
+	// table afterwards; the preflight check logs which ones.
+	AllowTriggers bool `name:"allow-triggers" help:"Migrate a table that has triggers, with a warning, instead of refusing. The triggers are not carried over to the new table and must be recreated after cutover" optional:"" default:"false"`
+	// CopyTriggers recreates the table's triggers on the new table at
+	// cutover, under the table lock, so they keep firing after the rename.
+	CopyTriggers bool `name:"copy-triggers" help:"Migrate a table that has triggers, recreating them on the new table at cutover (with the same names, definers and sql_mode)" optional:"" default:"false"`
+
+	// ExcludeColumns are not copied, replicated or checksummed: the new table
+	// keeps its default for them. This is for deliberately discarding a
+	// column's values (for example, re-adding a sensitive column as NULLable).
+	ExcludeColumns []string `name:"exclude-columns" help:"Comma-separated columns whose values are NOT copied to the new table, which keeps its default for them. The checksum ignores them too" optional:""`
+
+	// ChunkKey names an index that the copy and the checksum chunk the table
+	// on instead of the PRIMARY KEY, for better locality. The preflight
+	// checks require it to be UNIQUE with all of its columns NOT NULL. It
+	// can only be used when migrating a single table.
+	ChunkKey string `name:"chunk-key" help:"Name of a UNIQUE index, with all columns NOT NULL, that the copy and checksum chunk the table on instead of the PRIMARY KEY" optional:""`
+
+	// NewTableName and OldTableName replace the generated _<table>_new and
+	// _<table>_old names, for tooling that needs to know them in advance.
+	// They can only be used when migrating a single table. They are used
+	// as given: OldTableName is not timestamped by SkipDropAfterCutover.
+	// Unlike the generated names, an existing table with either name is
+	// only dropped if the migration's checkpoint table also exists, showing
+	// an earlier run created it; otherwise the migration refuses to start.
+	NewTableName string `name:"new-table-name" help:"Name of the new table rows are copied into, instead of _<table>_new" optional:""`
+	OldTableName string `name:"old-table-name" help:"Name the original table is renamed to at cutover, instead of _<table>_old" optional:""`
+
+	// NewTableCharset and NewTableCollation convert the new table with
+	// CONVERT TO CHARACTER SET before the ALTER is applied to it, so a
+	// migration can also change the table's character set. Setting either
+	// means the table is always copied, never changed with INSTANT or
+	// INPLACE DDL.
+	NewTableCharset   string `name:"new-table-charset" help:"Convert the new table to this character set before applying the ALTER (e.g. utf8mb4)" optional:""`
+	NewTableCollation string `name:"new-table-collation" help:"Collation for --new-table-charset (e.g. utf8mb4_0900_ai_ci); defaults to the character set's default collation" optional:""`
+
+	// ArtifactTableOptions are table options (e.g. ROW_FORMAT=COMPRESSED or
+	// TABLESPACE ts1) given to the tables spirit creates: they are appended
+	// to the checkpoint table's CREATE TABLE, and applied to the new table
+	// with an ALTER TABLE after it is created LIKE the original. This is for
+	// servers whose DDL policy refuses tables without certain options.
+	ArtifactTableOptions string `name:"artifact-table-options" help:"Table options (e.g. ROW_FORMAT=COMPRESSED) to create the new and checkpoint tables with" optional:""`
+
 	CheckpointMaxAge     time.Duration `name:"checkpoint-max-age" help:"Maximum age of a checkpoint before refusing to resume from it" optional:"" default:"168h"`
 	ChecksumYieldTimeout time.Duration `name:"checksum-yield-timeout" help:"Maximum duration for a single checksum pass before yielding to release long-running REPEATABLE READ transactions (reduces InnoDB HLL growth)" optional:"" default:"24h"`
 
+	// ThrottleQuery throttles on an application-specific signal: a query run
+	// against the source that returns a single integer, such as the depth of
+	// a queue table. The copy pauses while it exceeds ThrottleThreshold.
+	ThrottleQuery     string `name:"throttle-query" help:"A query returning a single integer (e.g. a queue depth), run against the source every 5s. The migration throttles while its value exceeds --throttle-threshold" optional:""`
+	ThrottleThreshold int64  `name:"throttle-threshold" help:"The value of --throttle-query above which the migration throttles" optional:"" default:"0"`
+
+	// MaxThreadsRunning throttles while the source's Threads_running status
+	// variable exceeds it, backing off under heavy OLTP load on any server.
+	// Zero disables it.
+	MaxThreadsRunning int64 `name:"max-threads-running" help:"Throttle while the source's Threads_running exceeds this value, polled every 5s. 0 disables it" optional:"" default:"0"`
+
 	// MaxCommitLatency throttles when observed commit latency exceeds this
 	// threshold. Currently auto-enabled only on Aurora (auto-detected); the
 	// default 100ms is intentionally a high upper bound to only cut the most
 	// extreme tail latencies. See issue #468.
 	MaxCommitLatency time.Duration `name:"max-commit-latency" help:"Throttle when average commit latency exceeds this threshold (currently only auto-enabled on Aurora)" optional:"" default:"100ms"`
 
+	// PreCutoverHook, if set, is called immediately before the cutover, once
+	// the copy and checksum are complete and any sentinel table is gone. If
+	// it returns an error the cutover does not happen, Run returns the error
+	// wrapped in ErrPreCutoverHook, and the migration can be run again (it
+	// resumes from its checkpoint, if one was written). The migration can
+	// still be aborted while it runs. PostCutoverHook, if
+	// set, is called immediately after the tables are renamed; an error from
+	// it is wrapped in ErrPostCutoverHook and returned by Run after the usual
+	// cleanup. Neither is called when the change is applied with INSTANT or
+	// INPLACE DDL, since there is no cutover.
+	PreCutoverHook  func(ctx context.Context) error `kong:"-"`
+	PostCutoverHook func(ctx context.Context) error `kong:"-"`
+
+	// ProgressCallback, if set, is called with the migration's progress every
+	// status.StatusInterval (30s) until cutover, on the same tick as the
+	// status log line. It is for driving a progress display without parsing
+	// logs; it runs on the status goroutine, so it should return quickly.
+	ProgressCallback func(p status.Progress) `kong:"-"`
+
+	// ConnBudget, if set, caps the connections this migration opens to the
+	// source together with every other migration given the same budget (see
+	// dbconn.ConnBudget). MigrationGroup sets it. Replica connections do not
+	// count against it.
+	ConnBudget *dbconn.ConnBudget `kong:"-"`
+
 	// Hidden options for now (supports more obscure cash/sq usecases)
 	InterpolateParams bool `name:"interpolate-params" help:"Enable interpolate params for DSN" optional:"" default:"false" hidden:""`
 	// Used for tests so we can concurrently execute without issues even though
@@ -104,42 +281,204 @@ type Migration struct {
 	useTestThrottler bool
 }
 
-// Validate is called by Kong after parsing to check for invalid flag combinations.
-// Zero values mean "use the default" (normalizeOptions fills them in), so they
-// are not rejected here; only explicitly-negative or otherwise invalid values
-// are caught.
+// Validate checks for invalid flag combinations. It is called by Kong after
+// parsing and by NewRunner, so it runs before any connection is opened, and it
+// reports every problem it finds rather than only the first. Zero values mean
+// "use the default" (normalizeOptions fills them in), so they are not rejected
+// here; only explicitly-negative or otherwise invalid values are caught.
 func (m *Migration) Validate() error {
+	var errs []error
+	if err := m.validateStatementSource(); err != nil {
+		errs = append(errs, err)
+	}
 	if m.Lint && m.LintOnly {
-		return errors.New("--lint and --lint-only cannot be used together")
+		errs = append(errs, errors.New("--lint and --lint-only cannot be used together"))
 	}
 	if m.Threads < 0 {
-		return fmt.Errorf("--threads must be non-negative, got %d", m.Threads)
+		errs = append(errs, fmt.Errorf("--threads must be non-negative, got %d", m.Threads))
 	}
 	if m.WriteThreads < 0 {
-		return fmt.Errorf("--write-threads must be non-negative, got %d", m.WriteThreads)
+		errs = append(errs, fmt.Errorf("--write-threads must be non-negative, got %d", m.WriteThreads))
+	}
+	if m.ChecksumThreads < 0 {
+		errs = append(errs, fmt.Errorf("--checksum-threads must be non-negative, got %d", m.ChecksumThreads))
+	}
+	for _, d := range []struct {
+		flag  string
+		value time.Duration
+	}{
+		{"--target-chunk-time", m.TargetChunkTime},
+		{"--replica-max-lag", m.ReplicaMaxLag},
+		{"--lock-wait-timeout", m.LockWaitTimeout},
+		{"--cutover-convergence-timeout", m.CutoverConvergenceTimeout},
+		{"--checkpoint-max-age", m.CheckpointMaxAge},
+		{"--checksum-yield-timeout", m.ChecksumYieldTimeout},
+		{"--max-commit-latency", m.MaxCommitLatency},
+	} {
+		if d.value < 0 {
+			errs = append(errs, fmt.Errorf("%s must be non-negative, got %s", d.flag, d.value))
+		}
+	}
+	for _, tbl := range slices.Sorted(maps.Keys(m.TableTargetChunkTime)) {
+		if d := m.TableTargetChunkTime[tbl]; d < 0 {
+			errs = append(errs, fmt.Errorf("--table-target-chunk-time for %s must be non-negative, got %s", tbl, d))
+		}
+	}
+	if m.ThrottleQuery == "" && m.ThrottleThreshold != 0 {
+		errs = append(errs, errors.New("--throttle-threshold requires --throttle-query"))
+	}
+	if m.MaxThreadsRunning < 0 {
+		errs = append(errs, fmt.Errorf("--max-threads-running must be non-negative, got %d", m.MaxThreadsRunning))
+	}
+	switch strings.ToUpper(m.TLSMode) {
+	case "", "PREFERRED", "REQUIRED", "VERIFY_CA", "VERIFY_IDENTITY":
+	case "DISABLED":
+		if m.TLSCertificatePath != "" {
+			errs = append(errs, errors.New("--tls-ca cannot be used with --tls-mode DISABLED"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("--tls-mode must be one of DISABLED, PREFERRED, REQUIRED, VERIFY_CA or VERIFY_IDENTITY, got %q", m.TLSMode))
+	}
+	for _, n := range []struct {
+		flag  string
+		value string
+	}{
+		{"--new-table-name", m.NewTableName},
+		{"--old-table-name", m.OldTableName},
+	} {
+		if len(n.value) > utils.MaxTableNameLength {
+			errs = append(errs, fmt.Errorf("%s must be %d characters or fewer, got %d", n.flag, utils.MaxTableNameLength, len(n.value)))
+		}
+	}
+	if m.LockNamespace != "" && !lockNamespacePattern.MatchString(m.LockNamespace) {
+		errs = append(errs, fmt.Errorf("--lock-namespace must be at most %d letters, digits, '_' or '-', got %q", maxLockNamespaceLength, m.LockNamespace))
+	}
+	if m.NewTableName != "" && strings.EqualFold(m.NewTableName, m.OldTableName) {
+		errs = append(errs, errors.New("--new-table-name and --old-table-name must be different"))
+	}
+	if m.FixedChunkRows > table.MaxDynamicRowSize {
+		errs = append(errs, fmt.Errorf("--fixed-chunk-rows must be at most %d, got %d", table.MaxDynamicRowSize, m.FixedChunkRows))
+	}
+	if m.NewTableCollation != "" && m.NewTableCharset == "" {
+		errs = append(errs, errors.New("--new-table-collation requires --new-table-charset"))
+	}
+	if m.ArtifactTableOptions != "" {
+		// Parsing refuses a second statement, but table options are not
+		// the only thing that parses after a column list: a partition
+		// clause, or a SELECT to populate the table, does as well.
+		ct, err := statement.ParseCreateTable("CREATE TABLE t (id int) " + m.ArtifactTableOptions)
+		switch {
+		case err != nil:
+			errs = append(errs, fmt.Errorf("--artifact-table-options must be a list of table options: %w", err))
+		case ct.Raw.Select != nil || ct.Raw.ReferTable != nil || ct.Raw.Partition != nil:
+			errs = append(errs, fmt.Errorf("--artifact-table-options must be a list of table options, got %q", m.ArtifactTableOptions))
+		}
+	}
+	switch m.OnExistingArtifacts {
+	case "", ArtifactPolicyDropAndRecreate, ArtifactPolicyFail:
+	default:
+		errs = append(errs, fmt.Errorf("--on-existing-artifacts must be %q or %q, got %q",
+			ArtifactPolicyDropAndRecreate, ArtifactPolicyFail, m.OnExistingArtifacts))
+	}
+	return errors.Join(errs...)
+}
+
+// validateStatementSource checks that the change is given in exactly one
+// way: --desired, --statement, or --table and --alter. (A missing --table or
+// --alter is reported by normalizeOptions, since --desired and --statement
+// supply them.)
+func (m *Migration) validateStatementSource() error {
+	if m.Desired != "" && (m.Statement != "" || m.Alter != "") {
+		return errors.New("--desired cannot be combined with --statement or --alter")
+	}
+	if m.Statement != "" && (m.Table != "" || m.Alter != "") {
+		return errors.New("only --statement or --table and --alter can be specified")
+	}
+	return nil
+}
+
+// validateTableNames checks --new-table-name and --old-table-name against the
+// statements being run. It is called once normalizeOptions has parsed them.
+func (m *Migration) validateTableNames(stmts []*statement.AbstractStatement) error {
+	if m.NewTableName == "" && m.OldTableName == "" {
+		return nil
+	}
+	if len(stmts) > 1 {
+		return errors.New("--new-table-name and --old-table-name can only be used when migrating a single table")
 	}
-	if m.TargetChunkTime < 0 {
-		return fmt.Errorf("--target-chunk-time must be non-negative, got %s", m.TargetChunkTime)
+	for _, name := range []string{m.NewTableName, m.OldTableName} {
+		// Compared case-insensitively: with lower_case_table_names the
+		// server treats names differing only in case as the same table.
+		if name != "" && strings.EqualFold(name, stmts[0].Table) {
+			return fmt.Errorf("--new-table-name and --old-table-name cannot be the name of the table being migrated (%q)", name)
+		}
 	}
-	if m.ReplicaMaxLag < 0 {
-		return fmt.Errorf("--replica-max-lag must be non-negative, got %s", m.ReplicaMaxLag)
+	return nil
+}
+
+// validateChunkKey checks that --chunk-key is only used when migrating a
+// single table, since each table has its own indexes.
+func (m *Migration) validateChunkKey(stmts []*statement.AbstractStatement) error {
+	if m.ChunkKey != "" && len(stmts) > 1 {
+		return errors.New("--chunk-key can only be used when migrating a single table")
 	}
-	if m.CheckpointMaxAge < 0 {
-		return fmt.Errorf("--checkpoint-max-age must be non-negative, got %s", m.CheckpointMaxAge)
+	return nil
+}
+
+// validateTableChunkOptions checks that --table-target-chunk-time and
+// --table-target-chunk-size only name tables being migrated, so that a typo
+// does not silently leave a table on the global target.
+func (m *Migration) validateTableChunkOptions(stmts []*statement.AbstractStatement) error {
+	for _, opt := range []struct {
+		flag   string
+		tables []string
+	}{
+		{"--table-target-chunk-time", slices.Sorted(maps.Keys(m.TableTargetChunkTime))},
+		{"--table-target-chunk-size", slices.Sorted(maps.Keys(m.TableTargetChunkSize))},
+	} {
+		for _, tbl := range opt.tables {
+			if !slices.ContainsFunc(stmts, func(stmt *statement.AbstractStatement) bool { return stmt.Table == tbl }) {
+				return fmt.Errorf("%s: table %q is not being migrated", opt.flag, tbl)
+			}
+		}
 	}
 	return nil
 }
 
+// tableTargetChunkTime returns the target chunk time for tableName: its
+// --table-target-chunk-time if set, otherwise --target-chunk-time.
+func (m *Migration) tableTargetChunkTime(tableName string) time.Duration {
+	if d, ok := m.TableTargetChunkTime[tableName]; ok && d > 0 {
+		return d
+	}
+	return m.TargetChunkTime
+}
+
+// tableTargetChunkSize returns the copy chunk byte budget for tableName:
+// its --table-target-chunk-size if set, otherwise --target-chunk-size.
+func (m *Migration) tableTargetChunkSize(tableName string) uint64 {
+	if size, ok := m.TableTargetChunkSize[tableName]; ok && size > 0 {
+		return size
+	}
+	return m.TargetChunkSize
+}
+
 func (m *Migration) Run() error {
 	migration, err := NewRunner(m)
 	if err != nil {
 		return err
 	}
-	defer utils.CloseAndLog(migration)
-	if err := migration.runChecks(context.TODO(), check.ScopePreRun); err != nil {
-		return err
+	return migration.runToCompletion()
+}
+
+// runToCompletion runs the pre-run checks and then the migration, and closes
+// the runner.
+func (r *Runner) runToCompletion() error {
+	defer utils.CloseAndLog(r)
+	if err := r.runChecks(context.TODO(), check.ScopePreRun); err != nil {
+		return fmt.Errorf("%w: %w", ErrPreflight, err)
 	}
-	if err := migration.Run(context.TODO()); err != nil {
+	if err := r.Run(context.TODO()); err != nil {
 		return err
 	}
 	return nil
@@ -160,6 +499,9 @@ func (m *Migration) normalizeOptions() (stmts []*statement.AbstractStatement, er
 	if m.Threads == 0 {
 		m.Threads = 4
 	}
+	if m.ChecksumThreads == 0 {
+		m.ChecksumThreads = m.Threads
+	}
 	if m.ReplicaMaxLag == 0 {
 		m.ReplicaMaxLag = 120 * time.Second
 	}
@@ -169,15 +511,21 @@ func (m *Migration) normalizeOptions() (stmts []*statement.AbstractStatement, er
 	if m.ChecksumYieldTimeout == 0 {
 		m.ChecksumYieldTimeout = checksum.DefaultYieldTimeout
 	}
+	if m.OnExistingArtifacts == "" {
+		m.OnExistingArtifacts = ArtifactPolicyDropAndRecreate
+	}
 
 	if err := m.normalizeConnectionOptions(); err != nil {
 		return nil, err
 	}
 
+	if err := m.validateStatementSource(); err != nil {
+		return nil, err
+	}
+	if m.Desired != "" { // the ALTER is computed from the desired state
+		return m.normalizeDesired()
+	}
 	if m.Statement != "" { // statement is specified
-		if m.Table != "" || m.Alter != "" {
-			return nil, errors.New("only --statement or --table and --alter can be specified")
-		}
 		// extract the table and alter from the statement.
 		// if it is a CREATE INDEX statement, we rewrite it to an alter statement.
 		// This also returns the StmtNode.
@@ -221,6 +569,32 @@ func (m *Migration) normalizeOptions() (stmts []*statement.AbstractStatement, er
 	return stmts, err
 }
 
+// normalizeDesired validates --desired, which must be a single CREATE TABLE
+// statement for --table (or the table it names, if --table is not set). The
+// statement it returns is the CREATE TABLE itself: the runner replaces it with
+// the ALTER from the live table to the desired state once it can read the
+// live table (see alterFromDesired).
+func (m *Migration) normalizeDesired() ([]*statement.AbstractStatement, error) {
+	stmts, err := statement.New(m.Desired)
+	if err != nil {
+		return nil, err
+	}
+	if len(stmts) != 1 || !stmts[0].IsCreateTable() {
+		return nil, errors.New("--desired must be a single CREATE TABLE statement")
+	}
+	stmt := stmts[0]
+	if stmt.Schema != "" && stmt.Schema != m.Database {
+		return nil, errors.New("schema name in --desired (`schema`.`table`) does not match --database")
+	}
+	if m.Table == "" {
+		m.Table = stmt.Table
+	} else if m.Table != stmt.Table {
+		return nil, fmt.Errorf("--desired is a CREATE TABLE for %q, not --table %q", stmt.Table, m.Table)
+	}
+	stmt.Schema = m.Database
+	return stmts, nil
+}
+
 func (m *Migration) normalizeConnectionOptions() error {
 	confParams, err := newConfParams(m.ConfFile)
 	if err != nil {
@@ -233,6 +607,16 @@ func (m *Migration) normalizeConnectionOptions() error {
 		hostAndPort := fmt.Sprintf("%s:%d", m.Host, confParams.GetPort())
 		m.Host = hostAndPort
 	}
+	username, password, err := m.Credentials.resolve(m.Username == "", m.Password == nil)
+	if err != nil {
+		return err
+	}
+	if m.Username == "" {
+		m.Username = username
+	}
+	if m.Password == nil {
+		m.Password = password
+	}
 	if m.Username == "" {
 		m.Username = confParams.GetUser()
 	}
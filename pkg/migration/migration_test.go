@@ -1,12 +1,15 @@
 package migration
 
 import (
+	"bytes"
 	"fmt"
 	"log/slog"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -297,6 +300,94 @@ func testConvertCharset(t *testing.T, enableBuffered bool) {
 	require.NoError(t, m.Run())
 }
 
+// TestNewTableCharset converts a latin1 table to utf8mb4 as part of an
+// unrelated ALTER. The table has a VARCHAR primary key, so it is copied and
+// checksummed with the composite chunker, whose chunk boundaries are compared
+// under latin1 on the original table and utf8mb4 on the new one.
+func TestNewTableCharset(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "t1newcharset", `CREATE TABLE t1newcharset (
+		id varchar(20) not null primary key,
+		b varchar(100) not null
+	) charset=latin1`)
+	testutils.RunSQL(t, `INSERT INTO t1newcharset VALUES ('a', 'à'), ('B', '€'), ('é', 'x'), ('z', 'ü'), ('Zé', 'ß')`)
+
+	m := NewTestMigration(t, WithTable("t1newcharset"), WithAlter("ADD COLUMN c INT"),
+		WithNewTableCharset("utf8mb4", "utf8mb4_0900_ai_ci"))
+	require.NoError(t, m.Run())
+
+	var charset, collation string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), `SELECT CCSA.CHARACTER_SET_NAME, T.TABLE_COLLATION
+		FROM information_schema.TABLES T
+		JOIN information_schema.COLLATION_CHARACTER_SET_APPLICABILITY CCSA ON CCSA.COLLATION_NAME = T.TABLE_COLLATION
+		WHERE T.TABLE_SCHEMA = DATABASE() AND T.TABLE_NAME = 't1newcharset'`).Scan(&charset, &collation))
+	require.Equal(t, "utf8mb4", charset)
+	require.Equal(t, "utf8mb4_0900_ai_ci", collation)
+	var b string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), `SELECT b FROM t1newcharset WHERE id = 'B'`).Scan(&b))
+	require.Equal(t, "€", b)
+}
+
+// TestArtifactTableOptions checks that --artifact-table-options is applied to
+// the new table, which becomes the table at cutover, and is part of the
+// checkpoint table's CREATE TABLE.
+func TestArtifactTableOptions(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "t1artifactopts", `CREATE TABLE t1artifactopts (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO t1artifactopts (name) VALUES ('a'), ('b'), ('c')")
+
+	m := NewTestRunner(t, "t1artifactopts", "ADD COLUMN c INT, ADD INDEX (c)",
+		WithArtifactTableOptions("ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8"))
+	var mu sync.Mutex
+	var stmts []string
+	m.OnExecDDL(func(stmt string) {
+		mu.Lock()
+		defer mu.Unlock()
+		stmts = append(stmts, stmt)
+	})
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+
+	var createOptions string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), `SELECT CREATE_OPTIONS FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 't1artifactopts'`).Scan(&createOptions))
+	require.Contains(t, createOptions, "row_format=COMPRESSED")
+	require.Contains(t, createOptions, "KEY_BLOCK_SIZE=8")
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Contains(t, stmts, "ALTER TABLE `_t1artifactopts_new` ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8")
+	i := slices.IndexFunc(stmts, func(stmt string) bool {
+		return strings.HasPrefix(stmt, "CREATE TABLE `_t1artifactopts_chkpnt`")
+	})
+	require.GreaterOrEqual(t, i, 0, "the checkpoint table was not created")
+	require.True(t, strings.HasSuffix(stmts[i], ") ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8"), stmts[i])
+}
+
+// TestExplainChunksWarnsOnFilesort migrates a table whose primary key mixes
+// descending and ascending parts, so the composite chunker's boundary query
+// cannot read rows in key order, and checks that --explain-chunks warns.
+func TestExplainChunksWarnsOnFilesort(t *testing.T) {
+	t.Parallel()
+	testutils.NewTestTable(t, "t1explaindesc", `CREATE TABLE t1explaindesc (
+		a int not null,
+		b int not null,
+		PRIMARY KEY (a DESC, b)
+	)`)
+	testutils.RunSQL(t, `INSERT INTO t1explaindesc VALUES (1, 1), (1, 2), (2, 1)`)
+
+	m := NewTestRunner(t, "t1explaindesc", "ENGINE=InnoDB", WithExplainChunks())
+	var logs bytes.Buffer
+	m.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+	require.Contains(t, logs.String(), "the chunk query needs a filesort or temporary table")
+	require.Contains(t, logs.String(), "table=t1explaindesc")
+}
+
 func TestStmtWorkflow(t *testing.T) {
 	t.Parallel()
 	testutils.RunSQL(t, `DROP TABLE IF EXISTS t1s`)
@@ -315,6 +406,58 @@ func TestStmtWorkflow(t *testing.T) {
 	testutils.RunSQL(t, `DROP TABLE IF EXISTS t1s`)
 }
 
+func TestExcludeColumns(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "excludecols", `CREATE TABLE excludecols (
+		id int not null primary key auto_increment,
+		b int not null,
+		ssn varchar(11) not null
+	)`)
+	tt.SeedRows(t, "INSERT INTO excludecols (b, ssn) SELECT 1, '123-45-6789' FROM dual", 1000)
+
+	// The key must always be copied.
+	m := NewTestRunner(t, "excludecols", "ENGINE=InnoDB", WithExcludeColumns("id"))
+	require.ErrorContains(t, m.Run(t.Context()), "part of the key")
+	require.NoError(t, m.Close())
+
+	// Re-add ssn as NULLable and exclude it: the checksum ignores it, and
+	// every row is left at the new default.
+	m = NewTestRunner(t, "excludecols", "MODIFY ssn varchar(11) NULL DEFAULT NULL", WithExcludeColumns("ssn"))
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+
+	var count int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM excludecols WHERE ssn IS NULL AND b = 1").Scan(&count))
+	require.Equal(t, 1000, count)
+}
+
+func TestChunkKey(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "chunkkeytbl", `CREATE TABLE chunkkeytbl (
+		id bigint not null primary key auto_increment,
+		tenant_id int not null,
+		external_id int not null,
+		note varchar(100) null,
+		KEY idx_note (note)
+	)`)
+	tt.SeedRows(t, "INSERT INTO chunkkeytbl (tenant_id, external_id) SELECT 0, 0", 1000)
+	testutils.RunSQL(t, "UPDATE chunkkeytbl SET tenant_id = id % 10, external_id = id")
+	testutils.RunSQL(t, "ALTER TABLE chunkkeytbl ADD UNIQUE KEY uk_tenant_external (tenant_id, external_id)")
+
+	// The index must be UNIQUE.
+	m := NewTestRunner(t, "chunkkeytbl", "ENGINE=InnoDB", WithChunkKey("idx_note"))
+	require.ErrorContains(t, m.Run(t.Context()), "cannot be chunked on index idx_note: the index is not UNIQUE")
+	require.NoError(t, m.Close())
+
+	m = NewTestRunner(t, "chunkkeytbl", "ADD COLUMN c int", WithChunkKey("uk_tenant_external"))
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+
+	var count int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM chunkkeytbl").Scan(&count))
+	require.Equal(t, 1024, count)
+}
+
 func TestCreateIndexIsRewritten(t *testing.T) {
 	t.Parallel()
 	testutils.NewTestTable(t, "t1createindex", `CREATE TABLE t1createindex (
@@ -406,6 +549,51 @@ func TestBufferedMultiTableMigration(t *testing.T) {
 	require.Equal(t, 100, count2)
 }
 
+// TestTableTargetChunkOptionsMigration runs a two-table migration with a
+// different chunk target for each table, and checks that the chunkers of
+// each table are sized by its own target.
+func TestTableTargetChunkOptionsMigration(t *testing.T) {
+	tt := testutils.NewTestTable(t, "tcto_small", `CREATE TABLE tcto_small (
+		id int not null primary key auto_increment,
+		val int not null
+	)`)
+	testutils.NewTestTable(t, "tcto_blobs", `CREATE TABLE tcto_blobs (
+		id int not null primary key auto_increment,
+		payload mediumblob not null
+	)`)
+	testutils.RunSQL(t, `INSERT INTO tcto_small (val) SELECT seq FROM (
+		WITH RECURSIVE seq_cte AS (SELECT 0 AS seq UNION ALL SELECT seq+1 FROM seq_cte WHERE seq < 99)
+		SELECT seq FROM seq_cte) t`)
+	testutils.RunSQL(t, `INSERT INTO tcto_blobs (payload) SELECT REPEAT('x', 10000) FROM (
+		WITH RECURSIVE seq_cte AS (SELECT 0 AS seq UNION ALL SELECT seq+1 FROM seq_cte WHERE seq < 99)
+		SELECT seq FROM seq_cte) t`)
+
+	m := NewTestMigration(t, WithStatement("ALTER TABLE tcto_small ADD COLUMN extra int DEFAULT 0; ALTER TABLE tcto_blobs ADD COLUMN extra int DEFAULT 0"),
+		WithTableTargetChunkTime("tcto_small", 2*time.Second), WithTableTargetChunkTime("tcto_blobs", 100*time.Millisecond),
+		WithTableTargetChunkSize("tcto_blobs", 64<<10))
+	r, err := NewRunner(m)
+	require.NoError(t, err)
+	require.NoError(t, r.Run(t.Context()))
+
+	for _, change := range r.changes {
+		checksumCfg, copyCfg := r.chunkerConfigs(change, nil)
+		switch change.table.TableName {
+		case "tcto_small":
+			require.Equal(t, 2*time.Second, checksumCfg.TargetChunkTime)
+			require.Equal(t, uint64(table.DefaultTargetChunkBytes), copyCfg.TargetChunkBytes)
+		case "tcto_blobs":
+			require.Equal(t, 100*time.Millisecond, checksumCfg.TargetChunkTime)
+			require.Equal(t, uint64(64<<10), copyCfg.TargetChunkBytes)
+		default:
+			t.Fatalf("unexpected table %s", change.table.TableName)
+		}
+	}
+	var count int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM tcto_blobs WHERE extra = 0`).Scan(&count))
+	require.Equal(t, 100, count)
+	require.NoError(t, r.Close())
+}
+
 func TestMigrationParamsDefaultsUsed(t *testing.T) {
 	t.Parallel()
 	migration := &Migration{Table: "test_table", Alter: "ENGINE=INNODB"}
@@ -808,6 +996,11 @@ func TestBadOptions(t *testing.T) {
 	_, err = NewRunner(&Migration{Host: cfg.Addr, Database: "mydatabase", Table: "mytable"})
 	require.Error(t, err)
 	require.ErrorContains(t, err, "alter statement is required")
+
+	// NewRunner calls Validate, so invalid combinations are rejected
+	// before the options are normalized.
+	_, err = NewRunner(&Migration{Host: cfg.Addr, Database: "mydatabase", Table: "mytable", Alter: "ENGINE=InnoDB", Lint: true, LintOnly: true})
+	require.ErrorContains(t, err, "--lint and --lint-only cannot be used together")
 }
 
 // TestBadAlter tests various invalid ALTER statement scenarios.
@@ -959,12 +1152,65 @@ func TestMigrationValidate(t *testing.T) {
 			wantErr: "--threads must be non-negative, got -5"},
 		{name: "negative write-threads", m: Migration{WriteThreads: -1},
 			wantErr: "--write-threads must be non-negative, got -1"},
+		{name: "negative checksum-threads", m: Migration{ChecksumThreads: -2},
+			wantErr: "--checksum-threads must be non-negative, got -2"},
 		{name: "negative target-chunk-time", m: Migration{TargetChunkTime: -time.Second},
 			wantErr: "--target-chunk-time must be non-negative, got -1s"},
 		{name: "negative replica-max-lag", m: Migration{ReplicaMaxLag: -time.Minute},
 			wantErr: "--replica-max-lag must be non-negative, got -1m0s"},
+		{name: "negative table-target-chunk-time", m: Migration{TableTargetChunkTime: map[string]time.Duration{"t1": -time.Second}},
+			wantErr: "--table-target-chunk-time for t1 must be non-negative, got -1s"},
 		{name: "negative checkpoint-max-age", m: Migration{CheckpointMaxAge: -time.Hour},
 			wantErr: "--checkpoint-max-age must be non-negative, got -1h0m0s"},
+		{name: "throttle query with threshold", m: Migration{ThrottleQuery: "SELECT COUNT(*) FROM jobs", ThrottleThreshold: 1000}},
+		{name: "throttle-threshold without throttle-query", m: Migration{ThrottleThreshold: 1000},
+			wantErr: "--throttle-threshold requires --throttle-query"},
+		{name: "negative max-threads-running", m: Migration{MaxThreadsRunning: -1},
+			wantErr: "--max-threads-running must be non-negative, got -1"},
+		{name: "fail on existing artifacts", m: Migration{OnExistingArtifacts: ArtifactPolicyFail}},
+		{name: "unknown on-existing-artifacts", m: Migration{OnExistingArtifacts: "ignore"},
+			wantErr: `--on-existing-artifacts must be "drop-and-recreate" or "fail", got "ignore"`},
+		{name: "negative lock-wait-timeout", m: Migration{LockWaitTimeout: -time.Second},
+			wantErr: "--lock-wait-timeout must be non-negative, got -1s"},
+		{name: "negative checksum-yield-timeout", m: Migration{ChecksumYieldTimeout: -time.Hour},
+			wantErr: "--checksum-yield-timeout must be non-negative, got -1h0m0s"},
+		{name: "statement with table", m: Migration{Statement: "ALTER TABLE t1 ENGINE=InnoDB", Table: "t1"},
+			wantErr: "only --statement or --table and --alter can be specified"},
+		{name: "desired with alter", m: Migration{Desired: "CREATE TABLE t1 (id int primary key)", Alter: "ENGINE=InnoDB"},
+			wantErr: "--desired cannot be combined with --statement or --alter"},
+		{name: "desired with table", m: Migration{Desired: "CREATE TABLE t1 (id int primary key)", Table: "t1"}},
+		{name: "tls-mode is case insensitive", m: Migration{TLSMode: "verify_ca", TLSCertificatePath: "/path/to/ca"}},
+		{name: "unknown tls-mode", m: Migration{TLSMode: "ON"},
+			wantErr: `--tls-mode must be one of DISABLED, PREFERRED, REQUIRED, VERIFY_CA or VERIFY_IDENTITY, got "ON"`},
+		{name: "tls-ca with tls disabled", m: Migration{TLSMode: "disabled", TLSCertificatePath: "/path/to/ca"},
+			wantErr: "--tls-ca cannot be used with --tls-mode DISABLED"},
+		{name: "custom table names", m: Migration{NewTableName: "t1_shadow", OldTableName: "t1_archive"}},
+		{name: "new-table-name too long", m: Migration{NewTableName: strings.Repeat("n", 65)},
+			wantErr: "--new-table-name must be 64 characters or fewer, got 65"},
+		{name: "old-table-name too long", m: Migration{OldTableName: strings.Repeat("o", 65)},
+			wantErr: "--old-table-name must be 64 characters or fewer, got 65"},
+		{name: "same new and old table name", m: Migration{NewTableName: "t1_x", OldTableName: "t1_x"},
+			wantErr: "--new-table-name and --old-table-name must be different"},
+		{name: "new and old table name differ only in case", m: Migration{NewTableName: "t1_x", OldTableName: "T1_X"},
+			wantErr: "--new-table-name and --old-table-name must be different"},
+		{name: "new table charset and collation", m: Migration{NewTableCharset: "utf8mb4", NewTableCollation: "utf8mb4_bin"}},
+		{name: "new table collation without charset", m: Migration{NewTableCollation: "utf8mb4_bin"},
+			wantErr: "--new-table-collation requires --new-table-charset"},
+		{name: "artifact table options", m: Migration{ArtifactTableOptions: "ROW_FORMAT=COMPRESSED KEY_BLOCK_SIZE=8"}},
+		{name: "artifact table options with another statement", m: Migration{ArtifactTableOptions: "ROW_FORMAT=COMPRESSED; DROP TABLE t1"},
+			wantErr: "--artifact-table-options must be a list of table options: expected exactly one statement, got 2"},
+		{name: "artifact table options with a select", m: Migration{ArtifactTableOptions: "ENGINE=InnoDB SELECT * FROM t1"},
+			wantErr: `--artifact-table-options must be a list of table options, got "ENGINE=InnoDB SELECT * FROM t1"`},
+		{name: "artifact table options with a partition clause", m: Migration{ArtifactTableOptions: "PARTITION BY HASH(id) PARTITIONS 4"},
+			wantErr: `--artifact-table-options must be a list of table options, got "PARTITION BY HASH(id) PARTITIONS 4"`},
+		{name: "artifact table options with a percent sign", m: Migration{ArtifactTableOptions: "COMMENT='100% compressed'"}},
+		{name: "fixed chunk rows", m: Migration{FixedChunkRows: 1000}},
+		{name: "fixed chunk rows too large", m: Migration{FixedChunkRows: 100001},
+			wantErr: "--fixed-chunk-rows must be at most 100000, got 100001"},
+		{name: "every problem is reported", m: Migration{Lint: true, LintOnly: true, Threads: -1, ThrottleThreshold: 5},
+			wantErr: "--lint and --lint-only cannot be used together\n" +
+				"--threads must be non-negative, got -1\n" +
+				"--throttle-threshold requires --throttle-query"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
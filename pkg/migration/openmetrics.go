@@ -0,0 +1,106 @@
+package migration
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/block/spirit/pkg/status"
+)
+
+// openMetricsContentType is the media type of the OpenMetrics text format.
+const openMetricsContentType = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+
+// MetricsHandler returns an http.Handler that serves the runner's current
+// progress in the OpenMetrics text format, so a migration embedded in a
+// service can be inspected with curl or scraped without a metrics sink:
+//
+//	http.Handle("/migration/metrics", migration.MetricsHandler(runner))
+//
+// The values are those of Runner.Progress, read on each request: the state
+// (as a state set), whether the migration is paused or throttled, the
+// buffered change count, the copy fraction and ETA, the rows copied per
+// table and the checksum's progress and fraction. Every sample carries the labels of
+// SetMetricsSink (schema, table and correlation_id).
+func MetricsHandler(r *Runner) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		var b strings.Builder
+		writeOpenMetrics(&b, r.metricsLabels(), r.Progress())
+		w.Header().Set("Content-Type", openMetricsContentType)
+		_, _ = io.WriteString(w, b.String())
+	})
+}
+
+// writeOpenMetrics writes progress as OpenMetrics text, each sample labeled
+// with labels, followed by the terminating # EOF.
+func writeOpenMetrics(w io.Writer, labels map[string]string, progress status.Progress) {
+	gauge := func(name, help string) {
+		fmt.Fprintf(w, "# TYPE %s gauge\n# HELP %s %s\n", name, name, help)
+	}
+	sample := func(name string, extra map[string]string, value float64) {
+		fmt.Fprintf(w, "%s%s %s\n", name, openMetricsLabels(labels, extra), strconv.FormatFloat(value, 'g', -1, 64))
+	}
+	boolValue := func(v bool) float64 {
+		if v {
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(w, "# TYPE spirit_migration_state stateset\n# HELP spirit_migration_state The state the migration is in.\n")
+	for state := status.Initial; state <= status.ErrCleanup; state++ {
+		sample("spirit_migration_state", map[string]string{"spirit_migration_state": state.String()}, boolValue(state == progress.CurrentState))
+	}
+	gauge("spirit_migration_paused", "Whether the migration is paused.")
+	sample("spirit_migration_paused", nil, boolValue(progress.Paused))
+	gauge("spirit_migration_throttled", "Whether the copy is throttled.")
+	sample("spirit_migration_throttled", nil, boolValue(progress.Throttled))
+	gauge("spirit_migration_delta_len", "Changes buffered to be applied to the new table.")
+	sample("spirit_migration_delta_len", nil, float64(progress.DeltaLen))
+	gauge("spirit_migration_copy_ratio", "Fraction of rows copied, across all tables.")
+	sample("spirit_migration_copy_ratio", nil, progress.CopyFraction())
+	if progress.ETA.State == status.ETAReady {
+		fmt.Fprintf(w, "# TYPE spirit_migration_copy_eta_seconds gauge\n# UNIT spirit_migration_copy_eta_seconds seconds\n# HELP spirit_migration_copy_eta_seconds Estimated time until the copy finishes.\n")
+		sample("spirit_migration_copy_eta_seconds", nil, progress.ETA.Duration.Seconds())
+	}
+	if len(progress.Tables) > 0 {
+		gauge("spirit_migration_table_rows_copied", "Rows copied so far, per table.")
+		for _, t := range progress.Tables {
+			sample("spirit_migration_table_rows_copied", map[string]string{"source_table": t.TableName}, float64(t.RowsCopied))
+		}
+		gauge("spirit_migration_table_rows_estimated", "Rows expected to be copied, per table.")
+		for _, t := range progress.Tables {
+			sample("spirit_migration_table_rows_estimated", map[string]string{"source_table": t.TableName}, float64(t.RowsTotal))
+		}
+	}
+	gauge("spirit_migration_checksum_rows_checked", "Rows verified by the checksum so far.")
+	sample("spirit_migration_checksum_rows_checked", nil, float64(progress.Checksum.RowsChecked))
+	gauge("spirit_migration_checksum_rows_estimated", "Rows the checksum is expected to verify.")
+	sample("spirit_migration_checksum_rows_estimated", nil, float64(progress.Checksum.RowsTotal))
+	gauge("spirit_migration_checksum_ratio", "Fraction of rows verified by the checksum.")
+	sample("spirit_migration_checksum_ratio", nil, progress.Checksum.Fraction())
+	fmt.Fprint(w, "# EOF\n")
+}
+
+// openMetricsLabels renders labels and extra, sorted by name, as {k="v",...}.
+func openMetricsLabels(labels, extra map[string]string) string {
+	all := maps.Clone(labels)
+	if all == nil {
+		all = make(map[string]string, len(extra))
+	}
+	maps.Copy(all, extra)
+	if len(all) == 0 {
+		return ""
+	}
+	pairs := make([]string, 0, len(all))
+	for _, k := range slices.Sorted(maps.Keys(all)) {
+		pairs = append(pairs, k+`="`+openMetricsEscaper.Replace(all[k])+`"`)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+var openMetricsEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
@@ -0,0 +1,105 @@
+package migration
+
+import (
+	"io"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/status"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	openMetricsMeta   = regexp.MustCompile(`^# (TYPE|HELP|UNIT) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.+)$`)
+	openMetricsSample = regexp.MustCompile(`^([a-zA-Z_:][a-zA-Z0-9_:]*)(\{(?:[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*",?)*\})? (\S+)$`)
+)
+
+// parseOpenMetrics checks that text is well-formed OpenMetrics: every sample
+// belongs to the family declared last, and the text ends in # EOF. It returns
+// the samples keyed by name and labels.
+func parseOpenMetrics(t *testing.T, text string) map[string]float64 {
+	t.Helper()
+	require.True(t, strings.HasSuffix(text, "# EOF\n"), "missing # EOF")
+	samples := make(map[string]float64)
+	family := ""
+	for line := range strings.Lines(strings.TrimSuffix(text, "# EOF\n")) {
+		line = strings.TrimSuffix(line, "\n")
+		if m := openMetricsMeta.FindStringSubmatch(line); m != nil {
+			if m[1] == "TYPE" {
+				require.Contains(t, []string{"gauge", "counter", "stateset", "info", "unknown"}, m[3], line)
+				family = m[2]
+			}
+			require.Equal(t, family, m[2], line)
+			continue
+		}
+		m := openMetricsSample.FindStringSubmatch(line)
+		require.NotNil(t, m, "malformed line %q", line)
+		require.Equal(t, family, m[1], line)
+		value, err := strconv.ParseFloat(m[3], 64)
+		require.NoError(t, err, line)
+		samples[m[1]+m[2]] = value
+	}
+	return samples
+}
+
+func TestWriteOpenMetrics(t *testing.T) {
+	var b strings.Builder
+	writeOpenMetrics(&b, map[string]string{"schema": "test", "table": "t1"}, status.Progress{
+		CurrentState: status.CopyRows,
+		Throttled:    true,
+		DeltaLen:     7,
+		ETA:          status.ETA{State: status.ETAReady, Duration: 90 * time.Second},
+		Tables:       []status.TableProgress{{TableName: "t1", RowsCopied: 250, RowsTotal: 1000}},
+	})
+	samples := parseOpenMetrics(t, b.String())
+
+	require.Equal(t, map[string]float64{
+		`spirit_migration_state{schema="test",spirit_migration_state="initial",table="t1"}`:                 0,
+		`spirit_migration_state{schema="test",spirit_migration_state="copyRows",table="t1"}`:                1,
+		`spirit_migration_state{schema="test",spirit_migration_state="applyChangeset",table="t1"}`:          0,
+		`spirit_migration_state{schema="test",spirit_migration_state="restoreSecondaryIndexes",table="t1"}`: 0,
+		`spirit_migration_state{schema="test",spirit_migration_state="analyzeTable",table="t1"}`:            0,
+		`spirit_migration_state{schema="test",spirit_migration_state="checksum",table="t1"}`:                0,
+		`spirit_migration_state{schema="test",spirit_migration_state="postChecksum",table="t1"}`:            0,
+		`spirit_migration_state{schema="test",spirit_migration_state="waitingOnSentinelTable",table="t1"}`:  0,
+		`spirit_migration_state{schema="test",spirit_migration_state="cutOver",table="t1"}`:                 0,
+		`spirit_migration_state{schema="test",spirit_migration_state="reverseWindow",table="t1"}`:           0,
+		`spirit_migration_state{schema="test",spirit_migration_state="close",table="t1"}`:                   0,
+		`spirit_migration_state{schema="test",spirit_migration_state="errCleanup",table="t1"}`:              0,
+		`spirit_migration_paused{schema="test",table="t1"}`:                                                 0,
+		`spirit_migration_throttled{schema="test",table="t1"}`:                                              1,
+		`spirit_migration_delta_len{schema="test",table="t1"}`:                                              7,
+		`spirit_migration_copy_ratio{schema="test",table="t1"}`:                                             0.25,
+		`spirit_migration_copy_eta_seconds{schema="test",table="t1"}`:                                       90,
+		`spirit_migration_table_rows_copied{schema="test",source_table="t1",table="t1"}`:                    250,
+		`spirit_migration_table_rows_estimated{schema="test",source_table="t1",table="t1"}`:                 1000,
+		`spirit_migration_checksum_rows_checked{schema="test",table="t1"}`:                                  0,
+		`spirit_migration_checksum_rows_estimated{schema="test",table="t1"}`:                                0,
+		`spirit_migration_checksum_ratio{schema="test",table="t1"}`:                                         0,
+	}, samples)
+}
+
+// TestMetricsHandler checks that the handler serves the runner's Progress,
+// labeled as its metrics sink would be.
+func TestMetricsHandler(t *testing.T) {
+	r, err := NewRunner(&Migration{Database: "test", Table: "t1", Alter: "ENGINE=InnoDB", CorrelationID: "CHG-1"})
+	require.NoError(t, err)
+	r.Pause()
+
+	rec := httptest.NewRecorder()
+	MetricsHandler(r).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Equal(t, "application/openmetrics-text; version=1.0.0; charset=utf-8", rec.Header().Get("Content-Type"))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	samples := parseOpenMetrics(t, string(body))
+
+	labels := `correlation_id="CHG-1",schema="test",table="t1"`
+	require.InDelta(t, 1, samples[`spirit_migration_state{correlation_id="CHG-1",schema="test",spirit_migration_state="initial",table="t1"}`], 0)
+	require.InDelta(t, 1, samples[`spirit_migration_paused{`+labels+`}`], 0)
+	require.Contains(t, samples, `spirit_migration_copy_ratio{`+labels+`}`)
+	require.NotContains(t, samples, `spirit_migration_copy_eta_seconds{`+labels+`}`)
+}
@@ -417,6 +417,54 @@ func TestCheckpointResumeDuringChecksum(t *testing.T) {
 	require.True(t, r2.usedResumeFromCheckpoint)
 }
 
+// TestCheckpointResumeChecksumSkipsVerifiedChunks checks that a migration
+// resumed with a checksum_watermark in its checkpoint continues the checksum
+// from that watermark: it checksums fewer chunks than the interrupted run
+// verified, rather than starting over.
+func TestCheckpointResumeChecksumSkipsVerifiedChunks(t *testing.T) {
+	t.Parallel()
+	dbName, _ := testutils.CreateUniqueTestDatabase(t)
+	testutils.RunSQLInDatabase(t, dbName, `CREATE TABLE cptskip (
+		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		pad VARCHAR(100) NOT NULL default 0)`)
+	testutils.RunSQLInDatabase(t, dbName, `CREATE TABLE _spirit_sentinel (id INT NOT NULL PRIMARY KEY)`)
+	testutils.RunSQLInDatabase(t, dbName, `INSERT INTO cptskip (pad) WITH RECURSIVE n (i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < 10000) SELECT REPEAT('a', 100) FROM n`)
+
+	r := NewTestRunner(t, "cptskip", "ENGINE=InnoDB",
+		WithDBName(dbName),
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithRespectSentinel())
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := make(chan error, 1)
+	go func() {
+		c <- r.Run(ctx)
+	}()
+	waitForStatus(t, r, status.WaitingOnSentinelTable)
+
+	// Checksum the whole table while Run is blocked on the sentinel, and
+	// checkpoint the checksum's watermark.
+	require.NoError(t, r.checksum(t.Context()))
+	require.NoError(t, r.DumpCheckpoint(t.Context()))
+	_, firstChunks, _ := r.checksumChunker.Progress()
+	require.Greater(t, firstChunks, uint64(1))
+	cancel()
+	require.Error(t, <-c)
+	require.NoError(t, r.Close())
+	testutils.RunSQLInDatabase(t, dbName, `DROP TABLE _spirit_sentinel`)
+
+	r2 := NewTestRunner(t, "cptskip", "ENGINE=InnoDB",
+		WithDBName(dbName),
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond))
+	require.NoError(t, r2.Run(t.Context()))
+	defer utils.CloseAndLog(r2)
+	require.True(t, r2.usedResumeFromCheckpoint)
+	_, resumedChunks, _ := r2.checksumChunker.Progress()
+	require.Less(t, resumedChunks, firstChunks, "the resumed checksum must not recheck chunks below the watermark")
+}
+
 func TestCheckpointDifferentRestoreOptions(t *testing.T) {
 	t.Parallel()
 	tt := testutils.NewTestTable(t, "cpt1difft1", `CREATE TABLE cpt1difft1 (
@@ -472,6 +520,38 @@ func TestCheckpointDifferentRestoreOptions(t *testing.T) {
 	require.NoError(t, m2.Close())
 }
 
+// TestCheckpointCosmeticallyDifferentAlter resumes a migration with the same
+// ALTER written differently: the checkpoint stores the canonical statement,
+// so whitespace, keyword case and a trailing semicolon must not prevent it.
+func TestCheckpointCosmeticallyDifferentAlter(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "cptcosmetic", `CREATE TABLE cptcosmetic (
+		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		id2 INT NOT NULL,
+		pad VARCHAR(100) NOT NULL default 0)`)
+	tt.SeedRows(t, `INSERT INTO cptcosmetic (id2, pad) SELECT 1, REPEAT('a', 100)`, 1000)
+
+	m := NewTestRunner(t, "cptcosmetic", "ADD COLUMN id3 INT NOT NULL DEFAULT 0, ADD INDEX(id2)",
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithTestThrottler())
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := make(chan error, 1)
+	go func() {
+		c <- m.Run(ctx)
+	}()
+	waitForCheckpoint(t, m)
+	cancel()
+	require.Error(t, <-c) // interrupted once a checkpoint is saved.
+	require.NoError(t, m.Close())
+
+	m2 := NewTestRunner(t, "cptcosmetic", "add column id3 int not null default 0,\n\tadd index (id2);", WithThreads(2))
+	require.NoError(t, m2.Run(t.Context()))
+	require.True(t, m2.usedResumeFromCheckpoint)
+	require.NoError(t, m2.Close())
+}
+
 func TestResumeFromCheckpointE2E(t *testing.T) {
 	t.Parallel()
 	tt := testutils.NewTestTable(t, "chkpresumetest", `CREATE TABLE chkpresumetest (
@@ -515,6 +595,139 @@ func TestResumeFromCheckpointE2E(t *testing.T) {
 	require.NoError(t, m2.Close())
 }
 
+// TestResumeFromCheckpointMoreThreads starts a migration with 4 threads and
+// resumes it with 8. The checkpoint does not store the thread count, so the
+// resumed run copies and checksums the rest of the table with 8, and every
+// row must arrive in the new table unchanged.
+func TestResumeFromCheckpointMoreThreads(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "chkpthreads", `CREATE TABLE chkpthreads (
+		id int(11) NOT NULL AUTO_INCREMENT,
+		pad varbinary(1024) NOT NULL,
+		PRIMARY KEY (id)
+	)`)
+	tt.SeedRows(t, "INSERT INTO chkpthreads (pad) SELECT RANDOM_BYTES(1024)", 100000)
+
+	m := NewTestRunner(t, "chkpthreads", "ADD INDEX(pad)",
+		WithThreads(4),
+		WithWriteThreads(4),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithTestThrottler())
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := make(chan error, 1)
+	go func() {
+		c <- m.Run(ctx)
+	}()
+	waitForCheckpoint(t, m)
+	cancel()
+	require.Error(t, <-c)
+	require.NoError(t, m.Close())
+
+	// Change rows on both sides of the watermark before resuming.
+	testutils.RunSQL(t, "INSERT INTO chkpthreads (pad) SELECT RANDOM_BYTES(1024) FROM chkpthreads LIMIT 1000")
+	testutils.RunSQL(t, "UPDATE chkpthreads SET pad = RANDOM_BYTES(1024) WHERE id % 100 = 0")
+	testutils.RunSQL(t, "DELETE FROM chkpthreads WHERE id % 100 = 1")
+	tableChecksum := func() (count int, crc int64) {
+		require.NoError(t, tt.DB.QueryRowContext(t.Context(),
+			"SELECT COUNT(*), COALESCE(BIT_XOR(CRC32(CONCAT(id, pad))), 0) FROM chkpthreads").Scan(&count, &crc))
+		return count, crc
+	}
+	wantCount, wantCRC := tableChecksum()
+
+	m2 := NewTestRunner(t, "chkpthreads", "ADD INDEX(pad)", WithThreads(8), WithWriteThreads(8))
+	require.NoError(t, m2.Run(t.Context()))
+	require.True(t, m2.usedResumeFromCheckpoint)
+	require.NoError(t, m2.Close())
+
+	gotCount, gotCRC := tableChecksum()
+	require.Equal(t, wantCount, gotCount)
+	require.Equal(t, wantCRC, gotCRC)
+}
+
+// TestResumeFromCheckpointCustomTableNames checks that a migration with
+// --new-table-name resumes into the same new table, and that the original
+// table is kept under --old-table-name.
+func TestResumeFromCheckpointCustomTableNames(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "chkpcustomnames", `CREATE TABLE chkpcustomnames (
+		id int(11) NOT NULL AUTO_INCREMENT,
+		pad varbinary(1024) NOT NULL,
+		PRIMARY KEY (id)
+	)`)
+	tt.SeedRows(t, "INSERT INTO chkpcustomnames (pad) SELECT RANDOM_BYTES(1024)", 100000)
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS chkpcustomnames_shadow, chkpcustomnames_archive")
+	t.Cleanup(func() {
+		testutils.RunSQL(t, "DROP TABLE IF EXISTS chkpcustomnames_shadow, chkpcustomnames_archive")
+	})
+
+	m := NewTestRunner(t, "chkpcustomnames", "ADD INDEX(pad)",
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithTestThrottler(),
+		WithTableNames("chkpcustomnames_shadow", "chkpcustomnames_archive"))
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := make(chan error, 1)
+	go func() {
+		c <- m.Run(ctx)
+	}()
+	waitForCheckpoint(t, m)
+	cancel()
+	require.Error(t, <-c)
+	require.NoError(t, m.Close())
+
+	m2 := NewTestRunner(t, "chkpcustomnames", "ADD INDEX(pad)",
+		WithThreads(4),
+		WithSkipDropAfterCutover(),
+		WithTableNames("chkpcustomnames_shadow", "chkpcustomnames_archive"))
+	require.NoError(t, m2.Run(t.Context()))
+	require.True(t, m2.usedResumeFromCheckpoint)
+	var count int
+	require.NoError(t, m2.db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'chkpcustomnames_archive'`).Scan(&count))
+	require.Equal(t, 1, count, "the original table should be kept under --old-table-name")
+	require.NoError(t, m2.Close())
+}
+
+// TestResumeFromCheckpointOtherChangeSource checks that a checkpoint is
+// resumed by the change source that wrote it: a GTID set with the GTID
+// client, and a binlog file:offset with the binlog client, even when
+// --enable-experimental-gtid was changed between the runs.
+func TestResumeFromCheckpointOtherChangeSource(t *testing.T) {
+	t.Parallel()
+	for _, firstGTID := range []bool{false, true} {
+		name := fmt.Sprintf("chkpsrc%t", firstGTID)
+		tt := testutils.NewTestTable(t, name, fmt.Sprintf(`CREATE TABLE %s (
+			id int(11) NOT NULL AUTO_INCREMENT,
+			pad varbinary(1024) NOT NULL,
+			PRIMARY KEY (id)
+		)`, name))
+		tt.SeedRows(t, fmt.Sprintf("INSERT INTO %s (pad) SELECT RANDOM_BYTES(1024)", name), 100000)
+
+		m := NewTestRunner(t, name, "ADD INDEX(pad)",
+			WithThreads(1),
+			WithTargetChunkTime(100*time.Millisecond),
+			WithTestThrottler(),
+			WithGTID(firstGTID))
+		ctx, cancel := context.WithCancel(t.Context())
+		c := make(chan error, 1)
+		go func() {
+			c <- m.Run(ctx)
+		}()
+		waitForCheckpoint(t, m)
+		cancel()
+		require.Error(t, <-c)
+		require.NoError(t, m.Close())
+
+		m2 := NewTestRunner(t, name, "ADD INDEX(pad)", WithThreads(4), WithGTID(!firstGTID))
+		require.NoError(t, m2.Run(t.Context()))
+		require.True(t, m2.usedResumeFromCheckpoint)
+		require.Equal(t, firstGTID, m2.gtidSource)
+		require.NoError(t, m2.Close())
+	}
+}
+
 func TestResumeFromCheckpointE2ECompositeVarcharPK(t *testing.T) {
 	t.Parallel()
 	testutils.NewTestTable(t, "compositevarcharpk", `CREATE TABLE compositevarcharpk (
@@ -1129,3 +1342,45 @@ func TestResumeErrorClassification(t *testing.T) {
 		require.False(t, resumeErrorIsDefinitive(err), "expected NOT definitive: %v", err)
 	}
 }
+
+// TestDeferSecondaryIndexesResume tests that a migration resumed without
+// --defer-secondary-indexes still adds the indexes deferred by the run that
+// wrote the checkpoint, since the checkpoint records the setting.
+func TestDeferSecondaryIndexesResume(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "deferidxresume", `CREATE TABLE deferidxresume (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		a int NOT NULL,
+		b varchar(255) NOT NULL,
+		KEY idx_a (a)
+	)`)
+	tt.SeedRows(t, "INSERT INTO deferidxresume (a, b) SELECT 1, 'a'", 100000)
+
+	alterSQL := "ADD INDEX idx_ab (a, b)"
+	m := NewTestRunner(t, "deferidxresume", alterSQL,
+		WithDeferSecondaryIndexes(),
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithTestThrottler())
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	c := make(chan error, 1)
+	go func() {
+		c <- m.Run(ctx)
+	}()
+	waitForCheckpoint(t, m)
+	cancel()
+	require.Error(t, <-c)
+	require.NoError(t, m.Close())
+
+	m2 := NewTestRunner(t, "deferidxresume", alterSQL, WithThreads(2))
+	require.NoError(t, m2.Run(t.Context()))
+	require.True(t, m2.usedResumeFromCheckpoint)
+	require.True(t, m2.deferIndexes)
+	ct, err := m2.getCreateTable(t.Context(), m2.migration.Database, "deferidxresume")
+	require.NoError(t, err)
+	for _, name := range []string{"idx_a", "idx_ab"} {
+		require.NotNil(t, ct.Indexes.ByName(name), "index %s should exist after cutover", name)
+	}
+	require.NoError(t, m2.Close())
+}
@@ -7,7 +7,10 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-sql-driver/mysql"
@@ -34,6 +37,7 @@ import (
 // These are really consts, but set to var for testing.
 var (
 	tableStatUpdateInterval = 5 * time.Minute
+	progressMetricsInterval = 5 * time.Second
 	checkpointTableName     = "_spirit_checkpoint" // const for multi-migration checkpoints.
 	// Sentinel-wait timing lives in pkg/sentinel (sentinel.WaitLimit /
 	// sentinel.CheckInterval / sentinel.TableName) and continuous-checksum
@@ -41,6 +45,37 @@ var (
 	// checksum.DefaultContinuousRetryDelay), so they are shared with move/sync.
 )
 
+var (
+	// ErrAborted is returned by Run when the migration was stopped by Abort.
+	ErrAborted = errors.New("migration aborted")
+	// ErrCannotAbort is returned by Abort once cutover has started.
+	ErrCannotAbort = errors.New("migration cannot be aborted: cutover has already started")
+	// ErrNothingToAbort is returned by Abort when Run has already returned
+	// without being aborted, so nothing was cleaned up.
+	ErrNothingToAbort = errors.New("nothing to abort: the migration has already stopped and its new and checkpoint tables were left in place")
+	// ErrPreflight wraps the error from a check that refused to start the
+	// migration.
+	ErrPreflight = errors.New("preflight check failed")
+	// ErrChecksumMismatch wraps a checksum failure that was caused by rows
+	// differing between the original and new tables.
+	ErrChecksumMismatch = errors.New("checksum mismatch")
+	// ErrCutover wraps an error from the table swap at cutover.
+	ErrCutover = errors.New("cutover failed")
+	// ErrTableChanged is returned by Run when a DDL against one of the
+	// migrated tables stopped the migration.
+	ErrTableChanged = errors.New("table changed during migration")
+	// ErrExistingArtifacts is returned by Run with ArtifactPolicyFail when a
+	// fresh migration finds its _new or checkpoint table already exists, or
+	// when any run finds one of its scratch tables already exists.
+	ErrExistingArtifacts = errors.New("refusing to drop existing migration tables")
+	// ErrPreCutoverHook wraps an error from Migration.PreCutoverHook. The
+	// cutover did not happen, and the migration can be run again.
+	ErrPreCutoverHook = errors.New("pre-cutover hook failed")
+	// ErrPostCutoverHook wraps an error from Migration.PostCutoverHook. The
+	// cutover has already happened.
+	ErrPostCutoverHook = errors.New("post-cutover hook failed")
+)
+
 // continuousDivergenceReporter is the minimal view of the sentinel-wait
 // continuous checker that the checkpoint machinery needs: "has this checker
 // observed any divergence?". Both the production *checksum.ContinuousChecker
@@ -62,10 +97,20 @@ type Runner struct {
 	// r.db pool let throttler polls queue behind chunk writes, which
 	// delayed the very signal we wanted to react to (and counted the
 	// throttler's own SELECT as an active query thread). nil unless Aurora
-	// throttling is enabled.
+	// throttling, --throttle-query or --max-threads-running is enabled.
 	monitorDB       *sql.DB
 	checkpointTable *table.TableInfo
 
+	// gtidSource selects the GTID change source over the binlog one. It is
+	// --enable-experimental-gtid for a fresh migration; a resumed migration
+	// uses whichever source wrote its checkpoint.
+	gtidSource bool
+
+	// deferIndexes is set when the new tables are created without their
+	// non-unique secondary indexes. It is --defer-secondary-indexes for a
+	// fresh migration; a resumed migration uses what its checkpoint recorded.
+	deferIndexes bool
+
 	// Changes enccapsulates all changes
 	// With a stmt, alter, table, newTable.
 	changes []*tableChange
@@ -73,6 +118,7 @@ type Runner struct {
 	status     status.State  // must use atomic helpers to change.
 	replClient change.Source // feed contains all binlog subscription activity.
 	throttler  throttler.Throttler
+	pauser     throttler.Pauser // see Pause; always one of the throttlers
 
 	copier       copier.Copier
 	copyChunker  table.Chunker // the chunker for copying
@@ -130,6 +176,9 @@ type Runner struct {
 	// reason about and avoids racing with Close() teardown of r.db and
 	// r.checkpointTable.
 	fatalOnce sync.Once
+	// tableChanged is set by fatalError when a DDL against a migrated
+	// table stopped the migration, so Run can return ErrTableChanged.
+	tableChanged atomic.Bool
 
 	// watchTaskWait blocks until the WatchTask goroutines (status/checkpoint
 	// dumpers) have exited. Set in startBackgroundRoutines and invoked from
@@ -137,17 +186,56 @@ type Runner struct {
 	// checkpoint INSERT can race with post-Close cleanup.
 	watchTaskWait func()
 
+	// abortMu serializes Abort against Run starting and against Run reaching
+	// the point of no return (cutover, or a DDL run directly on the table):
+	// either Abort marks the run aborted first (and Run then refuses to go
+	// further), or that point has been reached and Abort refuses. run drops
+	// the artifacts of an aborted migration itself, while it still holds the
+	// advisory lock, and records the outcome in abortErr. runDone is closed
+	// when Run returns, which is how Abort waits for that cleanup. runStopped
+	// is set once Run can no longer clean up, so a later Abort reports
+	// ErrNothingToAbort instead of success.
+	abortMu        sync.Mutex
+	aborted        bool
+	cutoverStarted bool
+	runStopped     bool
+	abortErr       error
+	runDone        chan struct{}
+
 	// MetricsSink
 	metricsSink metrics.Sink
+	// emittedPanicShrinks is the copier's panic shrink count when it was
+	// last sent, so emitProgressMetrics can send the increase as a counter.
+	// Only the metrics loop reads and writes it.
+	emittedPanicShrinks uint64
+
+	// ddlHook is called with each DDL statement before it runs (see
+	// OnExecDDL).
+	ddlHook func(stmt string)
+	// copyFallbackHook is called when MySQL's DDL can't be used and the
+	// table is copied instead (see OnCopyFallback).
+	copyFallbackHook func(err error)
 }
 
 var _ status.Task = (*Runner)(nil)
 
 func NewRunner(m *Migration) (*Runner, error) {
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
 	stmts, err := m.normalizeOptions()
 	if err != nil {
 		return nil, err
 	}
+	if err := m.validateTableNames(stmts); err != nil {
+		return nil, err
+	}
+	if err := m.validateTableChunkOptions(stmts); err != nil {
+		return nil, err
+	}
+	if err := m.validateChunkKey(stmts); err != nil {
+		return nil, err
+	}
 	changes := make([]*tableChange, 0, len(stmts))
 	for _, stmt := range stmts {
 		changes = append(changes, &tableChange{
@@ -155,11 +243,11 @@ func NewRunner(m *Migration) (*Runner, error) {
 		})
 	}
 	runner := &Runner{
-		migration:   m,
-		logger:      slog.Default(),
-		metricsSink: &metrics.NoopSink{},
-		changes:     changes,
+		migration: m,
+		changes:   changes,
 	}
+	runner.SetLogger(slog.Default())
+	runner.SetMetricsSink(&metrics.NoopSink{})
 	for _, change := range changes {
 		change.runner = runner // link back.
 	}
@@ -187,50 +275,187 @@ func (r *Runner) controlPlaneConns() int {
 	return len(r.changes) + 2
 }
 
+// readThreads is the pool's budget for the copy and checksum workers. They
+// run one after the other, so it is the larger of the two concurrencies.
+func (r *Runner) readThreads() int {
+	return max(r.migration.Threads, r.migration.ChecksumThreads)
+}
+
+// requiredConnections is the least number of connections the migration
+// holds at once when its pool is full: the pool as Run first sizes it, and
+// the advisory lock held for the whole run. Auto-sized write threads
+// (WriteThreads 0) are counted at their non-Aurora default; autoscaling can
+// grow the pool beyond this.
+func (r *Runner) requiredConnections() int {
+	writeThreads := r.migration.WriteThreads
+	if writeThreads == 0 {
+		writeThreads = throttler.DefaultWriteThreads
+	}
+	return r.readThreads() + writeThreads + r.controlPlaneConns() + 1
+}
+
+// SetMetricsSink sets the sink metrics are sent to. Every Metrics is labeled
+// with the schema and table being migrated (comma-separated for a
+// multi-table migration), so migrations sharing a sink do not collide, and
+// with the correlation_id if the migration has a CorrelationID.
 func (r *Runner) SetMetricsSink(sink metrics.Sink) {
-	r.metricsSink = sink
+	r.metricsSink = metrics.WithLabels(sink, r.metricsLabels())
+}
+
+// metricsLabels returns the labels SetMetricsSink attaches to every Metrics.
+func (r *Runner) metricsLabels() map[string]string {
+	labels := make(map[string]string, 3)
+	if len(r.changes) > 0 {
+		tables := make([]string, 0, len(r.changes))
+		for _, change := range r.changes {
+			tables = append(tables, change.stmt.Table)
+		}
+		labels["schema"] = r.changes[0].stmt.Schema
+		labels["table"] = strings.Join(tables, ",")
+	}
+	if id := r.migration.CorrelationID; id != "" {
+		labels["correlation_id"] = id
+	}
+	return labels
 }
 
+// SetLogger sets the logger. If the migration has a CorrelationID, it is
+// attached to every log line as the correlation_id attribute.
 func (r *Runner) SetLogger(logger *slog.Logger) {
+	if id := r.migration.CorrelationID; id != "" {
+		logger = logger.With("correlation_id", id)
+	}
 	r.logger = logger
 }
 
+// OnExecDDL sets a function that is called with the SQL of every DDL
+// statement the migration runs, immediately before it is executed: creating,
+// altering, analyzing, renaming and dropping the new, old, checkpoint and
+// sentinel tables, analyzing the original table to estimate its rows,
+// MySQL's INSTANT and INPLACE DDL, the triggers moved at cutover, and the
+// scratch tables of a dry run and of the check that MySQL accepts the ALTER.
+// It is called from the goroutine running the statement and must not block.
+func (r *Runner) OnExecDDL(hook func(stmt string)) {
+	r.ddlHook = hook
+}
+
+// OnCopyFallback sets a function that is called when the change can't be
+// applied with MySQL's INSTANT or INPLACE DDL and the migration falls back to
+// copying the table. err is the reason: the error MySQL returned for the
+// INSTANT attempt, joined with the one that ruled out INPLACE, or why MySQL's
+// DDL was not attempted at all (as for a multi-table migration). It is called
+// once per run, before the preflight checks, and must not block.
+func (r *Runner) OnCopyFallback(hook func(err error)) {
+	r.copyFallbackHook = hook
+}
+
+// fallBackToCopy reports that MySQL's DDL could not be used, for the reason
+// err, and that the table is copied instead.
+func (r *Runner) fallBackToCopy(err error) {
+	r.logger.Info("unable to use INSTANT or INPLACE DDL, falling back to copying the table", "error", err)
+	if r.copyFallbackHook != nil {
+		r.copyFallbackHook(err)
+	}
+}
+
+// recordDDL passes stmt, formatted with args as dbconn.Exec would, to the
+// hook set by OnExecDDL.
+func (r *Runner) recordDDL(stmt string, args ...any) {
+	if r.ddlHook == nil {
+		return
+	}
+	formatted, err := sqlescape.EscapeSQL(stmt, args...)
+	if err != nil {
+		return // executing it fails with the same error
+	}
+	r.ddlHook(formatted)
+}
+
+// newTableInfo returns a TableInfo for schema.name that reports the ANALYZE
+// TABLE it runs to the DDL hook.
+func (r *Runner) newTableInfo(schema, name string) *table.TableInfo {
+	tbl := table.NewTableInfo(r.db, schema, name)
+	tbl.DDLHook = r.ddlHook
+	return tbl
+}
+
+// execDDL is dbconn.Exec for DDL statements: it records the statement with
+// recordDDL before executing it.
+func (r *Runner) execDDL(ctx context.Context, stmt string, args ...any) error {
+	r.recordDDL(stmt, args...)
+	return dbconn.Exec(ctx, r.db, stmt, args...)
+}
+
 // attemptMySQLDDL tries to perform the DDL using MySQL's built-in
 // either with INSTANT or known safe INPLACE operations.
 func (r *Runner) attemptMySQLDDL(ctx context.Context) error {
 	if len(r.changes) > 1 {
 		return errors.New("attemptMySQLDDL only supports single-table changes")
 	}
+	if len(r.migration.ExcludeColumns) > 0 {
+		// MySQL's DDL would keep the excluded columns' values.
+		return errors.New("attemptMySQLDDL does not support --exclude-columns")
+	}
+	if r.migration.NewTableCharset != "" {
+		// MySQL's DDL would not convert the table.
+		return errors.New("attemptMySQLDDL does not support --new-table-charset")
+	}
 	return r.changes[0].attemptMySQLDDL(ctx)
 }
 
 func (r *Runner) Run(ctx context.Context) error {
 	ctx, r.cancelFunc = context.WithCancel(ctx)
 	defer r.cancelFunc()
-	r.startTime = time.Now()
-	bi := buildinfo.Get()
-	r.logger.Info("Starting spirit migration",
-		"version", bi.Version,
-		"commit", bi.Commit,
-		"build-date", bi.Date,
-		"go", bi.GoVer,
-		"dirty", bi.Modified,
-		"concurrency", r.migration.Threads,
-		"target-chunk-size", r.migration.TargetChunkTime,
-	)
+	r.abortMu.Lock()
+	if r.aborted {
+		r.abortMu.Unlock()
+		return ErrAborted
+	}
+	runDone := make(chan struct{})
+	r.runDone = runDone
+	r.abortMu.Unlock()
+	defer close(runDone)
+	err := r.run(ctx)
+	r.abortMu.Lock()
+	aborted := r.aborted
+	r.runStopped = true
+	r.abortMu.Unlock()
+	if err != nil {
+		if aborted {
+			// Whatever was in flight failed because Abort cancelled it.
+			return ErrAborted
+		}
+		if r.tableChanged.Load() {
+			// Likewise for a DDL against a migrated table, which cancels
+			// the run from fatalError.
+			return fmt.Errorf("%w: %w", ErrTableChanged, err)
+		}
+	}
+	return err
+}
 
-	// Create a database connection
-	// It will be closed in r.Close()
-	var err error
+// connect configures the connection pool from the migration's options and
+// opens the main database connection, unless it is already open.
+func (r *Runner) connect() (err error) {
+	if r.db != nil {
+		return nil
+	}
 	r.dbConfig = dbconn.NewDBConfig()
 	if r.migration.LockWaitTimeout > 0 {
 		r.dbConfig.LockWaitTimeout = int(r.migration.LockWaitTimeout.Seconds())
 	}
 	r.dbConfig.InterpolateParams = r.migration.InterpolateParams
+	r.dbConfig.ConnBudget = r.migration.ConnBudget
+	r.dbConfig.CredentialProvider = r.migration.Credentials.refresher(r.migration.Username, *r.migration.Password)
 	r.dbConfig.ForceKill = !r.migration.SkipForceKill
+	r.dbConfig.IgnoreShowWarningsErrors = r.migration.IgnoreShowWarningsErrors
+	r.dbConfig.LockNamespace = r.migration.LockNamespace
 	// Map TLS configuration from migration to dbConfig
 	r.dbConfig.TLSMode = r.migration.TLSMode
 	r.dbConfig.TLSCertificatePath = r.migration.TLSCertificatePath
+	if r.migration.CorrelationID != "" {
+		r.dbConfig.QueryComment = "correlation_id=" + r.migration.CorrelationID
+	}
 	// Size the connection pool the same way for both the buffered and
 	// unbuffered paths:
 	//
@@ -250,11 +475,45 @@ func (r *Runner) Run(ctx context.Context) error {
 	// setupCopierCheckerAndReplClient grows it to the final size after
 	// resolving WriteThreads. The pool only ever grows (via SetMaxOpenConns);
 	// later phases (checksum, cutover) ratchet it further but never shrink it.
-	r.dbConfig.MaxOpenConnections = r.migration.Threads + r.migration.WriteThreads + r.controlPlaneConns()
+	r.dbConfig.MaxOpenConnections = r.readThreads() + r.migration.WriteThreads + r.controlPlaneConns()
 	r.db, err = dbconn.New(r.dsn(), r.dbConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to main database (DSN: %s): %w", dbconn.RedactDSN(r.dsn()), err)
 	}
+	return nil
+}
+
+func (r *Runner) run(ctx context.Context) error {
+	r.startTime = time.Now()
+	bi := buildinfo.Get()
+	r.logger.Info("Starting spirit migration",
+		"version", bi.Version,
+		"commit", bi.Commit,
+		"build-date", bi.Date,
+		"go", bi.GoVer,
+		"dirty", bi.Modified,
+		"concurrency", r.migration.Threads,
+		"target-chunk-size", r.migration.TargetChunkTime,
+	)
+
+	// Create a database connection
+	// It will be closed in r.Close()
+	if err := r.connect(); err != nil {
+		return err
+	}
+
+	// With --desired, compute the ALTER to run from the live table. Linting
+	// and everything after it then see it as if it had been passed in.
+	if r.migration.Desired != "" {
+		upToDate, err := r.alterFromDesired(ctx)
+		if err != nil {
+			return err
+		}
+		if upToDate {
+			r.logger.Info("table already matches --desired; nothing to do", "table", r.migration.Table)
+			return nil
+		}
+	}
 
 	// Run linting if --lint or --lint-only is specified.
 	// --lint-only implies lint.
@@ -268,11 +527,19 @@ func (r *Runner) Run(ctx context.Context) error {
 		}
 	}
 
+	if r.migration.DryRun {
+		_, err := r.dryRun(ctx)
+		return err
+	}
+
 	if len(r.changes) == 1 {
 		// We only allow non-ALTERs (i.e. CREATE TABLE, DROP TABLE, RENAME TABLE)
 		// in single table mode.
 		if !r.changes[0].stmt.IsAlterTable() {
-			err := dbconn.Exec(ctx, r.db, r.changes[0].stmt.Statement)
+			if err := r.startPointOfNoReturn(); err != nil {
+				return err
+			}
+			err := r.execDDL(ctx, r.changes[0].stmt.Statement)
 			if err != nil {
 				return err
 			}
@@ -301,8 +568,8 @@ func (r *Runner) Run(ctx context.Context) error {
 	// Set info for all of the tables.
 	tables := make([]*table.TableInfo, 0, len(r.changes))
 	for _, change := range r.changes {
-		change.table = table.NewTableInfo(r.db, change.stmt.Schema, change.stmt.Table)
-		if err := change.table.SetInfo(ctx); err != nil {
+		change.table = r.newTableInfo(change.stmt.Schema, change.stmt.Table)
+		if err := dbconn.RetryableSetInfo(ctx, change.table, r.dbConfig); err != nil {
 			return err
 		}
 		tables = append(tables, change.table)
@@ -336,12 +603,29 @@ func (r *Runner) Run(ctx context.Context) error {
 			r.logger.Error("failed to release advisory lock", "error", err)
 		}
 	}()
+	// Deferred after the lock release so it runs first: if Abort stopped
+	// this run, its artifacts are dropped while the lock is still held, so
+	// they can never belong to another run of the same migration.
+	defer r.dropAbortedArtifacts(ctx)
 	// This step is technically optional, but first we attempt to
 	// use MySQL's built-in DDL. This is because it's usually faster
 	// when it is compatible. If it returns no error, that means it
 	// has been successful and the DDL is complete.
 	// Note: this function returns an error when in multi-table mode.
+	// An INSTANT or INPLACE DDL cannot be aborted once sent, so Abort
+	// refuses for as long as the attempt is in flight.
+	if err := r.startPointOfNoReturn(); err != nil {
+		return err
+	}
 	err = r.attemptMySQLDDL(ctx)
+	if err != nil {
+		// MySQL rejected the DDL without changing the table; the copy
+		// below can still be aborted.
+		r.abortMu.Lock()
+		r.cutoverStarted = false
+		r.abortMu.Unlock()
+		r.fallBackToCopy(err)
+	}
 	if err == nil {
 		r.logger.Info("apply complete",
 			"instant-ddl", r.usedInstantDDL,
@@ -352,7 +636,14 @@ func (r *Runner) Run(ctx context.Context) error {
 
 	// Perform preflight basic checks.
 	if err := r.runChecks(ctx, check.ScopePreflight); err != nil {
-		return err
+		return fmt.Errorf("%w: %w", ErrPreflight, err)
+	}
+	// Check that MySQL accepts each ALTER, on an empty scratch table, before
+	// any new table is created.
+	for _, change := range r.changes {
+		if err := change.validateAlter(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrPreflight, err)
+		}
 	}
 
 	// Perform setup steps, including resuming from a checkpoint (if available)
@@ -366,6 +657,9 @@ func (r *Runner) Run(ctx context.Context) error {
 	if err := r.runChecks(ctx, check.ScopePostSetup); err != nil {
 		return err
 	}
+	if r.migration.ExplainChunks {
+		r.explainChunks(ctx)
+	}
 
 	// Perform the main copy rows task. This is where the majority
 	// of migrations usually spend time. It is not strictly necessary,
@@ -378,6 +672,15 @@ func (r *Runner) Run(ctx context.Context) error {
 	r.logger.Info("copy rows complete")
 	r.copyDuration = time.Since(r.copier.StartTime())
 
+	// Build any secondary indexes deferred until after the copy.
+	if r.deferIndexes {
+		for _, change := range r.changes {
+			if err := change.addDeferredIndexes(ctx); err != nil {
+				return err
+			}
+		}
+	}
+
 	// Disable both watermark optimizations so that all changes can be flushed.
 	// For non-memory-comparable PKs this also drains the buffered map and
 	// switches the subscription into FIFO queue mode (see
@@ -419,26 +722,55 @@ func (r *Runner) Run(ctx context.Context) error {
 			return err
 		}
 	}
+	// A paused migration does not cut over until it is resumed.
+	if r.IsPaused() {
+		r.logger.Info("migration is paused, waiting to be resumed before cutover")
+		r.pauser.BlockWait(ctx)
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
 	// Run any checks that need to be done pre-cutover.
 	if err := r.runChecks(ctx, check.ScopeCutover); err != nil {
 		return err
 	}
+	// The pre-cutover hook runs before the point of no return, so the
+	// migration can still be aborted while it runs.
+	if r.migration.PreCutoverHook != nil {
+		if err := r.migration.PreCutoverHook(ctx); err != nil {
+			return fmt.Errorf("%w: %w", ErrPreCutoverHook, err)
+		}
+	}
 	// It's time for the final cut-over, where
 	// the tables are swapped under a lock.
+	if err := r.startPointOfNoReturn(); err != nil {
+		return err
+	}
 	r.status.Set(status.CutOver)
 	cutoverCfg := []*cutoverConfig{}
 	for _, change := range r.changes {
+		var triggers []trigger
+		if r.migration.CopyTriggers {
+			// Read the definitions before the cutover moves them, so a failed
+			// attempt can move them back and a retry can move them again.
+			if triggers, err = readTriggers(ctx, r.db, change.table.SchemaName, change.table.TableName); err != nil {
+				return err
+			}
+		}
 		cutoverCfg = append(cutoverCfg, &cutoverConfig{
 			table:          change.table,
 			newTable:       change.newTable,
 			oldTableName:   change.oldTableName(),
 			useTestCutover: r.migration.useTestCutover, // indicates we want the test cutover
+			triggers:       triggers,
 		})
 	}
 	cutover, err := NewCutOver(r.db, cutoverCfg, r.replClient, r.dbConfig, r.logger)
 	if err != nil {
 		return err
 	}
+	cutover.convergenceTimeout = r.migration.CutoverConvergenceTimeout
+	cutover.ddlHook = r.ddlHook
 	// Drop the _old table if it exists. This ensures
 	// that the rename will succeed (although there is a brief race)
 	for _, change := range r.changes {
@@ -447,7 +779,17 @@ func (r *Runner) Run(ctx context.Context) error {
 		}
 	}
 	if err := cutover.Run(ctx); err != nil {
-		return fmt.Errorf("cutover failed: %w", err)
+		return fmt.Errorf("%w: %w", ErrCutover, err)
+	}
+	// An error from the post-cutover hook is returned once the old table and
+	// checkpoint have been cleaned up: the cutover cannot be undone, and
+	// leaving the checkpoint behind would make a retry start over.
+	var postHookErr error
+	if r.migration.PostCutoverHook != nil {
+		if err := r.migration.PostCutoverHook(ctx); err != nil {
+			postHookErr = fmt.Errorf("%w: %w", ErrPostCutoverHook, err)
+			r.logger.Error("post-cutover hook failed", "error", err)
+		}
 	}
 	if !r.migration.SkipDropAfterCutover {
 		for _, change := range r.changes {
@@ -489,7 +831,7 @@ func (r *Runner) Run(ctx context.Context) error {
 			return err
 		}
 	}
-	return nil
+	return postHookErr
 }
 
 // postCopyPhase runs the work that happens between copy-rows and the
@@ -514,7 +856,7 @@ func (r *Runner) postCopyPhase(ctx context.Context) error {
 	r.status.Set(status.AnalyzeTable)
 	r.logger.Info("Running ANALYZE TABLE")
 	for _, change := range r.changes {
-		if err := dbconn.Exec(ctx, r.db, "ANALYZE TABLE %n.%n", change.newTable.SchemaName, change.newTable.TableName); err != nil {
+		if err := r.execDDL(ctx, "ANALYZE TABLE %n.%n", change.newTable.SchemaName, change.newTable.TableName); err != nil {
 			return err
 		}
 
@@ -552,8 +894,9 @@ func (r *Runner) runChecks(ctx context.Context, scope check.ScopeFlag) error {
 			Replicas:        r.replicas,
 			Table:           change.table,
 			Statement:       change.stmt,
-			TargetChunkTime: r.migration.TargetChunkTime,
+			TargetChunkTime: r.migration.tableTargetChunkTime(change.table.TableName),
 			Threads:         r.migration.Threads,
+			ChecksumThreads: r.migration.ChecksumThreads,
 			ReplicaMaxLag:   r.migration.ReplicaMaxLag,
 			ForceKill:       !r.migration.SkipForceKill,
 			// For the pre-run checks we don't have a DB connection yet.
@@ -561,9 +904,13 @@ func (r *Runner) runChecks(ctx context.Context, scope check.ScopeFlag) error {
 			Host:                 r.migration.Host,
 			Username:             r.migration.Username,
 			Password:             *r.migration.Password,
+			CredentialProvider:   r.migration.Credentials.refresher(r.migration.Username, *r.migration.Password),
 			TLSMode:              r.migration.TLSMode,
 			TLSCertificatePath:   r.migration.TLSCertificatePath,
 			SkipDropAfterCutover: r.migration.SkipDropAfterCutover,
+			AllowTriggers:        r.migration.AllowTriggers,
+			CopyTriggers:         r.migration.CopyTriggers,
+			ChunkKey:             r.migration.ChunkKey,
 			GTID:                 r.migration.EnableExperimentalGTID,
 		}, r.logger, scope); err != nil {
 			return err
@@ -655,9 +1002,48 @@ func (r *Runner) checkpointTableName() string {
 	// We also call the create functions for the sentinel
 	// and checkpoint tables.
 	if len(r.changes) > 1 {
+		if namespace := r.migration.LockNamespace; namespace != "" {
+			return "_spirit_" + namespace + "_checkpoint"
+		}
 		return checkpointTableName
 	}
-	return utils.CheckpointTableName(r.changes[0].table.TableName)
+	return r.changes[0].auxTableName("_chkpnt")
+}
+
+// InvolvedTables returns the fully qualified (schema.table) names of every
+// table this migration reads or writes: for each change the source, _new and
+// _old tables and the scratch tables the ALTER is tried on (_val, _idx and
+// _dry), followed by the checkpoint table and, with DeferCutOver, the
+// sentinel table. Orchestration can use it to assert no other job touches the
+// same tables concurrently. It only depends on the statement(s), so it is safe
+// to call before Run, except when SkipDropAfterCutover is set: the _old name
+// then includes the start timestamp (unless OldTableName is set), and an error
+// is returned until Run has started.
+func (r *Runner) InvolvedTables() ([]string, error) {
+	if r.migration.SkipDropAfterCutover && r.migration.OldTableName == "" && r.startTime.IsZero() {
+		return nil, errors.New("the _old table name is not known until Run has started because --skip-drop-after-cutover names it with the start time")
+	}
+	tables := make([]string, 0, len(r.changes)*(3+len(scratchTableSuffixes))+2)
+	for _, change := range r.changes {
+		schema := change.stmt.Schema
+		tables = append(tables,
+			schema+"."+change.stmt.Table,
+			schema+"."+change.newTableName(),
+			schema+"."+change.oldTableName(),
+		)
+		for _, name := range change.scratchTableNames() {
+			tables = append(tables, schema+"."+name)
+		}
+	}
+	// normalizeOptions sets every statement's schema to --database, so the
+	// checkpoint and sentinel tables (which always live in that schema) share
+	// it.
+	schema := r.changes[0].stmt.Schema
+	tables = append(tables, schema+"."+r.checkpointTableName())
+	if r.migration.DeferCutOver {
+		tables = append(tables, schema+"."+sentinel.TableName)
+	}
+	return tables, nil
 }
 
 // checkpointTbl returns a handle to this migration's checkpoint table (shared
@@ -671,7 +1057,10 @@ func (r *Runner) checkpointTableName() string {
 func (r *Runner) checkpointTbl() *checkpoint.Table {
 	// r.db's selected schema is the migrated schema (same connection sentinel
 	// uses), so the checkpoint table lands there — no schema is threaded in.
-	return checkpoint.NewTable(r.db, r.checkpointTableName(), checkpoint.Transient)
+	tbl := checkpoint.NewTable(r.db, r.checkpointTableName(), checkpoint.Transient)
+	tbl.SetDDLHook(r.ddlHook)
+	tbl.SetTableOptions(r.migration.ArtifactTableOptions)
+	return tbl
 }
 
 func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
@@ -695,6 +1084,13 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 			"write_threads", r.migration.WriteThreads)
 		autoscale = false
 	}
+	// OUTFILE copy is a variant of the unbuffered copier; the buffered copier
+	// reads rows into spirit and has no use for server-side files.
+	outfile := r.migration.EnableExperimentalOutfileCopy
+	if outfile && !r.migration.Unbuffered {
+		r.logger.Warn("--enable-experimental-outfile-copy has no effect without --unbuffered")
+		outfile = false
+	}
 	// redoAware tracks whether the Aurora threads throttler will run its
 	// redo-aware perf_schema signal (which excludes redo-log waiters). It gates
 	// the autoscaler's growth cap below: because that signal ignores redo-log
@@ -740,7 +1136,7 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 	// doc in Run). Sizing for maxWrite ensures a scaled-up applier never starves
 	// on connections. This is a no-op unless WriteThreads was auto-sized up from 0
 	// or autoscaling raised the ceiling; the pool only ever grows.
-	if poolSize := r.migration.Threads + maxWrite + r.controlPlaneConns(); poolSize > r.dbConfig.MaxOpenConnections {
+	if poolSize := r.readThreads() + maxWrite + r.controlPlaneConns(); poolSize > r.dbConfig.MaxOpenConnections {
 		r.dbConfig.MaxOpenConnections = poolSize
 		r.db.SetMaxOpenConns(poolSize)
 	}
@@ -782,6 +1178,10 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 		DBConfig:        r.dbConfig,
 		Applier:         appl,
 		Unbuffered:      r.migration.Unbuffered,
+		Outfile:         outfile,
+		ExcludeColumns:  r.migration.ExcludeColumns,
+		SkipForceIndex:  r.migration.SkipForceIndex,
+		ChunkKey:        r.migration.ChunkKey,
 		Autoscale: copier.AutoscaleConfig{
 			Enabled:      autoscale,
 			StartThreads: r.migration.WriteThreads,
@@ -798,7 +1198,8 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 	replConfig.Logger = r.logger
 	replConfig.CancelFunc = r.fatalError
 	replConfig.DBConfig = r.dbConfig
-	if r.migration.EnableExperimentalGTID {
+	replConfig.PeriodicFlushPaused = r.IsPaused
+	if r.gtidSource {
 		r.logger.Info("EXPERIMENTAL: using GTID-based change source")
 		r.replClient = change.NewGTIDClient(r.db, r.migration.Host, r.migration.Username, *r.migration.Password, appl, replConfig)
 	} else {
@@ -807,7 +1208,7 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 	// For each of the changes, we know the new table exists now
 	// So we should call SetInfo to populate the columns etc.
 	for _, change := range r.changes {
-		if err := change.newTable.SetInfo(ctx); err != nil {
+		if err := dbconn.RetryableSetInfo(ctx, change.newTable, r.dbConfig); err != nil {
 			return err
 		}
 		if err := r.replClient.AddSubscription(change.table, change.newTable, change.chunker); err != nil {
@@ -815,22 +1216,112 @@ func (r *Runner) setupCopierCheckerAndReplClient(ctx context.Context) error {
 		}
 	}
 
-	r.checker, err = checksum.NewChecker([]*sql.DB{r.db}, r.checksumChunker, []change.Source{r.replClient}, &checksum.CheckerConfig{
-		Concurrency:     r.migration.Threads,
+	r.checker, err = checksum.NewChecker([]*sql.DB{r.db}, r.checksumChunker, []change.Source{r.replClient}, r.checkerConfig())
+
+	return err
+}
+
+// checkerConfig returns the configuration of the checksum run before cutover.
+func (r *Runner) checkerConfig() *checksum.CheckerConfig {
+	return &checksum.CheckerConfig{
+		Concurrency:     r.migration.ChecksumThreads,
 		TargetChunkTime: r.migration.TargetChunkTime,
 		DBConfig:        r.dbConfig,
 		Logger:          r.logger,
 		FixDifferences:  true,
 		MaxRetries:      3,
 		YieldTimeout:    r.migration.ChecksumYieldTimeout,
-	})
+	}
+}
 
-	return err
+// checkNoExistingArtifacts returns ErrExistingArtifacts if a fresh migration
+// would have to drop a _new or checkpoint table to start. With
+// ArtifactPolicyFail it runs before anything is created, so a table left by
+// another tool or a concurrent run is never clobbered.
+func (r *Runner) checkNoExistingArtifacts(ctx context.Context) error {
+	var existing []string
+	for _, change := range r.changes {
+		exists, err := change.newTableExists(ctx)
+		if err != nil {
+			return err
+		}
+		if exists {
+			existing = append(existing, change.newTableName())
+		}
+	}
+	exists, err := r.checkpointTbl().Exists(ctx)
+	if err != nil {
+		return err
+	}
+	if exists {
+		existing = append(existing, r.checkpointTableName())
+	}
+	if len(existing) > 0 {
+		return fmt.Errorf("%w: %s; drop them or use --on-existing-artifacts=%s",
+			ErrExistingArtifacts, strings.Join(existing, ", "), ArtifactPolicyDropAndRecreate)
+	}
+	return nil
+}
+
+// checkCustomTableNames refuses a fresh start that would drop a table named
+// by --new-table-name or --old-table-name which spirit did not create. A
+// generated name is always spirit's, but a given one can be a typo for, or
+// collide with, a real table. The table is only taken to be left by an
+// earlier run if that run's checkpoint records the same names.
+func (r *Runner) checkCustomTableNames(ctx context.Context) error {
+	if r.migration.NewTableName == "" && r.migration.OldTableName == "" {
+		return nil
+	}
+	rec, err := r.checkpointTbl().ReadLatest(ctx)
+	if err != nil && !errors.Is(err, checkpoint.ErrNotFound) && !checkpoint.IsIncompatible(err) {
+		return err
+	}
+	if err == nil && r.checkpointHasTableNames(rec) {
+		return nil
+	}
+	change := r.changes[0] // the names can only be used when migrating a single table
+	for _, n := range []struct {
+		flag string
+		name string
+	}{
+		{"--new-table-name", r.migration.NewTableName},
+		{"--old-table-name", r.migration.OldTableName},
+	} {
+		if n.name == "" {
+			continue
+		}
+		exists, err := change.tableExists(ctx, n.name)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("%w: %s names existing table %s, and checkpoint table %s does not show that an earlier run with this name created it; drop it or choose another name",
+				ErrExistingArtifacts, n.flag, n.name, r.checkpointTableName())
+		}
+	}
+	return nil
+}
+
+// checkpointHasTableNames reports whether rec was written by a run with the
+// same --new-table-name and --old-table-name as this one.
+func (r *Runner) checkpointHasTableNames(rec checkpoint.Record) bool {
+	return rec.NewTableName == r.migration.NewTableName && rec.OldTableName == r.migration.OldTableName
 }
 
 // newMigration is called when resumeFromCheckpoint has failed.
 // It performs all the initial steps to prepare for a fresh migration.
 func (r *Runner) newMigration(ctx context.Context) error {
+	if err := r.checkCustomTableNames(ctx); err != nil {
+		return err
+	}
+	if r.migration.OnExistingArtifacts == ArtifactPolicyFail {
+		if err := r.checkNoExistingArtifacts(ctx); err != nil {
+			return err
+		}
+	}
+	// A failed resume may have picked the change source from its checkpoint.
+	r.gtidSource = r.migration.EnableExperimentalGTID
+	r.deferIndexes = r.migration.DeferSecondaryIndexes
 	// This is the non-resume path, so we need to create each of the new tables
 	// And apply the alters. This doesn't apply to resume.
 	for _, change := range r.changes {
@@ -840,6 +1331,11 @@ func (r *Runner) newMigration(ctx context.Context) error {
 		if err := change.alterNewTable(ctx); err != nil {
 			return err
 		}
+		if r.deferIndexes {
+			if err := change.dropDeferrableIndexes(ctx); err != nil {
+				return err
+			}
+		}
 	}
 	if err := r.checkpointTbl().Create(ctx); err != nil {
 		return err
@@ -848,6 +1344,7 @@ func (r *Runner) newMigration(ctx context.Context) error {
 		// Idempotent (CREATE IF NOT EXISTS): the sentinel is shared by every
 		// migration in the schema and must never pass through a "table absent"
 		// state that a concurrent deferred cutover's poll could observe.
+		r.recordDDL(sentinel.CreateStatement)
 		if err := sentinel.Create(ctx, r.db); err != nil {
 			return err
 		}
@@ -893,25 +1390,29 @@ func (r *Runner) closeReplicas() error {
 	return errors.Join(errs...)
 }
 
-// setupThrottler sets up the throttlers used to pace the copier:
+// setupThrottler sets up the throttlers used to pace the copier and checksum:
 //   - one replication throttler per --replica-dsn (slowest wins)
 //   - a commit-latency throttler if the source is detected as Aurora and
 //     --max-commit-latency is positive (issue #468)
 //   - an Aurora threads throttler whenever the source is detected as Aurora —
 //     the redo-aware perf_schema signal when the user can read the perf-schema
 //     tables it needs, else the Threads_running fallback (issue #831)
+//   - a query throttler if --throttle-query is set, pausing while its value
+//     exceeds --throttle-threshold
+//   - a Threads_running throttler if --max-threads-running is set
 //
 // Multiple replica DSNs can be specified as a comma-separated list.
 // This is common logic shared between resume and new migration paths.
 func (r *Runner) setupThrottler(ctx context.Context) error {
 	if r.migration.useTestThrottler {
 		// We are in tests, add a throttler that always throttles.
-		r.throttler = &throttler.Mock{}
+		r.throttler = throttler.NewMultiThrottler(&throttler.Mock{}, &r.pauser)
 		r.copier.SetThrottler(r.throttler)
+		r.checker.SetThrottler(&r.pauser)
 		return r.throttler.Open(ctx)
 	}
 
-	var throttlers []throttler.Throttler
+	throttlers := []throttler.Throttler{&r.pauser}
 
 	if r.migration.ReplicaDSN != "" {
 		replicaThrottlers, err := r.buildReplicaThrottlers()
@@ -954,12 +1455,22 @@ func (r *Runner) setupThrottler(ctx context.Context) error {
 	}
 	throttlers = append(throttlers, auroraRes.Throttlers...)
 
-	if len(throttlers) == 0 {
-		return nil // use default Noop throttler
+	probeThrottlers, err := r.buildProbeThrottlers()
+	if err != nil {
+		if r.monitorDB != nil {
+			_ = r.monitorDB.Close()
+			r.monitorDB = nil
+		}
+		_ = r.closeReplicas()
+		return err
 	}
+	throttlers = append(throttlers, probeThrottlers...)
 
 	r.throttler = throttler.NewMultiThrottler(throttlers...)
 	r.copier.SetThrottler(r.throttler)
+	// The checksum reads the whole table too, so it waits on the same
+	// throttlers. (The test throttler above only slows the copier.)
+	r.checker.SetThrottler(r.throttler)
 	if err := r.throttler.Open(ctx); err != nil {
 		// multiThrottler already closes child throttlers on partial Open
 		// failure, but the *sql.DB connections backing replica throttlers
@@ -976,6 +1487,42 @@ func (r *Runner) setupThrottler(ctx context.Context) error {
 	return nil
 }
 
+// buildProbeThrottlers returns the throttlers that poll the source with a
+// query: one for --throttle-query and one for --max-threads-running, if set.
+// Like the Aurora throttlers they poll on the monitor pool rather than r.db,
+// so their probes do not queue behind chunk writes; the pool is opened here
+// if the Aurora setup did not already need one.
+func (r *Runner) buildProbeThrottlers() ([]throttler.Throttler, error) {
+	if r.migration.ThrottleQuery == "" && r.migration.MaxThreadsRunning == 0 {
+		return nil, nil
+	}
+	if r.monitorDB == nil {
+		monitorCfg := *r.dbConfig // shallow copy — MaxOpenConnections is value-typed
+		monitorCfg.MaxOpenConnections = 2
+		monitorDB, err := dbconn.NewWithConnectionType(r.dsn(), &monitorCfg, "monitor database")
+		if err != nil {
+			return nil, err
+		}
+		r.monitorDB = monitorDB
+	}
+	var throttlers []throttler.Throttler
+	if r.migration.ThrottleQuery != "" {
+		t, err := throttler.NewQueryThrottler(r.monitorDB, r.migration.ThrottleQuery, r.migration.ThrottleThreshold, 0, r.logger)
+		if err != nil {
+			return nil, err
+		}
+		throttlers = append(throttlers, t)
+	}
+	if r.migration.MaxThreadsRunning > 0 {
+		t, err := throttler.NewThreadsRunningThrottler(r.monitorDB, r.migration.MaxThreadsRunning, 0, r.logger)
+		if err != nil {
+			return nil, err
+		}
+		throttlers = append(throttlers, t)
+	}
+	return throttlers, nil
+}
+
 // buildReplicaThrottlers opens the configured replica DSN(s) and returns a
 // throttler per replica. Replica connections are tracked on the runner so
 // they get closed alongside the main DB.
@@ -1038,12 +1585,118 @@ func (r *Runner) startBackgroundRoutines(ctx context.Context) {
 		go change.table.AutoUpdateStatistics(ctx, tableStatUpdateInterval, r.logger)
 	}
 	r.replClient.StartPeriodicFlush(ctx, change.DefaultFlushInterval)
+	go r.emitProgressMetricsLoop(ctx)
 	// Start go routines for checkpointing and dumping status. The returned
 	// wait function is invoked from Close() so we can be sure no late
 	// checkpoint INSERT lands after teardown begins.
 	r.watchTaskWait = status.WatchTask(ctx, r, r.logger)
 }
 
+// emitProgressMetricsLoop periodically sends the migration's progress to the
+// metrics sink as gauges, until ctx is cancelled, so it can be graphed without
+// parsing the status log.
+func (r *Runner) emitProgressMetricsLoop(ctx context.Context) {
+	pools := r.connPools()
+	ticker := time.NewTicker(progressMetricsInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.emitProgressMetrics(ctx)
+			r.emitPoolMetrics(ctx, pools)
+		}
+	}
+}
+
+// connPool is one of the runner's connection pools, named for the pool
+// label of its metrics.
+type connPool struct {
+	name string
+	db   *sql.DB
+}
+
+// connPools returns the runner's connection pools: the main pool and, when a
+// throttler polls the source, the monitor pool. Both are open by the time
+// the background routines start, and stay open until Close.
+func (r *Runner) connPools() []connPool {
+	pools := []connPool{{name: "main", db: r.db}}
+	if r.monitorDB != nil {
+		pools = append(pools, connPool{name: "monitor", db: r.monitorDB})
+	}
+	return pools
+}
+
+// emitPoolMetrics sends the statistics of each of pools, labeled with the
+// pool's name, so that a pool running out of connections (as the cutover
+// can, see CutOver.Run) shows up on a dashboard. Failures are logged at
+// Debug and dropped, as in emitProgressMetrics.
+func (r *Runner) emitPoolMetrics(ctx context.Context, pools []connPool) {
+	for _, pool := range pools {
+		stats := pool.db.Stats()
+		m := &metrics.Metrics{
+			Labels: map[string]string{"pool": pool.name},
+			Values: []metrics.MetricValue{
+				{Name: metrics.DBConnsMaxOpenMetricName, Type: metrics.GAUGE, Value: float64(stats.MaxOpenConnections)},
+				{Name: metrics.DBConnsInUseMetricName, Type: metrics.GAUGE, Value: float64(stats.InUse)},
+				{Name: metrics.DBConnsIdleMetricName, Type: metrics.GAUGE, Value: float64(stats.Idle)},
+				{Name: metrics.DBConnWaitCountMetricName, Type: metrics.GAUGE, Value: float64(stats.WaitCount)},
+				{Name: metrics.DBConnWaitSecondsMetricName, Type: metrics.GAUGE, Value: stats.WaitDuration.Seconds()},
+			},
+		}
+		sendCtx, cancel := context.WithTimeout(ctx, metrics.SinkTimeout)
+		if err := r.metricsSink.Send(sendCtx, m); err != nil {
+			r.logger.Debug("connection pool metrics send failed", "pool", pool.name, "error", err)
+		}
+		cancel()
+	}
+}
+
+// emitProgressMetrics sends one snapshot: copy progress and chunk size, the
+// change source's backlog and lag and, once the checksum has started, the
+// differences it has found. The chunk panic shrinks are a counter, sent as
+// the increase since the last snapshot. Failures are logged at Debug and
+// dropped — metrics must never affect the migration.
+func (r *Runner) emitProgressMetrics(ctx context.Context) {
+	m := &metrics.Metrics{
+		Values: []metrics.MetricValue{
+			{Name: metrics.DeltaLenMetricName, Type: metrics.GAUGE, Value: float64(r.replClient.GetDeltaLen())},
+			{Name: metrics.DeltaMemoryBytesMetricName, Type: metrics.GAUGE, Value: float64(r.replClient.DeltaMemoryBytes())},
+			{Name: metrics.BinlogLagSecondsMetricName, Type: metrics.GAUGE, Value: r.replClient.Lag().Seconds()},
+		},
+	}
+	r.chunkerMu.RLock()
+	copyChunker := r.copyChunker
+	r.chunkerMu.RUnlock()
+	if copyChunker != nil {
+		rowsCopied, chunksCopied, totalRows := copyChunker.Progress()
+		m.Values = append(m.Values,
+			metrics.MetricValue{Name: metrics.CopyRowsCopiedMetricName, Type: metrics.GAUGE, Value: float64(rowsCopied)},
+			metrics.MetricValue{Name: metrics.CopyRowsTotalMetricName, Type: metrics.GAUGE, Value: float64(totalRows)},
+			metrics.MetricValue{Name: metrics.CopyChunksCopiedMetricName, Type: metrics.GAUGE, Value: float64(chunksCopied)},
+		)
+	}
+	if r.copier != nil {
+		panicShrinks := r.copier.ChunkPanicShrinks()
+		m.Values = append(m.Values,
+			metrics.MetricValue{Name: metrics.CopyChunkSizeMetricName, Type: metrics.GAUGE, Value: float64(r.copier.CurrentChunkSize())},
+			metrics.MetricValue{Name: metrics.CopyChunkPanicShrinksMetricName, Type: metrics.COUNTER, Value: float64(panicShrinks - r.emittedPanicShrinks)},
+		)
+		r.emittedPanicShrinks = panicShrinks
+	}
+	if r.checker != nil && r.status.Get() >= status.Checksum {
+		m.Values = append(m.Values, metrics.MetricValue{
+			Name: metrics.ChecksumDifferencesMetricName, Type: metrics.GAUGE, Value: float64(r.checker.DifferencesFound()),
+		})
+	}
+	sendCtx, cancel := context.WithTimeout(ctx, metrics.SinkTimeout)
+	defer cancel()
+	if err := r.metricsSink.Send(sendCtx, m); err != nil {
+		r.logger.Debug("progress metrics send failed", "error", err)
+	}
+}
+
 // setup performs all the initial steps to prepare for the migration,
 // including:
 // - creating copier chunker
@@ -1075,7 +1728,8 @@ func (r *Runner) setup(ctx context.Context) error {
 		// run, or it was invalidated), or it can't be used — a mismatched
 		// alter, expired binlog, too-old checkpoint, truncation collision, or
 		// unreadable content. Spirit logs the reason and falls back to a
-		// fresh migration so it always makes forward progress.
+		// fresh migration so it always makes forward progress, unless
+		// ArtifactPolicyFail forbids dropping the tables left behind.
 		r.logger.Info("could not resume from checkpoint",
 			"reason", err,
 		) // explain why it failed.
@@ -1135,6 +1789,7 @@ func (r *Runner) fatalError(reason change.FatalReason) bool {
 			// Schema change — and, defensively, any future reason we don't
 			// recognize (invalidating is the safe default: it costs a restart,
 			// while wrongly resuming could corrupt data).
+			r.tableChanged.Store(reason == change.FatalReasonSchemaChange)
 			// Invalidate the checkpoint, so we don't try to resume.
 			// If we don't do this, the migration will permanently be blocked
 			// from proceeding. Letting it start again is the better choice.
@@ -1155,6 +1810,29 @@ func (r *Runner) fatalError(reason change.FatalReason) bool {
 	return true
 }
 
+// Pause halts the migration without losing its progress, for example
+// during a peak traffic window. The copy and checksum stop before their next
+// chunk, the periodic flush of changes to the new table stops, and cutover
+// waits. The change stream keeps reading, so changes are buffered in memory
+// until Resume; once the buffer reaches its limit the stream stops reading
+// too, and a pause longer than the source's binlog retention cannot be
+// resumed. The checkpoint is still written while paused.
+func (r *Runner) Pause() {
+	r.pauser.Pause()
+	r.logger.Info("migration paused")
+}
+
+// Resume continues a migration halted by Pause.
+func (r *Runner) Resume() {
+	r.pauser.Resume()
+	r.logger.Info("migration resumed")
+}
+
+// IsPaused returns true between Pause and Resume.
+func (r *Runner) IsPaused() bool {
+	return r.pauser.IsThrottled()
+}
+
 func (r *Runner) Progress() status.Progress {
 	var summary string
 	var eta status.ETA
@@ -1207,12 +1885,30 @@ func (r *Runner) Progress() status.Progress {
 			IsComplete: copyChunker.IsRead(),
 		})
 	}
+	var deltaLen int
+	var throttled bool
+	if state := r.status.Get(); state >= status.CopyRows && state <= status.CutOver {
+		deltaLen = r.replClient.GetDeltaLen()
+		throttled = r.copier.GetThrottler().IsThrottled()
+	}
 	return status.Progress{
 		CurrentState: r.status.Get(),
+		Paused:       r.IsPaused(),
 		Summary:      summary,
 		ETA:          eta,
 		Checksum:     checksum,
 		Tables:       tables,
+		DeltaLen:     deltaLen,
+		Throttled:    throttled,
+	}
+}
+
+// ReportProgress calls Migration.ProgressCallback, if set, with the current
+// Progress. status.WatchTask calls it every status.StatusInterval, alongside
+// the status log line, until cutover.
+func (r *Runner) ReportProgress() {
+	if r.migration.ProgressCallback != nil {
+		r.migration.ProgressCallback(r.Progress())
 	}
 }
 
@@ -1271,10 +1967,24 @@ func (r *Runner) Close() error {
 	return errors.Join(errs...)
 }
 
+// canonicalStatement returns the form of a migration's statement that is
+// stored in the checkpoint table and compared on resume (see
+// statement.Canonicalize), so that re-running with the same ALTER written
+// differently (whitespace, keyword case, a trailing semicolon) still resumes.
+// Checkpoints written before statements were canonicalized hold the literal
+// statement, so resume canonicalizes the stored one as well. A statement
+// that does not parse is returned as is.
+func canonicalStatement(sql string) string {
+	if canonical, err := statement.Canonicalize(sql); err == nil {
+		return canonical
+	}
+	return sql
+}
+
 func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 	// Check that the new table(s) exists and are readable.
 	for _, change := range r.changes {
-		newName := utils.NewTableName(change.table.TableName)
+		newName := change.newTableName()
 		if err := dbconn.Exec(ctx, r.db, "SELECT 1 FROM %n.%n LIMIT 1", change.stmt.Schema, newName); err != nil {
 			// Wrap the underlying error: resumeErrorIsDefinitive relies on it
 			// to tell "the table does not exist" (ER_NO_SUCH_TABLE — start
@@ -1304,10 +2014,17 @@ func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 	// migration we are running — this catches a changed alter, and (for the
 	// shared multi-table table) a stale checkpoint left by a different
 	// multi-table migration that previously ran in this schema.
-	if r.migration.Statement != rec.Statement {
+	if canonicalStatement(r.migration.Statement) != canonicalStatement(rec.Statement) {
 		return status.ErrMismatchedAlter
 	}
 
+	// A table named by --new-table-name or --old-table-name is only spirit's
+	// if the run that wrote the checkpoint was given the same name.
+	if !r.checkpointHasTableNames(rec) {
+		return fmt.Errorf("%w: stored new=%q old=%q, expected new=%q old=%q", status.ErrMismatchedTableNames,
+			rec.NewTableName, rec.OldTableName, r.migration.NewTableName, r.migration.OldTableName)
+	}
+
 	// In single-table mode the checkpoint table name is built by deterministic
 	// truncation, so two long table names that share a prefix can collide.
 	// Cross-check the stored original table name to guard against resuming
@@ -1330,12 +2047,34 @@ func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 	checksumWatermark := rec.ChecksumWatermark
 	binlogPosition := rec.Position
 
+	// Resume with the change source that wrote the checkpoint, whatever
+	// --enable-experimental-gtid says now: a GTID set can only be resumed by
+	// the GTID client, and a binlog file:offset only by the binlog client.
+	// Starting fresh instead would discard the copy progress. A GTID set is
+	// preferred when the checkpoint has one, since it stays valid across a
+	// failover where file:offset coordinates do not.
+	r.gtidSource = change.IsGTIDPosition(binlogPosition)
+	if r.gtidSource != r.migration.EnableExperimentalGTID {
+		r.logger.Warn("resuming with the change source the checkpoint was written by, not the configured one",
+			"gtid", r.gtidSource,
+			"position", binlogPosition,
+		)
+	}
+
+	// The new tables were built by the run that wrote the checkpoint, so
+	// whether their secondary indexes were deferred is up to that run.
+	r.deferIndexes = rec.DeferSecondaryIndexes
+	if r.deferIndexes != r.migration.DeferSecondaryIndexes {
+		r.logger.Warn("resuming with the --defer-secondary-indexes setting the checkpoint was written with, not the configured one",
+			"defer-secondary-indexes", r.deferIndexes,
+		)
+	}
+
 	// Initialize and call SetInfo on all the new tables, since we need the column info
 	for _, change := range r.changes {
 		// Initialize newTable with the expected new table name
-		newName := utils.NewTableName(change.table.TableName)
-		change.newTable = table.NewTableInfo(r.db, change.stmt.Schema, newName)
-		if err := change.newTable.SetInfo(ctx); err != nil {
+		change.newTable = r.newTableInfo(change.stmt.Schema, change.newTableName())
+		if err := dbconn.RetryableSetInfo(ctx, change.newTable, r.dbConfig); err != nil {
 			return err
 		}
 	}
@@ -1387,6 +2126,8 @@ func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 		"copier-watermark", copierWatermark,
 		"checksum-watermark", checksumWatermark,
 		"position", binlogPosition,
+		"threads", r.migration.Threads,
+		"write-threads", r.migration.WriteThreads,
 	)
 	r.usedResumeFromCheckpoint = true
 	return nil
@@ -1403,11 +2144,12 @@ func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 func resumeErrorIsDefinitive(err error) bool {
 	// Sentinel classifications produced along the resume path.
 	for _, definitive := range []error{
-		checkpoint.ErrNotFound,        // checkpoint table exists but holds no row
-		status.ErrMismatchedAlter,     // checkpoint belongs to a different statement
-		status.ErrCheckpointCollision, // checkpoint belongs to a different table
-		status.ErrCheckpointTooOld,    // replaying would be slower than restarting
-		status.ErrBinlogNotFound,      // position purged from (or unparseable by) the source
+		checkpoint.ErrNotFound,         // checkpoint table exists but holds no row
+		status.ErrMismatchedAlter,      // checkpoint belongs to a different statement
+		status.ErrMismatchedTableNames, // checkpoint belongs to different --new/--old-table-name
+		status.ErrCheckpointCollision,  // checkpoint belongs to a different table
+		status.ErrCheckpointTooOld,     // replaying would be slower than restarting
+		status.ErrBinlogNotFound,       // position purged from (or unparseable by) the source
 	} {
 		if errors.Is(err, definitive) {
 			return true
@@ -1436,6 +2178,27 @@ func resumeErrorIsDefinitive(err error) bool {
 	return false
 }
 
+// explainChunks warns about each table whose chunk boundary query sorts
+// rows itself (see table.ExplainChunk). Such a query reads and sorts every
+// row past the chunk pointer for each chunk, so the copy slows down badly on
+// a large table. It is advisory: a failed EXPLAIN is only logged.
+func (r *Runner) explainChunks(ctx context.Context) {
+	for _, change := range r.changes {
+		extras, err := table.ExplainChunk(ctx, change.chunker)
+		if err != nil {
+			r.logger.Warn("could not explain the chunk query", "table", change.table.TableName, "error", err)
+			continue
+		}
+		if table.ChunkNeedsSort(extras) {
+			r.logger.Warn("the chunk query needs a filesort or temporary table, which will make the copy slow; "+
+				"chunks are read in primary key order, so consider a primary key with ascending, full-length key parts",
+				"table", change.table.TableName,
+				"explain-extra", strings.Join(extras, "; "),
+			)
+		}
+	}
+}
+
 // initChunkers sets up the chunker(s) for the migration.
 // It does not open them yet, and we need to either
 // call Open() or OpenAtWatermark() later.
@@ -1450,24 +2213,11 @@ func (r *Runner) initChunkers() error {
 				"renames", columnRenames,
 			)
 		}
-		columnMapping := table.NewColumnMapping(change.table, change.newTable, columnRenames)
-		chunkerCfg := table.ChunkerConfig{
-			NewTable:        change.newTable,
-			TargetChunkTime: r.migration.TargetChunkTime,
-			Logger:          r.logger,
-			ColumnMapping:   columnMapping,
+		columnMapping, err := r.columnMapping(change)
+		if err != nil {
+			return err
 		}
-		// The buffered copier (the default) sizes chunks by an in-memory byte
-		// budget rather than copy time — the only path that reads rows into
-		// client memory, and the one whose time signal collapses under
-		// backpressure. This applies to the copy chunker only: the checksum
-		// runs server-side CRC and keeps the time signal. The legacy
-		// --unbuffered copier keeps the time signal (TargetChunkBytes == 0).
-		copyChunkerCfg := chunkerCfg
-		if !r.migration.Unbuffered {
-			copyChunkerCfg.TargetChunkBytes = r.migration.TargetChunkSize
-		}
-		var err error
+		chunkerCfg, copyChunkerCfg := r.chunkerConfigs(change, columnMapping)
 		change.chunker, err = table.NewChunker(change.table, copyChunkerCfg)
 		if err != nil {
 			return err
@@ -1488,6 +2238,58 @@ func (r *Runner) initChunkers() error {
 	return nil
 }
 
+// chunkerConfigs returns the configurations of change's checksum and copy
+// chunkers. Each table is sized by its own --table-target-chunk-time and
+// --table-target-chunk-size when they are set, and both chunk on --chunk-key
+// when it is set.
+func (r *Runner) chunkerConfigs(change *tableChange, columnMapping *table.ColumnMapping) (checksumCfg, copyCfg table.ChunkerConfig) {
+	checksumCfg = table.ChunkerConfig{
+		NewTable:        change.newTable,
+		TargetChunkTime: r.migration.tableTargetChunkTime(change.table.TableName),
+		Logger:          r.logger,
+		ColumnMapping:   columnMapping,
+		Key:             r.migration.ChunkKey,
+	}
+	// The buffered copier (the default) sizes chunks by an in-memory byte
+	// budget rather than copy time — the only path that reads rows into
+	// client memory, and the one whose time signal collapses under
+	// backpressure. This applies to the copy chunker only: the checksum
+	// runs server-side CRC and keeps the time signal. The legacy
+	// --unbuffered copier keeps the time signal (TargetChunkBytes == 0).
+	// --fixed-chunk-rows overrides both signals for the copy.
+	copyCfg = checksumCfg
+	if !r.migration.Unbuffered {
+		copyCfg.TargetChunkBytes = r.migration.tableTargetChunkSize(change.table.TableName)
+	}
+	copyCfg.FixedChunkSize = r.migration.FixedChunkRows
+	return checksumCfg, copyCfg
+}
+
+// columnMapping returns the column mapping from change's table to its new
+// table, shared by the chunkers, the copier, the replication applier and the
+// checksum. --exclude-columns are removed from it, so none of them touch those
+// columns. Only a single table can be migrated with --exclude-columns, and the
+// excluded columns must exist and not be part of the chunking key.
+func (r *Runner) columnMapping(change *tableChange) (*table.ColumnMapping, error) {
+	mapping := table.NewColumnMapping(change.table, change.newTable, change.stmt.ColumnRenameMap())
+	if len(r.migration.ExcludeColumns) == 0 {
+		return mapping, nil
+	}
+	if len(r.changes) > 1 {
+		return nil, errors.New("--exclude-columns can only be used when migrating a single table")
+	}
+	sourceColumns, _ := mapping.ColumnsSlice()
+	for _, col := range r.migration.ExcludeColumns {
+		if !slices.ContainsFunc(sourceColumns, func(c string) bool { return strings.EqualFold(c, col) }) {
+			return nil, fmt.Errorf("--exclude-columns: column %q is not copied to the new table", col)
+		}
+		if slices.ContainsFunc(change.table.KeyColumns, func(c string) bool { return strings.EqualFold(c, col) }) {
+			return nil, fmt.Errorf("--exclude-columns: column %q is part of the key and must be copied", col)
+		}
+	}
+	return mapping.Exclude(r.migration.ExcludeColumns), nil
+}
+
 // checksum creates the checksum which opens the read view
 func (r *Runner) checksum(ctx context.Context) error {
 	r.status.Set(status.Checksum)
@@ -1519,7 +2321,10 @@ func (r *Runner) checksum(ctx context.Context) error {
 	if err := r.checker.Run(ctx); err != nil {
 		if r.addsUniqueIndex() {
 			// Overwrite the error if we think it's because of a unique index addition
-			return errors.New("checksum failed after several attempts. This is likely related to your statement adding a UNIQUE index on non-unique data")
+			return fmt.Errorf("%w: checksum failed after several attempts. This is likely related to your statement adding a UNIQUE index on non-unique data", ErrChecksumMismatch)
+		}
+		if r.checker.DifferencesFound() > 0 {
+			return fmt.Errorf("%w: checksum failed: %w", ErrChecksumMismatch, err)
 		}
 		return fmt.Errorf("checksum failed: %w", err)
 	}
@@ -1629,18 +2434,32 @@ func (r *Runner) DumpCheckpoint(ctx context.Context) error {
 		originalTableName = r.changes[0].table.TableName
 	}
 	if err := r.checkpointTbl().Write(ctx, checkpoint.Record{
-		CopierWatermark:   copierWatermark,
-		ChecksumWatermark: checksumWatermark,
-		Position:          binlogPosition,
-		Statement:         r.migration.Statement,
-		OriginalTableName: originalTableName,
+		CopierWatermark:       copierWatermark,
+		ChecksumWatermark:     checksumWatermark,
+		Position:              binlogPosition,
+		Statement:             canonicalStatement(r.migration.Statement),
+		OriginalTableName:     originalTableName,
+		CorrelationID:         r.migration.CorrelationID,
+		NewTableName:          r.migration.NewTableName,
+		OldTableName:          r.migration.OldTableName,
+		DeferSecondaryIndexes: r.deferIndexes,
 	}); err != nil {
 		return status.ErrCouldNotWriteCheckpoint
 	}
 	return nil
 }
 
+// Status returns the migration status line, with paused=true appended while
+// the migration is paused.
 func (r *Runner) Status() string {
+	line := r.statusLine()
+	if line != "" && r.IsPaused() {
+		line += " paused=true"
+	}
+	return line
+}
+
+func (r *Runner) statusLine() string {
 	state := r.status.Get()
 	if state > status.CutOver {
 		return ""
@@ -1648,10 +2467,11 @@ func (r *Runner) Status() string {
 	switch state { //nolint: exhaustive
 	case status.CopyRows:
 		// Status for copy rows
-		return fmt.Sprintf("migration status: state=%s copy-progress=%s binlog-deltas=%v total-time=%s copier-time=%s copier-remaining-time=%v copier-is-throttled=%v conns-in-use=%d%s",
+		return fmt.Sprintf("migration status: state=%s copy-progress=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s copier-time=%s copier-remaining-time=%v copier-is-throttled=%v conns-in-use=%d%s",
 			r.status.Get().String(),
 			r.copier.GetProgress(),
 			r.replClient.GetDeltaLen(),
+			r.replClient.DeltaMemoryBytes(),
 			time.Since(r.startTime).Round(time.Second),
 			time.Since(r.copier.StartTime()).Round(time.Second),
 			r.copier.GetETA(),
@@ -1672,18 +2492,20 @@ func (r *Runner) Status() string {
 	case status.ApplyChangeset, status.PostChecksum:
 		// We've finished copying rows, and we are now trying to reduce the number of binlog deltas before
 		// proceeding to the checksum and then the final cutover.
-		return fmt.Sprintf("migration status: state=%s binlog-deltas=%v total-time=%s conns-in-use=%d%s",
+		return fmt.Sprintf("migration status: state=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s conns-in-use=%d%s",
 			r.status.Get().String(),
 			r.replClient.GetDeltaLen(),
+			r.replClient.DeltaMemoryBytes(),
 			time.Since(r.startTime).Round(time.Second),
 			r.db.Stats().InUse,
 			applier.StatusSuffix(r.applier),
 		)
 	case status.Checksum:
-		return fmt.Sprintf("migration status: state=%s checksum-progress=%s binlog-deltas=%v total-time=%s checksum-time=%s conns-in-use=%d",
+		return fmt.Sprintf("migration status: state=%s checksum-progress=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s checksum-time=%s conns-in-use=%d",
 			r.status.Get().String(),
 			r.checker.GetProgress().String(),
 			r.replClient.GetDeltaLen(),
+			r.replClient.DeltaMemoryBytes(),
 			time.Since(r.startTime).Round(time.Second),
 			time.Since(r.checker.StartTime()).Round(time.Second),
 			r.db.Stats().InUse,
@@ -1714,7 +2536,7 @@ func (r *Runner) invalidateChecksumWatermark(ctx context.Context) error {
 		r.checkpointTable.SchemaName,
 		r.checkpointTable.TableName,
 		"",
-		r.migration.Statement,
+		canonicalStatement(r.migration.Statement),
 	)
 }
 
@@ -1810,13 +2632,16 @@ func (r *Runner) runContinuousChecksum(ctx context.Context) error {
 func (r *Runner) buildContinuousChunker() (table.Chunker, error) {
 	chunkers := make([]table.Chunker, 0, len(r.changes))
 	for _, change := range r.changes {
-		columnRenames := change.stmt.ColumnRenameMap()
-		columnMapping := table.NewColumnMapping(change.table, change.newTable, columnRenames)
+		columnMapping, err := r.columnMapping(change)
+		if err != nil {
+			return nil, err
+		}
 		c, err := table.NewChunker(change.table, table.ChunkerConfig{
 			NewTable:        change.newTable,
-			TargetChunkTime: r.migration.TargetChunkTime,
+			TargetChunkTime: r.migration.tableTargetChunkTime(change.table.TableName),
 			Logger:          r.logger,
 			ColumnMapping:   columnMapping,
+			Key:             r.migration.ChunkKey,
 		})
 		if err != nil {
 			return nil, err
@@ -1826,6 +2651,101 @@ func (r *Runner) buildContinuousChunker() (table.Chunker, error) {
 	return table.NewMultiChunker(chunkers...), nil
 }
 
+// startPointOfNoReturn marks that Run is about to change the original table
+// (cutover, or a DDL applied directly), after which Abort returns
+// ErrCannotAbort. It returns ErrAborted if Abort got there first.
+func (r *Runner) startPointOfNoReturn() error {
+	r.abortMu.Lock()
+	defer r.abortMu.Unlock()
+	if r.aborted {
+		return ErrAborted
+	}
+	r.cutoverStarted = true
+	return nil
+}
+
+// dropAbortedArtifacts drops the _new and checkpoint tables when the run was
+// stopped by Abort. It is deferred by run so it executes while the advisory
+// lock is still held. ctx has been cancelled by Abort, so the drops use a
+// context without its cancellation. The sentinel table is never dropped: it
+// is shared by every deferred migration in the schema, and dropping it is
+// what tells them to cut over.
+func (r *Runner) dropAbortedArtifacts(ctx context.Context) {
+	r.abortMu.Lock()
+	aborted := r.aborted
+	// An Abort from here on is too late for the drops below.
+	r.runStopped = true
+	r.abortMu.Unlock()
+	if !aborted {
+		return
+	}
+	ctx = context.WithoutCancel(ctx)
+	// Wait for the checkpoint dumper to exit so it cannot write a checkpoint
+	// into a table we are about to drop.
+	if r.watchTaskWait != nil {
+		r.watchTaskWait()
+	}
+	r.logger.Info("migration aborted; dropping new and checkpoint tables")
+	var errs []error
+	for _, change := range r.changes {
+		if err := r.execDDL(ctx, "DROP TABLE IF EXISTS %n.%n", change.stmt.Schema, change.newTableName()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := r.checkpointTbl().Drop(ctx); err != nil {
+		errs = append(errs, err)
+	}
+	r.abortMu.Lock()
+	r.abortErr = errors.Join(errs...)
+	r.abortMu.Unlock()
+}
+
+// Abort stops a migration that has not yet reached its cutover and removes
+// everything it created, so no partial state remains: it cancels Run, and
+// Run drops the _new and checkpoint tables before it releases the advisory
+// lock. The sentinel table is left in place. Abort waits for that and
+// returns any error from the drops. Because the checkpoint is dropped, a later
+// run starts from scratch rather than resuming. Once cutover (or a DDL applied
+// directly by MySQL) has begun it refuses with ErrCannotAbort and changes
+// nothing.
+//
+// Abort must be called before Close, which closes the connection the cleanup
+// needs. If Run has already returned without being aborted, there is no lock
+// to drop the artifacts under, so they are left in place for a resume and
+// Abort returns ErrNothingToAbort.
+func (r *Runner) Abort(ctx context.Context) error {
+	if r.status.Get() == status.Close {
+		return errors.New("cannot abort a migration after Close has been called")
+	}
+	r.abortMu.Lock()
+	if r.cutoverStarted {
+		r.abortMu.Unlock()
+		return ErrCannotAbort
+	}
+	if r.runStopped && !r.aborted {
+		r.abortMu.Unlock()
+		return ErrNothingToAbort
+	}
+	r.aborted = true
+	runDone := r.runDone
+	r.abortMu.Unlock()
+
+	if runDone == nil {
+		// Run has not started, so there is nothing to stop or clean up.
+		// Marking the runner aborted makes a later Run return ErrAborted.
+		return nil
+	}
+	r.Cancel()
+	select {
+	case <-runDone:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	r.abortMu.Lock()
+	defer r.abortMu.Unlock()
+	return r.abortErr
+}
+
 func (r *Runner) Cancel() {
 	if r.cancelFunc != nil {
 		r.cancelFunc()
@@ -0,0 +1,128 @@
+package migration
+
+import (
+	"fmt"
+	"log/slog"
+	"testing"
+
+	"github.com/block/spirit/pkg/sentinel"
+	"github.com/block/spirit/pkg/status"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAbortBeforeRun checks that aborting a runner that has not started is a
+// no-op that makes a later Run refuse to start.
+func TestAbortBeforeRun(t *testing.T) {
+	password := ""
+	r, err := NewRunner(&Migration{
+		Host:     "localhost:3306",
+		Username: "root",
+		Password: &password,
+		Database: "test",
+		Table:    "aborttbefore",
+		Alter:    "ENGINE=InnoDB",
+	})
+	require.NoError(t, err)
+	require.NoError(t, r.Abort(t.Context()))
+	require.ErrorIs(t, r.Run(t.Context()), ErrAborted)
+}
+
+// TestAbortAfterCutoverRefuses pins that Abort is only valid before cutover:
+// once the tables may have been swapped, dropping _new would be wrong.
+func TestAbortAfterCutoverRefuses(t *testing.T) {
+	cancelled := false
+	r := &Runner{
+		logger:         slog.Default(),
+		cancelFunc:     func() { cancelled = true },
+		cutoverStarted: true,
+	}
+	r.status.Set(status.CutOver)
+	require.ErrorIs(t, r.Abort(t.Context()), ErrCannotAbort)
+	require.False(t, cancelled, "a refused abort must not cancel the migration")
+	require.False(t, r.aborted)
+}
+
+// TestAbortAfterRunReturned pins that aborting a runner whose Run has already
+// returned, for example after a copy error, reports that nothing was cleaned
+// up instead of claiming success.
+func TestAbortAfterRunReturned(t *testing.T) {
+	cancelled := false
+	r := &Runner{
+		logger:     slog.Default(),
+		cancelFunc: func() { cancelled = true },
+		runStopped: true,
+	}
+	require.ErrorIs(t, r.Abort(t.Context()), ErrNothingToAbort)
+	require.False(t, cancelled, "a refused abort must not cancel the migration")
+	require.False(t, r.aborted)
+}
+
+// TestAbortRacesPointOfNoReturn pins both orders of the race between Abort
+// and Run reaching a DDL or cutover: whichever comes first wins.
+func TestAbortRacesPointOfNoReturn(t *testing.T) {
+	r := &Runner{logger: slog.Default(), cancelFunc: func() {}}
+	require.NoError(t, r.startPointOfNoReturn())
+	require.ErrorIs(t, r.Abort(t.Context()), ErrCannotAbort)
+
+	r = &Runner{logger: slog.Default(), cancelFunc: func() {}}
+	require.NoError(t, r.Abort(t.Context()))
+	require.ErrorIs(t, r.startPointOfNoReturn(), ErrAborted)
+}
+
+// TestAbortMidCopy aborts a migration while it is copying rows and checks
+// that Run stops with ErrAborted and the _new and checkpoint tables are gone,
+// leaving only the original table. With defer-cutover the sentinel table is
+// left in place, since dropping it would let other deferred migrations cut
+// over.
+func TestAbortMidCopy(t *testing.T) {
+	for _, deferCutOver := range []bool{false, true} {
+		t.Run(fmt.Sprintf("defer-cutover=%t", deferCutOver), func(t *testing.T) {
+			testAbortMidCopy(t, deferCutOver)
+		})
+	}
+}
+
+func testAbortMidCopy(t *testing.T, deferCutOver bool) {
+	tbl := "abortmidcopy"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+utils.NewTableName(tbl)+", "+utils.CheckpointTableName(tbl)+", "+sentinel.TableName)
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, pad VARBINARY(1024))")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM dual")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" a, "+tbl+" b, "+tbl+" c LIMIT 100000")
+	for range 4 {
+		testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" LIMIT 10000")
+	}
+
+	opts := []RunnerOption{WithThreads(1), WithTestThrottler()}
+	if deferCutOver {
+		opts = append(opts, WithDeferCutOver())
+	}
+	m := NewTestRunner(t, tbl, "ENGINE=InnoDB", opts...)
+	defer utils.CloseAndLog(m)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- m.Run(t.Context())
+	}()
+	waitForStatus(t, m, status.CopyRows)
+	require.Equal(t, status.CopyRows, m.status.Get(), "the abort must happen mid-copy")
+
+	require.NoError(t, m.Abort(t.Context()))
+	require.ErrorIs(t, <-runErr, ErrAborted)
+
+	tableExists := func(name string) bool {
+		var n int
+		require.NoError(t, m.db.QueryRowContext(t.Context(),
+			"SELECT COUNT(*) FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?",
+			name).Scan(&n))
+		return n > 0
+	}
+	require.True(t, tableExists(tbl), "the original table must be untouched")
+	require.False(t, tableExists(utils.NewTableName(tbl)), "the _new table must be dropped")
+	require.False(t, tableExists(utils.CheckpointTableName(tbl)), "the checkpoint table must be dropped")
+	require.Equal(t, deferCutOver, tableExists(sentinel.TableName), "the sentinel table must never be dropped by an abort")
+
+	// Aborting twice is harmless.
+	require.NoError(t, m.Abort(t.Context()))
+}
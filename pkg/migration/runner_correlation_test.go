@@ -0,0 +1,59 @@
+package migration
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/status"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCorrelationIDInCheckpoint runs a migration with a correlation ID and
+// checks that DumpCheckpoint stores it in the checkpoint row, and that the
+// copy statements the server executes carry it as a SQL comment.
+func TestCorrelationIDInCheckpoint(t *testing.T) {
+	tbl := "correlationt1"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+utils.NewTableName(tbl)+", "+utils.CheckpointTableName(tbl))
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, pad VARBINARY(1024))")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM dual")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" a, "+tbl+" b, "+tbl+" c LIMIT 100000")
+	for range 4 {
+		testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" LIMIT 10000")
+	}
+
+	m := NewTestRunner(t, tbl, "ENGINE=InnoDB", WithThreads(1), WithTestThrottler(), WithCorrelationID("CHG-4242"))
+	defer utils.CloseAndLog(m)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- m.Run(t.Context())
+	}()
+	waitForStatus(t, m, status.CopyRows)
+	require.Eventually(t, func() bool {
+		return m.DumpCheckpoint(t.Context()) == nil
+	}, 60*time.Second, 10*time.Millisecond, "timeout waiting for a checkpoint")
+
+	rec, err := m.checkpointTbl().ReadLatest(t.Context())
+	require.NoError(t, err)
+	require.Equal(t, "CHG-4242", rec.CorrelationID)
+	require.Equal(t, "correlation_id=CHG-4242", m.dbConfig.QueryComment)
+
+	// SQL_TEXT keeps comments, so the copier's statements show up with the
+	// correlation ID in performance_schema while the copy runs.
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+	require.Eventually(t, func() bool {
+		var n int
+		err := db.QueryRowContext(t.Context(),
+			"SELECT COUNT(*) FROM performance_schema.events_statements_history WHERE sql_text LIKE ?",
+			"/* correlation_id=CHG-4242 */%").Scan(&n)
+		return err == nil && n > 0
+	}, 60*time.Second, 10*time.Millisecond, "timeout waiting for a statement with the correlation ID")
+
+	m.Cancel()
+	<-runErr
+}
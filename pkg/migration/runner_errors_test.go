@@ -0,0 +1,201 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/block/spirit/pkg/change"
+	"github.com/block/spirit/pkg/checkpoint"
+	"github.com/block/spirit/pkg/status"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// TestFatalErrorMarksTableChanged checks that only a schema-change fatal
+// makes Run report ErrTableChanged; a dead binlog stream does not.
+func TestFatalErrorMarksTableChanged(t *testing.T) {
+	r := &Runner{logger: slog.Default(), cancelFunc: func() {}}
+	require.True(t, r.fatalError(change.FatalReasonSchemaChange))
+	require.True(t, r.tableChanged.Load())
+
+	r = &Runner{logger: slog.Default(), cancelFunc: func() {}}
+	require.True(t, r.fatalError(change.FatalReasonStreamError))
+	require.False(t, r.tableChanged.Load())
+}
+
+// TestPreflightError checks that a table refused by the preflight checks
+// fails Run with ErrPreflight.
+func TestPreflightError(t *testing.T) {
+	tbl := "preflighterr"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl)
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL PRIMARY KEY, b INT) ENGINE=MyISAM")
+	t.Cleanup(func() { testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl) })
+
+	m := NewTestRunner(t, tbl, "ADD INDEX (b)")
+	defer utils.CloseAndLog(m)
+	err := m.Run(t.Context())
+	require.ErrorIs(t, err, ErrPreflight)
+	require.ErrorContains(t, err, "MyISAM")
+}
+
+// TestInvalidAlterError checks that an ALTER MySQL rejects fails Run with
+// ErrPreflight before the new or checkpoint table is created, and leaves no
+// scratch table behind.
+func TestInvalidAlterError(t *testing.T) {
+	tt := testutils.NewTestTable(t, "invalidalter", `CREATE TABLE invalidalter (
+		id INT NOT NULL PRIMARY KEY,
+		b INT
+	)`)
+	m := NewTestRunner(t, "invalidalter", "ADD INDEX idx_dup (b), ADD INDEX idx_dup (id)")
+	defer utils.CloseAndLog(m)
+	err := m.Run(t.Context())
+	require.ErrorIs(t, err, ErrPreflight)
+	require.ErrorContains(t, err, "idx_dup")
+	require.False(t, tableExists(t, tt.DB, utils.NewTableName("invalidalter")))
+	require.False(t, tableExists(t, tt.DB, utils.CheckpointTableName("invalidalter")))
+	require.False(t, tableExists(t, tt.DB, utils.AuxTableName("invalidalter", validateAlterSuffix)))
+}
+
+// TestExistingArtifactsError checks that with ArtifactPolicyFail a fresh
+// migration refuses to start when the _new table already exists, and leaves
+// that table alone.
+func TestExistingArtifactsError(t *testing.T) {
+	tbl := "existingartifacts"
+	newTbl := "_" + tbl + "_new"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+newTbl+", _"+tbl+"_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL PRIMARY KEY, b INT)")
+	testutils.RunSQL(t, "CREATE TABLE "+newTbl+" (id INT NOT NULL PRIMARY KEY)")
+	testutils.RunSQL(t, "INSERT INTO "+newTbl+" VALUES (1)")
+	t.Cleanup(func() { testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+newTbl) })
+
+	m := NewTestRunner(t, tbl, "ADD INDEX (b)", WithOnExistingArtifacts(ArtifactPolicyFail))
+	defer utils.CloseAndLog(m)
+	err := m.Run(t.Context())
+	require.ErrorIs(t, err, ErrExistingArtifacts)
+	require.ErrorContains(t, err, newTbl)
+
+	var count int
+	require.NoError(t, m.db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM "+newTbl).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+// TestExistingScratchTableError checks that with ArtifactPolicyFail a
+// migration refuses to drop an existing table with a scratch table's name,
+// and leaves that table alone.
+func TestExistingScratchTableError(t *testing.T) {
+	tbl := "existingscratch"
+	valTbl := utils.AuxTableName(tbl, validateAlterSuffix)
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+valTbl+", _"+tbl+"_new, _"+tbl+"_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL PRIMARY KEY, b INT)")
+	testutils.RunSQL(t, "CREATE TABLE "+valTbl+" (id INT NOT NULL PRIMARY KEY)")
+	testutils.RunSQL(t, "INSERT INTO "+valTbl+" VALUES (1)")
+	t.Cleanup(func() { testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+valTbl) })
+
+	m := NewTestRunner(t, tbl, "ADD INDEX (b)", WithOnExistingArtifacts(ArtifactPolicyFail))
+	defer utils.CloseAndLog(m)
+	err := m.Run(t.Context())
+	require.ErrorIs(t, err, ErrExistingArtifacts)
+	require.ErrorContains(t, err, valTbl)
+
+	var count int
+	require.NoError(t, m.db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM "+valTbl).Scan(&count))
+	require.Equal(t, 1, count)
+}
+
+// TestCustomTableNameExistingTable checks that a fresh migration refuses to
+// drop a table named by --new-table-name or --old-table-name when there is
+// no checkpoint to show an earlier run created it, or the checkpoint was
+// written by a run with other names, and leaves it alone.
+func TestCustomTableNameExistingTable(t *testing.T) {
+	tbl := "customnameexisting"
+	other := "customnameexisting_other"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+other+", _"+tbl+"_chkpnt")
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL PRIMARY KEY, b INT)")
+	testutils.RunSQL(t, "CREATE TABLE "+other+" (id INT NOT NULL PRIMARY KEY)")
+	testutils.RunSQL(t, "INSERT INTO "+other+" VALUES (1)")
+	t.Cleanup(func() { testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+other+", _"+tbl+"_chkpnt") })
+
+	runs := func() {
+		for _, names := range [][2]string{{other, ""}, {"", other}} {
+			m := NewTestRunner(t, tbl, "ADD INDEX (b)", WithTableNames(names[0], names[1]))
+			err := m.Run(t.Context())
+			require.ErrorIs(t, err, ErrExistingArtifacts)
+			require.ErrorContains(t, err, other)
+			require.NoError(t, m.Close())
+
+			var count int
+			require.NoError(t, m.db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM "+other).Scan(&count))
+			require.Equal(t, 1, count)
+		}
+	}
+	runs()
+
+	// A checkpoint left by an earlier run with a different ALTER and the
+	// generated table names does not make the named table spirit's.
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+	chkpnt := checkpoint.NewTable(db, "_"+tbl+"_chkpnt", checkpoint.Transient)
+	require.NoError(t, chkpnt.Create(t.Context()))
+	require.NoError(t, chkpnt.Write(t.Context(), checkpoint.Record{
+		Statement:         "ALTER TABLE `" + tbl + "` ADD COLUMN c INT",
+		OriginalTableName: tbl,
+	}))
+	runs()
+}
+
+// TestChecksumMismatchError checks that a checksum failure is reported as
+// ErrChecksumMismatch only when the checker found differing rows.
+func TestChecksumMismatchError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("DifferencesFound", func(t *testing.T) {
+		t.Parallel()
+		r := setupRunnerForChecksumTest(t, "chkerr_differences")
+		mc := &mockChecker{runErr: errors.New("simulated retry exhaustion")}
+		mc.differencesFound.Store(1)
+		r.checker = mc
+		err := r.checksum(t.Context())
+		require.ErrorIs(t, err, ErrChecksumMismatch)
+		require.ErrorIs(t, err, mc.runErr)
+	})
+
+	t.Run("ContextCancelled", func(t *testing.T) {
+		t.Parallel()
+		r := setupRunnerForChecksumTest(t, "chkerr_cancelled")
+		r.checker = &mockChecker{runErr: context.Canceled}
+		err := r.checksum(t.Context())
+		require.ErrorIs(t, err, context.Canceled)
+		require.NotErrorIs(t, err, ErrChecksumMismatch)
+	})
+}
+
+// TestTableChangedError checks that a DDL against the table while it is being
+// copied stops Run with ErrTableChanged.
+func TestTableChangedError(t *testing.T) {
+	tbl := "tablechangederr"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+utils.NewTableName(tbl)+", "+utils.CheckpointTableName(tbl))
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, pad VARBINARY(1024))")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM dual")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" a, "+tbl+" b, "+tbl+" c LIMIT 100000")
+	for range 4 {
+		testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(1024) FROM "+tbl+" LIMIT 10000")
+	}
+	t.Cleanup(func() { testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl) })
+
+	m := NewTestRunner(t, tbl, "ENGINE=InnoDB", WithThreads(1), WithTestThrottler())
+	defer utils.CloseAndLog(m)
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- m.Run(t.Context())
+	}()
+	waitForStatus(t, m, status.CopyRows)
+
+	testutils.RunSQL(t, "ALTER TABLE "+tbl+" ADD COLUMN b INT")
+	require.ErrorIs(t, <-runErr, ErrTableChanged)
+}
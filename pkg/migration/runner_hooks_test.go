@@ -0,0 +1,209 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+// tableExists reports whether name exists in the test schema.
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	require.NoError(t, db.QueryRowContext(t.Context(), `SELECT COUNT(*) FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?`, name).Scan(&count))
+	return count > 0
+}
+
+// TestCutoverHooks checks that the hooks run immediately before and after
+// the tables are renamed.
+func TestCutoverHooks(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "cutoverhooks", `CREATE TABLE cutoverhooks (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO cutoverhooks (name) VALUES ('a'), ('b'), ('c')")
+
+	m := NewTestRunner(t, "cutoverhooks", "ENGINE=InnoDB")
+	var calls []string
+	m.migration.PreCutoverHook = func(ctx context.Context) error {
+		calls = append(calls, "pre")
+		require.True(t, tableExists(t, tt.DB, "_cutoverhooks_new"), "the new table has not been renamed yet")
+		return nil
+	}
+	m.migration.PostCutoverHook = func(ctx context.Context) error {
+		calls = append(calls, "post")
+		require.False(t, tableExists(t, tt.DB, "_cutoverhooks_new"), "the new table has been renamed")
+		require.True(t, tableExists(t, tt.DB, "_cutoverhooks_old"), "the old table has not been dropped yet")
+		return nil
+	}
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+	require.Equal(t, []string{"pre", "post"}, calls)
+}
+
+// TestPreCutoverHookError checks that a failing pre-cutover hook stops the
+// cutover, and that the migration can then be run again.
+func TestPreCutoverHookError(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "precutoverhookerr", `CREATE TABLE precutoverhookerr (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO precutoverhookerr (name) VALUES ('a'), ('b'), ('c')")
+
+	hookErr := errors.New("feature flag service unavailable")
+	m := NewTestRunner(t, "precutoverhookerr", "ENGINE=InnoDB")
+	m.migration.PreCutoverHook = func(ctx context.Context) error {
+		return hookErr
+	}
+	err := m.Run(t.Context())
+	require.ErrorIs(t, err, ErrPreCutoverHook)
+	require.ErrorIs(t, err, hookErr)
+	require.NoError(t, m.Close())
+	require.True(t, tableExists(t, tt.DB, "_precutoverhookerr_new"), "the cutover must not have happened")
+	require.False(t, tableExists(t, tt.DB, "_precutoverhookerr_old"))
+
+	m2 := NewTestRunner(t, "precutoverhookerr", "ENGINE=InnoDB")
+	require.NoError(t, m2.Run(t.Context()))
+	require.NoError(t, m2.Close())
+}
+
+// TestAbortDuringPreCutoverHook checks that the migration can still be
+// aborted while the pre-cutover hook runs, since no rename has started.
+func TestAbortDuringPreCutoverHook(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "abortprecutover", `CREATE TABLE abortprecutover (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO abortprecutover (name) VALUES ('a'), ('b'), ('c')")
+
+	m := NewTestRunner(t, "abortprecutover", "ENGINE=InnoDB")
+	abortErr := make(chan error, 1)
+	m.migration.PreCutoverHook = func(ctx context.Context) error {
+		go func() {
+			abortErr <- m.Abort(context.Background())
+		}()
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	require.Error(t, m.Run(t.Context()))
+	require.NoError(t, <-abortErr)
+	require.NoError(t, m.Close())
+	require.False(t, tableExists(t, tt.DB, "_abortprecutover_old"), "the cutover must not have happened")
+}
+
+// TestPostCutoverHookError checks that an error from the post-cutover hook
+// is returned, and that the migration is still cleaned up.
+func TestPostCutoverHookError(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "postcutoverhookerr", `CREATE TABLE postcutoverhookerr (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+
+	m := NewTestRunner(t, "postcutoverhookerr", "ENGINE=InnoDB")
+	m.migration.PostCutoverHook = func(ctx context.Context) error {
+		return errors.New("cache warm-up failed")
+	}
+	require.ErrorIs(t, m.Run(t.Context()), ErrPostCutoverHook)
+	require.NoError(t, m.Close())
+	require.False(t, tableExists(t, tt.DB, "_postcutoverhookerr_old"))
+	require.False(t, tableExists(t, tt.DB, "_postcutoverhookerr_chkpnt"))
+}
+
+// TestOnExecDDL checks that every DDL statement of a migration that copies
+// the table is reported, in the order it runs.
+func TestOnExecDDL(t *testing.T) {
+	t.Parallel()
+	testutils.NewTestTable(t, "execddlhook", `CREATE TABLE execddlhook (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO execddlhook (name) VALUES ('a'), ('b'), ('c')")
+
+	// ADD INDEX can't be INSTANT and isn't considered safe for INPLACE, so
+	// the table is copied.
+	m := NewTestRunner(t, "execddlhook", "ADD COLUMN c INT, ADD INDEX (c)")
+	var mu sync.Mutex
+	var stmts []string
+	m.OnExecDDL(func(stmt string) {
+		mu.Lock()
+		defer mu.Unlock()
+		stmts = append(stmts, stmt)
+	})
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := []string{
+		"ANALYZE TABLE `execddlhook`",
+		"ALTER TABLE `execddlhook` ALGORITHM=INSTANT, ADD COLUMN c INT, ADD INDEX (c)",
+		"CREATE TABLE `_execddlhook_new` LIKE `execddlhook`",
+		"ALTER TABLE `_execddlhook_new` ADD COLUMN c INT, ADD INDEX (c)",
+		"CREATE TABLE `_execddlhook_chkpnt` (",
+		"ANALYZE TABLE ",
+		"DROP TABLE IF EXISTS `_execddlhook_old`",
+		"RENAME TABLE ",
+		"DROP TABLE IF EXISTS `_execddlhook_old`",
+		"DROP TABLE IF EXISTS `_execddlhook_chkpnt`",
+	}
+	i := 0
+	for _, stmt := range stmts {
+		if i < len(expected) && strings.HasPrefix(stmt, expected[i]) {
+			i++
+		}
+	}
+	require.Len(t, expected, i, "statement %q not reported in order; got %q", expected[min(i, len(expected)-1)], stmts)
+}
+
+// TestOnCopyFallback checks that the copy fallback is reported once, with the
+// error MySQL returned for the INSTANT attempt.
+func TestOnCopyFallback(t *testing.T) {
+	t.Parallel()
+	testutils.NewTestTable(t, "copyfallbackhook", `CREATE TABLE copyfallbackhook (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+	testutils.RunSQL(t, "INSERT INTO copyfallbackhook (name) VALUES ('a'), ('b'), ('c')")
+
+	m := NewTestRunner(t, "copyfallbackhook", "ADD INDEX (name)")
+	var fallbacks []error
+	m.OnCopyFallback(func(err error) {
+		fallbacks = append(fallbacks, err)
+	})
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+	require.Len(t, fallbacks, 1)
+	var myErr *mysql.MySQLError
+	require.ErrorAs(t, fallbacks[0], &myErr, "the error from the ALGORITHM=INSTANT attempt is attached")
+	require.ErrorContains(t, fallbacks[0], "ALGORITHM=INSTANT")
+}
+
+// TestOnCopyFallbackInstant checks that the hook isn't called when the change
+// is applied with INSTANT DDL.
+func TestOnCopyFallbackInstant(t *testing.T) {
+	t.Parallel()
+	testutils.NewTestTable(t, "copyfallbackinstant", `CREATE TABLE copyfallbackinstant (
+		id int NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name varchar(255) NOT NULL
+	)`)
+
+	m := NewTestRunner(t, "copyfallbackinstant", "ADD COLUMN c INT")
+	m.OnCopyFallback(func(err error) {
+		t.Errorf("unexpected copy fallback: %v", err)
+	})
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+	require.True(t, m.usedInstantDDL)
+}
@@ -0,0 +1,136 @@
+package migration
+
+import (
+	"database/sql"
+	"log/slog"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/change"
+	"github.com/block/spirit/pkg/copier"
+	"github.com/block/spirit/pkg/metrics"
+	"github.com/block/spirit/pkg/status"
+	"github.com/block/spirit/pkg/table"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+// laggingFeed is a change.Source double with a fixed backlog and lag.
+type laggingFeed struct {
+	change.Source // nil: only the methods below are called
+}
+
+func (f *laggingFeed) GetDeltaLen() int        { return 42 }
+func (f *laggingFeed) DeltaMemoryBytes() int64 { return 4096 }
+func (f *laggingFeed) Lag() time.Duration      { return 1500 * time.Millisecond }
+
+func TestEmitProgressMetrics(t *testing.T) {
+	sink := metrics.NewInMemorySink()
+	r := &Runner{replClient: &laggingFeed{}, metricsSink: sink, logger: slog.Default()}
+	r.emitProgressMetrics(t.Context())
+	require.Equal(t, map[string]float64{
+		metrics.DeltaLenMetricName:         42,
+		metrics.DeltaMemoryBytesMetricName: 4096,
+		metrics.BinlogLagSecondsMetricName: 1.5,
+	}, sink.Snapshot())
+}
+
+// TestEmitProgressMetricsChunkSize checks that the copier's current chunk
+// size and panic shrink count are sent once the copier exists.
+func TestEmitProgressMetricsChunkSize(t *testing.T) {
+	chunker := table.NewMockChunker("t1", 1000)
+	chunker.SetChunkSize(2500)
+	cfg := copier.NewCopierDefaultConfig()
+	cfg.Unbuffered = true
+	c, err := copier.NewCopier(nil, chunker, cfg)
+	require.NoError(t, err)
+
+	sink := metrics.NewInMemorySink()
+	r := &Runner{replClient: &laggingFeed{}, copier: c, metricsSink: sink, logger: slog.Default()}
+	r.emitProgressMetrics(t.Context())
+	snapshot := sink.Snapshot()
+	require.InDelta(t, 2500, snapshot[metrics.CopyChunkSizeMetricName], 0)
+	require.Contains(t, snapshot, metrics.CopyChunkPanicShrinksMetricName)
+	require.Zero(t, snapshot[metrics.CopyChunkPanicShrinksMetricName])
+}
+
+// TestEmitPoolMetrics checks that each connection pool's statistics are
+// sent labeled with the pool's name.
+func TestEmitPoolMetrics(t *testing.T) {
+	main, err := sql.Open("mysql", "root@tcp(127.0.0.1:1)/test") // never connects
+	require.NoError(t, err)
+	defer utils.CloseAndLog(main)
+	main.SetMaxOpenConns(7)
+	monitor, err := sql.Open("mysql", "root@tcp(127.0.0.1:1)/test")
+	require.NoError(t, err)
+	defer utils.CloseAndLog(monitor)
+	monitor.SetMaxOpenConns(2)
+
+	sink := metrics.NewPrometheusSink("spirit")
+	r := &Runner{db: main, monitorDB: monitor, metricsSink: sink, logger: slog.Default()}
+	r.emitPoolMetrics(t.Context(), r.connPools())
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Contains(t, rec.Body.String(), `spirit_db_conns_max_open{pool="main"} 7`)
+	require.Contains(t, rec.Body.String(), `spirit_db_conns_max_open{pool="monitor"} 2`)
+	require.Contains(t, rec.Body.String(), `spirit_db_conns_in_use{pool="main"} 0`)
+	require.Contains(t, rec.Body.String(), `spirit_db_conn_wait_count{pool="monitor"} 0`)
+}
+
+// TestMetricsSinkLabels checks that a migration's metrics are labeled with
+// the schema and table, so two migrations can share a Prometheus sink.
+func TestMetricsSinkLabels(t *testing.T) {
+	sink := metrics.NewPrometheusSink("spirit")
+	for _, tbl := range []string{"t1", "t2"} {
+		r, err := NewRunner(&Migration{Database: "test", Table: tbl, Alter: "ENGINE=InnoDB", CorrelationID: "CHG-1"})
+		require.NoError(t, err)
+		r.SetMetricsSink(sink)
+		r.replClient = &laggingFeed{}
+		r.emitProgressMetrics(t.Context())
+	}
+	rec := httptest.NewRecorder()
+	sink.ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	require.Contains(t, rec.Body.String(), `spirit_delta_len{correlation_id="CHG-1",schema="test",table="t1"} 42`)
+	require.Contains(t, rec.Body.String(), `spirit_delta_len{correlation_id="CHG-1",schema="test",table="t2"} 42`)
+}
+
+// TestProgressCallback checks that Migration.ProgressCallback receives the
+// migration's progress on the status ticker while it copies.
+func TestProgressCallback(t *testing.T) {
+	tt := testutils.NewTestTable(t, "progresscallback", `CREATE TABLE progresscallback (
+		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		pad VARBINARY(100))`)
+	tt.SeedRows(t, "INSERT INTO progresscallback (pad) SELECT RANDOM_BYTES(100) FROM dual", 10000)
+
+	m := NewTestRunner(t, "progresscallback", "ENGINE=InnoDB",
+		WithThreads(1),
+		WithTargetChunkTime(100*time.Millisecond),
+		WithTestThrottler())
+	defer utils.CloseAndLog(m)
+	var mu sync.Mutex
+	var reports []status.Progress
+	m.migration.ProgressCallback = func(p status.Progress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, p)
+	}
+	require.NoError(t, m.Run(t.Context()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	var copying *status.Progress
+	for i := range reports {
+		require.LessOrEqual(t, reports[i].CurrentState, status.CutOver)
+		if reports[i].CurrentState == status.CopyRows && copying == nil {
+			copying = &reports[i]
+		}
+	}
+	require.NotNil(t, copying, "no progress was reported during the copy")
+	require.Len(t, copying.Tables, 1)
+	require.Equal(t, "progresscallback", copying.Tables[0].TableName)
+	require.NotEqual(t, status.ETANone, copying.ETA.State)
+	require.LessOrEqual(t, copying.CopyFraction(), 1.0)
+}
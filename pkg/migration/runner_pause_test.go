@@ -0,0 +1,55 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/status"
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauseReported(t *testing.T) {
+	r, err := NewRunner(&Migration{Database: "test", Table: "pausereported", Alter: "ENGINE=InnoDB"})
+	require.NoError(t, err)
+	require.False(t, r.IsPaused())
+
+	r.Pause()
+	require.True(t, r.IsPaused())
+	require.True(t, r.Progress().Paused)
+	require.True(t, r.pauser.IsThrottled(), "the copier and checksum throttler must be paused")
+
+	r.Resume()
+	require.False(t, r.IsPaused())
+	require.False(t, r.Progress().Paused)
+}
+
+// TestPauseResume pauses a migration before it starts, checks that it stops
+// in copy-rows without copying anything, and then resumes it to completion.
+func TestPauseResume(t *testing.T) {
+	tbl := "pauseresume"
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS "+tbl+", "+utils.NewTableName(tbl))
+	testutils.RunSQL(t, "CREATE TABLE "+tbl+" (id INT NOT NULL AUTO_INCREMENT PRIMARY KEY, pad VARBINARY(100))")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(100) FROM dual")
+	testutils.RunSQL(t, "INSERT INTO "+tbl+" (pad) SELECT RANDOM_BYTES(100) FROM "+tbl+" a, "+tbl+" b, "+tbl+" c LIMIT 10000")
+
+	m := NewTestRunner(t, tbl, "ENGINE=InnoDB", WithThreads(1))
+	defer utils.CloseAndLog(m)
+	m.Pause()
+
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- m.Run(t.Context())
+	}()
+	waitForStatus(t, m, status.CopyRows)
+	time.Sleep(time.Second)
+	require.Equal(t, status.CopyRows, m.status.Get(), "a paused migration must not leave copy-rows")
+	require.Contains(t, m.Status(), "paused=true")
+	rowsCopied, _, _ := m.copyChunker.Progress()
+	require.Zero(t, rowsCopied, "a paused migration must not copy rows")
+
+	m.Resume()
+	require.NoError(t, <-runErr)
+	require.False(t, m.Progress().Paused)
+}
@@ -21,3 +21,23 @@ func TestControlPlaneConns(t *testing.T) {
 	multi := &Runner{changes: make([]*tableChange, 3)}
 	require.Equal(t, 5, multi.controlPlaneConns())
 }
+
+// TestChecksumThreads checks that --checksum-threads sets the checker's
+// concurrency independently of --threads, defaults to it, and that the pool
+// is sized for the larger of the two.
+func TestChecksumThreads(t *testing.T) {
+	r, err := NewRunner(&Migration{Database: "test", Table: "t1", Alter: "ENGINE=InnoDB", Threads: 8})
+	require.NoError(t, err)
+	require.Equal(t, 8, r.checkerConfig().Concurrency)
+	require.Equal(t, 8, r.readThreads())
+
+	r, err = NewRunner(&Migration{Database: "test", Table: "t1", Alter: "ENGINE=InnoDB", Threads: 8, ChecksumThreads: 2})
+	require.NoError(t, err)
+	require.Equal(t, 2, r.checkerConfig().Concurrency)
+	require.Equal(t, 8, r.readThreads())
+
+	r, err = NewRunner(&Migration{Database: "test", Table: "t1", Alter: "ENGINE=InnoDB", Threads: 2, ChecksumThreads: 6})
+	require.NoError(t, err)
+	require.Equal(t, 6, r.checkerConfig().Concurrency)
+	require.Equal(t, 6, r.readThreads())
+}
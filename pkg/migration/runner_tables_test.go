@@ -0,0 +1,249 @@
+package migration
+
+import (
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/table"
+	"github.com/stretchr/testify/require"
+)
+
+// TestInvolvedTables asserts that the source, _new, _old, scratch,
+// checkpoint and sentinel tables are all reported, fully qualified, without needing a
+// database connection.
+func TestInvolvedTables(t *testing.T) {
+	password := ""
+	r, err := NewRunner(&Migration{
+		Host:     "localhost:3306",
+		Username: "root",
+		Password: &password,
+		Database: "test",
+		Table:    "involvedt1",
+		Alter:    "ENGINE=InnoDB",
+	})
+	require.NoError(t, err)
+	tables, err := r.InvolvedTables()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"test.involvedt1",
+		"test._involvedt1_new",
+		"test._involvedt1_old",
+		"test._involvedt1_val",
+		"test._involvedt1_idx",
+		"test._involvedt1_dry",
+		"test._involvedt1_chkpnt",
+	}, tables)
+
+	// Multi-table migrations share the schema-wide checkpoint table, and
+	// defer-cutover adds the sentinel table.
+	r, err = NewRunner(&Migration{
+		Host:         "localhost:3306",
+		Username:     "root",
+		Password:     &password,
+		Database:     "test",
+		Statement:    "ALTER TABLE involvedt1 ENGINE=InnoDB; ALTER TABLE involvedt2 ENGINE=InnoDB",
+		DeferCutOver: true,
+	})
+	require.NoError(t, err)
+	tables, err = r.InvolvedTables()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"test.involvedt1",
+		"test._involvedt1_new",
+		"test._involvedt1_old",
+		"test._involvedt1_val",
+		"test._involvedt1_idx",
+		"test._involvedt1_dry",
+		"test.involvedt2",
+		"test._involvedt2_new",
+		"test._involvedt2_old",
+		"test._involvedt2_val",
+		"test._involvedt2_idx",
+		"test._involvedt2_dry",
+		"test._spirit_checkpoint",
+		"test._spirit_sentinel",
+	}, tables)
+}
+
+// TestInvolvedTablesLockNamespace asserts that a LockNamespace is part of
+// every auxiliary table name, so runs in different namespaces, which don't
+// exclude each other, use different tables.
+func TestInvolvedTablesLockNamespace(t *testing.T) {
+	password := ""
+	r, err := NewRunner(&Migration{
+		Host:          "localhost:3306",
+		Username:      "root",
+		Password:      &password,
+		Database:      "test",
+		Table:         "involvedt1",
+		Alter:         "ENGINE=InnoDB",
+		LockNamespace: "tool_a",
+	})
+	require.NoError(t, err)
+	tables, err := r.InvolvedTables()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"test.involvedt1",
+		"test._involvedt1_tool_a_new",
+		"test._involvedt1_tool_a_old",
+		"test._involvedt1_tool_a_val",
+		"test._involvedt1_tool_a_idx",
+		"test._involvedt1_tool_a_dry",
+		"test._involvedt1_tool_a_chkpnt",
+	}, tables)
+
+	r, err = NewRunner(&Migration{
+		Host:          "localhost:3306",
+		Username:      "root",
+		Password:      &password,
+		Database:      "test",
+		Statement:     "ALTER TABLE involvedt1 ENGINE=InnoDB; ALTER TABLE involvedt2 ENGINE=InnoDB",
+		LockNamespace: "tool_a",
+	})
+	require.NoError(t, err)
+	tables, err = r.InvolvedTables()
+	require.NoError(t, err)
+	require.Contains(t, tables, "test._spirit_tool_a_checkpoint")
+
+	_, err = NewRunner(&Migration{
+		Database:      "test",
+		Table:         "involvedt1",
+		Alter:         "ENGINE=InnoDB",
+		LockNamespace: "not a namespace",
+	})
+	require.ErrorContains(t, err, "--lock-namespace must be")
+}
+
+// TestInvolvedTablesSkipDropAfterCutover asserts that the timestamped _old
+// name is only reported once the start time is known.
+func TestInvolvedTablesSkipDropAfterCutover(t *testing.T) {
+	password := ""
+	r, err := NewRunner(&Migration{
+		Host:                 "localhost:3306",
+		Username:             "root",
+		Password:             &password,
+		Database:             "test",
+		Table:                "involvedt1",
+		Alter:                "ENGINE=InnoDB",
+		SkipDropAfterCutover: true,
+	})
+	require.NoError(t, err)
+	_, err = r.InvolvedTables()
+	require.Error(t, err)
+
+	r.startTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tables, err := r.InvolvedTables()
+	require.NoError(t, err)
+	require.Contains(t, tables, "test._involvedt1_old_20240102_030405")
+}
+
+// TestInvolvedTablesCustomNames asserts that --new-table-name and
+// --old-table-name replace the generated names, and that the old name is
+// known before Run even with SkipDropAfterCutover.
+func TestInvolvedTablesCustomNames(t *testing.T) {
+	password := ""
+	m := &Migration{
+		Host:                 "localhost:3306",
+		Username:             "root",
+		Password:             &password,
+		Database:             "test",
+		Table:                "involvedt1",
+		Alter:                "ENGINE=InnoDB",
+		SkipDropAfterCutover: true,
+		NewTableName:         "involvedt1_shadow",
+		OldTableName:         "involvedt1_archive",
+	}
+	r, err := NewRunner(m)
+	require.NoError(t, err)
+	tables, err := r.InvolvedTables()
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"test.involvedt1",
+		"test.involvedt1_shadow",
+		"test.involvedt1_archive",
+		"test._involvedt1_val",
+		"test._involvedt1_idx",
+		"test._involvedt1_dry",
+		"test._involvedt1_chkpnt",
+	}, tables)
+
+	// The names can't be shared by several tables, or be the table itself.
+	m.Table, m.Alter = "", ""
+	m.Statement = "ALTER TABLE involvedt1 ENGINE=InnoDB; ALTER TABLE involvedt2 ENGINE=InnoDB"
+	_, err = NewRunner(m)
+	require.ErrorContains(t, err, "can only be used when migrating a single table")
+
+	m.Statement = ""
+	m.Table, m.Alter = "involvedt1", "ENGINE=InnoDB"
+	m.OldTableName = "involvedt1"
+	_, err = NewRunner(m)
+	require.ErrorContains(t, err, "cannot be the name of the table being migrated")
+
+	// Table names are compared case-insensitively, as the server does with
+	// lower_case_table_names.
+	m.Statement = ""
+	m.OldTableName = "InvolvedT1"
+	_, err = NewRunner(m)
+	require.ErrorContains(t, err, "cannot be the name of the table being migrated")
+}
+
+// TestTableChunkOptions checks that each table of a multi-table migration
+// has its chunkers sized by its own --table-target-chunk-time and
+// --table-target-chunk-size, falling back to the global targets, and that
+// the options can only name tables being migrated.
+func TestTableChunkOptions(t *testing.T) {
+	m := &Migration{
+		Database:             "test",
+		Statement:            "ALTER TABLE chunkopt_small ENGINE=InnoDB; ALTER TABLE chunkopt_blobs ENGINE=InnoDB; ALTER TABLE chunkopt_other ENGINE=InnoDB",
+		TargetChunkTime:      500 * time.Millisecond,
+		TargetChunkSize:      16 << 20,
+		TableTargetChunkTime: map[string]time.Duration{"chunkopt_small": 2 * time.Second, "chunkopt_blobs": 100 * time.Millisecond},
+		TableTargetChunkSize: map[string]uint64{"chunkopt_blobs": 1 << 20},
+	}
+	r, err := NewRunner(m)
+	require.NoError(t, err)
+	require.Len(t, r.changes, 3)
+	want := map[string]struct {
+		time time.Duration
+		size uint64
+	}{
+		"chunkopt_small": {2 * time.Second, 16 << 20},
+		"chunkopt_blobs": {100 * time.Millisecond, 1 << 20},
+		"chunkopt_other": {500 * time.Millisecond, 16 << 20},
+	}
+	for _, change := range r.changes {
+		change.table = table.NewTableInfo(nil, "test", change.stmt.Table)
+		checksumCfg, copyCfg := r.chunkerConfigs(change, nil)
+		require.Equal(t, want[change.stmt.Table].time, checksumCfg.TargetChunkTime, change.stmt.Table)
+		require.Equal(t, want[change.stmt.Table].time, copyCfg.TargetChunkTime, change.stmt.Table)
+		require.Zero(t, checksumCfg.TargetChunkBytes, change.stmt.Table)
+		require.Equal(t, want[change.stmt.Table].size, copyCfg.TargetChunkBytes, change.stmt.Table)
+	}
+
+	m.TableTargetChunkSize = map[string]uint64{"chunkopt_typo": 1 << 20}
+	_, err = NewRunner(m)
+	require.ErrorContains(t, err, `--table-target-chunk-size: table "chunkopt_typo" is not being migrated`)
+}
+
+// TestChunkKeyOption checks that --chunk-key is passed to both chunkers, and
+// that it can only be used when migrating a single table.
+func TestChunkKeyOption(t *testing.T) {
+	m := &Migration{
+		Database: "test",
+		Table:    "chunkkeyopt_t1",
+		Alter:    "ENGINE=InnoDB",
+		ChunkKey: "uk_tenant_external",
+	}
+	r, err := NewRunner(m)
+	require.NoError(t, err)
+	change := r.changes[0]
+	change.table = table.NewTableInfo(nil, "test", change.stmt.Table)
+	checksumCfg, copyCfg := r.chunkerConfigs(change, nil)
+	require.Equal(t, "uk_tenant_external", checksumCfg.Key)
+	require.Equal(t, "uk_tenant_external", copyCfg.Key)
+
+	m.Table, m.Alter = "", ""
+	m.Statement = "ALTER TABLE chunkkeyopt_t1 ENGINE=InnoDB; ALTER TABLE chunkkeyopt_t2 ENGINE=InnoDB"
+	_, err = NewRunner(m)
+	require.ErrorContains(t, err, "--chunk-key can only be used when migrating a single table")
+}
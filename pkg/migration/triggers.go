@@ -0,0 +1,95 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/block/spirit/pkg/dbconn/sqlescape"
+	"github.com/block/spirit/pkg/utils"
+)
+
+// trigger is a trigger on the table being migrated, as read from
+// information_schema.triggers. With --copy-triggers the cutover moves each
+// one from the original table to the new table (see moveTriggersStmts).
+type trigger struct {
+	name      string
+	timing    string // BEFORE or AFTER
+	event     string // INSERT, UPDATE or DELETE
+	statement string // the trigger body
+	sqlMode   string
+	definer   string // user@host
+}
+
+// readTriggers returns the triggers on a table, in the order they fire for
+// each timing and event, so recreating them in this order preserves it.
+func readTriggers(ctx context.Context, db *sql.DB, schemaName, tableName string) ([]trigger, error) {
+	rows, err := db.QueryContext(ctx, `SELECT trigger_name, action_timing, event_manipulation,
+		action_statement, sql_mode, definer
+		FROM information_schema.triggers
+		WHERE event_object_schema = ? AND event_object_table = ?
+		ORDER BY event_manipulation, action_timing, action_order`, schemaName, tableName)
+	if err != nil {
+		return nil, err
+	}
+	defer utils.CloseAndLog(rows)
+	var triggers []trigger
+	for rows.Next() {
+		var t trigger
+		if err := rows.Scan(&t.name, &t.timing, &t.event, &t.statement, &t.sqlMode, &t.definer); err != nil {
+			return nil, err
+		}
+		triggers = append(triggers, t)
+	}
+	return triggers, rows.Err()
+}
+
+// createStatement returns the CREATE TRIGGER statement for t on tableName.
+// The definer is kept, since a trigger runs with its definer's privileges.
+func (t trigger) createStatement(tableName string) string {
+	return fmt.Sprintf("CREATE DEFINER=%s TRIGGER %s %s %s ON %s FOR EACH ROW %s",
+		quoteAccount(t.definer),
+		sqlescape.EscapeIdentifier(t.name),
+		t.timing,
+		t.event,
+		sqlescape.EscapeIdentifier(tableName),
+		t.statement,
+	)
+}
+
+// quoteAccount quotes a user@host account name. The host part cannot contain
+// an @, so the account is split at the last one.
+func quoteAccount(account string) string {
+	i := strings.LastIndex(account, "@")
+	if i < 0 {
+		return sqlescape.EscapeIdentifier(account)
+	}
+	return sqlescape.EscapeIdentifier(account[:i]) + "@" + sqlescape.EscapeIdentifier(account[i+1:])
+}
+
+// moveTriggersStmts returns the statements that move triggers to tableName.
+// Trigger names are unique per schema, so the trigger on the other table is
+// dropped before it is recreated under the same name. Each is created with
+// the sql_mode it was originally created with, which the server stores with
+// the trigger and uses to parse its body; the session's own sql_mode is
+// restored afterwards.
+//
+// The statements must run under the cutover's table lock, where neither table
+// can be written: the new table must not have the triggers while the copier
+// and the applier write to it, or they would fire a second time for changes
+// the original table's triggers already made.
+func moveTriggersStmts(triggers []trigger, tableName string) []string {
+	if len(triggers) == 0 {
+		return nil
+	}
+	stmts := []string{"SET @spirit_sql_mode = @@SESSION.sql_mode"}
+	for _, t := range triggers {
+		stmts = append(stmts,
+			"DROP TRIGGER IF EXISTS "+sqlescape.EscapeIdentifier(t.name),
+			sqlescape.MustEscapeSQL("SET SESSION sql_mode = %?", t.sqlMode),
+			t.createStatement(tableName),
+		)
+	}
+	return append(stmts, "SET SESSION sql_mode = @spirit_sql_mode")
+}
@@ -0,0 +1,74 @@
+package migration
+
+import (
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTriggerCreateStatement(t *testing.T) {
+	trg := trigger{
+		name:      "ins_total",
+		timing:    "BEFORE",
+		event:     "INSERT",
+		statement: "SET NEW.total = NEW.a + NEW.b",
+		sqlMode:   "STRICT_TRANS_TABLES",
+		definer:   "app@%",
+	}
+	require.Equal(t, "CREATE DEFINER=`app`@`%` TRIGGER `ins_total` BEFORE INSERT ON `_t1_new` FOR EACH ROW SET NEW.total = NEW.a + NEW.b",
+		trg.createStatement("_t1_new"))
+
+	require.Equal(t, []string{
+		"SET @spirit_sql_mode = @@SESSION.sql_mode",
+		"DROP TRIGGER IF EXISTS `ins_total`",
+		"SET SESSION sql_mode = 'STRICT_TRANS_TABLES'",
+		trg.createStatement("_t1_new"),
+		"SET SESSION sql_mode = @spirit_sql_mode",
+	}, moveTriggersStmts([]trigger{trg}, "_t1_new"))
+	require.Empty(t, moveTriggersStmts(nil, "_t1_new"))
+}
+
+func TestQuoteAccount(t *testing.T) {
+	require.Equal(t, "`root`@`localhost`", quoteAccount("root@localhost"))
+	// Only the last @ separates the host.
+	require.Equal(t, "`svc@app`@`10.0.0.%`", quoteAccount("svc@app@10.0.0.%"))
+	require.Equal(t, "`root`", quoteAccount("root"))
+}
+
+// TestCopyTriggers migrates a table with a BEFORE INSERT trigger with
+// CopyTriggers, and checks that the trigger is on the table after cutover and
+// still fires. The trigger counts the inserts of each row, so it also checks
+// that the trigger did not fire again for the rows copied to the new table.
+func TestCopyTriggers(t *testing.T) {
+	t.Parallel()
+	tt := testutils.NewTestTable(t, "copytrg", `CREATE TABLE copytrg (
+		id INT NOT NULL PRIMARY KEY AUTO_INCREMENT,
+		a INT NOT NULL,
+		inserts INT NULL
+	)`)
+	testutils.RunSQL(t, `DROP TRIGGER IF EXISTS copytrg_count`)
+	testutils.RunSQL(t, `CREATE TRIGGER copytrg_count BEFORE INSERT ON copytrg
+		FOR EACH ROW SET NEW.inserts = COALESCE(NEW.inserts, 0) + 1`)
+	testutils.RunSQL(t, `INSERT INTO copytrg (a) VALUES (1), (2), (3)`)
+
+	m := NewTestRunner(t, "copytrg", "ADD COLUMN c INT", WithCopyTriggers())
+	require.NoError(t, m.Run(t.Context()))
+	require.NoError(t, m.Close())
+
+	var triggerTable string
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), `SELECT event_object_table FROM information_schema.triggers
+		WHERE trigger_schema = DATABASE() AND trigger_name = 'copytrg_count'`).Scan(&triggerTable))
+	require.Equal(t, "copytrg", triggerTable)
+
+	var maxInserts int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SELECT MAX(inserts) FROM copytrg").Scan(&maxInserts))
+	require.Equal(t, 1, maxInserts)
+
+	// The trigger fires on the new table.
+	_, err := tt.DB.ExecContext(t.Context(), "INSERT INTO copytrg (a, c) VALUES (10, 1)")
+	require.NoError(t, err)
+	var inserts int
+	require.NoError(t, tt.DB.QueryRowContext(t.Context(), "SELECT inserts FROM copytrg WHERE a = 10").Scan(&inserts))
+	require.Equal(t, 1, inserts)
+}
@@ -52,3 +52,25 @@ func TestSchemaDiffDetectsRealMismatch(t *testing.T) {
 	require.NotEmpty(t, diff, "a genuine column type mismatch must still be reported")
 	require.Contains(t, diff, "sku", "the reported diff should name the mismatched column")
 }
+
+// TestSchemaDiffDetectsNarrowerColumn covers a pre-created target whose
+// column is narrower than the source's: copying VARCHAR(255) values into a
+// VARCHAR(10) column would fail (or truncate, without strict mode) part way
+// through the copy, so it must be reported before the copy starts.
+func TestSchemaDiffDetectsNarrowerColumn(t *testing.T) {
+	source := "CREATE TABLE `customer` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `email` varchar(255) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB"
+	target := "CREATE TABLE `customer` (\n" +
+		"  `id` bigint NOT NULL,\n" +
+		"  `email` varchar(10) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`)\n" +
+		") ENGINE=InnoDB"
+
+	diff, err := schemaDiff("customer", source, target)
+	require.NoError(t, err)
+	require.Contains(t, diff, "email")
+	require.Contains(t, diff, "varchar(255)", "the diff should widen the target column back to the source type")
+}
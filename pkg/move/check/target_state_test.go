@@ -203,4 +203,17 @@ func TestTargetStateCheckSchemaTypesAndCollation(t *testing.T) {
 		require.Contains(t, err.Error(), "schema does not match")
 		require.Contains(t, err.Error(), "name")
 	})
+
+	// Test 4: a narrower column of the same type FAILS before any rows are
+	// copied, rather than when the first long value reaches the target.
+	t.Run("narrower column fails", func(t *testing.T) {
+		_, err := tgtDB.ExecContext(t.Context(),
+			fmt.Sprintf("CREATE TABLE %s.t (id VARCHAR(64) COLLATE utf8mb4_bin NOT NULL, name VARCHAR(10), PRIMARY KEY (id))", tgtName))
+		require.NoError(t, err)
+		defer func() { _, _ = tgtDB.ExecContext(t.Context(), fmt.Sprintf("DROP TABLE %s.t", tgtName)) }()
+		err = targetStateCheck(t.Context(), newResources(), slog.Default())
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "schema does not match")
+		require.Contains(t, err.Error(), "name")
+	})
 }
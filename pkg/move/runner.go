@@ -256,7 +256,7 @@ func (r *Runner) getTables(ctx context.Context, src *sourceInfo) ([]*table.Table
 
 		tableInfo := table.NewTableInfo(src.db, src.config.DBName, tableName)
 		tableInfo.Host = src.config.Addr // Set the Host field for disambiguation in multi-chunker
-		if err := tableInfo.SetInfo(ctx); err != nil {
+		if err := dbconn.RetryableSetInfo(ctx, tableInfo, r.dbConfig); err != nil {
 			return nil, err
 		}
 
@@ -290,7 +290,7 @@ func (r *Runner) getTables(ctx context.Context, src *sourceInfo) ([]*table.Table
 // createTargetTables creates tables on all targets.
 // If DeferSecondaryIndexes is enabled, tables are created without secondary indexes.
 // Secondary indexes will be added later by restoreSecondaryIndexes() before cutover.
-// This function skips tables that already exist (they were validated by checkTargetEmpty).
+// This function skips tables that already exist (they were validated by the target_state check).
 func (r *Runner) createTargetTables(ctx context.Context) error {
 	// All sources have identical schemas (enforced by the source_schema_consistency
 	// check at ScopePostSetup), so use sources[0] for SHOW CREATE TABLE.
@@ -387,7 +387,7 @@ func (r *Runner) resumeFromCheckpoint(ctx context.Context) error {
 	for _, src := range r.sourceTables {
 		for i, target := range r.targets {
 			targetTable := table.NewTableInfo(target.DB, target.Config.DBName, src.TableName)
-			if err := targetTable.SetInfo(ctx); err != nil {
+			if err := dbconn.RetryableSetInfo(ctx, targetTable, r.dbConfig); err != nil {
 				return fmt.Errorf("failed to get table info for target %d table %s: %w", i, src.TableName, err)
 			}
 			if !slices.Equal(src.Columns, targetTable.Columns) {
@@ -1362,10 +1362,11 @@ func (r *Runner) Status() string {
 	switch state { //nolint:exhaustive
 	case status.CopyRows:
 		// Status for copy rows
-		return fmt.Sprintf("migration status: state=%s copy-progress=%s binlog-deltas=%v total-time=%s copier-time=%s copier-remaining-time=%v copier-is-throttled=%v%s",
+		return fmt.Sprintf("migration status: state=%s copy-progress=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s copier-time=%s copier-remaining-time=%v copier-is-throttled=%v%s",
 			r.status.Get().String(),
 			r.copier.GetProgress(),
 			r.getDeltaLenAll(),
+			r.getDeltaMemoryBytesAll(),
 			time.Since(r.startTime).Round(time.Second),
 			time.Since(r.copier.StartTime()).Round(time.Second),
 			r.copier.GetETA(),
@@ -1382,18 +1383,20 @@ func (r *Runner) Status() string {
 	case status.ApplyChangeset, status.PostChecksum:
 		// We've finished copying rows, and we are now trying to reduce the number of binlog deltas before
 		// proceeding to the checksum and then the final cutover.
-		return fmt.Sprintf("migration status: state=%s binlog-deltas=%v total-time=%s%s",
+		return fmt.Sprintf("migration status: state=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s%s",
 			r.status.Get().String(),
 			r.getDeltaLenAll(),
+			r.getDeltaMemoryBytesAll(),
 			time.Since(r.startTime).Round(time.Second),
 			applier.StatusSuffix(r.applier),
 		)
 	case status.Checksum:
 		// This could take a while if it's a large table.
-		return fmt.Sprintf("migration status: state=%s checksum-progress=%s binlog-deltas=%v total-time=%s checksum-time=%s",
+		return fmt.Sprintf("migration status: state=%s checksum-progress=%s binlog-deltas=%v binlog-delta-bytes=%d total-time=%s checksum-time=%s",
 			r.status.Get().String(),
 			r.checker.GetProgress().String(),
 			r.getDeltaLenAll(),
+			r.getDeltaMemoryBytesAll(),
 			time.Since(r.startTime).Round(time.Second),
 			time.Since(r.checker.StartTime()).Round(time.Second),
 		)
@@ -2125,6 +2128,16 @@ func (r *Runner) getDeltaLenAll() int {
 	return total
 }
 
+// getDeltaMemoryBytesAll returns the estimated memory held by pending changes
+// across all replication clients.
+func (r *Runner) getDeltaMemoryBytesAll() int64 {
+	var total int64
+	for i := range r.sources {
+		total += r.sources[i].replClient.DeltaMemoryBytes()
+	}
+	return total
+}
+
 // stopPeriodicFlushAll stops periodic flushing on all replication clients.
 func (r *Runner) stopPeriodicFlushAll() {
 	for i := range r.sources {
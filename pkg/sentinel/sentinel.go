@@ -19,8 +19,6 @@ import (
 	"fmt"
 	"log/slog"
 	"time"
-
-	"github.com/block/spirit/pkg/dbconn"
 )
 
 // TableName is the fixed name of the sentinel table. It is intentionally a
@@ -28,6 +26,10 @@ import (
 // which table to drop to release a deferred cutover.
 const TableName = "_spirit_sentinel"
 
+// CreateStatement is the statement Create runs, for callers that record the
+// DDL they execute.
+const CreateStatement = "CREATE TABLE IF NOT EXISTS `" + TableName + "` (id int NOT NULL PRIMARY KEY)"
+
 // WaitLimit bounds how long Wait blocks for the sentinel to be dropped before
 // giving up; CheckInterval is the existence-probe period. They are package
 // vars (not consts) only so tests can shorten them; production never overrides
@@ -46,7 +48,8 @@ var (
 // recreates it, and TestSentinelCreateNeverObservedAbsent relies on CREATE IF
 // NOT EXISTS so a concurrent existence probe never sees it missing.
 func Create(ctx context.Context, db *sql.DB) error {
-	return dbconn.Exec(ctx, db, "CREATE TABLE IF NOT EXISTS %n (id int NOT NULL PRIMARY KEY)", TableName)
+	_, err := db.ExecContext(ctx, CreateStatement)
+	return err
 }
 
 // Exists reports whether the sentinel table is present in db's currently-selected
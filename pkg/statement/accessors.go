@@ -14,6 +14,19 @@ func (indexes Indexes) HasInvisible() bool {
 	return false
 }
 
+// Primary returns the PRIMARY KEY index, or nil if the table has none. It
+// matches on the index type rather than the name, since a table-level
+// PRIMARY KEY is parsed without one; only CreateTable.GetIndexes names it.
+func (indexes Indexes) Primary() *Index {
+	for _, idx := range indexes {
+		if idx.Type == "PRIMARY KEY" {
+			return &idx
+		}
+	}
+
+	return nil
+}
+
 func (constraints Constraints) HasForeignKeys() bool {
 	for _, c := range constraints {
 		if c.Type == "FOREIGN KEY" {
@@ -0,0 +1,126 @@
+package statement
+
+import "github.com/pingcap/tidb/pkg/parser/ast"
+
+// This file holds AlterSpecs, which describes the clauses of an ALTER TABLE
+// statement with the same Column, Index and Constraint types ParseCreateTable
+// produces, so callers do not need to walk the parser's AST themselves.
+
+// AlterSpecKind is the kind of operation an AlterSpec performs.
+type AlterSpecKind string
+
+const (
+	AlterAddColumn    AlterSpecKind = "add_column"
+	AlterDropColumn   AlterSpecKind = "drop_column"
+	AlterModifyColumn AlterSpecKind = "modify_column"
+	AlterChangeColumn AlterSpecKind = "change_column"
+	AlterRenameColumn AlterSpecKind = "rename_column"
+	// AlterAddIndex covers every index-like ADD: PRIMARY KEY, UNIQUE, INDEX,
+	// FULLTEXT and SPATIAL. Index.Type tells them apart.
+	AlterAddIndex        AlterSpecKind = "add_index"
+	AlterDropIndex       AlterSpecKind = "drop_index"
+	AlterRenameIndex     AlterSpecKind = "rename_index"
+	AlterIndexVisibility AlterSpecKind = "index_visibility"
+	AlterDropPrimaryKey  AlterSpecKind = "drop_primary_key"
+	// AlterAddConstraint covers ADD FOREIGN KEY and ADD CHECK. Constraint.Type
+	// tells them apart.
+	AlterAddConstraint  AlterSpecKind = "add_constraint"
+	AlterDropForeignKey AlterSpecKind = "drop_foreign_key"
+	AlterDropCheck      AlterSpecKind = "drop_check"
+	AlterTableOptions   AlterSpecKind = "table_options"
+	// AlterOther is any clause without a structured form here, such as
+	// ALTER COLUMN ... SET DEFAULT or a partitioning clause. Raw holds it.
+	AlterOther AlterSpecKind = "other"
+)
+
+// AlterSpec is one clause of an ALTER TABLE statement.
+//
+// Name is the column, index or constraint the clause acts on, as it is named
+// before the clause is applied; for an ADD it is the name being added, which is
+// empty for an unnamed index or constraint (the server assigns one). A PRIMARY
+// KEY is named "PRIMARY". NewName is set for CHANGE COLUMN, RENAME COLUMN and
+// RENAME INDEX.
+//
+// Column, Index and Constraint are parsed the same way as in ParseCreateTable,
+// but without the table-level normalization rules: an inline PRIMARY KEY,
+// UNIQUE or CHECK on an added column stays on the Column.
+type AlterSpec struct {
+	Raw          *ast.AlterTableSpec `json:"-"`
+	Kind         AlterSpecKind       `json:"kind"`
+	Name         string              `json:"name,omitempty"`
+	NewName      string              `json:"new_name,omitempty"`
+	Column       *Column             `json:"column,omitempty"`        // ADD, MODIFY and CHANGE COLUMN
+	Index        *Index              `json:"index,omitempty"`         // AlterAddIndex
+	Constraint   *Constraint         `json:"constraint,omitempty"`    // AlterAddConstraint
+	Invisible    *bool               `json:"invisible,omitempty"`     // AlterIndexVisibility
+	TableOptions *TableOptions       `json:"table_options,omitempty"` // AlterTableOptions
+}
+
+// AlterSpecs returns the clauses of an ALTER TABLE statement in the order they
+// appear. An ADD COLUMN that adds several columns at once, as in
+// ADD COLUMN (a INT, b INT), is returned as one AlterSpec per column.
+// Returns ErrNotAlterTable for any other kind of statement.
+func (a *AbstractStatement) AlterSpecs() ([]AlterSpec, error) {
+	alterStmt, ok := a.AsAlterTable()
+	if !ok {
+		return nil, ErrNotAlterTable
+	}
+	// The parse helpers are methods on CreateTable but do not depend on any
+	// table state, so an empty receiver is enough.
+	ct := &CreateTable{}
+	var specs []AlterSpec
+	for _, spec := range alterStmt.Specs {
+		switch spec.Tp { //nolint:exhaustive
+		case ast.AlterTableAddColumns:
+			for _, colDef := range spec.NewColumns {
+				col := ct.parseColumn(colDef)
+				specs = append(specs, AlterSpec{Raw: spec, Kind: AlterAddColumn, Name: col.Name, Column: &col})
+			}
+		case ast.AlterTableDropColumn:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterDropColumn, Name: spec.OldColumnName.Name.O})
+		case ast.AlterTableModifyColumn:
+			col := ct.parseColumn(spec.NewColumns[0])
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterModifyColumn, Name: col.Name, Column: &col})
+		case ast.AlterTableChangeColumn:
+			col := ct.parseColumn(spec.NewColumns[0])
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterChangeColumn, Name: spec.OldColumnName.Name.O, NewName: col.Name, Column: &col})
+		case ast.AlterTableRenameColumn:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterRenameColumn, Name: spec.OldColumnName.Name.O, NewName: spec.NewColumnName.Name.O})
+		case ast.AlterTableAddConstraint:
+			specs = append(specs, ct.addConstraintSpec(spec))
+		case ast.AlterTableDropIndex:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterDropIndex, Name: spec.Name})
+		case ast.AlterTableRenameIndex:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterRenameIndex, Name: spec.FromKey.O, NewName: spec.ToKey.O})
+		case ast.AlterTableIndexInvisible:
+			invisible := spec.Visibility == ast.IndexVisibilityInvisible
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterIndexVisibility, Name: spec.IndexName.O, Invisible: &invisible})
+		case ast.AlterTableDropPrimaryKey:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterDropPrimaryKey, Name: "PRIMARY"})
+		case ast.AlterTableDropForeignKey:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterDropForeignKey, Name: spec.Name})
+		case ast.AlterTableDropCheck:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterDropCheck, Name: spec.Constraint.Name})
+		case ast.AlterTableOption:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterTableOptions, TableOptions: ct.parseTableOptions(spec.Options)})
+		default:
+			specs = append(specs, AlterSpec{Raw: spec, Kind: AlterOther})
+		}
+	}
+	return specs, nil
+}
+
+func (ct *CreateTable) addConstraintSpec(spec *ast.AlterTableSpec) AlterSpec {
+	switch spec.Constraint.Tp { //nolint:exhaustive
+	case ast.ConstraintForeignKey, ast.ConstraintCheck:
+		constraint := ct.parseConstraint(spec.Constraint)
+		return AlterSpec{Raw: spec, Kind: AlterAddConstraint, Name: constraint.Name, Constraint: &constraint}
+	case ast.ConstraintPrimaryKey, ast.ConstraintKey, ast.ConstraintIndex,
+		ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex,
+		ast.ConstraintFulltext, ast.ConstraintSpatial:
+		index := ct.parseIndex(spec.Constraint)
+		return AlterSpec{Raw: spec, Kind: AlterAddIndex, Name: indexName(&index), Index: &index}
+	default:
+		return AlterSpec{Raw: spec, Kind: AlterOther}
+	}
+}
@@ -0,0 +1,116 @@
+package statement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAlterSpecs(t *testing.T) {
+	stmts, err := New(`ALTER TABLE t1
+		ADD COLUMN email VARCHAR(255) NOT NULL DEFAULT '' COMMENT 'pii',
+		ADD COLUMN (a INT, b INT UNSIGNED),
+		DROP COLUMN legacy,
+		MODIFY COLUMN name VARCHAR(100) NULL,
+		CHANGE COLUMN old_col new_col BIGINT,
+		RENAME COLUMN x TO y,
+		ADD UNIQUE INDEX email (email),
+		ADD INDEX (a, b),
+		ADD PRIMARY KEY (id),
+		DROP INDEX old_idx,
+		RENAME INDEX i1 TO i2,
+		ALTER INDEX i3 INVISIBLE,
+		DROP PRIMARY KEY,
+		ADD CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parents (id) ON DELETE CASCADE,
+		ADD CONSTRAINT chk_a CHECK (a > 0) NOT ENFORCED,
+		DROP FOREIGN KEY fk_old,
+		DROP CHECK chk_old,
+		ENGINE=InnoDB,
+		ALTER COLUMN a SET DEFAULT 1`)
+	require.NoError(t, err)
+
+	specs, err := stmts[0].AlterSpecs()
+	require.NoError(t, err)
+
+	kinds := make([]AlterSpecKind, 0, len(specs))
+	for _, spec := range specs {
+		require.NotNil(t, spec.Raw)
+		kinds = append(kinds, spec.Kind)
+	}
+	require.Equal(t, []AlterSpecKind{
+		AlterAddColumn, AlterAddColumn, AlterAddColumn, AlterDropColumn,
+		AlterModifyColumn, AlterChangeColumn, AlterRenameColumn,
+		AlterAddIndex, AlterAddIndex, AlterAddIndex, AlterDropIndex,
+		AlterRenameIndex, AlterIndexVisibility, AlterDropPrimaryKey,
+		AlterAddConstraint, AlterAddConstraint, AlterDropForeignKey,
+		AlterDropCheck, AlterTableOptions, AlterOther,
+	}, kinds)
+
+	email := specs[0]
+	require.Equal(t, "email", email.Name)
+	require.Equal(t, "varchar", email.Column.Type)
+	require.Equal(t, 255, *email.Column.Length)
+	require.False(t, email.Column.Nullable)
+	require.Equal(t, "", *email.Column.Default)
+	require.Equal(t, "pii", *email.Column.Comment)
+
+	// ADD COLUMN (a, b) is split into one spec per column.
+	require.Equal(t, "a", specs[1].Name)
+	require.Equal(t, "b", specs[2].Name)
+	require.True(t, *specs[2].Column.Unsigned)
+
+	require.Equal(t, "legacy", specs[3].Name)
+	require.Nil(t, specs[3].Column)
+	require.Equal(t, "name", specs[4].Name)
+	require.True(t, specs[4].Column.Nullable)
+
+	change := specs[5]
+	require.Equal(t, "old_col", change.Name)
+	require.Equal(t, "new_col", change.NewName)
+	require.Equal(t, "bigint", change.Column.Type)
+
+	require.Equal(t, "x", specs[6].Name)
+	require.Equal(t, "y", specs[6].NewName)
+
+	require.Equal(t, "email", specs[7].Name)
+	require.Equal(t, "UNIQUE", specs[7].Index.Type)
+	require.Equal(t, []string{"email"}, specs[7].Index.Columns)
+	// An unnamed index is left for the server to name.
+	require.Empty(t, specs[8].Name)
+	require.Equal(t, "INDEX", specs[8].Index.Type)
+	require.Equal(t, []string{"a", "b"}, specs[8].Index.Columns)
+	require.Equal(t, "PRIMARY", specs[9].Name)
+	require.Equal(t, "PRIMARY KEY", specs[9].Index.Type)
+
+	require.Equal(t, "old_idx", specs[10].Name)
+	require.Equal(t, "i1", specs[11].Name)
+	require.Equal(t, "i2", specs[11].NewName)
+	require.Equal(t, "i3", specs[12].Name)
+	require.True(t, *specs[12].Invisible)
+	require.Equal(t, "PRIMARY", specs[13].Name)
+
+	fk := specs[14]
+	require.Equal(t, "fk_parent", fk.Name)
+	require.Equal(t, "FOREIGN KEY", fk.Constraint.Type)
+	require.Equal(t, []string{"parent_id"}, fk.Constraint.Columns)
+	require.Equal(t, "parents", fk.Constraint.References.Table)
+	require.Equal(t, "CASCADE", *fk.Constraint.References.OnDelete)
+
+	check := specs[15]
+	require.Equal(t, "chk_a", check.Name)
+	require.Equal(t, "CHECK", check.Constraint.Type)
+	require.Equal(t, "`a`>0", *check.Constraint.Expression)
+	require.True(t, check.Constraint.NotEnforced)
+
+	require.Equal(t, "fk_old", specs[16].Name)
+	require.Equal(t, "chk_old", specs[17].Name)
+	require.Equal(t, "InnoDB", *specs[18].TableOptions.Engine)
+	require.Empty(t, specs[19].Name)
+}
+
+func TestAlterSpecsNotAlter(t *testing.T) {
+	stmts, err := New("CREATE TABLE t1 (id INT PRIMARY KEY)")
+	require.NoError(t, err)
+	_, err = stmts[0].AlterSpecs()
+	require.ErrorIs(t, err, ErrNotAlterTable)
+}
@@ -26,6 +26,14 @@ type CreateTable struct {
 	Constraints  Constraints          `json:"constraints"`
 	TableOptions *TableOptions        `json:"table_options,omitempty"`
 	Partition    *PartitionOptions    `json:"partition,omitempty"`
+
+	// Origin records where the statement was loaded from, such as
+	// "file:schema/users.sql". ParseCreateTable leaves it empty; loaders that
+	// know the source set it.
+	Origin string `json:"origin,omitempty"`
+	// Offset is the byte offset of the CREATE TABLE statement in the text
+	// given to ParseCreateTable, after any leading whitespace and comments.
+	Offset int `json:"-"`
 }
 
 // Column represents a table column definition
@@ -85,8 +93,9 @@ type Index struct {
 	// guess at the server-assigned one (the column name, suffixed on collision),
 	// so diffIndexes pairs it with an equivalent live unique index by column set
 	// even when the names differ, rather than emitting a spurious DROP+ADD.
-	// Not serialized: it is a diff-time hint, not part of the logical schema.
-	InlineDerived bool `json:"-"`
+	// It is a diff-time hint rather than part of the logical schema, but is
+	// serialized so a table decoded from JSON diffs the same as the original.
+	InlineDerived bool `json:"inline_derived,omitempty"`
 }
 
 // Constraint represents a table constraint
@@ -127,6 +136,8 @@ type TableOptions struct {
 	Comment       *string `json:"comment,omitempty"`
 	AutoIncrement *uint64 `json:"auto_increment,omitempty"`
 	RowFormat     *string `json:"row_format,omitempty"`
+	Compression   *string `json:"compression,omitempty"` // InnoDB page compression, e.g. zlib, lz4, none
+	Encryption    *string `json:"encryption,omitempty"`  // InnoDB encryption at rest, Y or N
 }
 
 // PartitionOptions represents table partitioning configuration
@@ -233,7 +244,8 @@ func ParseCreateTable(sql string) (*CreateTable, error) {
 
 	// Parse into structured format
 	ct := &CreateTable{
-		Raw: createStmt,
+		Raw:    createStmt,
+		Offset: statementOffset(sql),
 	}
 	// Parse into structured format
 	ct.parseToStruct()
@@ -325,6 +337,14 @@ func (ct *CreateTable) GetTableOptions() map[string]any {
 		if opts.RowFormat != nil {
 			options["row_format"] = *opts.RowFormat
 		}
+
+		if opts.Compression != nil {
+			options["compression"] = *opts.Compression
+		}
+
+		if opts.Encryption != nil {
+			options["encryption"] = *opts.Encryption
+		}
 	}
 
 	return options
@@ -736,10 +756,13 @@ func (ct *CreateTable) parseConstraint(constraint *ast.Constraint) Constraint {
 		}
 	}
 
-	// Clean up options map if empty
+	// Clean up options map and columns if empty
 	if len(constr.Options) == 0 {
 		constr.Options = nil
 	}
+	if len(constr.Columns) == 0 {
+		constr.Columns = nil
+	}
 
 	return constr
 }
@@ -846,6 +869,16 @@ func (ct *CreateTable) parseTableOptions(options []*ast.TableOption) *TableOptio
 				tableOpts.RowFormat = &rowFormat
 				hasOptions = true
 			}
+		case ast.TableOptionCompression:
+			if option.StrValue != "" {
+				tableOpts.Compression = &option.StrValue
+				hasOptions = true
+			}
+		case ast.TableOptionEncryption:
+			if option.StrValue != "" {
+				tableOpts.Encryption = &option.StrValue
+				hasOptions = true
+			}
 		}
 	}
 
@@ -953,10 +986,13 @@ func (ct *CreateTable) parsePartitionDefinition(def *ast.PartitionDefinition) Pa
 		partDef.SubPartitions = append(partDef.SubPartitions, subDef)
 	}
 
-	// Clean up options map if empty
+	// Clean up options map and subpartitions if empty
 	if len(partDef.Options) == 0 {
 		partDef.Options = nil
 	}
+	if len(partDef.SubPartitions) == 0 {
+		partDef.SubPartitions = nil
+	}
 
 	return partDef
 }
@@ -143,6 +143,41 @@ func TestSchemaAnalyzer_ComplexConstraints(t *testing.T) {
 	require.Contains(t, *fkConstraint.Definition, "REFERENCES users")
 }
 
+// TestParseForeignKeyReference verifies that the referenced table, columns and
+// referential actions are available as structured fields, so callers need not
+// pick them out of Definition.
+func TestParseForeignKeyReference(t *testing.T) {
+	ct, err := ParseCreateTable(`CREATE TABLE order_items (
+		id INT PRIMARY KEY,
+		order_id INT NOT NULL,
+		shop_id INT NOT NULL,
+		sku_id INT NOT NULL,
+		CONSTRAINT fk_order FOREIGN KEY (order_id, shop_id) REFERENCES orders (id, shop_id) ON DELETE CASCADE ON UPDATE RESTRICT,
+		CONSTRAINT fk_sku FOREIGN KEY (sku_id) REFERENCES skus (id) ON DELETE NO ACTION
+	)`)
+	require.NoError(t, err)
+	require.Len(t, ct.Constraints, 2)
+
+	fk := ct.Constraints[0]
+	require.Equal(t, "fk_order", fk.Name)
+	require.Equal(t, []string{"order_id", "shop_id"}, fk.Columns)
+	require.NotNil(t, fk.References)
+	require.Equal(t, "orders", fk.References.Table)
+	require.Equal(t, []string{"id", "shop_id"}, fk.References.Columns)
+	require.NotNil(t, fk.References.OnDelete)
+	require.Equal(t, "CASCADE", *fk.References.OnDelete)
+	require.NotNil(t, fk.References.OnUpdate)
+	require.Equal(t, "RESTRICT", *fk.References.OnUpdate)
+
+	// NO ACTION is MySQL's default and is normalized to absent, as is an
+	// action that is not specified at all.
+	fk = ct.Constraints[1]
+	require.Equal(t, "skus", fk.References.Table)
+	require.Equal(t, []string{"id"}, fk.References.Columns)
+	require.Nil(t, fk.References.OnDelete)
+	require.Nil(t, fk.References.OnUpdate)
+}
+
 // TestParseCheckConstraintEnforcement verifies that the [NOT] ENFORCED state
 // of CHECK constraints is captured at parse time, including MySQL's canonical
 // SHOW CREATE TABLE form which wraps NOT ENFORCED in a versioned comment:
@@ -182,6 +217,24 @@ func TestParseCheckConstraintEnforcement(t *testing.T) {
 	require.True(t, ct.Constraints[0].NotEnforced)
 	require.False(t, ct.Constraints[1].NotEnforced)
 	require.False(t, ct.Constraints[2].NotEnforced)
+
+	// Column-level CHECKs are hoisted to table-level constraints, and unnamed
+	// ones get MySQL's <table>_chk_<n> name; the enforcement state carries
+	// over.
+	ct, err = ParseCreateTable("CREATE TABLE products (" +
+		"price int CHECK (price >= 0), " +
+		"qty int CONSTRAINT chk_qty CHECK (qty < 1000) NOT ENFORCED)")
+	require.NoError(t, err)
+	constraints := ct.GetConstraints()
+	require.Len(t, constraints, 2)
+	require.Equal(t, "products_chk_1", constraints[0].Name)
+	require.Equal(t, "CHECK", constraints[0].Type)
+	require.Equal(t, "`price`>=0", *constraints[0].Expression)
+	require.False(t, constraints[0].NotEnforced)
+	require.Equal(t, "chk_qty", constraints[1].Name)
+	require.Equal(t, "`qty`<1000", *constraints[1].Expression)
+	require.True(t, constraints[1].NotEnforced)
+	require.Equal(t, "CHECK (`qty`<1000) NOT ENFORCED", *constraints[1].Definition)
 }
 
 func TestSchemaAnalyzer_UnsignedSupport(t *testing.T) {
@@ -397,6 +450,37 @@ func TestSchemaAnalyzer_DescendingIndexKeyParts(t *testing.T) {
 	require.True(t, pfx.ColumnList[0].Desc, "prefix key part should be descending")
 }
 
+// TestIndexPrefixLength checks that a key part's prefix length is kept on
+// IndexColumn.Length, and rendered back by ToSQL and AddClause: dropping it
+// would re-create the index over the whole column, or fail for TEXT.
+func TestIndexPrefixLength(t *testing.T) {
+	ct, err := ParseCreateTable(`CREATE TABLE t1 (
+		id INT PRIMARY KEY,
+		name VARCHAR(255),
+		body TEXT,
+		KEY idx_name (name(20), id),
+		KEY idx_body (body(100))
+	)`)
+	require.NoError(t, err)
+
+	idx := ct.GetIndexes().ByName("idx_name")
+	require.NotNil(t, idx)
+	require.Equal(t, []string{"name", "id"}, idx.Columns)
+	require.Len(t, idx.ColumnList, 2)
+	require.Equal(t, "name", idx.ColumnList[0].Name)
+	require.NotNil(t, idx.ColumnList[0].Length)
+	require.Equal(t, 20, *idx.ColumnList[0].Length)
+	require.Nil(t, idx.ColumnList[1].Length)
+	require.Equal(t, "ADD INDEX `idx_name` (`name`(20), `id`)", idx.AddClause())
+
+	rendered := ct.ToSQL()
+	require.Contains(t, rendered, "(`name`(20), `id`)")
+	require.Contains(t, rendered, "(`body`(100))")
+	reparsed, err := ParseCreateTable(rendered)
+	require.NoError(t, err)
+	require.Equal(t, 100, *reparsed.GetIndexes().ByName("idx_body").ColumnList[0].Length)
+}
+
 // Benchmark to show performance characteristics
 func BenchmarkParseCreateTable(b *testing.B) {
 	sql := `
@@ -1132,8 +1216,8 @@ func TestComprehensiveParsingFromTiDBTestSuite(t *testing.T) {
 			Validate: func(t *testing.T, createTable *CreateTable) {
 				columns := createTable.GetColumns()
 				require.Len(t, columns, 1)
-				// Note: Column comments might be parsed as empty string due to TiDB parser behavior
-				// This is expected based on our earlier investigation
+				require.NotNil(t, columns[0].Comment)
+				require.Equal(t, "User name", *columns[0].Comment)
 			},
 		},
 
@@ -1391,6 +1475,54 @@ func TestCurrentTimestampPrecision(t *testing.T) {
 	}
 }
 
+// TestOnUpdateCurrentTimestamp tests that ON UPDATE CURRENT_TIMESTAMP is
+// parsed into Column.OnUpdate, including fractional seconds precision, and
+// that OnUpdate is nil when the clause is absent.
+func TestOnUpdateCurrentTimestamp(t *testing.T) {
+	testCases := []struct {
+		name             string
+		sql              string
+		expectedOnUpdate *string
+	}{
+		{
+			name:             "no ON UPDATE clause",
+			sql:              "CREATE TABLE t1 (id INT PRIMARY KEY, updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)",
+			expectedOnUpdate: nil,
+		},
+		{
+			name:             "ON UPDATE CURRENT_TIMESTAMP",
+			sql:              "CREATE TABLE t1 (id INT PRIMARY KEY, updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP)",
+			expectedOnUpdate: new("current_timestamp"),
+		},
+		{
+			name:             "ON UPDATE CURRENT_TIMESTAMP(6) with microsecond precision",
+			sql:              "CREATE TABLE t1 (id INT PRIMARY KEY, updated_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6))",
+			expectedOnUpdate: new("current_timestamp(6)"),
+		},
+		{
+			name:             "ON UPDATE NOW(6) normalized to CURRENT_TIMESTAMP(6)",
+			sql:              "CREATE TABLE t1 (id INT PRIMARY KEY, updated_at DATETIME(6) ON UPDATE NOW(6))",
+			expectedOnUpdate: new("current_timestamp(6)"),
+		},
+		{
+			name:             "ON UPDATE without a DEFAULT",
+			sql:              "CREATE TABLE t1 (id INT PRIMARY KEY, updated_at TIMESTAMP NULL ON UPDATE CURRENT_TIMESTAMP)",
+			expectedOnUpdate: new("current_timestamp"),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ct, err := ParseCreateTable(tc.sql)
+			require.NoError(t, err)
+
+			col := ct.Columns.ByName("updated_at")
+			require.NotNil(t, col)
+			require.Equal(t, tc.expectedOnUpdate, col.OnUpdate)
+		})
+	}
+}
+
 // TestExpressionDefaultParsing tests that expression defaults (e.g., DEFAULT (json_object()))
 // are correctly parsed with DefaultIsExpr=true, while literal defaults remain DefaultIsExpr=false.
 func TestExpressionDefaultParsing(t *testing.T) {
@@ -1682,6 +1814,27 @@ func TestCharsetCollationOverride(t *testing.T) {
 	}
 }
 
+// TestCompressionEncryptionTableOptions tests that COMPRESSION and ENCRYPTION
+// table options are captured in the parsed table options.
+func TestCompressionEncryptionTableOptions(t *testing.T) {
+	sql := "CREATE TABLE test (id INT PRIMARY KEY) ENGINE=InnoDB COMPRESSION='zlib' ENCRYPTION='Y'"
+	ct, err := ParseCreateTable(sql)
+	require.NoError(t, err)
+
+	opts := ct.GetTableOptions()
+	require.Equal(t, "zlib", opts["compression"])
+	require.Equal(t, "Y", opts["encryption"])
+	require.Equal(t, "zlib", *ct.TableOptions.Compression)
+	require.Equal(t, "Y", *ct.TableOptions.Encryption)
+
+	// Neither option is reported when absent.
+	ct, err = ParseCreateTable("CREATE TABLE test (id INT PRIMARY KEY) ENGINE=InnoDB")
+	require.NoError(t, err)
+	opts = ct.GetTableOptions()
+	require.NotContains(t, opts, "compression")
+	require.NotContains(t, opts, "encryption")
+}
+
 // TestBinaryTypeNotAppliedToNonTextTypes tests that the binary flag conversion
 // only applies to text types and not to other types
 func TestBinaryTypeNotAppliedToNonTextTypes(t *testing.T) {
@@ -1873,3 +2026,182 @@ func TestSpatialIndexParsing(t *testing.T) {
 	require.Equal(t, "SPATIAL", spatial.Type)
 	require.Equal(t, []string{"location"}, spatial.Columns)
 }
+
+// TestCreateTableToSQL verifies that ToSQL round-trips: parsing its output
+// yields a table that diffs as identical to the original, and rendering that
+// again produces the same text. The cases cover the tricky parts: identifier
+// quoting, ENUM/SET values with quotes, defaults, indexes, constraints,
+// partitioning and table options.
+func TestCreateTableToSQL(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+	}{
+		{"basic", `CREATE TABLE users (
+			id INT PRIMARY KEY AUTO_INCREMENT,
+			name VARCHAR(255) NOT NULL,
+			email VARCHAR(255) UNIQUE,
+			created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+		)`},
+		{"quoted identifiers", "CREATE TABLE `order` (`select` INT NOT NULL, `we``ird col` VARCHAR(10), `key` INT, PRIMARY KEY (`select`), KEY `from` (`we``ird col`, `key`))"},
+		{"enum and set", `CREATE TABLE t1 (
+			id INT PRIMARY KEY,
+			status ENUM('active', 'it''s off', 'back\\slash', '') NOT NULL DEFAULT 'active',
+			flags SET('a', 'b,c', 'd''e') DEFAULT NULL
+		)`},
+		{"types and defaults", `CREATE TABLE t1 (
+			id BIGINT UNSIGNED NOT NULL,
+			price DECIMAL(10,2) NOT NULL DEFAULT '0.00',
+			ratio DOUBLE DEFAULT NULL,
+			label VARCHAR(20) CHARACTER SET latin1 COLLATE latin1_bin DEFAULT 'TRUE' COMMENT 'it''s a label',
+			doc JSON DEFAULT (json_object()),
+			data VARBINARY(16),
+			total INT GENERATED ALWAYS AS (id * 2) VIRTUAL,
+			g GEOMETRY NOT NULL SRID 4326,
+			PRIMARY KEY (id)
+		)`},
+		{"indexes", `CREATE TABLE t1 (
+			id INT NOT NULL,
+			a VARCHAR(100),
+			b INT,
+			body TEXT,
+			PRIMARY KEY (id),
+			UNIQUE KEY uk_a (a(10), b DESC),
+			KEY idx_expr ((b + 1)) COMMENT 'expr' INVISIBLE,
+			KEY idx_hash (b) USING HASH,
+			FULLTEXT KEY ft_body (body)
+		)`},
+		{"constraints", `CREATE TABLE t1 (
+			id INT PRIMARY KEY,
+			parent_id INT,
+			age INT CHECK (age >= 0),
+			CONSTRAINT chk_age CHECK (age < 200) NOT ENFORCED,
+			CONSTRAINT fk_parent FOREIGN KEY (parent_id) REFERENCES parents (id) ON DELETE CASCADE ON UPDATE SET NULL
+		)`},
+		{"table options", `CREATE TABLE t1 (id INT PRIMARY KEY)
+			ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci
+			ROW_FORMAT=COMPRESSED COMMENT='it''s a table'`},
+		{"range columns partition", `CREATE TABLE t1 (id INT NOT NULL, region VARCHAR(10) NOT NULL, PRIMARY KEY (id, region))
+			PARTITION BY LIST COLUMNS (region) (PARTITION p0 VALUES IN ('2020', 'o''hare'), PARTITION p1 VALUES IN ('asia'))`},
+		{"range partition", `CREATE TABLE t1 (id INT NOT NULL, created DATE NOT NULL, PRIMARY KEY (id, created))
+			PARTITION BY RANGE (YEAR(created)) (PARTITION p0 VALUES LESS THAN (2020), PARTITION pmax VALUES LESS THAN MAXVALUE)`},
+		{"hash partition", `CREATE TABLE t1 (id INT NOT NULL PRIMARY KEY) PARTITION BY HASH (id) PARTITIONS 4`},
+		{"temporary if not exists", `CREATE TEMPORARY TABLE IF NOT EXISTS t1 (id INT PRIMARY KEY)`},
+	}
+	opts := &DiffOptions{} // compare everything
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := ParseCreateTable(tt.sql)
+			require.NoError(t, err)
+			rendered := ct.ToSQL()
+
+			reparsed, err := ParseCreateTable(rendered)
+			require.NoError(t, err, "ToSQL produced unparseable SQL:\n%s", rendered)
+			diff, err := ct.Diff(reparsed, opts)
+			require.NoError(t, err)
+			require.Empty(t, diff, "round-trip changed the table:\n%s", rendered)
+			require.Equal(t, ct.Temporary, reparsed.Temporary)
+			require.Equal(t, ct.IfNotExists, reparsed.IfNotExists)
+			require.Equal(t, rendered, reparsed.ToSQL(), "ToSQL is not deterministic")
+		})
+	}
+}
+
+// TestCreateTableToSQLAfterMutation verifies the main use of ToSQL: mutate a
+// parsed table and render the result.
+func TestCreateTableToSQLAfterMutation(t *testing.T) {
+	ct, err := ParseCreateTable("CREATE TABLE t1 (id INT NOT NULL, name VARCHAR(50), PRIMARY KEY (id))")
+	require.NoError(t, err)
+	ct.Columns[1].Length = new(100)
+	ct.Columns[1].Nullable = false
+
+	require.Equal(t, "CREATE TABLE `t1` (\n"+
+		"  `id` int NOT NULL,\n"+
+		"  `name` varchar(100) NOT NULL,\n"+
+		"  PRIMARY KEY (`id`)\n"+
+		")", ct.ToSQL())
+}
+
+func TestIndexesPrimary(t *testing.T) {
+	ct, err := ParseCreateTable("CREATE TABLE t1 (a INT, b INT, c INT, PRIMARY KEY (a, b), INDEX idx_c (c))")
+	require.NoError(t, err)
+	// The parsed table-level PRIMARY KEY has no name, so Primary matches on type.
+	pk := ct.Indexes.Primary()
+	require.NotNil(t, pk)
+	require.Equal(t, []string{"a", "b"}, pk.Columns)
+	require.Equal(t, pk.Columns, ct.GetIndexes().Primary().Columns)
+
+	ct, err = ParseCreateTable("CREATE TABLE t1 (a INT, INDEX idx_a (a))")
+	require.NoError(t, err)
+	require.Nil(t, ct.GetIndexes().Primary())
+}
+
+// TestParseCreateTableComments checks that column and index comments hold
+// the exact comment text: quotes and backslashes unescaped, multi-byte
+// characters intact, and no comment for an empty COMMENT.
+func TestParseCreateTableComments(t *testing.T) {
+	ct, err := ParseCreateTable(`CREATE TABLE t (
+		email varchar(255) COMMENT 'pii:email',
+		quoted int COMMENT 'it''s a "q" \\ done',
+		double_quoted int COMMENT "say 'hi'",
+		unicode int COMMENT 'naïve 日本',
+		empty int COMMENT '',
+		none int,
+		KEY email (email) COMMENT 'pii:index',
+		KEY quoted (quoted) COMMENT 'it\'s',
+		KEY empty (empty) COMMENT '',
+		KEY none (none)
+	)`)
+	require.NoError(t, err)
+
+	columnComments := map[string]*string{}
+	for _, col := range ct.Columns {
+		columnComments[col.Name] = col.Comment
+	}
+	require.Equal(t, map[string]*string{
+		"email":         new("pii:email"),
+		"quoted":        new(`it's a "q" \ done`),
+		"double_quoted": new("say 'hi'"),
+		"unicode":       new("naïve 日本"),
+		"empty":         nil,
+		"none":          nil,
+	}, columnComments)
+
+	indexComments := map[string]*string{}
+	for _, idx := range ct.Indexes {
+		indexComments[idx.Name] = idx.Comment
+	}
+	require.Equal(t, map[string]*string{
+		"email":  new("pii:index"),
+		"quoted": new("it's"),
+		"empty":  nil,
+		"none":   nil,
+	}, indexComments)
+}
+
+// TestParseCreateTableOffset verifies that Offset points at the CREATE
+// keyword, past any leading whitespace and comments, so callers can map a
+// table back to a line in the file it came from.
+func TestParseCreateTableOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix string
+	}{
+		{"none", ""},
+		{"whitespace", "\n\n  \t"},
+		{"line comments", "-- users table\n# owned by payments\n\n"},
+		{"block comment", "/* users\n   table */\n"},
+		{"mixed", "-- a\n/* b */ \n#c\n--\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := ParseCreateTable(tt.prefix + "CREATE TABLE users (id INT PRIMARY KEY)")
+			require.NoError(t, err)
+			require.Equal(t, len(tt.prefix), ct.Offset)
+			require.Empty(t, ct.Origin, "Origin is set by loaders, not the parser")
+		})
+	}
+
+	// An executable comment is part of the statement, not a comment.
+	require.Equal(t, 3, statementOffset("\n\n\n/*!40101 SET x=1 */"))
+}
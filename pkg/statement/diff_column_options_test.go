@@ -165,6 +165,14 @@ func TestDiffColumnAttributeOptions(t *testing.T) {
 			target:   "CREATE TABLE t1 (id INT PRIMARY KEY, b INT, CONSTRAINT t1_chk_1 CHECK (b > 0))",
 			expected: "",
 		},
+		{
+			// NOT ENFORCED on a column-level CHECK survives hoisting, so it
+			// matches MySQL's canonical form for the same constraint.
+			name:     "ColumnCheckNotEnforcedVsCanonicalNoChange",
+			source:   "CREATE TABLE t1 (id INT PRIMARY KEY, b INT, CONSTRAINT `t1_chk_1` CHECK ((`b` > 0)) /*!80016 NOT ENFORCED */)",
+			target:   "CREATE TABLE t1 (id INT PRIMARY KEY, b INT CHECK (b > 0) NOT ENFORCED)",
+			expected: "",
+		},
 		// ADD COLUMN must carry the attributes too
 		{
 			name:     "AddColumnWithOnUpdate",
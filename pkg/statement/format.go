@@ -8,51 +8,91 @@ import (
 )
 
 // This file holds the helpers that render parsed schema elements back into the
-// SQL fragments used to build ALTER TABLE clauses. They are free functions; the
-// CreateTable receivers that assemble the statements live in diff.go.
-
-// formatColumnDefinition formats a column definition for ALTER TABLE
-func formatColumnDefinition(col *Column) string {
-	var parts []string
-
-	// Column name and type
-	typeDef := col.Type
-
-	// Determine the full type definition including length/precision/values
-	switch {
-	case col.Type == "enum" && len(col.EnumValues) > 0:
-		var values []string
-		for _, v := range col.EnumValues {
-			values = append(values, fmt.Sprintf("'%s'", sqlescape.EscapeString(v)))
-		}
-		typeDef = fmt.Sprintf("enum(%s)", strings.Join(values, ","))
-	case col.Type == "set" && len(col.SetValues) > 0:
-		var values []string
-		for _, v := range col.SetValues {
-			values = append(values, fmt.Sprintf("'%s'", sqlescape.EscapeString(v)))
-		}
-		typeDef = fmt.Sprintf("set(%s)", strings.Join(values, ","))
-	case col.Precision != nil && col.Scale != nil:
-		// DECIMAL(precision, scale) - check Precision/Scale BEFORE Length!
-		// DECIMAL columns have both Length and Precision/Scale set, but we want Precision/Scale
-		typeDef = fmt.Sprintf("%s(%d,%d)", col.Type, *col.Precision, *col.Scale)
-	case col.Precision != nil:
-		// DECIMAL(precision) or other numeric types with precision only
-		typeDef = fmt.Sprintf("%s(%d)", col.Type, *col.Precision)
-	case col.Length != nil:
-		// VARCHAR, CHAR, etc.
-		typeDef = fmt.Sprintf("%s(%d)", col.Type, *col.Length)
+// SQL fragments used to build ALTER TABLE clauses, and ToSQL, which assembles
+// the same fragments into a full CREATE TABLE. The CreateTable receivers that
+// assemble ALTER statements live in diff.go.
+
+// ToSQL renders the table back into a CREATE TABLE statement. Columns,
+// indexes and constraints are emitted in their parsed order, followed by the
+// table options and partitioning, so the output is deterministic and
+// ParseCreateTable(ct.ToSQL()) yields an equivalent table. Identifiers are
+// always quoted. Subpartitioning is not rendered.
+func (ct *CreateTable) ToSQL() string {
+	var defs []string
+	for i := range ct.Columns {
+		defs = append(defs, formatColumnDefinition(&ct.Columns[i]))
+	}
+	for i := range ct.Indexes {
+		defs = append(defs, indexDefinition(&ct.Indexes[i]))
+	}
+	// formatAddConstraint renders an ALTER TABLE clause; without the
+	// leading ADD it is a valid CREATE TABLE definition.
+	for i := range ct.Constraints {
+		defs = append(defs, strings.TrimPrefix(formatAddConstraint(&ct.Constraints[i]), "ADD "))
 	}
 
-	if col.Unsigned != nil && *col.Unsigned {
-		typeDef += " unsigned"
+	var sb strings.Builder
+	sb.WriteString("CREATE ")
+	if ct.Temporary {
+		sb.WriteString("TEMPORARY ")
+	}
+	sb.WriteString("TABLE ")
+	if ct.IfNotExists {
+		sb.WriteString("IF NOT EXISTS ")
 	}
+	sb.WriteString(sqlescape.EscapeIdentifier(ct.TableName))
+	sb.WriteString(" (\n  ")
+	sb.WriteString(strings.Join(defs, ",\n  "))
+	sb.WriteString("\n)")
+	if opts := formatTableOptions(ct.TableOptions); opts != "" {
+		sb.WriteString(" " + opts)
+	}
+	if ct.Partition != nil {
+		sb.WriteString("\n" + formatPartitionOptions(ct.Partition))
+	}
+	return sb.String()
+}
 
-	if col.Zerofill != nil && *col.Zerofill {
-		typeDef += " zerofill"
+// formatTableOptions formats the table options of a CREATE TABLE, in the
+// order SHOW CREATE TABLE uses.
+func formatTableOptions(to *TableOptions) string {
+	if to == nil {
+		return ""
+	}
+	var parts []string
+	if to.Engine != nil {
+		parts = append(parts, "ENGINE="+*to.Engine)
+	}
+	if to.AutoIncrement != nil {
+		parts = append(parts, fmt.Sprintf("AUTO_INCREMENT=%d", *to.AutoIncrement))
+	}
+	if to.Charset != nil {
+		parts = append(parts, "DEFAULT CHARSET="+*to.Charset)
+	}
+	if to.Collation != nil {
+		parts = append(parts, "COLLATE="+*to.Collation)
+	}
+	if to.RowFormat != nil {
+		parts = append(parts, "ROW_FORMAT="+*to.RowFormat)
+	}
+	if to.Compression != nil {
+		parts = append(parts, fmt.Sprintf("COMPRESSION='%s'", sqlescape.EscapeString(*to.Compression)))
 	}
+	if to.Encryption != nil {
+		parts = append(parts, fmt.Sprintf("ENCRYPTION='%s'", sqlescape.EscapeString(*to.Encryption)))
+	}
+	if to.Comment != nil {
+		parts = append(parts, fmt.Sprintf("COMMENT='%s'", sqlescape.EscapeString(*to.Comment)))
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatColumnDefinition formats a column definition for ALTER TABLE
+func formatColumnDefinition(col *Column) string {
+	var parts []string
 
-	parts = append(parts, fmt.Sprintf("%s %s", sqlescape.EscapeIdentifier(col.Name), typeDef))
+	// Column name and type
+	parts = append(parts, fmt.Sprintf("%s %s", sqlescape.EscapeIdentifier(col.Name), formatColumnType(col)))
 
 	// Charset and collation (skip for binary types and JSON)
 	isBinaryType := col.Type == "varbinary" || col.Type == "binary" ||
@@ -141,6 +181,47 @@ func formatColumnDefinition(col *Column) string {
 	return strings.Join(parts, " ")
 }
 
+// formatColumnType formats a column's data type, including its length or
+// precision, ENUM/SET values and the unsigned and zerofill attributes.
+func formatColumnType(col *Column) string {
+	typeDef := col.Type
+
+	// Determine the full type definition including length/precision/values
+	switch {
+	case col.Type == "enum" && len(col.EnumValues) > 0:
+		var values []string
+		for _, v := range col.EnumValues {
+			values = append(values, fmt.Sprintf("'%s'", sqlescape.EscapeString(v)))
+		}
+		typeDef = fmt.Sprintf("enum(%s)", strings.Join(values, ","))
+	case col.Type == "set" && len(col.SetValues) > 0:
+		var values []string
+		for _, v := range col.SetValues {
+			values = append(values, fmt.Sprintf("'%s'", sqlescape.EscapeString(v)))
+		}
+		typeDef = fmt.Sprintf("set(%s)", strings.Join(values, ","))
+	case col.Precision != nil && col.Scale != nil:
+		// DECIMAL(precision, scale) - check Precision/Scale BEFORE Length!
+		// DECIMAL columns have both Length and Precision/Scale set, but we want Precision/Scale
+		typeDef = fmt.Sprintf("%s(%d,%d)", col.Type, *col.Precision, *col.Scale)
+	case col.Precision != nil:
+		// DECIMAL(precision) or other numeric types with precision only
+		typeDef = fmt.Sprintf("%s(%d)", col.Type, *col.Precision)
+	case col.Length != nil:
+		// VARCHAR, CHAR, etc.
+		typeDef = fmt.Sprintf("%s(%d)", col.Type, *col.Length)
+	}
+
+	if col.Unsigned != nil && *col.Unsigned {
+		typeDef += " unsigned"
+	}
+
+	if col.Zerofill != nil && *col.Zerofill {
+		typeDef += " zerofill"
+	}
+	return typeDef
+}
+
 // formatAddIndex formats an ADD INDEX clause
 func formatAddIndex(idx *Index) string {
 	var parts []string
@@ -224,6 +305,12 @@ func formatAddIndex(idx *Index) string {
 	return strings.Join(parts, " ")
 }
 
+// AddClause returns the ALTER TABLE clause that adds the index, for example
+// "ADD INDEX `idx_a` (`a`)".
+func (i Index) AddClause() string {
+	return formatAddIndex(&i)
+}
+
 // formatAddConstraint formats an ADD CONSTRAINT clause
 func formatAddConstraint(constr *Constraint) string {
 	var parts []string
@@ -0,0 +1,80 @@
+package statement
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// A parsed CreateTable round-trips through encoding/json, so tools can cache
+// parsed schemas and diff them later without the original SQL. The Raw AST
+// fields are not serialized and are nil after unmarshaling; nothing the diff
+// needs depends on them.
+//
+// The only values that need help are partition bounds: Values holds plain
+// strings alongside the partitionStringLiteral and partitionMaxValue types,
+// which a plain []any would flatten into indistinguishable JSON strings and
+// objects. They are encoded as:
+//
+//	"10"                 numeric literal or expression (plain string)
+//	{"string": "2020"}   quoted string literal
+//	{"maxvalue": true}   the MAXVALUE keyword
+
+// partitionValueJSON is the object form of a typed partition value.
+type partitionValueJSON struct {
+	String   *string `json:"string,omitempty"`
+	MaxValue bool    `json:"maxvalue,omitempty"`
+}
+
+func (v PartitionValues) MarshalJSON() ([]byte, error) {
+	values := make([]any, 0, len(v.Values))
+	for _, val := range v.Values {
+		switch val := val.(type) {
+		case partitionStringLiteral:
+			s := string(val)
+			values = append(values, partitionValueJSON{String: &s})
+		case partitionMaxValue:
+			values = append(values, partitionValueJSON{MaxValue: true})
+		case string:
+			values = append(values, val)
+		default:
+			return nil, fmt.Errorf("unsupported partition value type %T", val)
+		}
+	}
+	return json.Marshal(struct {
+		Type   string `json:"type"`
+		Values []any  `json:"values"`
+	}{v.Type, values})
+}
+
+func (v *PartitionValues) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Type   string            `json:"type"`
+		Values []json.RawMessage `json:"values"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	v.Type = raw.Type
+	v.Values = make([]any, 0, len(raw.Values))
+	for _, msg := range raw.Values {
+		var s string
+		if err := json.Unmarshal(msg, &s); err == nil {
+			v.Values = append(v.Values, s)
+			continue
+		}
+		var typed partitionValueJSON
+		if err := json.Unmarshal(msg, &typed); err != nil {
+			return fmt.Errorf("invalid partition value %s: %w", msg, err)
+		}
+		switch {
+		case typed.String != nil:
+			v.Values = append(v.Values, partitionStringLiteral(*typed.String))
+		case typed.MaxValue:
+			v.Values = append(v.Values, partitionMaxValue{})
+		default:
+			return errors.New("invalid partition value: " + string(msg))
+		}
+	}
+	return nil
+}
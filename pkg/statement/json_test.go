@@ -0,0 +1,122 @@
+package statement
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// clearRaw zeroes the AST pointers, which are not serialized.
+func clearRaw(ct *CreateTable) {
+	ct.Raw = nil
+	for i := range ct.Columns {
+		ct.Columns[i].Raw = nil
+	}
+	for i := range ct.Indexes {
+		ct.Indexes[i].Raw = nil
+	}
+	for i := range ct.Constraints {
+		ct.Constraints[i].Raw = nil
+	}
+}
+
+func TestCreateTableJSONRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		sql  string
+	}{
+		{
+			name: "columns indexes and constraints",
+			sql: `CREATE TABLE orders (
+				id BIGINT UNSIGNED NOT NULL AUTO_INCREMENT,
+				customer_id INT NOT NULL,
+				code VARCHAR(32) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin UNIQUE,
+				status ENUM('new','paid','shipped') NOT NULL DEFAULT 'new',
+				total DECIMAL(10,2) DEFAULT NULL COMMENT 'in cents',
+				doc JSON DEFAULT (json_object()),
+				total_x2 DECIMAL(12,2) GENERATED ALWAYS AS (total * 2) VIRTUAL,
+				created_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6) ON UPDATE CURRENT_TIMESTAMP(6),
+				PRIMARY KEY (id),
+				KEY idx_customer (customer_id, created_at DESC),
+				KEY idx_code_prefix (code(8)) INVISIBLE,
+				CONSTRAINT chk_total CHECK (total >= 0),
+				CONSTRAINT fk_customer FOREIGN KEY (customer_id) REFERENCES customers (id) ON DELETE CASCADE
+			) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_0900_ai_ci COMMENT='orders' ROW_FORMAT=DYNAMIC`,
+		},
+		{
+			name: "range columns partitions",
+			sql: `CREATE TABLE events (
+				id INT NOT NULL,
+				region VARCHAR(10) NOT NULL,
+				year INT NOT NULL,
+				PRIMARY KEY (id, region, year)
+			) PARTITION BY RANGE COLUMNS (year, region) (
+				PARTITION p0 VALUES LESS THAN (2020, 'm') COMMENT 'old',
+				PARTITION p1 VALUES LESS THAN (2030, MAXVALUE),
+				PARTITION p2 VALUES LESS THAN (MAXVALUE, MAXVALUE)
+			)`,
+		},
+		{
+			name: "list columns partitions with numeric-looking strings",
+			sql: `CREATE TABLE regions (
+				id INT NOT NULL,
+				code VARCHAR(10) NOT NULL,
+				PRIMARY KEY (id, code)
+			) PARTITION BY LIST COLUMNS (code) (
+				PARTITION p_old VALUES IN ('2019', '2020'),
+				PARTITION p_new VALUES IN ('2021', 'asia')
+			)`,
+		},
+		{
+			name: "range partitions with maxvalue and subpartitions",
+			sql: `CREATE TABLE logs (
+				id INT NOT NULL,
+				ts INT NOT NULL
+			) PARTITION BY RANGE (ts) SUBPARTITION BY HASH (id) SUBPARTITIONS 2 (
+				PARTITION p0 VALUES LESS THAN (1000),
+				PARTITION pmax VALUES LESS THAN MAXVALUE
+			)`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ct, err := ParseCreateTable(tt.sql)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(ct)
+			require.NoError(t, err)
+			var decoded CreateTable
+			require.NoError(t, json.Unmarshal(data, &decoded))
+
+			// A decoded table diffs clean against the original in both
+			// directions; the diff does not need the AST.
+			diff, err := ct.Diff(&decoded, nil)
+			require.NoError(t, err)
+			require.Nil(t, diff)
+			diff, err = decoded.Diff(ct, nil)
+			require.NoError(t, err)
+			require.Nil(t, diff)
+
+			clearRaw(ct)
+			require.Equal(t, ct, &decoded)
+		})
+	}
+}
+
+func TestPartitionValuesJSON(t *testing.T) {
+	values := PartitionValues{
+		Type:   "LESS_THAN",
+		Values: []any{"10", partitionStringLiteral("2020"), partitionMaxValue{}},
+	}
+	data, err := json.Marshal(values)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"type":"LESS_THAN","values":["10",{"string":"2020"},{"maxvalue":true}]}`, string(data))
+
+	var decoded PartitionValues
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	require.Equal(t, values, decoded)
+
+	require.Error(t, json.Unmarshal([]byte(`{"type":"IN","values":[{}]}`), &decoded))
+	require.Error(t, json.Unmarshal([]byte(`{"type":"IN","values":[1]}`), &decoded))
+}
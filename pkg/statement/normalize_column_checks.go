@@ -53,24 +53,32 @@ func (columnCheckNormalizer) Normalize(ct *CreateTable) *CreateTable {
 		if col.Check == nil {
 			continue
 		}
-		// Recover the user-supplied constraint name (if any) from the raw
-		// column option; unnamed ones are auto-numbered below.
+		// Recover the user-supplied constraint name (if any) and the
+		// enforcement state from the raw column option; unnamed ones are
+		// auto-numbered below. As with table-level CHECKs, the parser
+		// defaults Enforced to true.
 		name := ""
+		notEnforced := false
 		if col.Raw != nil {
 			for _, opt := range col.Raw.Options {
 				if opt.Tp == ast.ColumnOptionCheck {
 					name = opt.ConstraintName
+					notEnforced = !opt.Enforced
 					break
 				}
 			}
 		}
 		expr := *col.Check
 		definition := fmt.Sprintf("CHECK (%s)", expr)
+		if notEnforced {
+			definition += " NOT ENFORCED"
+		}
 		ct.Constraints = append(ct.Constraints, Constraint{
-			Name:       name,
-			Type:       "CHECK",
-			Expression: &expr,
-			Definition: &definition,
+			Name:        name,
+			Type:        "CHECK",
+			Expression:  &expr,
+			Definition:  &definition,
+			NotEnforced: notEnforced,
 		})
 		col.Check = nil
 		if name != "" {
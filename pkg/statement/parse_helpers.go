@@ -137,3 +137,35 @@ func extractPrecisionScaleFromTypeString(typeStr string) (int, int) {
 
 	return 0, 0
 }
+
+// statementOffset returns the byte offset of the first token in sql, skipping
+// leading whitespace and comments. The parser does not record where a
+// statement starts: the text it keeps includes any leading comments. An
+// executable comment (/*! ... */) or optimizer hint (/*+ ... */) is a token,
+// not a comment.
+func statementOffset(sql string) int {
+	i := 0
+	for i < len(sql) {
+		rest := sql[i:]
+		switch {
+		case strings.ContainsRune(" \t\r\n\f\v", rune(sql[i])):
+			i++
+		case rest[0] == '#', strings.HasPrefix(rest, "--") && (len(rest) == 2 || rest[2] <= ' '):
+			// MySQL only treats -- as a comment when followed by whitespace.
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				return len(sql)
+			}
+			i += end + 1
+		case strings.HasPrefix(rest, "/*") && !strings.HasPrefix(rest, "/*!") && !strings.HasPrefix(rest, "/*+"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				return i // unterminated; let the parser report it
+			}
+			i += end + 4
+		default:
+			return i
+		}
+	}
+	return i
+}
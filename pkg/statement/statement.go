@@ -165,6 +165,30 @@ func MustNew(statement string) []*AbstractStatement {
 	return stmt
 }
 
+// Canonicalize returns sql as the parser restores it: keywords upper-cased,
+// identifiers back-quoted, whitespace and comments normalized, and multiple
+// statements joined with "; ". Two statements that differ only cosmetically
+// canonicalize to the same string; identifier case is preserved because table
+// names can be case-sensitive.
+func Canonicalize(sql string) (string, error) {
+	stmtNodes, _, err := parser.New().Parse(sql, "", "")
+	if err != nil {
+		return "", err
+	}
+	if len(stmtNodes) == 0 {
+		return "", ErrNoStatements
+	}
+	restored := make([]string, 0, len(stmtNodes))
+	for _, node := range stmtNodes {
+		var sb strings.Builder
+		if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return "", fmt.Errorf("could not restore statement: %w", err)
+		}
+		restored = append(restored, sb.String())
+	}
+	return strings.Join(restored, "; "), nil
+}
+
 func (a *AbstractStatement) IsAlterTable() bool {
 	_, ok := (*a.StmtNode).(*ast.AlterTableStmt)
 	return ok
@@ -439,3 +439,35 @@ func TestColumnRenameMap(t *testing.T) {
 	renames = stmts[0].ColumnRenameMap()
 	require.Equal(t, map[string]string{"Foo": "Bar"}, renames)
 }
+
+func TestCanonicalize(t *testing.T) {
+	canonical, err := Canonicalize("ALTER TABLE `t1` ADD COLUMN `a` INT, DROP COLUMN `b`")
+	require.NoError(t, err)
+	for _, sql := range []string{
+		"ALTER TABLE `t1` ADD COLUMN `a` INT, DROP COLUMN `b`",
+		"alter table t1 add column a int, drop column b",
+		"ALTER  TABLE t1\n\tADD COLUMN a INT,\n\tDROP COLUMN b;",
+		"ALTER TABLE t1 ADD a int, DROP b",
+	} {
+		got, err := Canonicalize(sql)
+		require.NoError(t, err, sql)
+		require.Equal(t, canonical, got, sql)
+	}
+
+	// A different change, or a different table, does not match.
+	other, err := Canonicalize("ALTER TABLE t1 ADD COLUMN a BIGINT, DROP COLUMN b")
+	require.NoError(t, err)
+	require.NotEqual(t, canonical, other)
+	other, err = Canonicalize("ALTER TABLE T1 ADD COLUMN a INT, DROP COLUMN b")
+	require.NoError(t, err)
+	require.NotEqual(t, canonical, other)
+
+	multi, err := Canonicalize("alter table t1 engine=innodb; alter table t2 engine=innodb")
+	require.NoError(t, err)
+	require.Equal(t, "ALTER TABLE `t1` ENGINE = innodb; ALTER TABLE `t2` ENGINE = innodb", multi)
+
+	_, err = Canonicalize("ALTER TABLE t1 ADD COLUMN")
+	require.Error(t, err)
+	_, err = Canonicalize("")
+	require.ErrorIs(t, err, ErrNoStatements)
+}
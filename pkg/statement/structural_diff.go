@@ -0,0 +1,189 @@
+package statement
+
+import "strings"
+
+// This file holds Diff, which reports the differences between two tables as
+// structured entries rather than ALTER statements. It reuses the comparison
+// helpers in equality.go and the renderers in format.go.
+
+// DifferenceKind is the kind of schema element a SchemaDifference is about.
+type DifferenceKind string
+
+const (
+	DifferenceColumn DifferenceKind = "column"
+	DifferenceIndex  DifferenceKind = "index"
+	DifferenceOption DifferenceKind = "option"
+)
+
+// DifferenceChange describes how a schema element differs.
+type DifferenceChange string
+
+const (
+	// ChangeAdded means the element exists only in the second table.
+	ChangeAdded DifferenceChange = "added"
+	// ChangeRemoved means the element exists only in the first table.
+	ChangeRemoved DifferenceChange = "removed"
+	// ChangeType means a column's data type differs, including its length,
+	// precision, ENUM/SET values or unsigned attribute.
+	ChangeType DifferenceChange = "type"
+	// ChangeNullability means a column changed between NULL and NOT NULL.
+	ChangeNullability DifferenceChange = "nullability"
+	// ChangeModified means the element differs in some other way, such as a
+	// column default or comment, an index definition or a table option value.
+	ChangeModified DifferenceChange = "modified"
+)
+
+// SchemaDifference is one difference reported by Diff. Old and New hold the
+// element's value in the first and second table: the full definition for an
+// added, removed or modified column or index, the data type for ChangeType,
+// "NULL" or "NOT NULL" for ChangeNullability, and the option value for a
+// table option. The side where the element does not exist is empty.
+type SchemaDifference struct {
+	Kind   DifferenceKind   `json:"kind"`
+	Change DifferenceChange `json:"change"`
+	Name   string           `json:"name"`
+	Old    string           `json:"old,omitempty"`
+	New    string           `json:"new,omitempty"`
+}
+
+// Diff compares table a against table b and reports their column, index and
+// table option differences, in that order. Unlike (*CreateTable).Diff it does
+// not produce ALTER statements: it is meant for inspecting drift, where each
+// difference needs to be examined on its own. A column whose type and
+// nullability both change is reported once for each, plus a ChangeModified
+// entry if anything else about it changed too. Column and index names are
+// matched case-insensitively. The AUTO_INCREMENT counter is not compared.
+// Returns nil if the tables are the same.
+func Diff(a, b *CreateTable) []SchemaDifference {
+	var diffs []SchemaDifference
+	diffs = append(diffs, diffColumnStructure(a, b)...)
+	diffs = append(diffs, diffIndexStructure(a, b)...)
+	diffs = append(diffs, diffTableOptionStructure(a.TableOptions, b.TableOptions)...)
+	return diffs
+}
+
+func diffColumnStructure(a, b *CreateTable) (diffs []SchemaDifference) {
+	bColumns := make(map[string]*Column, len(b.Columns))
+	for i := range b.Columns {
+		bColumns[strings.ToLower(b.Columns[i].Name)] = &b.Columns[i]
+	}
+	aColumns := make(map[string]bool, len(a.Columns))
+	opts := NewDiffOptions()
+	for i := range a.Columns {
+		oldCol := &a.Columns[i]
+		aColumns[strings.ToLower(oldCol.Name)] = true
+		newCol, ok := bColumns[strings.ToLower(oldCol.Name)]
+		if !ok {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceColumn, Change: ChangeRemoved, Name: oldCol.Name, Old: formatColumnDefinition(oldCol)})
+			continue
+		}
+		if oldType, newType := formatColumnType(oldCol), formatColumnType(newCol); oldType != newType {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceColumn, Change: ChangeType, Name: newCol.Name, Old: oldType, New: newType})
+		}
+		if oldCol.Nullable != newCol.Nullable {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceColumn, Change: ChangeNullability, Name: newCol.Name, Old: nullability(oldCol), New: nullability(newCol)})
+		}
+		// Take the type and nullability from the new column so that
+		// columnsEqualWithContext only sees the remaining attributes.
+		rest := *oldCol
+		rest.Type, rest.Length, rest.Precision, rest.Scale = newCol.Type, newCol.Length, newCol.Precision, newCol.Scale
+		rest.Unsigned, rest.Zerofill, rest.EnumValues, rest.SetValues = newCol.Unsigned, newCol.Zerofill, newCol.EnumValues, newCol.SetValues
+		rest.Nullable = newCol.Nullable
+		if !a.columnsEqualWithContext(&rest, newCol, b, opts) {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceColumn, Change: ChangeModified, Name: newCol.Name, Old: formatColumnDefinition(oldCol), New: formatColumnDefinition(newCol)})
+		}
+	}
+	for i := range b.Columns {
+		if !aColumns[strings.ToLower(b.Columns[i].Name)] {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceColumn, Change: ChangeAdded, Name: b.Columns[i].Name, New: formatColumnDefinition(&b.Columns[i])})
+		}
+	}
+	return diffs
+}
+
+func diffIndexStructure(a, b *CreateTable) (diffs []SchemaDifference) {
+	bIndexes := make(map[string]*Index, len(b.Indexes))
+	for i := range b.Indexes {
+		bIndexes[indexKey(&b.Indexes[i])] = &b.Indexes[i]
+	}
+	aIndexes := make(map[string]bool, len(a.Indexes))
+	for i := range a.Indexes {
+		oldIdx := &a.Indexes[i]
+		aIndexes[indexKey(oldIdx)] = true
+		newIdx, ok := bIndexes[indexKey(oldIdx)]
+		switch {
+		case !ok:
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceIndex, Change: ChangeRemoved, Name: indexName(oldIdx), Old: indexDefinition(oldIdx)})
+		case !indexesEqual(oldIdx, newIdx):
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceIndex, Change: ChangeModified, Name: indexName(newIdx), Old: indexDefinition(oldIdx), New: indexDefinition(newIdx)})
+		}
+	}
+	for i := range b.Indexes {
+		if !aIndexes[indexKey(&b.Indexes[i])] {
+			diffs = append(diffs, SchemaDifference{Kind: DifferenceIndex, Change: ChangeAdded, Name: indexName(&b.Indexes[i]), New: indexDefinition(&b.Indexes[i])})
+		}
+	}
+	return diffs
+}
+
+func diffTableOptionStructure(a, b *TableOptions) (diffs []SchemaDifference) {
+	options := []struct {
+		name     string
+		old, new *string
+	}{
+		{"ENGINE", a.getEngine(), b.getEngine()},
+		{"CHARSET", a.getCharset(), b.getCharset()},
+		{"COLLATE", a.getCollation(), b.getCollation()},
+		{"ROW_FORMAT", a.getRowFormat(), b.getRowFormat()},
+		{"COMPRESSION", a.getCompression(), b.getCompression()},
+		{"ENCRYPTION", a.getEncryption(), b.getEncryption()},
+		{"COMMENT", a.getComment(), b.getComment()},
+	}
+	for _, opt := range options {
+		if ptrEqual(opt.old, opt.new) {
+			continue
+		}
+		diff := SchemaDifference{Kind: DifferenceOption, Change: ChangeModified, Name: opt.name}
+		if opt.old == nil {
+			diff.Change = ChangeAdded
+		} else {
+			diff.Old = *opt.old
+		}
+		if opt.new == nil {
+			diff.Change = ChangeRemoved
+		} else {
+			diff.New = *opt.new
+		}
+		diffs = append(diffs, diff)
+	}
+	return diffs
+}
+
+// indexKey identifies an index across the two tables. The table-level
+// PRIMARY KEY is parsed without a name, so it is keyed by its type.
+func indexKey(idx *Index) string {
+	if idx.Type == "PRIMARY KEY" {
+		return "PRIMARY"
+	}
+	return strings.ToLower(idx.Name)
+}
+
+func indexName(idx *Index) string {
+	if idx.Type == "PRIMARY KEY" {
+		return "PRIMARY"
+	}
+	return idx.Name
+}
+
+// indexDefinition renders an index as it appears in CREATE TABLE: the ALTER
+// TABLE clause from formatAddIndex without its leading ADD.
+func indexDefinition(idx *Index) string {
+	return strings.TrimPrefix(formatAddIndex(idx), "ADD ")
+}
+
+func nullability(col *Column) string {
+	if col.Nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
@@ -0,0 +1,65 @@
+package statement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStructuralDiff(t *testing.T) {
+	live, err := ParseCreateTable(`CREATE TABLE t1 (
+		id INT NOT NULL,
+		name VARCHAR(50) NULL,
+		status ENUM('a', 'b') NOT NULL,
+		note TEXT,
+		legacy INT,
+		PRIMARY KEY (id),
+		KEY idx_name (name),
+		KEY idx_legacy (legacy)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='old'`)
+	require.NoError(t, err)
+	desired, err := ParseCreateTable(`CREATE TABLE t1 (
+		id INT NOT NULL,
+		name VARCHAR(100) NOT NULL,
+		status ENUM('a', 'b', 'c') NOT NULL,
+		note TEXT COMMENT 'free text',
+		created_at DATETIME,
+		PRIMARY KEY (id),
+		KEY idx_name (name, created_at),
+		UNIQUE KEY uk_created (created_at)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 ROW_FORMAT=DYNAMIC`)
+	require.NoError(t, err)
+
+	require.Equal(t, []SchemaDifference{
+		{Kind: DifferenceColumn, Change: ChangeType, Name: "name", Old: "varchar(50)", New: "varchar(100)"},
+		{Kind: DifferenceColumn, Change: ChangeNullability, Name: "name", Old: "NULL", New: "NOT NULL"},
+		{Kind: DifferenceColumn, Change: ChangeType, Name: "status", Old: "enum('a','b')", New: "enum('a','b','c')"},
+		{Kind: DifferenceColumn, Change: ChangeModified, Name: "note", Old: "`note` text NULL", New: "`note` text NULL COMMENT 'free text'"},
+		{Kind: DifferenceColumn, Change: ChangeRemoved, Name: "legacy", Old: "`legacy` int NULL"},
+		{Kind: DifferenceColumn, Change: ChangeAdded, Name: "created_at", New: "`created_at` datetime NULL"},
+		{Kind: DifferenceIndex, Change: ChangeModified, Name: "idx_name", Old: "INDEX `idx_name` (`name`)", New: "INDEX `idx_name` (`name`, `created_at`)"},
+		{Kind: DifferenceIndex, Change: ChangeRemoved, Name: "idx_legacy", Old: "INDEX `idx_legacy` (`legacy`)"},
+		{Kind: DifferenceIndex, Change: ChangeAdded, Name: "uk_created", New: "UNIQUE INDEX `uk_created` (`created_at`)"},
+		{Kind: DifferenceOption, Change: ChangeAdded, Name: "ROW_FORMAT", New: "DYNAMIC"},
+		{Kind: DifferenceOption, Change: ChangeRemoved, Name: "COMMENT", Old: "old"},
+	}, Diff(live, desired))
+}
+
+func TestStructuralDiffIdentical(t *testing.T) {
+	// Spelling differences that normalize away are not differences.
+	a, err := ParseCreateTable("CREATE TABLE t1 (id INT(11) PRIMARY KEY, Name VARCHAR(10) CHARACTER SET utf8mb4) CHARSET=utf8mb4 AUTO_INCREMENT=5")
+	require.NoError(t, err)
+	b, err := ParseCreateTable("CREATE TABLE t1 (id INT NOT NULL, name VARCHAR(10), PRIMARY KEY (id)) CHARSET=utf8mb4 AUTO_INCREMENT=900")
+	require.NoError(t, err)
+	require.Empty(t, Diff(a, b))
+}
+
+func TestStructuralDiffPrimaryKey(t *testing.T) {
+	a, err := ParseCreateTable("CREATE TABLE t1 (id INT NOT NULL, b INT NOT NULL, PRIMARY KEY (id))")
+	require.NoError(t, err)
+	b, err := ParseCreateTable("CREATE TABLE t1 (id INT NOT NULL, b INT NOT NULL, PRIMARY KEY (id, b))")
+	require.NoError(t, err)
+	require.Equal(t, []SchemaDifference{
+		{Kind: DifferenceIndex, Change: ChangeModified, Name: "PRIMARY", Old: "PRIMARY KEY (`id`)", New: "PRIMARY KEY (`id`, `b`)"},
+	}, Diff(a, b))
+}
@@ -3,7 +3,7 @@ package statement
 import "strconv"
 
 // This file holds nil-safe accessor helpers for TableOptions, used by the
-// table-option diffing in diff.go.
+// table-option diffing in diff.go and structural_diff.go.
 
 // Helper methods for TableOptions to handle nil safely
 func (to *TableOptions) getEngine() *string {
@@ -48,3 +48,17 @@ func (to *TableOptions) getAutoIncrement() *string {
 	s := strconv.FormatUint(*to.AutoIncrement, 10)
 	return &s
 }
+
+func (to *TableOptions) getCompression() *string {
+	if to == nil {
+		return nil
+	}
+	return to.Compression
+}
+
+func (to *TableOptions) getEncryption() *string {
+	if to == nil {
+		return nil
+	}
+	return to.Encryption
+}
@@ -39,6 +39,7 @@ type ETA struct {
 
 type Progress struct {
 	CurrentState State  // current state, i.e. CopyRows
+	Paused       bool   // the migration is paused; CurrentState is where it will continue
 	Summary      string // text based representation, i.e. "12.5% copyRows ETA 1h 30m"
 
 	// ETA is the structured remaining row-copy estimate and its availability.
@@ -52,6 +53,27 @@ type Progress struct {
 	// Tables contains per-table progress for multi-table migrations.
 	// For single-table migrations, this will have one entry.
 	Tables []TableProgress
+
+	// DeltaLen is the number of changes buffered from the change stream and
+	// not yet applied to the new table(s). Throttled reports whether the
+	// copy is currently held back by a throttler (or by a pause). Both are
+	// zero before the copy starts and after cutover.
+	DeltaLen  int
+	Throttled bool
+}
+
+// CopyFraction returns the fraction of rows copied across all tables, from 0
+// to 1. It is 0 when the number of rows is not known yet.
+func (p Progress) CopyFraction() float64 {
+	var copied, total uint64
+	for _, t := range p.Tables {
+		copied += t.RowsCopied
+		total += t.RowsTotal
+	}
+	if total == 0 {
+		return 0
+	}
+	return min(1, float64(copied)/float64(total))
 }
 
 // ChecksumProgress tracks progress of the checksum phase, where Spirit verifies
@@ -62,14 +84,21 @@ type ChecksumProgress struct {
 	RowsTotal   uint64 // total rows to verify
 }
 
+// Fraction returns the fraction of rows verified, from 0 to 1. It is 0 when
+// the number of rows is not known yet. RowsTotal is the chunker's estimate,
+// so it holds for any key type, and the fraction is capped at 1 when more
+// rows are checked than were estimated.
+func (c ChecksumProgress) Fraction() float64 {
+	if c.RowsTotal == 0 {
+		return 0
+	}
+	return min(1, float64(c.RowsChecked)/float64(c.RowsTotal))
+}
+
 // String renders the checksum progress for the human-readable summary line,
 // e.g. "71436/221193 32.30%".
 func (c ChecksumProgress) String() string {
-	pct := float64(0)
-	if c.RowsTotal > 0 {
-		pct = float64(c.RowsChecked) / float64(c.RowsTotal) * 100
-	}
-	return fmt.Sprintf("%d/%d %.2f%%", c.RowsChecked, c.RowsTotal, pct)
+	return fmt.Sprintf("%d/%d %.2f%%", c.RowsChecked, c.RowsTotal, c.Fraction()*100)
 }
 
 // TableProgress tracks progress for a single table in the migration.
@@ -18,6 +18,7 @@ func TestChecksumProgressString(t *testing.T) {
 		{"half way", ChecksumProgress{RowsChecked: 500, RowsTotal: 1000}, "500/1000 50.00%"},
 		{"complete", ChecksumProgress{RowsChecked: 1000, RowsTotal: 1000}, "1000/1000 100.00%"},
 		{"partial percent", ChecksumProgress{RowsChecked: 71436, RowsTotal: 221193}, "71436/221193 32.30%"},
+		{"more rows than estimated", ChecksumProgress{RowsChecked: 1200, RowsTotal: 1000}, "1200/1000 100.00%"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -25,3 +26,21 @@ func TestChecksumProgressString(t *testing.T) {
 		})
 	}
 }
+
+func TestChecksumProgressFraction(t *testing.T) {
+	assert.Zero(t, ChecksumProgress{}.Fraction())
+	assert.Zero(t, ChecksumProgress{RowsChecked: 10}.Fraction())
+	assert.InDelta(t, 0.25, ChecksumProgress{RowsChecked: 250, RowsTotal: 1000}.Fraction(), 1e-9)
+	assert.InDelta(t, 1, ChecksumProgress{RowsChecked: 1200, RowsTotal: 1000}.Fraction(), 1e-9)
+}
+
+func TestProgressCopyFraction(t *testing.T) {
+	assert.Zero(t, Progress{}.CopyFraction())
+	assert.Zero(t, Progress{Tables: []TableProgress{{RowsCopied: 10}}}.CopyFraction())
+	assert.InDelta(t, 0.25, Progress{Tables: []TableProgress{
+		{RowsCopied: 10, RowsTotal: 100},
+		{RowsCopied: 40, RowsTotal: 100},
+	}}.CopyFraction(), 1e-9)
+	// The total is an estimate, so more rows can be copied than expected.
+	assert.InDelta(t, 1, Progress{Tables: []TableProgress{{RowsCopied: 120, RowsTotal: 100}}}.CopyFraction(), 1e-9)
+}
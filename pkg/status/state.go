@@ -13,6 +13,7 @@ var (
 	ErrBinlogNotFound          = errors.New("checkpoint binlog file not found on server")
 	ErrCheckpointTooOld        = errors.New("checkpoint is too old to safely resume")
 	ErrCheckpointCollision     = errors.New("checkpoint belongs to a different table (truncation collision)")
+	ErrMismatchedTableNames    = errors.New("table names in checkpoint table do not match the --new-table-name and --old-table-name specified here")
 	ErrCouldNotWriteCheckpoint = errors.New("could not write checkpoint")
 	ErrWatermarkNotReady       = errors.New("watermark not ready")
 )
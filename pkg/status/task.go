@@ -20,6 +20,13 @@ type Task interface {
 	Cancel() // a callback to be able to cancel the task.
 }
 
+// ProgressReporter is implemented by tasks that hand their Progress to a
+// caller-supplied callback. WatchTask calls ReportProgress on each status
+// tick, after logging Status.
+type ProgressReporter interface {
+	ReportProgress()
+}
+
 // WatchTask periodically does the status reporting for a task.
 // This includes writing to the logger the current state,
 // and dumping checkpoints.
@@ -50,6 +57,9 @@ func continuallyDumpStatus(ctx context.Context, task Task, logger *slog.Logger)
 				return
 			}
 			logger.Info(task.Status()) // call the task to write the status
+			if reporter, ok := task.(ProgressReporter); ok {
+				reporter.ReportProgress()
+			}
 		}
 	}
 }
@@ -121,6 +121,33 @@ func TestWatchTaskFiresAndStopsOnCancel(t *testing.T) {
 	}
 }
 
+// reportingTask is a fakeTask that also implements ProgressReporter.
+type reportingTask struct {
+	*fakeTask
+	reportCh chan struct{}
+}
+
+func (r *reportingTask) ReportProgress() {
+	select {
+	case r.reportCh <- struct{}{}:
+	default:
+	}
+}
+
+// TestWatchTaskReportsProgress verifies that a task implementing
+// ProgressReporter is asked to report its progress on each status tick.
+func TestWatchTaskReportsProgress(t *testing.T) {
+	setTestIntervals(t, 2*time.Millisecond, time.Hour)
+	task := &reportingTask{fakeTask: newFakeTask(CopyRows), reportCh: make(chan struct{}, 64)}
+	ctx, cancel := context.WithCancel(t.Context())
+
+	wait := WatchTask(ctx, task, slog.Default())
+	waitSignal(t, task.reportCh, "first progress report")
+	waitSignal(t, task.reportCh, "second progress report")
+	cancel()
+	wait()
+}
+
 // TestWatchTaskStopsPastCutover verifies both loops exit on their own
 // (no ctx cancellation) once the task reports a state past CutOver:
 // the status loop on state > CutOver, the checkpoint loop on
@@ -1,9 +1,13 @@
 package table
 
 import (
+	"database/sql"
 	"encoding/json"
 	"testing"
 
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	"github.com/go-sql-driver/mysql"
 	"github.com/stretchr/testify/require"
 )
 
@@ -331,3 +335,36 @@ func TestWatermarkPerTable(t *testing.T) {
 	_, err = WatermarkPerTable("not-json", t1, t2)
 	require.Error(t, err)
 }
+
+// TestChunkStringEscapingInterpolateParams checks that the WHERE clause the
+// copier and checksum build from a chunk selects string keys containing
+// quotes, backslashes and '?' with the driver's InterpolateParams off and
+// on, including in a statement that also has driver arguments.
+func TestChunkStringEscapingInterpolateParams(t *testing.T) {
+	testutils.RunSQL(t, `DROP TABLE IF EXISTS chunkescape`)
+	testutils.RunSQL(t, `CREATE TABLE chunkescape (id VARCHAR(64) NOT NULL PRIMARY KEY)`)
+	tricky := []string{`it's`, `say "hi"`, `back\slash`, `trailing\`, `question?mark`, `'?'`}
+	for _, interpolate := range []bool{false, true} {
+		cfg, err := mysql.ParseDSN(testutils.DSN())
+		require.NoError(t, err)
+		cfg.InterpolateParams = interpolate
+		db, err := sql.Open("mysql", cfg.FormatDSN())
+		require.NoError(t, err)
+		defer utils.CloseAndLog(db)
+		if !interpolate {
+			for _, s := range tricky {
+				_, err = db.ExecContext(t.Context(), "INSERT INTO chunkescape VALUES (?)", s)
+				require.NoError(t, err)
+			}
+		}
+		for _, s := range tricky {
+			bound := &Boundary{Value: []Datum{{Val: s, Tp: unknownType}}, Inclusive: true}
+			chunk := &Chunk{Key: []string{"id"}, LowerBound: bound, UpperBound: bound}
+			var count int
+			require.NoError(t, db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM chunkescape WHERE "+chunk.String()).Scan(&count))
+			require.Equal(t, 1, count, "interpolateParams=%v, key %q", interpolate, s)
+			require.NoError(t, db.QueryRowContext(t.Context(), "SELECT COUNT(*) FROM chunkescape WHERE "+chunk.String()+" AND id <> ?", "other").Scan(&count))
+			require.Equal(t, 1, count, "interpolateParams=%v, key %q with an argument", interpolate, s)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package table
 
 import (
+	"fmt"
 	"log/slog"
 	"time"
 )
@@ -66,6 +67,17 @@ type Chunker interface {
 	Tables() []*TableInfo
 }
 
+// ChunkSizer is implemented by chunkers that size their chunks from
+// Feedback, so the chunk size can be observed as it changes.
+type ChunkSizer interface {
+	// ChunkSize returns the number of rows the next chunk is sized for.
+	ChunkSize() uint64
+	// PanicShrinks returns the number of chunks that overshot their
+	// target by more than DynamicPanicFactor, each of which shrank the
+	// chunk size immediately.
+	PanicShrinks() uint64
+}
+
 // MappedChunker is a Chunker that operates on a single source→target table pair
 // and carries a ColumnMapping describing the column relationship between them.
 // The multiChunker does not implement this interface because it wraps multiple
@@ -75,7 +87,9 @@ type MappedChunker interface {
 	// ColumnMapping returns the column mapping between source and target tables,
 	// including any column renames.
 	ColumnMapping() *ColumnMapping
-	KeyAboveHighWatermark(key0 any) bool
+	// KeyAboveHighWatermark is passed all the columns of a row's PRIMARY
+	// KEY, and KeyBelowLowWatermark only the first.
+	KeyAboveHighWatermark(key []any) bool
 	KeyBelowLowWatermark(key0 any) bool
 }
 
@@ -95,6 +109,13 @@ type ChunkerConfig struct {
 	// paths never see row bytes and keep the time signal. See
 	// dynamicChunkSizer.TargetChunkBytes.
 	TargetChunkBytes uint64
+	// FixedChunkSize, when non-zero, disables dynamic chunk sizing: every
+	// chunk has this ChunkSize and Feedback does not change it. It takes
+	// precedence over TargetChunkTime and TargetChunkBytes. The composite
+	// chunker then returns exactly this many rows per chunk (except the
+	// last); the optimistic chunker spans this many key values, which is
+	// the same number of rows when the auto-increment key has no gaps.
+	FixedChunkSize uint64
 	// Logger is the structured logger. Defaults to slog.Default().
 	Logger *slog.Logger
 	// ColumnMapping describes the column relationship between source and target tables,
@@ -105,6 +126,14 @@ type ChunkerConfig struct {
 	// table has an auto-increment primary key.
 	Key   string
 	Where string
+	// Descending makes the optimistic chunker walk the key from its maximum
+	// value down to its minimum, so the newest rows of an auto-increment
+	// table are processed first. The low watermark is then the lowest key
+	// below which nothing has been copied, and a chunker opened at it
+	// continues downward. Only the optimistic chunker supports it, so
+	// NewChunker returns an error for a table it would give the composite
+	// chunker.
+	Descending bool
 }
 
 // NewChunker creates a new MappedChunker for the given source table.
@@ -134,18 +163,21 @@ func NewChunker(t *TableInfo, config ChunkerConfig) (MappedChunker, error) {
 			Ti:                t,
 			NewTi:             newTable,
 			columnMapping:     config.ColumnMapping,
-			dynamicChunkSizer: dynamicChunkSizer{ChunkerTarget: config.TargetChunkTime, TargetChunkBytes: config.TargetChunkBytes},
-			watermarkTracker:  watermarkTracker{lowerBoundWatermarkMap: make(map[string]*Chunk)},
+			dynamicChunkSizer: dynamicChunkSizer{ChunkerTarget: config.TargetChunkTime, TargetChunkBytes: config.TargetChunkBytes, FixedChunkSize: config.FixedChunkSize},
+			watermarkTracker:  watermarkTracker{lowerBoundWatermarkMap: make(map[string]*Chunk), descending: config.Descending},
 			logger:            config.Logger,
 		}, nil
 	}
+	if config.Descending {
+		return nil, fmt.Errorf("table %s: descending order requires a single-column auto-increment key, and no Key or Where", t.TableName)
+	}
 	return &chunkerComposite{
 		Ti:                t,
 		NewTi:             newTable,
 		columnMapping:     config.ColumnMapping,
 		keyName:           config.Key,
 		where:             config.Where,
-		dynamicChunkSizer: dynamicChunkSizer{ChunkerTarget: config.TargetChunkTime},
+		dynamicChunkSizer: dynamicChunkSizer{ChunkerTarget: config.TargetChunkTime, FixedChunkSize: config.FixedChunkSize},
 		watermarkTracker:  watermarkTracker{lowerBoundWatermarkMap: make(map[string]*Chunk)},
 		logger:            config.Logger,
 	}, nil
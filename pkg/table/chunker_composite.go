@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"reflect"
 	"slices"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -47,7 +48,10 @@ type compositeWatermark struct {
 	RowsCopied uint64
 }
 
-var _ MappedChunker = &chunkerComposite{}
+var (
+	_ MappedChunker = &chunkerComposite{}
+	_ ChunkSizer    = &chunkerComposite{}
+)
 
 func (t *chunkerComposite) additionalConditionsSQL(whereSent bool) string {
 	if t.where == "" {
@@ -77,14 +81,10 @@ func (t *chunkerComposite) Next() (*Chunk, error) {
 	return chunk, nil
 }
 
-// next computes the next chunk. Caller must hold t.Mutex.
-func (t *chunkerComposite) next() (*Chunk, error) {
-	if t.finalChunkSent {
-		return nil, ErrTableIsRead
-	}
-	if !t.isOpen {
-		return nil, ErrTableNotOpen
-	}
+// nextQuery returns the query that finds the upper bound of the next chunk:
+// the chunk key of the row chunkSize rows past the chunk pointer. Caller
+// must hold t.Mutex.
+func (t *chunkerComposite) nextQuery() string {
 	// Start prefetching the next chunk
 	// First assume it's the first chunk, we can overwrite this
 	// just below.
@@ -110,7 +110,18 @@ func (t *chunkerComposite) next() (*Chunk, error) {
 			t.chunkSize,
 		)
 	}
-	upperDatums, err := t.nextQueryToDatums(query)
+	return query
+}
+
+// next computes the next chunk. Caller must hold t.Mutex.
+func (t *chunkerComposite) next() (*Chunk, error) {
+	if t.finalChunkSent {
+		return nil, ErrTableIsRead
+	}
+	if !t.isOpen {
+		return nil, ErrTableNotOpen
+	}
+	upperDatums, err := t.nextQueryToDatums(t.nextQuery())
 	if err != nil {
 		return nil, err
 	}
@@ -293,6 +304,10 @@ func (t *chunkerComposite) OpenAtWatermark(checkpnt string) error {
 	if err != nil {
 		return err
 	}
+	if !slices.Equal(chunk.Key, t.chunkKeys) {
+		return fmt.Errorf("watermark is for chunks on (%s), but the chunker is on (%s)",
+			strings.Join(chunk.Key, ", "), strings.Join(t.chunkKeys, ", "))
+	}
 	// We can restore from chunk.UpperBound, but because it is a < operator,
 	// There might be an annoying off by 1 error. So let's just restore
 	// from the chunk.LowerBound.
@@ -319,7 +334,7 @@ func (t *chunkerComposite) Reset() error {
 	// Reset all state to initial values
 	t.chunkPtrs = []Datum{} // reset to empty slice (first chunk)
 	t.finalChunkSent = false
-	t.chunkSize = StartingChunkSize
+	t.chunkSize = t.initialChunkSize()
 	t.watermark = nil
 	t.lowerBoundWatermarkMap = make(map[string]*Chunk, 0)
 	t.inflightChunks = 0
@@ -348,7 +363,7 @@ func (t *chunkerComposite) Feedback(chunk *Chunk, d time.Duration, actualRows ui
 	// Check if the feedback is based on an earlier chunker size.
 	// if it is, it is misleading to incorporate feedback now.
 	// We should just skip it. We also skip if dynamic chunking is disabled.
-	if chunk.ChunkSize != t.chunkSize || t.disableDynamicChunker {
+	if chunk.ChunkSize != t.chunkSize || !t.isDynamic() {
 		return
 	}
 
@@ -410,7 +425,7 @@ func (t *chunkerComposite) open() (err error) {
 		t.keyName = "PRIMARY"
 	}
 	t.finalChunkSent = false
-	t.chunkSize = StartingChunkSize
+	t.chunkSize = t.initialChunkSize()
 	t.inflightChunks = 0
 	t.checkpointHighPtr = Datum{} // reset checkpoint high pointer
 
@@ -426,6 +441,21 @@ func (t *chunkerComposite) IsRead() bool {
 	return t.finalChunkSent
 }
 
+// ChunkSize returns the number of rows the next chunk is sized for.
+func (t *chunkerComposite) ChunkSize() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.chunkSize
+}
+
+// PanicShrinks returns the number of chunks whose feedback overshot the
+// target by more than DynamicPanicFactor.
+func (t *chunkerComposite) PanicShrinks() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.panicShrinks
+}
+
 // Progress returns the current progress of the chunker
 // It is up the implementation to determine how it
 // wants to do that. For the composite chunker we use
@@ -437,17 +467,18 @@ func (t *chunkerComposite) Progress() (uint64, uint64, uint64) {
 
 // KeyAboveHighWatermark checks if a key is above the high watermark (chunkPtr).
 // TRUE means the caller will discard the event, so if there is any ambiguity
-// it is important to return FALSE (buffer the change). In particular, for
-// multi-column chunk keys only a strictly-greater first column is unambiguous;
-// see the comparison at the bottom of this function.
-// This optimization works with comparable types in key[0] (first column): numeric, string, binary, temporal.
+// it is important to return FALSE (buffer the change). The key is the full
+// PRIMARY KEY, and is compared with chunkPtrs as a tuple, in the same order
+// as the row constructor comparison the chunks are read with (see
+// expandRowConstructorComparison); a key equal to chunkPtrs is buffered.
+// This optimization works with comparable types in the key columns: numeric, string, binary, temporal.
 // For VARCHAR/TEXT with collations, Go's byte-order comparison may differ from MySQL's collation order
 // (e.g., 'aa' = 'AA' in utf8mb4_0900_ai_ci, or "ch" > "h" in utf8mb4_czech_ci), which can cause
 // events to be incorrectly discarded or buffered. However, checksum will fix any discrepancies.
 // Binary types use byte-order comparison matching Go, so they work correctly.
 // Note: Watermark optimizations are disabled before checksum phase (see runner.go).
 // See: https://github.com/block/spirit/issues/479
-func (t *chunkerComposite) KeyAboveHighWatermark(key0 any) bool {
+func (t *chunkerComposite) KeyAboveHighWatermark(key []any) bool {
 	t.Lock()
 	defer t.Unlock()
 
@@ -468,10 +499,17 @@ func (t *chunkerComposite) KeyAboveHighWatermark(key0 any) bool {
 		return false
 	}
 
+	// key is the PRIMARY KEY, so it can't be compared with the chunk
+	// pointers when the chunks are ranges of another index.
+	if !t.chunksOnPrimaryKey() || len(key) == 0 {
+		return false
+	}
+
 	// If we've sent the final chunk, nothing is above
 	if t.finalChunkSent {
 		return false
 	}
+	key0 := key[0]
 
 	// Convert key0 to Datum for comparison
 	var keyDatum Datum
@@ -507,8 +545,7 @@ func (t *chunkerComposite) KeyAboveHighWatermark(key0 any) bool {
 		}
 	}
 
-	// Check if key is above the current chunkPtr[0] using strict
-	// GreaterThan (see below for why; supports numeric, string, temporal).
+	// Check if key is above the current chunkPtrs (see below).
 	if len(t.chunkPtrs) == 0 {
 		// chunkPtrs not dispatched yet, key is above checkpointHighPtr.
 		// Same reasoning as the IsNil branch above: returning TRUE here
@@ -518,22 +555,39 @@ func (t *chunkerComposite) KeyAboveHighWatermark(key0 any) bool {
 		// SELECT's snapshot. Return FALSE so the change is buffered.
 		return false
 	}
-	// We only see key[0] here, but chunkPtrs is the full tuple upper bound
-	// of all dispatched chunks. Only a strictly greater key[0] guarantees
-	// the whole tuple sorts above every dispatched chunk: with chunkPtrs =
-	// (5, 100) the tuple (5, 50) is below the watermark even though key[0]
-	// == chunkPtrs[0], so equality is ambiguous and must buffer (return
-	// FALSE) per the contract. For single-column keys equality could safely
-	// be discarded instead, but buffering it is also safe (flush-time
-	// watermark logic handles it) and not worth a separate branch.
-	above, err := keyDatum.GreaterThan(t.chunkPtrs[0])
+	// chunkPtrs is the full tuple upper bound of all dispatched chunks, so
+	// the key is above the watermark only if it sorts strictly after it.
+	// With chunkPtrs = (5, 100), the key (5, 150) is above it, but (5, 50)
+	// is not. A key equal to chunkPtrs is buffered: only a strictly greater
+	// key is certain not to be in a dispatched chunk.
+	above, err := keyAboveTuple(key, t.chunkPtrs)
 	if err != nil {
-		t.logger.Error("comparing chunkPtrs[0] in KeyAboveHighWatermark", "error", err)
+		t.logger.Error("comparing chunkPtrs in KeyAboveHighWatermark", "error", err)
 		return false
 	}
 	return above
 }
 
+// keyAboveTuple returns true if key sorts strictly after tuple: the first
+// column in which they differ decides, as in a row constructor comparison.
+// A key that equals tuple in every column it has is not above it.
+func keyAboveTuple(key []any, tuple []Datum) (bool, error) {
+	for i := range min(len(key), len(tuple)) {
+		keyDatum, err := NewDatum(key[i], tuple[i].Tp)
+		if err != nil {
+			return false, err
+		}
+		c, err := keyDatum.compare(tuple[i])
+		if err != nil {
+			return false, err
+		}
+		if c != 0 {
+			return c > 0, nil
+		}
+	}
+	return false, nil
+}
+
 // KeyBelowLowWatermark checks if a key is below the low watermark.
 // This optimization works with comparable types in key[0] (first column): numeric, string, binary, temporal.
 // For VARCHAR/TEXT with collations, Go's byte-order comparison may differ from MySQL's collation order
@@ -565,6 +619,12 @@ func (t *chunkerComposite) KeyBelowLowWatermark(key0 any) bool {
 		return true
 	}
 
+	// As in KeyAboveHighWatermark, key0 can only be compared with the
+	// watermark when the chunks are ranges of the PRIMARY KEY.
+	if !t.chunksOnPrimaryKey() {
+		return false
+	}
+
 	// If watermark isn't ready yet, return false (nothing has been confirmed as copied yet)
 	if t.watermark == nil || t.watermark.UpperBound == nil || len(t.watermark.UpperBound.Value) == 0 {
 		return false
@@ -602,6 +662,12 @@ func (t *chunkerComposite) SetKey(keyName string, where string) error {
 	return t.resolveKey()
 }
 
+// chunksOnPrimaryKey returns true if the chunks are ranges of the PRIMARY
+// KEY, and not of an index set with SetKey or ChunkerConfig.Key.
+func (t *chunkerComposite) chunksOnPrimaryKey() bool {
+	return t.keyName == "" || strings.EqualFold(t.keyName, "PRIMARY")
+}
+
 // resolveKey resolves keyName to its index columns and merges in PK columns.
 func (t *chunkerComposite) resolveKey() error {
 	keyCols, err := t.Ti.DescIndex(t.keyName)
@@ -936,15 +936,15 @@ func TestCompositeChunkerWatermarkOptimizations(t *testing.T) {
 	// per the ambiguity contract on KeyAboveHighWatermark we must return
 	// FALSE so the binlog applier buffers the change rather than silently
 	// dropping it. See issue #746.
-	require.False(t, comp.KeyAboveHighWatermark(1))
-	require.False(t, comp.KeyAboveHighWatermark(100))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1}))
+	require.False(t, comp.KeyAboveHighWatermark([]any{100}))
 	require.False(t, comp.KeyBelowLowWatermark(1)) // watermark not ready
 
 	require.NoError(t, comp.Open())
 
 	// After Open() but before first Next(), still no chunks dispatched,
 	// so the IsNil branch keeps returning FALSE for the same reason.
-	require.False(t, comp.KeyAboveHighWatermark(1))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1}))
 	require.False(t, comp.KeyBelowLowWatermark(1))
 
 	// Get first chunk for tenant_id=1
@@ -969,14 +969,14 @@ func TestCompositeChunkerWatermarkOptimizations(t *testing.T) {
 	require.True(t, val1 >= 1 && val1 <= 3, "First chunk upper bound should be within data range")
 
 	// Key below the lowest 'a' value should not be above watermark
-	require.False(t, comp.KeyAboveHighWatermark(0))
+	require.False(t, comp.KeyAboveHighWatermark([]any{0}))
 
 	// The PK here is (a,b,c) — multi-column — so a key EQUAL to chunkPtr[0]
 	// is ambiguous: the full tuple (val1, b, c) may be below the dispatched
 	// upper bound. It must NOT be reported above the high watermark.
-	require.False(t, comp.KeyAboveHighWatermark(val1))
+	require.False(t, comp.KeyAboveHighWatermark([]any{val1}))
 	// Strictly above chunkPtr[0] is unambiguous: above the high watermark.
-	require.True(t, comp.KeyAboveHighWatermark(val1+1))
+	require.True(t, comp.KeyAboveHighWatermark([]any{val1 + 1}))
 
 	// Nothing is below low watermark yet (no feedback given)
 	require.False(t, comp.KeyBelowLowWatermark(1))
@@ -1029,8 +1029,8 @@ func TestCompositeChunkerWatermarkOptimizations(t *testing.T) {
 	}
 
 	// After final chunk is sent, everything should be below, nothing above
-	require.False(t, comp.KeyAboveHighWatermark(1))
-	require.False(t, comp.KeyAboveHighWatermark(100))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1}))
+	require.False(t, comp.KeyAboveHighWatermark([]any{100}))
 	require.True(t, comp.KeyBelowLowWatermark(1))
 	require.True(t, comp.KeyBelowLowWatermark(100))
 
@@ -1196,13 +1196,21 @@ func TestCompositeChunkerKeyAboveHighWatermarkMultiColumn(t *testing.T) {
 	// The bug: 5 >= 5 returned TRUE, so events for tuples like (5, 50) —
 	// below the dispatched bound (5, 101) — were permanently discarded.
 	// Equal first column is ambiguous; the event must be buffered.
-	require.False(t, comp.KeyAboveHighWatermark(5))
+	require.False(t, comp.KeyAboveHighWatermark([]any{5}))
 	// Strictly above on the first column is unambiguous: every tuple
 	// (6, x) sorts above (5, 101). Safe to discard.
-	require.True(t, comp.KeyAboveHighWatermark(6))
-	require.True(t, comp.KeyAboveHighWatermark(9))
+	require.True(t, comp.KeyAboveHighWatermark([]any{6}))
+	require.True(t, comp.KeyAboveHighWatermark([]any{9}))
 	// Strictly below remains below.
-	require.False(t, comp.KeyAboveHighWatermark(4))
+	require.False(t, comp.KeyAboveHighWatermark([]any{4}))
+
+	// Given the full key, the tuples are compared: (5, 50) is below the
+	// bound, (5, 101) is the bound itself, and (5, 150) is above it.
+	require.False(t, comp.KeyAboveHighWatermark([]any{5, 50}))
+	require.False(t, comp.KeyAboveHighWatermark([]any{5, 101}))
+	require.True(t, comp.KeyAboveHighWatermark([]any{5, 150}))
+	require.True(t, comp.KeyAboveHighWatermark([]any{6, 1}))
+	require.False(t, comp.KeyAboveHighWatermark([]any{4, 500}))
 
 	require.NoError(t, comp.Close())
 }
@@ -1263,9 +1271,9 @@ func TestCompositeChunkerKeyAboveHighWatermarkSingleColumn(t *testing.T) {
 	// The boundary value equals the exclusive upper bound. Under strict
 	// greater-than it is not above the watermark, so the event is buffered
 	// rather than discarded (safe; the row is re-read by the next chunk).
-	require.False(t, comp.KeyAboveHighWatermark(1001))
-	require.True(t, comp.KeyAboveHighWatermark(1002))
-	require.False(t, comp.KeyAboveHighWatermark(1000))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1001}))
+	require.True(t, comp.KeyAboveHighWatermark([]any{1002}))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1000}))
 
 	require.NoError(t, comp.Close())
 }
@@ -1334,8 +1342,8 @@ func TestCompositeChunkerWatermarkNonNumeric(t *testing.T) {
 	// For VARCHAR keys, the optimization should work (not fall back to conservative)
 	// Test with a key that's clearly above the first chunk's upper bound
 	upperVal := chunk1.UpperBound.Value[0].Val.(string)
-	require.False(t, comp.KeyAboveHighWatermark("key00001")) // Below or equal to upper bound
-	require.True(t, comp.KeyAboveHighWatermark("zzzzzzzzz")) // Above upper bound
+	require.False(t, comp.KeyAboveHighWatermark([]any{"key00001"})) // Below or equal to upper bound
+	require.True(t, comp.KeyAboveHighWatermark([]any{"zzzzzzzzz"})) // Above upper bound
 
 	// KeyBelowLowWatermark should work with VARCHAR comparison
 	comp.Feedback(chunk1, 100*time.Millisecond, 100)
@@ -1413,8 +1421,8 @@ func TestCompositeChunkerWatermarkDateTime(t *testing.T) {
 	// For DATETIME keys, the optimization should work
 	// Test with timestamps that are clearly above/below the first chunk's upper bound
 	upperVal := chunk1.UpperBound.Value[0].Val.(string)
-	require.False(t, comp.KeyAboveHighWatermark("2024-01-01 00:00:00")) // Below upper bound
-	require.True(t, comp.KeyAboveHighWatermark("2025-12-31 23:59:59"))  // Above upper bound
+	require.False(t, comp.KeyAboveHighWatermark([]any{"2024-01-01 00:00:00"})) // Below upper bound
+	require.True(t, comp.KeyAboveHighWatermark([]any{"2025-12-31 23:59:59"}))  // Above upper bound
 
 	// KeyBelowLowWatermark should work with DATETIME comparison
 	comp.Feedback(chunk1, 100*time.Millisecond, 100)
@@ -1747,7 +1755,7 @@ func TestCompositeChunkerCheckpointHighPtr(t *testing.T) {
 	// Before OpenAtWatermark: no chunks dispatched and no checkpoint
 	// loaded yet, so the IsNil-both branch returns FALSE per the ambiguity
 	// contract (events buffered, not dropped). See issue #746.
-	require.False(t, comp.KeyAboveHighWatermark(1))
+	require.False(t, comp.KeyAboveHighWatermark([]any{1}))
 
 	// Simulate a watermark at a=200 — the copier had reached this point before interruption.
 	watermark := `{"ChunkJSON":"{\"Key\":[\"a\",\"b\"],\"ChunkSize\":1000,\"LowerBound\":{\"Value\":[\"100\",\"1\"],\"Inclusive\":true},\"UpperBound\":{\"Value\":[\"200\",\"1\"],\"Inclusive\":false}}","RowsCopied":200}`
@@ -1758,21 +1766,21 @@ func TestCompositeChunkerCheckpointHighPtr(t *testing.T) {
 	require.False(t, comp.checkpointHighPtr.IsNil(), "checkpointHighPtr should be set after OpenAtWatermark")
 
 	// Key a=150 is below the watermark — should NOT be above high watermark.
-	require.False(t, comp.KeyAboveHighWatermark(150))
+	require.False(t, comp.KeyAboveHighWatermark([]any{150}))
 
 	// Key a=300 is above the watermark but below checkpointHighPtr (~500).
 	// This key may have been copied before the interruption.
 	// It should NOT be considered "above high watermark" — we must not discard events for it.
-	require.False(t, comp.KeyAboveHighWatermark(300))
+	require.False(t, comp.KeyAboveHighWatermark([]any{300}))
 
 	// Key a=499 is at the max of the destination — should NOT be above.
-	require.False(t, comp.KeyAboveHighWatermark(499))
+	require.False(t, comp.KeyAboveHighWatermark([]any{499}))
 
 	// Key a=501 is above checkpointHighPtr — safe to discard.
-	require.True(t, comp.KeyAboveHighWatermark(501))
+	require.True(t, comp.KeyAboveHighWatermark([]any{501}))
 
 	// Key a=999 is well above — safe to discard.
-	require.True(t, comp.KeyAboveHighWatermark(999))
+	require.True(t, comp.KeyAboveHighWatermark([]any{999}))
 
 	require.NoError(t, comp.Close())
 }
@@ -1880,3 +1888,52 @@ func TestCompositeChunkerReservedWordTableName(t *testing.T) {
 	}
 	require.NoError(t, chunker.Close())
 }
+
+func TestCompositeChunkerWatermarkOptimizationsSecondaryKey(t *testing.T) {
+	// The replication client passes the first column of the PRIMARY KEY,
+	// which can't be compared with chunk pointers on another index.
+	newChunker := func(keyName string) *chunkerComposite {
+		chunker := &chunkerComposite{
+			keyName:           keyName,
+			chunkPtrs:         []Datum{{Val: int64(100), Tp: signedType}},
+			dynamicChunkSizer: dynamicChunkSizer{ChunkerTarget: ChunkerDefaultTarget},
+			watermarkTracker:  watermarkTracker{lowerBoundWatermarkMap: make(map[string]*Chunk)},
+			logger:            slog.Default(),
+		}
+		chunker.watermark = &Chunk{
+			Key:        []string{"a"},
+			LowerBound: &Boundary{Value: []Datum{{Val: int64(1), Tp: signedType}}, Inclusive: true},
+			UpperBound: &Boundary{Value: []Datum{{Val: int64(50), Tp: signedType}}},
+		}
+		return chunker
+	}
+	chunker := newChunker("PRIMARY")
+	require.True(t, chunker.KeyAboveHighWatermark([]any{int64(200)}))
+	require.True(t, chunker.KeyBelowLowWatermark(int64(10)))
+
+	chunker = newChunker("uk_tenant_external")
+	require.False(t, chunker.KeyAboveHighWatermark([]any{int64(200)}))
+	require.False(t, chunker.KeyBelowLowWatermark(int64(10)))
+}
+
+func TestKeyAboveTuple(t *testing.T) {
+	tuple := []Datum{{Val: int64(5), Tp: signedType}, {Val: "m", Tp: binaryType}}
+	for _, tc := range []struct {
+		key  []any
+		want bool
+	}{
+		{[]any{6, "a"}, true},
+		{[]any{4, "z"}, false},
+		{[]any{5, "n"}, true},
+		{[]any{5, "l"}, false},
+		{[]any{5, "m"}, false}, // equal is not above
+		{[]any{5}, false},      // a shorter key is only compared on its columns
+		{[]any{6}, true},
+	} {
+		above, err := keyAboveTuple(tc.key, tuple)
+		require.NoError(t, err)
+		require.Equal(t, tc.want, above, "%v", tc.key)
+	}
+	_, err := keyAboveTuple([]any{"x"}, tuple)
+	require.Error(t, err)
+}
@@ -42,7 +42,10 @@ type FeedbackCall struct {
 	Timestamp  time.Time
 }
 
-var _ MappedChunker = &MockChunker{}
+var (
+	_ MappedChunker = &MockChunker{}
+	_ ChunkSizer    = &MockChunker{}
+)
 
 // NewMockChunker creates a new mock chunker for testing
 func NewMockChunker(tableName string, totalRows uint64) *MockChunker {
@@ -91,6 +94,18 @@ func (m *MockChunker) SetChunkSize(size uint64) {
 	m.chunkSize = size
 }
 
+// ChunkSize returns the size set by SetChunkSize.
+func (m *MockChunker) ChunkSize() uint64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.chunkSize
+}
+
+// PanicShrinks always returns 0: the mock does not resize chunks.
+func (m *MockChunker) PanicShrinks() uint64 {
+	return 0
+}
+
 // Test helper methods
 func (m *MockChunker) SimulateProgress(percentage float64) {
 	m.mu.Lock()
@@ -244,12 +259,17 @@ func (m *MockChunker) Feedback(chunk *Chunk, duration time.Duration, actualRows
 	})
 }
 
-// KeyAboveHighWatermark returns true if the given key is above the current watermark
+// KeyAboveHighWatermark returns true if the first column of the given key is above the current watermark
 // It returns FALSE in cases that are difficult to determine (e.g. non-numeric keys)
-func (m *MockChunker) KeyAboveHighWatermark(key any) bool {
+func (m *MockChunker) KeyAboveHighWatermark(keys []any) bool {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
+	if len(keys) == 0 {
+		return false
+	}
+	key := keys[0]
+
 	// Convert key to uint64 for comparison
 	var keyPos uint64
 	switch v := key.(type) {
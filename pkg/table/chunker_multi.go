@@ -18,7 +18,10 @@ type multiChunker struct {
 	isOpen   bool
 }
 
-var _ Chunker = &multiChunker{}
+var (
+	_ Chunker    = &multiChunker{}
+	_ ChunkSizer = &multiChunker{}
+)
 
 // NewMultiChunker creates a new multi-chunker that wraps multiple chunkers
 func NewMultiChunker(c ...Chunker) Chunker {
@@ -200,6 +203,35 @@ func (m *multiChunker) Progress() (uint64, uint64, uint64) {
 	return totalRowsCopied, totalChunksCopied, totalRowsExpected
 }
 
+// ChunkSize returns the largest chunk size of the wrapped chunkers that
+// size their chunks dynamically, since each table is sized on its own.
+func (m *multiChunker) ChunkSize() uint64 {
+	m.Lock()
+	defer m.Unlock()
+
+	var size uint64
+	for _, chunker := range m.chunkers {
+		if sizer, ok := chunker.(ChunkSizer); ok {
+			size = max(size, sizer.ChunkSize())
+		}
+	}
+	return size
+}
+
+// PanicShrinks returns the total panic shrinks of the wrapped chunkers.
+func (m *multiChunker) PanicShrinks() uint64 {
+	m.Lock()
+	defer m.Unlock()
+
+	var total uint64
+	for _, chunker := range m.chunkers {
+		if sizer, ok := chunker.(ChunkSizer); ok {
+			total += sizer.PanicShrinks()
+		}
+	}
+	return total
+}
+
 // TableProgress contains progress information for a single table
 type TableProgress struct {
 	TableName  string
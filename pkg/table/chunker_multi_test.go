@@ -177,6 +177,19 @@ func TestMultiChunkerProgress(t *testing.T) {
 	require.Equal(t, uint64(3000), totalRows)  // 1000 + 2000
 }
 
+// TestMultiChunkerChunkSize checks that the multi-chunker reports the
+// largest chunk size of the tables it wraps, and their total panic shrinks.
+func TestMultiChunkerChunkSize(t *testing.T) {
+	mock1 := NewMockChunker("table1", 1000)
+	mock2 := NewMockChunker("table2", 2000)
+	chunker := NewMultiChunker(mock1, mock2).(*multiChunker)
+
+	mock1.SetChunkSize(250)
+	mock2.SetChunkSize(4000)
+	require.Equal(t, uint64(4000), chunker.ChunkSize())
+	require.Zero(t, chunker.PanicShrinks())
+}
+
 func TestMultiChunkerFeedbackRouting(t *testing.T) {
 	mock1 := NewMockChunker("table1", 1000)
 	mock2 := NewMockChunker("table2", 2000)
@@ -15,8 +15,9 @@ type chunkerOptimistic struct {
 	sync.Mutex
 	// dynamicChunkSizer owns chunkSize / chunkTimingInfo / ChunkerTarget /
 	// disableDynamicChunker. watermarkTracker owns watermark /
-	// lowerBoundWatermarkMap / checkpointHighPtr. Embedded so existing
-	// call sites can keep reading these as t.chunkSize, t.watermark, etc.
+	// lowerBoundWatermarkMap / checkpointHighPtr / descending. Embedded so
+	// existing call sites can keep reading these as t.chunkSize,
+	// t.watermark, etc.
 	dynamicChunkSizer
 	watermarkTracker
 
@@ -40,7 +41,10 @@ type chunkerOptimistic struct {
 	logger *slog.Logger
 }
 
-var _ MappedChunker = &chunkerOptimistic{}
+var (
+	_ MappedChunker = &chunkerOptimistic{}
+	_ ChunkSizer    = &chunkerOptimistic{}
+)
 
 // nextChunkByPrefetching uses prefetching instead of feedback to determine the chunk size.
 // It is used when the chunker detects that there are very large gaps in the sequence.
@@ -48,8 +52,12 @@ var _ MappedChunker = &chunkerOptimistic{}
 // t.chunkSize is reliable. It is also expanded again based on feedback.
 func (t *chunkerOptimistic) nextChunkByPrefetching() (*Chunk, error) {
 	key := QuoteColumns(t.Ti.KeyColumns[:1])
-	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s > ? ORDER BY %s LIMIT 1 OFFSET %d",
-		key, t.Ti.QuotedTableName, key, key, t.chunkSize,
+	operator, order := OpGreaterThan, ""
+	if t.descending {
+		operator, order = OpLessThan, " DESC"
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE %s %s ? ORDER BY %s%s LIMIT 1 OFFSET %d",
+		key, t.Ti.QuotedTableName, key, operator, key, order, t.chunkSize,
 	)
 	//nolint: noctx // too much refactoring to add context here
 	rows, err := t.Ti.db.Query(query, t.chunkPtr.String())
@@ -73,6 +81,9 @@ func (t *chunkerOptimistic) nextChunkByPrefetching() (*Chunk, error) {
 			return nil, fmt.Errorf("failed to create datum from upperVal: %w", err)
 		}
 		t.chunkPtr = maxVal
+		if t.descending {
+			minVal, maxVal = maxVal, minVal // the key found is below the chunkPtr
+		}
 
 		// If the difference between min and max is less than
 		// MaxDynamicRowSize we can turn off prefetching. Range may error
@@ -83,7 +94,7 @@ func (t *chunkerOptimistic) nextChunkByPrefetching() (*Chunk, error) {
 				"min-val", minVal,
 				"max-val", maxVal,
 				"max-dynamic-row-size", MaxDynamicRowSize)
-			t.chunkSize = StartingChunkSize // reset
+			t.chunkSize = t.initialChunkSize() // reset
 			t.chunkPrefetchingEnabled = false
 		}
 
@@ -105,6 +116,17 @@ func (t *chunkerOptimistic) nextChunkByPrefetching() (*Chunk, error) {
 	// If there were no rows, it means we are indeed
 	// on the final chunk.
 	t.finalChunkSent = true
+	if t.descending {
+		return &Chunk{
+			ChunkSize:  t.chunkSize,
+			Key:        t.Ti.KeyColumns,
+			UpperBound: &Boundary{[]Datum{t.chunkPtr}, false},
+			Table:      t.Ti,
+			NewTable:   t.NewTi,
+
+			ColumnMapping: t.columnMapping,
+		}, nil
+	}
 	return &Chunk{
 		ChunkSize:  t.chunkSize,
 		Key:        t.Ti.KeyColumns,
@@ -138,6 +160,9 @@ func (t *chunkerOptimistic) next() (*Chunk, error) {
 	if !t.isOpen {
 		return nil, ErrTableNotOpen
 	}
+	if t.descending {
+		return t.nextDescending()
+	}
 
 	// If there is a minimum value, we attempt to apply
 	// the minimum value optimization.
@@ -215,6 +240,62 @@ func (t *chunkerOptimistic) next() (*Chunk, error) {
 	}, nil
 }
 
+// nextDescending is next() for a chunker that walks the key downward: the
+// first chunk is open above the maximum value, each chunk then ends where the
+// previous one started, and the last is open below the minimum value. Rows
+// inserted during the copy land in the first chunk, which has already been
+// dispatched, so the statistics are not refreshed near the end as they are
+// in ascending order. Caller must hold t.Mutex.
+func (t *chunkerOptimistic) nextDescending() (*Chunk, error) {
+	if t.chunkPtr.IsNil() {
+		t.chunkPtr = t.Ti.MaxValue()
+		return &Chunk{
+			ChunkSize:  t.chunkSize,
+			Key:        t.Ti.KeyColumns,
+			LowerBound: &Boundary{[]Datum{t.chunkPtr}, true},
+			Table:      t.Ti,
+			NewTable:   t.NewTi,
+
+			ColumnMapping: t.columnMapping,
+		}, nil
+	}
+	if t.chunkPrefetchingEnabled {
+		return t.nextChunkByPrefetching()
+	}
+	atOrPastMin, err := t.chunkPtr.LessThanOrEqual(t.Ti.MinValue())
+	if err != nil {
+		return nil, fmt.Errorf("comparing chunkPtr to minValue: %w", err)
+	}
+	if atOrPastMin {
+		t.finalChunkSent = true
+		return &Chunk{
+			ChunkSize:  t.chunkSize,
+			Key:        t.Ti.KeyColumns,
+			UpperBound: &Boundary{[]Datum{t.chunkPtr}, false},
+			Table:      t.Ti,
+			NewTable:   t.NewTi,
+
+			ColumnMapping: t.columnMapping,
+		}, nil
+	}
+	maxVal := t.chunkPtr
+	minVal, err := t.chunkPtr.Sub(t.chunkSize)
+	if err != nil {
+		return nil, fmt.Errorf("advancing chunkPtr: %w", err)
+	}
+	t.chunkPtr = minVal
+	return &Chunk{
+		ChunkSize:  t.chunkSize,
+		Key:        t.Ti.KeyColumns,
+		LowerBound: &Boundary{[]Datum{minVal}, true},
+		UpperBound: &Boundary{[]Datum{maxVal}, false},
+		Table:      t.Ti,
+		NewTable:   t.NewTi,
+
+		ColumnMapping: t.columnMapping,
+	}, nil
+}
+
 // Open opens a table to be used by NextChunk(). See also OpenAtWatermark()
 // to resume from a specific point.
 func (t *chunkerOptimistic) Open() (err error) {
@@ -275,8 +356,15 @@ func (t *chunkerOptimistic) OpenAtWatermark(cp string) error {
 	// below — so a row that no longer exists on the source is removed from
 	// the target rather than resurrected, and a discarded DELETE for it is a
 	// no-op.
+	//
+	// In descending order the rows copied before the resume may extend below
+	// the watermark instead, so it is the lowest value in the new table.
 	if t.NewTi != nil {
-		checkpointHighPtr, err := NewDatum(t.NewTi.MaxValue().Val, t.Ti.MaxValue().Tp)
+		newTableEnd := t.NewTi.MaxValue()
+		if t.descending {
+			newTableEnd = t.NewTi.MinValue()
+		}
+		checkpointHighPtr, err := NewDatum(newTableEnd.Val, t.Ti.MaxValue().Tp)
 		if err != nil {
 			return fmt.Errorf("failed to create checkpointHighPtr: %w", err)
 		}
@@ -289,9 +377,13 @@ func (t *chunkerOptimistic) OpenAtWatermark(cp string) error {
 	// We can restore from chunk.UpperBound, but because it is a < operator,
 	// There might be an annoying off by 1 error. So let's just restore
 	// from the chunk.LowerBound. Because this chunker only support single-column
-	// keys, it uses Value[0].
+	// keys, it uses Value[0]. In descending order the chunk is re-copied
+	// from its UpperBound down.
 	t.watermark = chunk
 	t.chunkPtr = chunk.LowerBound.Value[0]
+	if t.descending {
+		t.chunkPtr = chunk.UpperBound.Value[0]
+	}
 
 	// For the optimistic chunker, we also calculate progress (i.e. rowsCopied)
 	// based on the progress of copying the auto_increment key, so we don't have
@@ -307,6 +399,15 @@ func (t *chunkerOptimistic) OpenAtWatermark(cp string) error {
 	if err != nil {
 		return fmt.Errorf("failed to parse chunkPtr to uint64: %w", err)
 	}
+	// In descending order it is the distance travelled down from MaxValue,
+	// or zero if that can't be computed.
+	if t.descending {
+		t.rowsCopied = 0
+		if maxVal, maxErr := strconv.ParseUint(t.Ti.MaxValue().String(), 10, 64); maxErr == nil && maxVal >= ptrVal {
+			t.rowsCopied = maxVal - ptrVal
+		}
+		return nil
+	}
 	// MinValue() may not be a non-negative integer we can subtract (e.g. a
 	// signed key holding negative values). In that case fall back to the
 	// absolute pointer rather than failing the resume.
@@ -335,7 +436,7 @@ func (t *chunkerOptimistic) Reset() error {
 	t.chunkPtr = NewNilDatum(t.Ti.keyDatums[0])
 	t.checkpointHighPtr = NewNilDatum(t.Ti.keyDatums[0]) // reset checkpoint high pointer
 	t.finalChunkSent = false
-	t.chunkSize = StartingChunkSize
+	t.chunkSize = t.initialChunkSize()
 	t.watermark = nil
 	t.lowerBoundWatermarkMap = make(map[string]*Chunk, 0)
 	t.inflightChunks = 0
@@ -374,7 +475,7 @@ func (t *chunkerOptimistic) Feedback(chunk *Chunk, d time.Duration, _ uint64) {
 	// Check if the feedback is based on an earlier chunker size.
 	// if it is, it is misleading to incorporate feedback now.
 	// We should just skip it. We also skip if dynamic chunking is disabled.
-	if chunk.ChunkSize != t.chunkSize || t.disableDynamicChunker {
+	if chunk.ChunkSize != t.chunkSize || !t.isDynamic() {
 		return
 	}
 
@@ -433,7 +534,7 @@ func (t *chunkerOptimistic) maybeSwitchToPrefetchBytes(newTarget uint64, p90Byte
 // the mutex.
 func (t *chunkerOptimistic) switchToPrefetch() {
 	t.logger.Warn("switching to prefetch algorithm")
-	t.chunkSize = StartingChunkSize // reset
+	t.chunkSize = t.initialChunkSize() // reset
 	t.chunkPrefetchingEnabled = true
 }
 
@@ -468,7 +569,7 @@ func (t *chunkerOptimistic) open() (err error) {
 	t.isOpen = true
 	t.chunkPtr = NewNilDatum(t.Ti.keyDatums[0])
 	t.finalChunkSent = false
-	t.chunkSize = StartingChunkSize
+	t.chunkSize = t.initialChunkSize()
 	t.inflightChunks = 0
 
 	// Initialize progress tracking
@@ -486,6 +587,21 @@ func (t *chunkerOptimistic) IsRead() bool {
 	return t.finalChunkSent
 }
 
+// ChunkSize returns the number of rows the next chunk is sized for.
+func (t *chunkerOptimistic) ChunkSize() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.chunkSize
+}
+
+// PanicShrinks returns the number of chunks whose feedback overshot the
+// target by more than DynamicPanicFactor.
+func (t *chunkerOptimistic) PanicShrinks() uint64 {
+	t.Lock()
+	defer t.Unlock()
+	return t.panicShrinks
+}
+
 // Progress returns the current progress of the chunker as (rowsCopied, totalRows)
 // It is up to the chunker implementation to select the formula. The optimistic
 // chunker is based on the progress of the auto_increment column.
@@ -500,10 +616,9 @@ func (t *chunkerOptimistic) Progress() (uint64, uint64, uint64) {
 // KeyAboveHighWatermark returns true if the key is above the high watermark.
 // TRUE means that the row will be discarded so if there is any ambiguity,
 // it's important to return FALSE.
-// The Key in this context is really key[0], but the optimistic
-// chunker only supports single-column primary keys that are auto-increment,
-// so it is a safe assumption.
-func (t *chunkerOptimistic) KeyAboveHighWatermark(key0 any) bool {
+// Only key[0] is compared, since the optimistic chunker only supports
+// single-column primary keys that are auto-increment.
+func (t *chunkerOptimistic) KeyAboveHighWatermark(key []any) bool {
 	t.Lock()
 	defer t.Unlock()
 	if t.chunkPtr.IsNil() && t.checkpointHighPtr.IsNil() {
@@ -531,6 +646,10 @@ func (t *chunkerOptimistic) KeyAboveHighWatermark(key0 any) bool {
 	if t.finalChunkSent {
 		return false // we're done, so everything is below.
 	}
+	if len(key) == 0 {
+		return false
+	}
+	key0 := key[0]
 	keyDatum, err := NewDatum(key0, t.chunkPtr.Tp)
 	if err != nil {
 		// If we can't convert the key, return false to be safe (don't discard the row)
@@ -544,6 +663,9 @@ func (t *chunkerOptimistic) KeyAboveHighWatermark(key0 any) bool {
 	// row issue.
 	if !t.checkpointHighPtr.IsNil() {
 		atOrAbove, err := t.checkpointHighPtr.GreaterThanOrEqual(keyDatum)
+		if t.descending {
+			atOrAbove, err = keyDatum.GreaterThanOrEqual(t.checkpointHighPtr)
+		}
 		if err != nil {
 			t.logger.Error("comparing checkpointHighPtr in KeyAboveHighWatermark", "error", err)
 			return false
@@ -552,8 +674,12 @@ func (t *chunkerOptimistic) KeyAboveHighWatermark(key0 any) bool {
 			return false
 		}
 	}
-	// Finally we check the chunkPtr.
+	// Finally we check the chunkPtr. In descending order the keys the
+	// chunker has yet to reach are those below it.
 	above, err := keyDatum.GreaterThanOrEqual(t.chunkPtr)
+	if t.descending {
+		above, err = keyDatum.LessThan(t.chunkPtr)
+	}
 	if err != nil {
 		t.logger.Error("comparing chunkPtr in KeyAboveHighWatermark", "error", err)
 		return false
@@ -591,20 +717,24 @@ func (t *chunkerOptimistic) KeyBelowLowWatermark(key0 any) bool {
 	}
 
 	// We are in the regular state, so we can compare the watermark's
-	// upperBound to the key to decide what to return.
+	// upperBound to the key to decide what to return. In descending
+	// order everything at or above its lowerBound has been copied.
 	keyDatum, err := NewDatum(key0, t.chunkPtr.Tp)
 	if err != nil {
 		// If we can't convert the key, return false to be safe (buffer the change, don't flush)
 		t.logger.Error("failed to create keyDatum in KeyBelowLowWatermark", "key", key0, "error", err)
 		return false
 	}
-	watermarkDatum, err := NewDatum(t.watermark.UpperBound.Value[0].Val, t.chunkPtr.Tp)
+	watermarkDatum, err := NewDatum(t.endBound(t.watermark).Value[0].Val, t.chunkPtr.Tp)
 	if err != nil {
 		// If we can't convert the watermark, return false to be safe (buffer the change, don't flush)
 		t.logger.Error("failed to create watermarkDatum in KeyBelowLowWatermark", "error", err)
 		return false
 	}
 	below, err := watermarkDatum.GreaterThan(keyDatum)
+	if t.descending {
+		below, err = keyDatum.GreaterThanOrEqual(watermarkDatum)
+	}
 	if err != nil {
 		t.logger.Error("comparing watermark in KeyBelowLowWatermark", "error", err)
 		return false
@@ -50,17 +50,17 @@ func TestOptimisticChunkerBasic(t *testing.T) {
 	// it's important to return FALSE" — this returns FALSE so the binlog
 	// applier buffers the change rather than silently dropping it. See
 	// issue #746.
-	require.False(t, chunker.KeyAboveHighWatermark(1))
+	require.False(t, chunker.KeyAboveHighWatermark([]any{1}))
 
 	_, err := chunker.Next()
 	require.NoError(t, err)
 
-	require.True(t, chunker.KeyAboveHighWatermark(100)) // we are at 1
+	require.True(t, chunker.KeyAboveHighWatermark([]any{100})) // we are at 1
 
 	_, err = chunker.Next()
 	require.NoError(t, err)
 
-	require.False(t, chunker.KeyAboveHighWatermark(100)) // we are at 1001
+	require.False(t, chunker.KeyAboveHighWatermark([]any{100})) // we are at 1001
 
 	for range 999 {
 		_, err = chunker.Next()
@@ -121,8 +121,8 @@ func TestLowWatermark(t *testing.T) {
 	require.JSONEq(t, "{\"Key\":[\"id\"],\"ChunkSize\":1000,\"LowerBound\":{\"Value\": [\"1\"],\"Inclusive\":true},\"UpperBound\":{\"Value\": [\"1001\"],\"Inclusive\":false}}", watermark)
 
 	// Check key w.r.t. watermark
-	require.False(t, chunker.KeyAboveHighWatermark(1000))
-	require.True(t, chunker.KeyAboveHighWatermark(1001))
+	require.False(t, chunker.KeyAboveHighWatermark([]any{1000}))
+	require.True(t, chunker.KeyAboveHighWatermark([]any{1001}))
 	require.True(t, chunker.KeyBelowLowWatermark(1000)) // 1000 is done, so this is below.
 	require.False(t, chunker.KeyBelowLowWatermark(1001))
 
@@ -360,6 +360,49 @@ func TestOptimisticDynamicChunking(t *testing.T) {
 	require.Equal(t, "584", chunk.LowerBound.Value[0].String())
 }
 
+// TestOptimisticFixedChunkSize checks that with a FixedChunkSize every chunk
+// is that size, however fast or slow the feedback says it was.
+func TestOptimisticFixedChunkSize(t *testing.T) {
+	t1 := newTableInfo4Test("test", "t1")
+	t1.minValue = Datum{Val: int64(1), Tp: signedType}
+	t1.maxValue = Datum{Val: int64(1000000), Tp: signedType}
+	t1.EstimatedRows = 1000000
+	t1.KeyColumns = []string{"id"}
+	t1.keyColumnsMySQLTp = []string{"bigint"}
+	t1.keyDatums = []datumTp{signedType}
+	t1.KeyIsAutoInc = true
+	t1.Columns = []string{"id", "name"}
+	t1.columnsMySQLTps = map[string]string{"id": "bigint"}
+
+	chunker, err := NewChunker(t1, ChunkerConfig{TargetChunkTime: 100 * time.Millisecond, FixedChunkSize: 250})
+	require.NoError(t, err)
+	require.NoError(t, chunker.Open())
+
+	// The first chunk is open-ended below the minimum, so check from the second.
+	chunk, err := chunker.Next()
+	require.NoError(t, err)
+	chunker.Feedback(chunk, time.Second, 1)
+	for i := range 100 {
+		chunk, err = chunker.Next()
+		require.NoError(t, err)
+		require.Equal(t, uint64(250), chunk.ChunkSize)
+		lower := chunk.LowerBound.Value[0].Val.(int64)
+		upper := chunk.UpperBound.Value[0].Val.(int64)
+		require.Equal(t, int64(250), upper-lower)
+		// Alternate between far too slow and far too fast.
+		if i%2 == 0 {
+			chunker.Feedback(chunk, 10*time.Second, 1)
+		} else {
+			chunker.Feedback(chunk, time.Microsecond, 1)
+		}
+	}
+
+	require.NoError(t, chunker.Reset())
+	chunk, err = chunker.Next()
+	require.NoError(t, err)
+	require.Equal(t, uint64(250), chunk.ChunkSize)
+}
+
 // TestOptimisticResumeProgressAccounting is a regression test for block/spirit#950:
 // on resume, rowsCopied was seeded from the absolute chunk pointer rather than
 // its distance from MinValue. For tables whose low keys have been purged
@@ -564,8 +607,8 @@ func TestOptimisticChunkerReset(t *testing.T) {
 	// Verify KeyAboveHighWatermark behavior is reset
 	// In the previous copy we had Next()'ed up to id=2000
 	// Here we have only up to 1001.
-	require.True(t, chunker.KeyAboveHighWatermark(1500), "KeyAboveHighWatermark not reset correctly")
-	require.False(t, chunker.KeyAboveHighWatermark(900), "KeyAboveHighWatermark not reset correctly")
+	require.True(t, chunker.KeyAboveHighWatermark([]any{1500}), "KeyAboveHighWatermark not reset correctly")
+	require.False(t, chunker.KeyAboveHighWatermark([]any{900}), "KeyAboveHighWatermark not reset correctly")
 
 	resetChunk3, err := chunker.Next()
 	require.NoError(t, err)
@@ -678,3 +721,125 @@ func TestOptimisticChunkerReservedWordTableName(t *testing.T) {
 
 	require.NoError(t, opt.Close())
 }
+
+// TestOptimisticDescending checks that a Descending chunker emits chunks from
+// the maximum value down, and that its watermark and resume point follow.
+func TestOptimisticDescending(t *testing.T) {
+	t1 := newTableInfo4Test("test", "t1")
+	t1.minValue = Datum{Val: int64(1), Tp: signedType}
+	t1.maxValue = Datum{Val: int64(1000), Tp: signedType}
+	t1.EstimatedRows = 1000
+	t1.KeyColumns = []string{"id"}
+	t1.keyColumnsMySQLTp = []string{"bigint"}
+	t1.keyDatums = []datumTp{signedType}
+	t1.KeyIsAutoInc = true
+	t1.Columns = []string{"id", "name"}
+	t1.columnsMySQLTps = map[string]string{"id": "bigint"}
+
+	chunker, err := NewChunker(t1, ChunkerConfig{NewTable: t1, FixedChunkSize: 250, Descending: true})
+	require.NoError(t, err)
+	require.NoError(t, chunker.Open())
+
+	var chunks []*Chunk
+	for {
+		chunk, err := chunker.Next()
+		if err != nil {
+			require.ErrorIs(t, err, ErrTableIsRead)
+			break
+		}
+		chunks = append(chunks, chunk)
+	}
+	var got []string
+	for _, chunk := range chunks {
+		got = append(got, chunk.String())
+	}
+	require.Equal(t, []string{
+		"`id` >= 1000",
+		"`id` >= 750 AND `id` < 1000",
+		"`id` >= 500 AND `id` < 750",
+		"`id` >= 250 AND `id` < 500",
+		"`id` >= 0 AND `id` < 250",
+		"`id` < 0",
+	}, got)
+
+	// Chunks completing out of order only move the watermark down once the
+	// chunks above them are done.
+	chunker.Feedback(chunks[0], time.Second, 1)
+	chunker.Feedback(chunks[2], time.Second, 1)
+	watermark, err := chunker.GetLowWatermark()
+	require.ErrorIs(t, err, ErrWatermarkNotReady)
+	require.Empty(t, watermark)
+	chunker.Feedback(chunks[1], time.Second, 1)
+	watermark, err = chunker.GetLowWatermark()
+	require.NoError(t, err)
+	require.JSONEq(t, `{"Key":["id"],"ChunkSize":250,"LowerBound":{"Value":["500"],"Inclusive":true},"UpperBound":{"Value":["750"],"Inclusive":false}}`, watermark)
+	require.True(t, chunker.KeyBelowLowWatermark(500), "copied: at or above the watermark")
+	require.False(t, chunker.KeyBelowLowWatermark(499))
+
+	// A chunker opened at the watermark re-copies the watermark chunk and
+	// continues downward. The new table already holds rows down to 300,
+	// copied before the resume, so changes to those keys are kept.
+	t2 := newTableInfo4Test("test", "_t1_new")
+	t2.minValue = Datum{Val: int64(300), Tp: signedType}
+	t2.maxValue = Datum{Val: int64(1000), Tp: signedType}
+	chunker2, err := NewChunker(t1, ChunkerConfig{NewTable: t2, FixedChunkSize: 250, Descending: true})
+	require.NoError(t, err)
+	require.NoError(t, chunker2.OpenAtWatermark(watermark))
+	chunk, err := chunker2.Next()
+	require.NoError(t, err)
+	require.Equal(t, "`id` >= 500 AND `id` < 750", chunk.String())
+	require.True(t, chunker2.KeyAboveHighWatermark([]any{299}), "not reached yet: below the chunk pointer and the new table")
+	require.False(t, chunker2.KeyAboveHighWatermark([]any{300}))
+	require.False(t, chunker2.KeyAboveHighWatermark([]any{500}))
+	chunker2.Feedback(chunk, time.Second, 1)
+	chunk, err = chunker2.Next()
+	require.NoError(t, err)
+	require.Equal(t, "`id` >= 250 AND `id` < 500", chunk.String())
+	rowsCopied, _, _ := chunker2.Progress()
+	require.Equal(t, uint64(500), rowsCopied, "progress is the distance from the maximum value to the resumed chunk, plus that chunk")
+
+	// Only the optimistic chunker walks the key downward.
+	_, err = NewChunker(t1, ChunkerConfig{Key: "PRIMARY", Descending: true})
+	require.ErrorContains(t, err, "descending order")
+}
+
+// TestOptimisticDescendingTable walks a small auto-increment table with a
+// Descending chunker and checks that the rows come out newest first.
+func TestOptimisticDescendingTable(t *testing.T) {
+	testutils.RunSQL(t, `DROP TABLE IF EXISTS tdescending`)
+	testutils.RunSQL(t, `CREATE TABLE tdescending (
+		id INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		name VARCHAR(16) NOT NULL
+	)`)
+	testutils.RunSQL(t, `INSERT INTO tdescending (name) VALUES ('a'), ('b'), ('c'), ('d'), ('e'), ('f'), ('g'), ('h'), ('i'), ('j')`)
+
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+	t1 := NewTableInfo(db, "test", "tdescending")
+	require.NoError(t, t1.SetInfo(t.Context()))
+
+	chunker, err := NewChunker(t1, ChunkerConfig{FixedChunkSize: 3, Descending: true})
+	require.NoError(t, err)
+	require.NoError(t, chunker.Open())
+
+	var ids []int
+	for {
+		chunk, err := chunker.Next()
+		if err != nil {
+			require.ErrorIs(t, err, ErrTableIsRead)
+			break
+		}
+		rows, err := db.QueryContext(t.Context(), "SELECT id FROM tdescending WHERE "+chunk.String()+" ORDER BY id DESC")
+		require.NoError(t, err)
+		for rows.Next() {
+			var id int
+			require.NoError(t, rows.Scan(&id))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		utils.CloseAndLog(rows)
+		chunker.Feedback(chunk, time.Millisecond, 1)
+	}
+	require.Equal(t, []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, ids)
+}
@@ -2,6 +2,7 @@ package table
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/block/spirit/pkg/dbconn/sqlescape"
@@ -21,6 +22,8 @@ type ColumnMapping struct {
 	// Pre-computed intersection results
 	sourceColumns []string // non-generated source columns that exist in target
 	targetColumns []string // corresponding target column names (renamed where applicable)
+
+	floatEpsilon float64 // see WithFloatEpsilon
 }
 
 // NewColumnMapping creates a ColumnMapping between source and target tables,
@@ -88,6 +91,52 @@ func (m *ColumnMapping) computeIntersection() ([]string, []string) {
 	return srcCols, tgtCols
 }
 
+// Exclude returns a copy of the mapping without the given columns, which are
+// matched case-insensitively against the source column names. The copy, the
+// replication applier and the checksum then all leave those columns alone, so
+// they keep whatever the target table gives them (its default). Columns not in
+// the mapping are ignored.
+func (m *ColumnMapping) Exclude(columns []string) *ColumnMapping {
+	if m == nil || len(columns) == 0 {
+		return m
+	}
+	excluded := make(map[string]struct{}, len(columns))
+	for _, col := range columns {
+		excluded[strings.ToLower(col)] = struct{}{}
+	}
+	out := &ColumnMapping{
+		sourceTable:  m.sourceTable,
+		targetTable:  m.targetTable,
+		renames:      m.renames,
+		floatEpsilon: m.floatEpsilon,
+	}
+	for i, col := range m.sourceColumns {
+		if _, ok := excluded[strings.ToLower(col)]; ok {
+			continue
+		}
+		out.sourceColumns = append(out.sourceColumns, col)
+		out.targetColumns = append(out.targetColumns, m.targetColumns[i])
+	}
+	return out
+}
+
+// WithFloatEpsilon returns a copy of the mapping whose ChecksumExprs compare
+// FLOAT and DOUBLE columns (by the target table's type) to within epsilon
+// rather than exactly: each value is divided by epsilon and rounded to an
+// integer before it is hashed. Two values closer than epsilon then usually
+// checksum the same, such as a value stored as a DOUBLE and as a FLOAT
+// after a type change. This weakens the checksum: a difference smaller than
+// epsilon is never detected, and two near-equal values on either side of a
+// rounding boundary still differ. An epsilon of 0 compares exactly.
+func (m *ColumnMapping) WithFloatEpsilon(epsilon float64) *ColumnMapping {
+	if m == nil || m.floatEpsilon == epsilon {
+		return m
+	}
+	out := *m
+	out.floatEpsilon = epsilon
+	return &out
+}
+
 // Columns returns two comma-separated, backtick-quoted column lists
 // for source and target. When there are no renames, both strings are identical.
 func (m *ColumnMapping) Columns() (source, target string) {
@@ -123,7 +172,9 @@ const checksumSeparator = ", '#', "
 // and CAST, with a '#' separator literal between every value (see
 // checksumSeparator). The CAST type always comes from the target table's type
 // definition, but the cast itself is side-dependent for JSON columns (see
-// castExpr), so the two expressions can differ even without renames.
+// castExpr), so the two expressions can differ even without renames. FLOAT
+// and DOUBLE columns are rounded instead of cast when the mapping has a
+// float epsilon (see WithFloatEpsilon).
 func (m *ColumnMapping) ChecksumExprs() (source, target string, err error) {
 	sourceExprs := make([]string, len(m.sourceColumns))
 	targetExprs := make([]string, len(m.targetColumns))
@@ -140,12 +191,23 @@ func (m *ColumnMapping) ChecksumExprs() (source, target string, err error) {
 		if err != nil {
 			return "", "", err
 		}
+		if m.floatEpsilon > 0 && isFloatTp(m.targetTable.columnsMySQLTps[m.targetColumns[i]]) {
+			srcCast = roundToEpsilon(m.sourceColumns[i], m.floatEpsilon)
+			tgtCast = roundToEpsilon(m.targetColumns[i], m.floatEpsilon)
+		}
 		sourceExprs[i] = "IFNULL(" + srcCast + ",'')" + checksumSeparator + "ISNULL(`" + m.sourceColumns[i] + "`)"
 		targetExprs[i] = "IFNULL(" + tgtCast + ",'')" + checksumSeparator + "ISNULL(`" + m.targetColumns[i] + "`)"
 	}
 	return strings.Join(sourceExprs, checksumSeparator), strings.Join(targetExprs, checksumSeparator), nil
 }
 
+// roundToEpsilon returns col divided by epsilon and rounded to an integer.
+// The epsilon is written with an exponent, which makes it a DOUBLE literal,
+// so the division is done in floating point whatever the column's type.
+func roundToEpsilon(col string, epsilon float64) string {
+	return "ROUND(" + sqlescape.EscapeIdentifier(col) + " / " + strconv.FormatFloat(epsilon, 'e', -1, 64) + ")"
+}
+
 // RepairExprs returns the SELECT expression list and the INSERT column list
 // for the checksum's same-server chunk repair:
 //
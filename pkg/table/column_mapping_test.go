@@ -46,6 +46,30 @@ func TestColumnMappingColumnsSlice(t *testing.T) {
 	require.Equal(t, []string{"a", "c"}, cols)
 }
 
+func TestColumnMappingExclude(t *testing.T) {
+	t1 := NewTableInfo(nil, "test", "t1")
+	t1new := NewTableInfo(nil, "test", "t1_new")
+	t1.NonGeneratedColumns = []string{"a", "b", "c"}
+	t1new.NonGeneratedColumns = []string{"a", "x", "c"}
+	m := NewColumnMapping(t1, t1new, map[string]string{"b": "x"})
+
+	// Columns are matched by their source name, case-insensitively.
+	excluded := m.Exclude([]string{"B", "nonexistent"})
+	src, tgt := excluded.ColumnsSlice()
+	require.Equal(t, []string{"a", "c"}, src)
+	require.Equal(t, []string{"a", "c"}, tgt)
+	require.Equal(t, []int{0, 2}, excluded.SourceColumnIndices())
+
+	// The original mapping is unchanged.
+	src, tgt = m.ColumnsSlice()
+	require.Equal(t, []string{"a", "b", "c"}, src)
+	require.Equal(t, []string{"a", "x", "c"}, tgt)
+
+	require.Same(t, m, m.Exclude(nil))
+	var nilMapping *ColumnMapping
+	require.Nil(t, nilMapping.Exclude([]string{"a"}))
+}
+
 func TestColumnMappingWithRenames(t *testing.T) {
 	t1 := NewTableInfo(nil, "test", "t1")
 	t1new := NewTableInfo(nil, "test", "t1_new")
@@ -224,6 +248,36 @@ func TestColumnMappingChecksumExprsJSONAsymmetric(t *testing.T) {
 	require.Contains(t, tgt, "CAST(`j` AS json)")
 }
 
+func TestColumnMappingChecksumExprsFloatEpsilon(t *testing.T) {
+	t1 := NewTableInfo(nil, "test", "t1")
+	t1new := NewTableInfo(nil, "test", "t1_new")
+	t1.NonGeneratedColumns = []string{"id", "f", "d"}
+	t1new.NonGeneratedColumns = []string{"id", "f", "d"}
+	t1new.columnsMySQLTps = map[string]string{"id": "int", "f": "float(7,4)", "d": "double unsigned"}
+
+	// Without an epsilon, floats are compared exactly.
+	m := NewColumnMapping(t1, t1new, nil)
+	require.Same(t, m, m.WithFloatEpsilon(0))
+	src, _, err := m.ChecksumExprs()
+	require.NoError(t, err)
+	require.Contains(t, src, "CAST(`f` AS char)")
+
+	// With one, they are rounded to multiples of it on both sides, and other
+	// columns are unchanged.
+	src, tgt, err := m.WithFloatEpsilon(0.001).ChecksumExprs()
+	require.NoError(t, err)
+	for _, expr := range []string{src, tgt} {
+		require.Contains(t, expr, "ROUND(`f` / 1e-03)")
+		require.Contains(t, expr, "ROUND(`d` / 1e-03)")
+		require.Contains(t, expr, "CAST(`id` AS signed)")
+	}
+	// The epsilon survives Exclude.
+	src, _, err = m.WithFloatEpsilon(0.5).Exclude([]string{"d"}).ChecksumExprs()
+	require.NoError(t, err)
+	require.Contains(t, src, "ROUND(`f` / 5e-01)")
+	require.NotContains(t, src, "`d`")
+}
+
 func TestColumnMappingRepairExprs(t *testing.T) {
 	// The repair (REPLACE INTO ... SELECT) must store the text image of JSON
 	// documents, not the source bytes — so JSON columns are wrapped in the
@@ -303,6 +303,29 @@ func (d Datum) Add(addVal uint64) (Datum, error) {
 	return ret, nil
 }
 
+// Sub returns d - subVal, clamped at the type's minimum value. Like Add, it
+// returns an error if d is not numeric.
+func (d Datum) Sub(subVal uint64) (Datum, error) {
+	if !d.IsNumeric() {
+		return Datum{}, fmt.Errorf("Datum.Sub: not supported on non-numeric type %v", d.Tp)
+	}
+	ret := d
+	if d.Tp == signedType {
+		returnVal := d.Val.(int64) - int64(subVal)
+		if returnVal > d.Val.(int64) {
+			returnVal = int64(math.MinInt64) // underflow
+		}
+		ret.Val = returnVal
+		return ret, nil
+	}
+	if subVal > d.Val.(uint64) {
+		ret.Val = uint64(0) // underflow
+		return ret, nil
+	}
+	ret.Val = d.Val.(uint64) - subVal
+	return ret, nil
+}
+
 // Range returns the diff between two datums as a uint64. Returns an
 // error on non-numeric types for the same reason Add does.
 func (d Datum) Range(d2 Datum) (uint64, error) {
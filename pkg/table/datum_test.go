@@ -66,6 +66,19 @@ func TestDatum(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, "18446744073709551615", overflowUnsignedResult.String())
 
+	// Sub is the mirror image: it clamps at the minimum value.
+	newsigned, err = newsigned.Sub(10)
+	require.NoError(t, err)
+	require.Equal(t, "1", newsigned.String())
+	underflowSigned, err := NewDatum(int64(math.MinInt64)+10, signedType)
+	require.NoError(t, err)
+	underflowSignedResult, err := underflowSigned.Sub(100)
+	require.NoError(t, err)
+	require.Equal(t, strconv.Itoa(math.MinInt64), underflowSignedResult.String())
+	underflowUnsignedResult, err := unsigned.Sub(100)
+	require.NoError(t, err)
+	require.Equal(t, "0", underflowUnsignedResult.String())
+
 	// Test unsigned with signed input
 	unsigned, err = NewDatum(int(1), unsignedType)
 	require.NoError(t, err)
@@ -29,6 +29,10 @@ type dynamicChunkSizer struct {
 	TargetChunkBytes uint64
 	chunkByteInfo    []uint64
 
+	// FixedChunkSize, when non-zero, turns dynamic sizing off: every chunk
+	// is this size and feedback never changes it (see ChunkerConfig).
+	FixedChunkSize uint64
+
 	disableDynamicChunker bool // only used by the test suite
 	// pinnedAtFloor records that we have already warned about the chunk size
 	// being stuck at MinDynamicRowSize. It suppresses the per-chunk
@@ -36,6 +40,22 @@ type dynamicChunkSizer struct {
 	// and is re-armed by updateChunkerTarget once the chunk size climbs back
 	// above the floor. See panicShrink.
 	pinnedAtFloor bool
+	// panicShrinks counts calls to panicShrink and panicShrinkBytes,
+	// including those at the floor that could not shrink further.
+	panicShrinks uint64
+}
+
+// initialChunkSize is the size the chunker starts (and restarts) at.
+func (d *dynamicChunkSizer) initialChunkSize() uint64 {
+	if d.FixedChunkSize > 0 {
+		return d.FixedChunkSize
+	}
+	return StartingChunkSize
+}
+
+// isDynamic reports whether feedback is used to resize chunks.
+func (d *dynamicChunkSizer) isDynamic() bool {
+	return d.FixedChunkSize == 0 && !d.disableDynamicChunker
 }
 
 // panicShrink reacts to a chunk whose processing time blew past the panic
@@ -52,6 +72,7 @@ type dynamicChunkSizer struct {
 // the real condition and suppress further lines until we climb back off the
 // floor. Caller must hold the chunker's mutex.
 func (d *dynamicChunkSizer) panicShrink(logger *slog.Logger, dur time.Duration) {
+	d.panicShrinks++
 	newTarget := uint64(float64(d.chunkSize) / float64(DynamicPanicFactor*2))
 	if d.chunkSize <= MinDynamicRowSize {
 		if !d.pinnedAtFloor {
@@ -161,6 +182,7 @@ func (d *dynamicChunkSizer) calculateNewTargetChunkBytes() (newTargetRows uint64
 // count immediately to protect client memory. Caller must hold the chunker's
 // mutex.
 func (d *dynamicChunkSizer) panicShrinkBytes(logger *slog.Logger, bytes uint64) {
+	d.panicShrinks++
 	newTarget := uint64(float64(d.chunkSize) / float64(DynamicPanicFactor*2))
 	if d.chunkSize <= MinDynamicRowSize {
 		if !d.pinnedAtFloor {
@@ -99,6 +99,7 @@ func TestPanicShrinkAboveFloorLogs(t *testing.T) {
 	require.Equal(t, 1, h.counts[highChunkMsg], "should log the shrink once")
 	require.Zero(t, h.counts[pinnedMsg], "not at the floor yet")
 	require.False(t, d.pinnedAtFloor)
+	require.Equal(t, uint64(1), d.panicShrinks)
 }
 
 // TestPanicShrinkAtFloorSuppressesFlood is the regression test for the log
@@ -122,6 +123,8 @@ func TestPanicShrinkAtFloorSuppressesFlood(t *testing.T) {
 	require.Equal(t, 1, h.counts[pinnedMsg],
 		"exactly one Warn for the whole stuck period, not one per chunk")
 	require.True(t, d.pinnedAtFloor)
+	require.Equal(t, uint64(10_000), d.panicShrinks,
+		"every overshoot is counted, even when the chunk size cannot shrink")
 }
 
 // TestPanicShrinkReArmsAfterRecovery verifies that if the chunk size climbs
@@ -0,0 +1,66 @@
+package table
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+)
+
+// ExplainChunk runs EXPLAIN on the query chunker uses to find the upper
+// bound of its next chunk, and returns the Extra column of each row of the
+// plan (e.g. "Using index; Using filesort"). Only the composite chunker
+// finds chunk boundaries with a query, so for other chunkers it returns
+// nil. The chunker must be open.
+func ExplainChunk(ctx context.Context, chunker Chunker) ([]string, error) {
+	t, ok := chunker.(*chunkerComposite)
+	if !ok {
+		return nil, nil
+	}
+	t.Lock()
+	query := t.nextQuery()
+	t.Unlock()
+	rows, err := t.Ti.db.QueryContext(ctx, "EXPLAIN "+query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	columnNames, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	extraIdx := -1
+	for i, name := range columnNames {
+		if strings.EqualFold(name, "Extra") {
+			extraIdx = i
+		}
+	}
+	var extras []string
+	for rows.Next() {
+		columns := make([]sql.NullString, len(columnNames))
+		dest := make([]any, len(columns))
+		for i := range columns {
+			dest[i] = &columns[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		if extraIdx >= 0 {
+			extras = append(extras, columns[extraIdx].String)
+		}
+	}
+	return extras, rows.Err()
+}
+
+// ChunkNeedsSort reports whether any of the Extra values returned by
+// ExplainChunk show the chunk query sorting rows itself (a filesort or a
+// temporary table) rather than reading them in key order from the index.
+// Such a query reads and sorts everything past the chunk pointer for every
+// chunk.
+func ChunkNeedsSort(extras []string) bool {
+	for _, extra := range extras {
+		if strings.Contains(extra, "Using filesort") || strings.Contains(extra, "Using temporary") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package table
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkNeedsSort(t *testing.T) {
+	require.False(t, ChunkNeedsSort(nil))
+	require.False(t, ChunkNeedsSort([]string{"Using index"}))
+	require.True(t, ChunkNeedsSort([]string{"Using index; Using filesort"}))
+	require.True(t, ChunkNeedsSort([]string{"", "Using temporary"}))
+}
+
+func TestExplainChunk(t *testing.T) {
+	// A mix of descending and ascending key parts means the index cannot be
+	// read in the order the chunk query sorts by, so MySQL has to filesort.
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS explain_desc_t1, explain_asc_t1")
+	testutils.RunSQL(t, "CREATE TABLE explain_desc_t1 (a INT NOT NULL, b INT NOT NULL, PRIMARY KEY (a DESC, b))")
+	testutils.RunSQL(t, "CREATE TABLE explain_asc_t1 (a INT NOT NULL, b INT NOT NULL, PRIMARY KEY (a, b))")
+	testutils.RunSQL(t, "INSERT INTO explain_desc_t1 VALUES (1, 1), (1, 2), (2, 1)")
+	testutils.RunSQL(t, "INSERT INTO explain_asc_t1 VALUES (1, 1), (1, 2), (2, 1)")
+
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer db.Close()
+
+	for tbl, needsSort := range map[string]bool{"explain_desc_t1": true, "explain_asc_t1": false} {
+		ti := NewTableInfo(db, "test", tbl)
+		require.NoError(t, ti.SetInfo(t.Context()))
+		chunker, err := NewChunker(ti, ChunkerConfig{})
+		require.NoError(t, err)
+		require.NoError(t, chunker.Open())
+		extras, err := ExplainChunk(t.Context(), chunker)
+		require.NoError(t, err)
+		require.NotEmpty(t, extras)
+		require.Equal(t, needsSort, ChunkNeedsSort(extras), "table %s: %v", tbl, extras)
+	}
+}
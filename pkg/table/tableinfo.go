@@ -62,6 +62,13 @@ type TableInfo struct {
 	// which only needs SELECT. Set before calling SetInfo.
 	DisableAnalyze bool
 
+	// DDLHook, if set, is called with the ANALYZE TABLE statement
+	// setRowEstimate runs, immediately before it is executed, so callers
+	// that keep an audit trail of DDL see it too. It is called from the
+	// goroutine refreshing the statistics and must not block. Set before
+	// calling SetInfo.
+	DDLHook func(stmt string)
+
 	// Host is an optional identifier for the MySQL server this table belongs to.
 	// It is used by MultiChunker to disambiguate tables with the same SchemaName
 	// and TableName on different servers (e.g., in N:M move operations).
@@ -160,7 +167,11 @@ func (t *TableInfo) setRowEstimate(ctx context.Context) error {
 	// server; callers reading from a least-privilege (SELECT-only) or
 	// read-only source set DisableAnalyze to skip it (see the field doc).
 	if !t.DisableAnalyze {
-		if _, err := t.db.ExecContext(ctx, "ANALYZE TABLE "+t.QuotedTableName); err != nil {
+		stmt := "ANALYZE TABLE " + t.QuotedTableName
+		if t.DDLHook != nil {
+			t.DDLHook(stmt)
+		}
+		if _, err := t.db.ExecContext(ctx, stmt); err != nil {
 			return err
 		}
 	}
@@ -157,6 +157,16 @@ func textRoundTripCast(quotedCol string) string {
 	return "CAST(CAST(" + quotedCol + " AS char CHARACTER SET utf8mb4) AS json)"
 }
 
+// isFloatTp reports whether tp is a FLOAT or DOUBLE type.
+func isFloatTp(tp string) bool {
+	tp = strings.TrimSuffix(removeZerofill(strings.ToLower(tp)), " unsigned")
+	switch removeWidth(removeDecimalWidth(tp)) {
+	case "float", "double", "double precision", "real":
+		return true
+	}
+	return false
+}
+
 func removeWidth(s string) string {
 	regex := regexp.MustCompile(`\([0-9]+\)`)
 	s = regex.ReplaceAllString(s, "")
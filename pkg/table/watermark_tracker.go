@@ -15,10 +15,16 @@ import (
 // checkpointHighPtr is set on resume-from-checkpoint and used by
 // KeyAboveHighWatermark before chunkPtr advances, to prevent re-applying
 // changes for keys that were already copied in a previous run.
+//
+// When descending is set, chunks are dispatched from the highest key down
+// (see ChunkerConfig.Descending), so the roles of the bounds swap: a chunk
+// aligns when its UpperBound matches the watermark's LowerBound, and
+// lowerBoundWatermarkMap is keyed by UpperBound.
 type watermarkTracker struct {
 	watermark              *Chunk
 	lowerBoundWatermarkMap map[string]*Chunk
 	checkpointHighPtr      Datum
+	descending             bool
 
 	// inflightChunks counts chunks that have been dispatched via Next()
 	// but not yet returned via Feedback(). Dispatch and commit are
@@ -53,9 +59,27 @@ func (w *watermarkTracker) allDispatchedChunksFedBack() bool {
 	return w.inflightChunks == 0
 }
 
+// startBound returns the bound chunk starts from in the order chunks are
+// dispatched: its LowerBound, or its UpperBound when descending.
+func (w *watermarkTracker) startBound(chunk *Chunk) *Boundary {
+	if w.descending {
+		return chunk.UpperBound
+	}
+	return chunk.LowerBound
+}
+
+// endBound returns the bound chunk ends at in the order chunks are
+// dispatched: its UpperBound, or its LowerBound when descending.
+func (w *watermarkTracker) endBound(chunk *Chunk) *Boundary {
+	if w.descending {
+		return chunk.LowerBound
+	}
+	return chunk.UpperBound
+}
+
 // isSpecialRestoredChunk reports whether `chunk` is the first chunk
 // dispatched after resume-from-checkpoint. The restored chunk shares
-// its lower bound with the saved watermark, which the alignment rules
+// its start bound with the saved watermark, which the alignment rules
 // below would otherwise reject as already-seen. Caller must hold the
 // chunker's mutex.
 func (w *watermarkTracker) isSpecialRestoredChunk(chunk *Chunk) bool {
@@ -63,7 +87,7 @@ func (w *watermarkTracker) isSpecialRestoredChunk(chunk *Chunk) bool {
 		w.watermark == nil || w.watermark.LowerBound == nil || w.watermark.UpperBound == nil {
 		return false // restored checkpoints always have both.
 	}
-	return chunk.LowerBound.comparesTo(w.watermark.LowerBound)
+	return w.startBound(chunk).comparesTo(w.startBound(w.watermark))
 }
 
 // waterMarkMapNotEmpty reports whether there are buffered out-of-order
@@ -81,48 +105,51 @@ func (w *watermarkTracker) waterMarkMapNotEmpty() bool {
 //     watermark to the chunk's UpperBound. Then drain the map of any
 //     chunks whose lowerBound now aligns with the new watermark.
 //
+// When descending, read "start bound" (UpperBound) for lowerBound and "end
+// bound" (LowerBound) for UpperBound above.
+//
 // Caller must hold the chunker's mutex. The logger is passed in because
 // watermarkTracker does not own one — keeping it field-less avoids an
 // ambiguous-field-promotion clash with dynamicChunkSizer when both are
 // embedded into the same chunker struct.
 func (w *watermarkTracker) bumpWatermark(chunk *Chunk, logger *slog.Logger) {
-	if chunk.UpperBound == nil {
+	if w.endBound(chunk) == nil {
 		return
 	}
 	// First chunk, or the special restored chunk: set and drain stored chunks.
-	if (w.watermark == nil && chunk.LowerBound == nil) || w.isSpecialRestoredChunk(chunk) {
+	if (w.watermark == nil && w.startBound(chunk) == nil) || w.isSpecialRestoredChunk(chunk) {
 		w.watermark = chunk
 		w.drainAlignedChunks()
 		return
 	}
 
 	// Past the first-chunk case, every subsequent chunk must have a
-	// lower bound. A nil here would mean the chunker dispatched a second
+	// start bound. A nil here would mean the chunker dispatched a second
 	// open-bounded chunk, which is a bug.
-	if chunk.LowerBound == nil {
+	if w.startBound(chunk) == nil {
 		errMsg := fmt.Sprintf("watermarkTracker.bumpWatermark: nil lowerBound value encountered more than once: %v", chunk)
 		logger.Error(errMsg)
 		panic(errMsg) // Fatal equivalent - log and panic
 	}
 
 	// Out-of-order chunk: buffer it for later alignment.
-	if w.watermark == nil || !w.watermark.UpperBound.comparesTo(chunk.LowerBound) {
-		w.lowerBoundWatermarkMap[chunk.LowerBound.valuesString()] = chunk
+	if w.watermark == nil || !w.endBound(w.watermark).comparesTo(w.startBound(chunk)) {
+		w.lowerBoundWatermarkMap[w.startBound(chunk).valuesString()] = chunk
 		return
 	}
 
-	// Chunk aligns with the current watermark.UpperBound: it becomes the
+	// Chunk aligns with the current watermark's end bound: it becomes the
 	// new watermark.
 	w.watermark = chunk
 	w.drainAlignedChunks()
 }
 
 // drainAlignedChunks pulls chunks out of lowerBoundWatermarkMap whose
-// lowerBound matches the current watermark.UpperBound, advancing the
+// start bound matches the current watermark's end bound, advancing the
 // watermark each time. Stops when nothing aligns or the map is empty.
 func (w *watermarkTracker) drainAlignedChunks() {
-	for w.waterMarkMapNotEmpty() && w.watermark.UpperBound != nil {
-		key := w.watermark.UpperBound.valuesString()
+	for w.waterMarkMapNotEmpty() && w.endBound(w.watermark) != nil {
+		key := w.endBound(w.watermark).valuesString()
 		next, ok := w.lowerBoundWatermarkMap[key]
 		if !ok {
 			return
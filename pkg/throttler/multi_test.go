@@ -2,7 +2,10 @@ package throttler
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"io"
+	"log/slog"
 	"math"
 	"sync/atomic"
 	"testing"
@@ -250,3 +253,37 @@ func TestMultiThrottler_UpdateLag_ReturnsFirstError(t *testing.T) {
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "lag error")
 }
+
+func TestNewMultiReplicationThrottler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	_, err := NewMultiReplicationThrottler(nil, time.Second, logger)
+	require.Error(t, err)
+
+	// sql.Open does not connect, so the handles are never used here.
+	db1, err := sql.Open("mysql", "root@tcp(127.0.0.1:1)/test")
+	require.NoError(t, err)
+	defer db1.Close()
+	db2, err := sql.Open("mysql", "root@tcp(127.0.0.1:2)/test")
+	require.NoError(t, err)
+	defer db2.Close()
+
+	single, err := NewMultiReplicationThrottler([]*sql.DB{db1}, time.Second, logger)
+	require.NoError(t, err)
+	require.IsType(t, &Replica{}, single)
+
+	throttler, err := NewMultiReplicationThrottler([]*sql.DB{db1, db2}, time.Second, logger)
+	require.NoError(t, err)
+	mt, ok := throttler.(*multiThrottler)
+	require.True(t, ok)
+	require.Len(t, mt.throttlers, 2)
+	// Replication lag is a binary signal, so the composite is not gradual.
+	_, ok = throttler.(GradualThrottler)
+	require.False(t, ok)
+
+	// Any one replica falling behind throttles the copy.
+	mt.throttlers[0].(*Replica).applyLag(10)
+	mt.throttlers[1].(*Replica).applyLag(5_000)
+	require.True(t, throttler.IsThrottled())
+	mt.throttlers[1].(*Replica).applyLag(10)
+	require.False(t, throttler.IsThrottled())
+}
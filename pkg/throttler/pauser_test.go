@@ -0,0 +1,41 @@
+package throttler
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPauser(t *testing.T) {
+	var p Pauser
+	require.False(t, p.IsThrottled())
+	p.BlockWait(t.Context()) // returns immediately
+	p.Resume()               // no-op while not paused
+
+	p.Pause()
+	p.Pause() // no-op while paused
+	require.True(t, p.IsThrottled())
+
+	done := make(chan struct{})
+	go func() {
+		p.BlockWait(t.Context())
+		close(done)
+	}()
+	select {
+	case <-done:
+		t.Fatal("BlockWait returned while paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	p.Resume()
+	<-done
+	require.False(t, p.IsThrottled())
+
+	// A cancelled context releases BlockWait even while paused.
+	p.Pause()
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+	p.BlockWait(ctx)
+	require.True(t, p.IsThrottled())
+}
@@ -0,0 +1,171 @@
+package throttler
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+)
+
+// Query throttles on an application-specific signal: a user-supplied SQL
+// query that returns a single integer (such as the depth of a queue table),
+// pausing the copy while the value exceeds the threshold (--throttle-query,
+// --throttle-threshold).
+//
+// Like Replica, it fails closed: the user asked for the copy to be paced by
+// this signal, so when the probe has not succeeded within three intervals
+// (and at least staleSignalThreshold), IsThrottled() reports true until it
+// recovers.
+type Query struct {
+	db        *sql.DB
+	query     string
+	signal    string // names the value in logs and errors
+	threshold int64
+	interval  time.Duration
+	logger    *slog.Logger
+
+	lastValue atomic.Int64
+	stale     staleGuard
+
+	// cancel stops the probe loop started by Open, and done is closed when it
+	// has returned, so Close does not leave it running.
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+var _ Throttler = &Query{}
+
+// NewQueryThrottler returns a Throttler that runs query against db every
+// interval (5s if interval is not positive) and throttles while the value it
+// returns is greater than threshold. The query must return exactly one row
+// with one integer column; Open fails if the first probe does not.
+func NewQueryThrottler(db *sql.DB, query string, threshold int64, interval time.Duration, logger *slog.Logger) (Throttler, error) {
+	if query == "" {
+		return nil, errors.New("throttle query must not be empty")
+	}
+	if interval <= 0 {
+		interval = loopInterval
+	}
+	return &Query{
+		db:        db,
+		query:     query,
+		signal:    "throttle query",
+		threshold: threshold,
+		interval:  interval,
+		logger:    logger,
+	}, nil
+}
+
+// Open runs the probe once, so a query that fails or does not return an
+// integer is reported immediately, and then starts probing every interval
+// until ctx is cancelled or Close is called.
+func (q *Query) Open(ctx context.Context) error {
+	if err := q.UpdateLag(ctx); err != nil {
+		return err
+	}
+	ctx, q.cancel = context.WithCancel(ctx)
+	q.done = make(chan struct{})
+	go func() {
+		defer close(q.done)
+		ticker := time.NewTicker(q.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := q.UpdateLag(ctx); err != nil && ctx.Err() == nil {
+					q.logger.Error("error running throttle query", "signal", q.signal, "error", err)
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops the probe loop and waits for it to return.
+func (q *Query) Close() error {
+	if q.cancel == nil {
+		return nil
+	}
+	q.cancel()
+	<-q.done
+	return nil
+}
+
+// IsThrottled returns true when the last value returned by the query exceeds
+// the threshold, and also while the probe has not succeeded recently (see the
+// type comment).
+func (q *Query) IsThrottled() bool {
+	if stale, entering := q.stale.check(q.staleThreshold()); stale {
+		if entering {
+			q.logger.Warn("throttle query keeps failing; failing closed and pausing copying until it recovers",
+				"signal", q.signal,
+				"last_successful_probe_age", q.stale.age().String(),
+				"stale_threshold", q.staleThreshold().String())
+		}
+		return true
+	}
+	return q.lastValue.Load() > q.threshold
+}
+
+// BlockWait blocks until the throttle clears, or up to 60s to allow some
+// progress to be made. It respects context cancellation.
+func (q *Query) BlockWait(ctx context.Context) {
+	timer := time.NewTimer(blockWaitInterval)
+	defer timer.Stop()
+
+	for range 60 {
+		if !q.IsThrottled() {
+			return
+		}
+
+		timer.Reset(blockWaitInterval)
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+			// Continue checking
+		}
+	}
+	q.logger.Warn("throttle query wait timed out",
+		"signal", q.signal,
+		"value", q.lastValue.Load(),
+		"threshold", q.threshold,
+		"probe_unobservable", q.stale.gapExceeds(q.staleThreshold()))
+}
+
+// UpdateLag runs the query once and records its value.
+func (q *Query) UpdateLag(ctx context.Context) error {
+	var value int64
+	if err := q.db.QueryRowContext(ctx, q.query).Scan(&value); err != nil {
+		return fmt.Errorf("could not run throttle query for %s (it must return a single integer): %w", q.signal, err)
+	}
+	q.applyValue(value)
+	return nil
+}
+
+// applyValue updates state from a single successful probe. Split out so tests
+// can drive the state without a database.
+func (q *Query) applyValue(value int64) {
+	if q.stale.markFresh() {
+		q.logger.Info("throttle query recovered; resuming query-based throttling", "signal", q.signal)
+	}
+	q.lastValue.Store(value)
+	if value > q.threshold {
+		q.logger.Warn("throttle query value exceeds threshold, throttling in progress",
+			"signal", q.signal,
+			"value", value,
+			"threshold", q.threshold)
+	}
+}
+
+// staleThreshold is how old the last successful probe may be before the
+// value is no longer trusted: three intervals, as for the built-in throttlers,
+// but never less than staleSignalThreshold.
+func (q *Query) staleThreshold() time.Duration {
+	return max(3*q.interval, staleSignalThreshold)
+}
@@ -0,0 +1,117 @@
+package throttler
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestQuery(t *testing.T, threshold int64) *Query {
+	t.Helper()
+	q, err := NewQueryThrottler(nil, "SELECT 1", threshold, 0, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	require.NoError(t, err)
+	return q.(*Query)
+}
+
+func TestNewQueryThrottler(t *testing.T) {
+	_, err := NewQueryThrottler(nil, "", 10, time.Second, slog.Default())
+	require.Error(t, err)
+
+	// A non-positive interval falls back to the default.
+	q := newTestQuery(t, 10)
+	require.Equal(t, loopInterval, q.interval)
+}
+
+func TestQuery_ThresholdBasedThrottling(t *testing.T) {
+	q := newTestQuery(t, 100)
+
+	q.applyValue(5)
+	require.False(t, q.IsThrottled())
+
+	q.applyValue(100) // exactly at the threshold is not over it
+	require.False(t, q.IsThrottled())
+
+	q.applyValue(101)
+	require.True(t, q.IsThrottled())
+
+	q.applyValue(50)
+	require.False(t, q.IsThrottled())
+}
+
+func TestQuery_FailsClosedWhenProbeUnobservable(t *testing.T) {
+	q := newTestQuery(t, 100)
+	q.applyValue(5)
+	require.False(t, q.IsThrottled())
+
+	ageLastSample(&q.stale, q.staleThreshold()+time.Second)
+	require.True(t, q.IsThrottled())
+
+	q.applyValue(5)
+	require.False(t, q.IsThrottled())
+}
+
+func TestQuery_StaleThresholdScalesWithInterval(t *testing.T) {
+	q := newTestQuery(t, 100)
+	require.Equal(t, staleSignalThreshold, q.staleThreshold())
+	q.interval = time.Minute
+	require.Equal(t, 3*time.Minute, q.staleThreshold())
+}
+
+func TestQuery_CloseWithoutOpen(t *testing.T) {
+	q := newTestQuery(t, 100)
+	require.NoError(t, q.Close())
+}
+
+func TestQuery_UpdateLagWrapsCause(t *testing.T) {
+	db, err := sql.Open("mysql", "user:pass@tcp(127.0.0.1:0)/test")
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	q := newTestQuery(t, 100)
+	q.db = db
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	err = q.UpdateLag(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+	require.ErrorContains(t, err, "could not run throttle query")
+}
+
+func TestQueryThrottler(t *testing.T) {
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	// A query that does not return an integer fails on Open.
+	bad, err := NewQueryThrottler(db, "SELECT 'not a number'", 10, time.Millisecond, slog.Default())
+	require.NoError(t, err)
+	require.Error(t, bad.Open(t.Context()))
+
+	testutils.RunSQL(t, "DROP TABLE IF EXISTS throttle_queue")
+	testutils.RunSQL(t, "CREATE TABLE throttle_queue (id INT NOT NULL PRIMARY KEY)")
+	testutils.RunSQL(t, "INSERT INTO throttle_queue VALUES (1), (2), (3)")
+
+	throttler, err := NewQueryThrottler(db, "SELECT COUNT(*) FROM throttle_queue", 2, 10*time.Millisecond, slog.Default())
+	require.NoError(t, err)
+	require.NoError(t, throttler.Open(t.Context()))
+	require.True(t, throttler.IsThrottled())
+
+	// The probe loop picks up the queue draining.
+	testutils.RunSQL(t, "DELETE FROM throttle_queue WHERE id = 3")
+	require.Eventually(t, func() bool {
+		return !throttler.IsThrottled()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Close stops the probe loop before returning; goleak in TestMain checks
+	// that it is gone.
+	require.NoError(t, throttler.Close())
+}
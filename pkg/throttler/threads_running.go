@@ -0,0 +1,32 @@
+package throttler
+
+import (
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+// NewThreadsRunningThrottler returns a Throttler that polls the
+// Threads_running status variable of db (normally the primary) every interval
+// (5s if interval is not positive) and throttles while it is greater than
+// maxThreadsRunning (--max-threads-running). It is a back-off for heavy OLTP
+// load on any MySQL server, where the Aurora threads throttler instead sizes
+// its limit from the instance's vCPUs and only runs on Aurora.
+//
+// The count includes spirit's own connections that are running a query when
+// it is sampled, including the probe itself, so the limit should leave a few
+// threads of headroom above the application's own peak. Like the query
+// throttler it is built on, it fails closed if polling keeps failing.
+func NewThreadsRunningThrottler(db *sql.DB, maxThreadsRunning int64, interval time.Duration, logger *slog.Logger) (Throttler, error) {
+	if maxThreadsRunning <= 0 {
+		return nil, errors.New("max threads running must be positive")
+	}
+	t, err := NewQueryThrottler(db, threadsRunningQuery, maxThreadsRunning, interval, logger)
+	if err != nil {
+		return nil, err
+	}
+	q := t.(*Query)
+	q.signal = "Threads_running"
+	return q, nil
+}
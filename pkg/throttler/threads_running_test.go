@@ -0,0 +1,46 @@
+package throttler
+
+import (
+	"database/sql"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/block/spirit/pkg/testutils"
+	"github.com/block/spirit/pkg/utils"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewThreadsRunningThrottler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	_, err := NewThreadsRunningThrottler(nil, 0, time.Second, logger)
+	require.Error(t, err)
+
+	throttler, err := NewThreadsRunningThrottler(nil, 50, time.Second, logger)
+	require.NoError(t, err)
+	q := throttler.(*Query)
+	require.Equal(t, threadsRunningQuery, q.query)
+	require.Equal(t, "Threads_running", q.signal)
+
+	q.applyValue(50)
+	require.False(t, q.IsThrottled())
+	q.applyValue(51)
+	require.True(t, q.IsThrottled())
+}
+
+func TestThreadsRunningThrottler(t *testing.T) {
+	db, err := sql.Open("mysql", testutils.DSN())
+	require.NoError(t, err)
+	defer utils.CloseAndLog(db)
+
+	// An idle test server is far below this limit; the probe counts itself,
+	// so the value is at least 1.
+	throttler, err := NewThreadsRunningThrottler(db, 1000, 10*time.Millisecond, slog.Default())
+	require.NoError(t, err)
+	require.NoError(t, throttler.Open(t.Context()))
+	require.False(t, throttler.IsThrottled())
+	require.GreaterOrEqual(t, throttler.(*Query).lastValue.Load(), int64(1))
+	require.NoError(t, throttler.Close())
+}
@@ -4,6 +4,7 @@ package throttler
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"log/slog"
 	"time"
 )
@@ -48,3 +49,23 @@ func NewReplicationThrottler(replica *sql.DB, lagTolerance time.Duration, logger
 		logger:       logger,
 	}, nil
 }
+
+// NewMultiReplicationThrottler returns a Throttler that watches several
+// replicas with the same lag tolerance and throttles while any of them is
+// behind, so the copy is paced by the slowest replica. Each replica gets its
+// own replication throttler (see NewReplicationThrottler), combined with
+// NewMultiThrottler; a single replica is returned unwrapped.
+func NewMultiReplicationThrottler(replicas []*sql.DB, lagTolerance time.Duration, logger *slog.Logger) (Throttler, error) {
+	if len(replicas) == 0 {
+		return nil, errors.New("no replicas to throttle on")
+	}
+	throttlers := make([]Throttler, 0, len(replicas))
+	for _, replica := range replicas {
+		t, err := NewReplicationThrottler(replica, lagTolerance, logger)
+		if err != nil {
+			return nil, err
+		}
+		throttlers = append(throttlers, t)
+	}
+	return NewMultiThrottler(throttlers...), nil
+}